@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/internal/clearing"
+)
+
+// The entry point of the local clearing house simulator. It answers the
+// batches of the exchange directory once or, with -watch, every interval.
+//
+//	go run ./cmd/clearing-sim -dir ./clearing -max-amount 100000000 \
+//		-return DE89370400440532013000=AC04
+func main() {
+	sim := clearing.Simulator{Returns: make(map[string]string)}
+	flag.StringVar(&sim.Root, "dir", "./clearing", "exchange directory")
+	flag.Int64Var(&sim.MaxAmount, "max-amount", 0, "return the larger transfers with AM02, 0 is no limit")
+	flag.Func("return", "return the transfers to ACCOUNT=REASON, repeatable", func(v string) error {
+		account, reason, ok := strings.Cut(v, "=")
+		if !ok || account == "" || reason == "" {
+			return fmt.Errorf("%q is not ACCOUNT=REASON", v)
+		}
+		sim.Returns[account] = reason
+		return nil
+	})
+	watch := flag.Duration("watch", 0, "answer the new batches every interval, 0 is once")
+	flag.Parse()
+
+	for {
+		n, err := sim.Run()
+		if err != nil {
+			log.Fatal(fmt.Errorf("clearing simulator error: %w", err))
+		}
+		if n > 0 {
+			log.Printf("answered %d batches", n)
+		}
+		if *watch <= 0 {
+			return
+		}
+		time.Sleep(*watch)
+	}
+}
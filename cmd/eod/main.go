@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"alukart32.com/bank/config"
+	"alukart32.com/bank/internal/app"
+)
+
+// The entry point of the end-of-day run, it closes the due business
+// dates and exits.
+func main() {
+	cfg, err := config.New(config.Default)
+	if err != nil {
+		log.Fatal(fmt.Errorf("read config error: %w", err))
+	}
+
+	if err = app.RunEOD(cfg); err != nil {
+		log.Fatal(fmt.Errorf("eod error: %w", err))
+	}
+}
@@ -68,6 +68,222 @@ type (
 		ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"3000us"`
 	}
 
+	// GRPC is the representation of grpc server settings
+	GRPC struct {
+		// Port specifies the listening port of the server.
+		//
+		// Default is 9090.
+		Port string `env:"GRPC_PORT" env-default:"9090"`
+
+		// ShutdownTimeout is the maximum duration of the graceful stop
+		// before the running server is stopped forcibly.
+		//
+		// Default is 3s.
+		ShutdownTimeout time.Duration `env:"GRPC_SHUTDOWN_TIMEOUT" env-default:"3s"`
+	}
+
+	// Auth is the representation of request authentication settings.
+	Auth struct {
+		// JWTSecret specifies the HMAC key used to verify the signature
+		// of bearer tokens. The app doesn't start without it.
+		JWTSecret string `env:"AUTH_JWT_SECRET"`
+	}
+
+	// Stream is the representation of account event streaming settings.
+	Stream struct {
+		// Buffer specifies the number of events kept for a subscriber
+		// before it is considered too slow and disconnected.
+		//
+		// Default is 64.
+		Buffer int `env:"STREAM_BUFFER" env-default:"64"`
+
+		// Heartbeat is the interval of keep-alive comments sent to idle
+		// subscribers.
+		//
+		// Default is 15s.
+		Heartbeat time.Duration `env:"STREAM_HEARTBEAT" env-default:"15s"`
+
+		// ResumeWindow is the period before the last event of a
+		// reconnecting subscriber whose entries are replayed again, so
+		// the entries committed after it with smaller ids aren't missed.
+		// It must cover the longest posting transaction.
+		//
+		// Default is 1m.
+		ResumeWindow time.Duration `env:"STREAM_RESUME_WINDOW" env-default:"1m"`
+	}
+
+	// AccountNumber is the representation of the account number format:
+	// country code, check digits, bank code and sequence number.
+	AccountNumber struct {
+		// CountryCode specifies the ISO 3166 country code the numbers
+		// start with.
+		//
+		// Default is RU.
+		CountryCode string `env:"ACCOUNT_NUMBER_COUNTRY" env-default:"RU"`
+
+		// BankCode specifies the alphanumeric code of the bank that
+		// follows the check digits.
+		//
+		// Default is ALKB.
+		BankCode string `env:"ACCOUNT_NUMBER_BANK_CODE" env-default:"ALKB"`
+
+		// SequenceLength specifies the number of digits of the zero
+		// padded account sequence number.
+		//
+		// Default is 12.
+		SequenceLength int `env:"ACCOUNT_NUMBER_SEQUENCE_LENGTH" env-default:"12"`
+	}
+
+	// Payee is the representation of saved payee settings.
+	Payee struct {
+		// CoolingOff is the period after a payee is saved during which
+		// transfers to it can't exceed CoolingOffLimit. A zero value
+		// disables the cooling-off period.
+		//
+		// Default is 0.
+		CoolingOff time.Duration `env:"PAYEE_COOLING_OFF" env-default:"0"`
+
+		// CoolingOffLimit specifies the largest amount, in minor units,
+		// allowed during the cooling-off period.
+		//
+		// Default is 100000.
+		CoolingOffLimit int64 `env:"PAYEE_COOLING_OFF_LIMIT" env-default:"100000"`
+	}
+
+	// Approval is the representation of the maker-checker settings.
+	Approval struct {
+		// TTL is the period a transfer waits for the approval before it
+		// expires.
+		//
+		// Default is 24h.
+		TTL time.Duration `env:"APPROVAL_TTL" env-default:"24h"`
+	}
+
+	// Risk is the representation of the transfer risk rules settings.
+	Risk struct {
+		// ReviewScore is the lowest risk score of the transfers held for
+		// manual review. A zero value disables reviews.
+		//
+		// Default is 50.
+		ReviewScore int `env:"RISK_REVIEW_SCORE" env-default:"50"`
+
+		// BlockScore is the lowest risk score of the blocked transfers.
+		// A zero value disables blocking.
+		//
+		// Default is 100.
+		BlockScore int `env:"RISK_BLOCK_SCORE" env-default:"100"`
+
+		// LargeAmount specifies the smallest amount, in minor units,
+		// considered risky for a first transfer to a recipient.
+		//
+		// Default is 5000000.
+		LargeAmount int64 `env:"RISK_LARGE_AMOUNT" env-default:"5000000"`
+
+		// QuietFrom and QuietTo specify the UTC hours range in which
+		// transfers are unusual.
+		//
+		// Default is from 1 to 5.
+		QuietFrom int `env:"RISK_QUIET_FROM" env-default:"1"`
+		QuietTo   int `env:"RISK_QUIET_TO" env-default:"5"`
+
+		// RapidWindow and RapidCount specify the number of transfers
+		// within the period that makes the next one rapid.
+		//
+		// Default is 5 transfers in 10m.
+		RapidWindow time.Duration `env:"RISK_RAPID_WINDOW" env-default:"10m"`
+		RapidCount  int64         `env:"RISK_RAPID_COUNT" env-default:"5"`
+
+		// AverageFactor specifies how many times an amount must exceed
+		// the average transfer of the account to be unusual.
+		//
+		// Default is 10.
+		AverageFactor int64 `env:"RISK_AVERAGE_FACTOR" env-default:"10"`
+	}
+
+	// Screening is the representation of the sanctions screening settings.
+	Screening struct {
+		// Watchlists specifies the comma separated paths of the OFAC SDN
+		// CSV and UN consolidated list XML files. A zero value disables
+		// the screening.
+		Watchlists []string `env:"SCREENING_WATCHLISTS" env-separator:","`
+
+		// FlagScore is the lowest name similarity, from 0 to 1, of the
+		// matches flagged for the compliance review.
+		//
+		// Default is 0.85.
+		FlagScore float64 `env:"SCREENING_FLAG_SCORE" env-default:"0.85"`
+
+		// BlockScore is the lowest name similarity of the blocked
+		// matches.
+		//
+		// Default is 0.97.
+		BlockScore float64 `env:"SCREENING_BLOCK_SCORE" env-default:"0.97"`
+	}
+
+	// Cash is the representation of the cash operations limits, in minor
+	// units, of each channel. The deposits and the withdrawals are limited
+	// separately. A zero value disables the limit.
+	Cash struct {
+		// Default is 50000000 single and 100000000 daily.
+		TellerMaxSingle int64 `env:"CASH_TELLER_MAX_SINGLE" env-default:"50000000"`
+		TellerDaily     int64 `env:"CASH_TELLER_DAILY" env-default:"100000000"`
+
+		// Default is 5000000 single and 10000000 daily.
+		ATMMaxSingle int64 `env:"CASH_ATM_MAX_SINGLE" env-default:"5000000"`
+		ATMDaily     int64 `env:"CASH_ATM_DAILY" env-default:"10000000"`
+
+		// Default is 1500000 single and 5000000 daily.
+		CardTopUpMaxSingle int64 `env:"CASH_CARD_TOPUP_MAX_SINGLE" env-default:"1500000"`
+		CardTopUpDaily     int64 `env:"CASH_CARD_TOPUP_DAILY" env-default:"5000000"`
+	}
+
+	// EOD is the representation of the end-of-day run settings.
+	EOD struct {
+		// Schedule is the interval the in-process scheduler checks for
+		// the due business dates at. A zero value disables the scheduler,
+		// the runs are started by the eod command or the API only.
+		//
+		// Default is 0.
+		Schedule time.Duration `env:"EOD_SCHEDULE" env-default:"0"`
+
+		// Cutoff is the time after the end of the UTC business date its
+		// run is due at.
+		//
+		// Default is 30m.
+		Cutoff time.Duration `env:"EOD_CUTOFF" env-default:"30m"`
+	}
+
+	// Clearing is the representation of the exchange with the clearing
+	// house.
+	Clearing struct {
+		// Dir is the exchange directory, the batches are written to its
+		// outbox and the responses are read from its inbox.
+		//
+		// Default is ./clearing.
+		Dir string `env:"CLEARING_DIR" env-default:"./clearing"`
+
+		// BankName is the originator of the exported batch files.
+		//
+		// Default is Alukart Bank.
+		BankName string `env:"CLEARING_BANK_NAME" env-default:"Alukart Bank"`
+
+		// RoutingNumber is the routing number of the bank, the origin of
+		// the NACHA files, and CompanyID is its company id in the ACH
+		// batches. The NACHA files can't be exported without them.
+		RoutingNumber string `env:"CLEARING_ROUTING_NUMBER"`
+		CompanyID     string `env:"CLEARING_COMPANY_ID"`
+
+		// OperatorRoutingNumber and OperatorName are the ACH operator the
+		// NACHA files are sent to.
+		OperatorRoutingNumber string `env:"CLEARING_OPERATOR_ROUTING_NUMBER"`
+		OperatorName          string `env:"CLEARING_OPERATOR_NAME"`
+
+		// IBAN and BIC are the account of the bank debited by the SEPA
+		// credit transfers. The SEPA files can't be exported without them.
+		IBAN string `env:"CLEARING_IBAN"`
+		BIC  string `env:"CLEARING_BIC"`
+	}
+
 	// Log is used for event logging configuration
 	Log struct {
 		// Level specifies the message importance level.
@@ -78,9 +294,20 @@ type (
 
 	// Config holds all configuration structs, such as DB, HTTP, LOG
 	Config struct {
-		DB     DB
-		HTTP   HTTP
-		Logger Log
+		DB        DB
+		HTTP      HTTP
+		GRPC      GRPC
+		Auth      Auth
+		Stream    Stream
+		Number    AccountNumber
+		Payee     Payee
+		Risk      Risk
+		Approval  Approval
+		Screening Screening
+		Cash      Cash
+		EOD       EOD
+		Clearing  Clearing
+		Logger    Log
 	}
 )
 
@@ -12,4 +12,6 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  Currency  `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// Number is the IBAN-style account number given to customers.
+	Number string `json:"number,omitempty"`
 }
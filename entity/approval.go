@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending_approval"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalExpired  ApprovalStatus = "expired"
+)
+
+// ApprovalPolicy requires the transfers from the account of at least the
+// threshold to be approved by one of the approvers other than the
+// initiator.
+type ApprovalPolicy struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Threshold int64     `json:"threshold"`
+	Approvers []string  `json:"approvers"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TransferApproval is a transfer waiting for the approval of a second
+// person. The transfer is executed only when it is approved before the
+// expiry.
+type TransferApproval struct {
+	ID          int64          `json:"id"`
+	Transfer    Transfer       `json:"transfer"`
+	Status      ApprovalStatus `json:"status"`
+	InitiatedBy string         `json:"initiated_by"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	Comment     string         `json:"comment,omitempty"`
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// AuditGenesisHash is the previous hash of the first audit record.
+var AuditGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// AuditActor is the caller of a state changing operation.
+type AuditActor struct {
+	Subject   string `json:"subject"`
+	RequestID string `json:"request_id,omitempty"`
+	ClientIP  string `json:"client_ip,omitempty"`
+}
+
+// AuditRecord is a state change of the target. Each record holds the
+// hash of the previous one, so a changed or removed record breaks the
+// chain.
+type AuditRecord struct {
+	ID int64 `json:"id"`
+	AuditActor
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// ComputeHash returns the hex encoded SHA-256 of the record fields and
+// the previous hash.
+func (r AuditRecord) ComputeHash() string {
+	h := sha256.New()
+	for _, v := range []string{
+		r.PrevHash, r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		r.Subject, r.RequestID, r.ClientIP,
+		r.Action, r.TargetType, r.TargetID,
+		string(r.Before), string(r.After),
+	} {
+		h.Write([]byte(v))
+		// the zero byte separates the fields, so they can't be shifted
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AuditVerification is the result of the audit log chain check.
+type AuditVerification struct {
+	Records int64 `json:"records"`
+	Valid   bool  `json:"valid"`
+	// BrokenID is the first record that doesn't match its hash or the
+	// hash of the previous record.
+	BrokenID int64 `json:"broken_id,omitempty"`
+}
+
+// Audited target types.
+const (
+	AuditTargetAccount          = "account"
+	AuditTargetTransfer         = "transfer"
+	AuditTargetExternalTransfer = "external_transfer"
+)
+
+// Audited actions.
+const (
+	AuditAccountCreate      = "account.create"
+	AuditAccountUpdateOwner = "account.update_owner"
+	AuditHolderInvite       = "account.invite_holder"
+	AuditHolderAccept       = "account.accept_holder"
+	AuditHolderRemove       = "account.remove_holder"
+	AuditCashDeposit        = "cash.deposit"
+	AuditCashWithdrawal     = "cash.withdrawal"
+	AuditTransferCreate     = "transfer.create"
+	AuditTransferFail       = "transfer.fail"
+	AuditTransferApprove    = "transfer.approve"
+	AuditTransferRelease    = "transfer.release"
+	AuditTransferRollback   = "transfer.rollback"
+	AuditExternalCreate     = "external_transfer.create"
+	AuditExternalApprove    = "external_transfer.approve"
+	AuditExternalRelease    = "external_transfer.release"
+	AuditExternalReject     = "external_transfer.reject"
+)
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountBalance is the balance of the account holding the entries made
+// before the time.
+type AccountBalance struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Currency  Currency  `json:"currency"`
+	Balance   int64     `json:"balance"`
+	AsOf      time.Time `json:"as_of"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// BatchRun is the result of a batch over the accounts, like the interest
+// accrual or the maintenance fees. The accounts done by the previous runs
+// of the date are skipped.
+type BatchRun struct {
+	Date      time.Time `json:"date"`
+	Processed int       `json:"processed"`
+	Skipped   int       `json:"skipped"`
+	Failed    int       `json:"failed"`
+	// Total is the amount of the run, the accrued micros of the interest
+	// accrual or the posted amount of the others.
+	Total int64 `json:"total"`
+}
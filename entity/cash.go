@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CashChannel is the way the cash enters or leaves the bank.
+type CashChannel string
+
+const (
+	CashTeller    CashChannel = "teller"
+	CashATM       CashChannel = "atm"
+	CashCardTopUp CashChannel = "card_topup"
+)
+
+// GLCode returns the code of the GL account the cash of the channel is
+// posted against.
+func (c CashChannel) GLCode() string {
+	switch c {
+	case CashATM:
+		return GLCodeATMCash
+	case CashCardTopUp:
+		return GLCodeCardSettlement
+	default:
+		return GLCodeCash
+	}
+}
+
+func (c CashChannel) Valid() bool {
+	switch c {
+	case CashTeller, CashATM, CashCardTopUp:
+		return true
+	}
+	return false
+}
+
+type CashDirection string
+
+const (
+	CashDeposit    CashDirection = "deposit"
+	CashWithdrawal CashDirection = "withdrawal"
+)
+
+// CashLimit is the limit of the operations of one direction through the
+// channel. A zero limit is not enforced.
+type CashLimit struct {
+	MaxSingle int64 `json:"max_single"`
+	// Daily is the total of the account within the current UTC day.
+	Daily int64 `json:"daily"`
+}
+
+// CashOperation is the deposit or withdrawal of the account, it is
+// returned as the receipt.
+type CashOperation struct {
+	ID        int64         `json:"id"`
+	Reference string        `json:"reference"`
+	Direction CashDirection `json:"direction"`
+	Channel   CashChannel   `json:"channel"`
+	TellerID  string        `json:"teller_id,omitempty"`
+	AccountID uuid.UUID     `json:"account_id"`
+	Amount    int64         `json:"amount"`
+	Currency  Currency      `json:"currency"`
+	// Balance is the account balance after the operation.
+	Balance   int64     `json:"balance"`
+	EntryID   int64     `json:"entry_id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CashReference returns the receipt reference of the sequence number,
+// e.g. DEP-20230317-00000042.
+func CashReference(d CashDirection, date time.Time, seq int64) string {
+	prefix := "DEP"
+	if d == CashWithdrawal {
+		prefix = "WDL"
+	}
+	return fmt.Sprintf("%s-%s-%08d", prefix, date.UTC().Format("20060102"), seq)
+}
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalTransferStatus is the state of the transfer to the other bank.
+// The held transfers wait for the decision of their hold, the pending
+// ones wait for the next batch, the sent ones wait for the response of
+// the clearing house.
+type ExternalTransferStatus string
+
+const (
+	ExternalHeld     ExternalTransferStatus = "held"
+	ExternalPending  ExternalTransferStatus = "pending"
+	ExternalSent     ExternalTransferStatus = "sent"
+	ExternalSettled  ExternalTransferStatus = "settled"
+	ExternalReturned ExternalTransferStatus = "returned"
+	ExternalRejected ExternalTransferStatus = "rejected"
+)
+
+// ExternalHold is the reason the transfer is held before the submission.
+type ExternalHold string
+
+const (
+	// HoldApproval waits for an approver of the account approval policy.
+	HoldApproval ExternalHold = "approval"
+	// HoldReview waits for an operator to review the risk signals.
+	HoldReview ExternalHold = "review"
+)
+
+// Return reasons of the clearing house, the ISO 20022 codes.
+const (
+	ReturnInvalidAccount = "AC01"
+	ReturnClosedAccount  = "AC04"
+	ReturnAmountExceeded = "AM02"
+	ReturnInvalidBank    = "RC01"
+)
+
+// ExternalTransfer is the transfer from the customer account to the
+// account at the other bank. The customer is debited into the suspense
+// account when the transfer is created, the amount leaves the bank when
+// the transfer is settled or goes back to the customer when it is
+// returned. The held transfer keeps the amount in the suspense account
+// until it is released or rejected and refunded.
+type ExternalTransfer struct {
+	ID                 int64     `json:"id"`
+	AccountID          uuid.UUID `json:"account_id"`
+	Amount             int64     `json:"amount"`
+	Currency           Currency  `json:"currency"`
+	BeneficiaryName    string    `json:"beneficiary_name"`
+	BeneficiaryAccount string    `json:"beneficiary_account"`
+	// BeneficiaryBank is the BIC of the bank of the beneficiary.
+	BeneficiaryBank string                 `json:"beneficiary_bank"`
+	Description     string                 `json:"description,omitempty"`
+	Reference       string                 `json:"reference,omitempty"`
+	Status          ExternalTransferStatus `json:"status"`
+	BatchID         *int64                 `json:"batch_id,omitempty"`
+	ReturnReason    string                 `json:"return_reason,omitempty"`
+	EntryID         int64                  `json:"entry_id"`
+	Hold            ExternalHold           `json:"hold,omitempty"`
+	Score           int                    `json:"score,omitempty"`
+	Signals         RiskSignals            `json:"signals,omitempty"`
+	ReviewedBy      string                 `json:"reviewed_by,omitempty"`
+	Comment         string                 `json:"comment,omitempty"`
+	CreatedBy       string                 `json:"created_by"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	// Fees are charged from the account on top of the amount when the
+	// transfer is submitted.
+	Fees []FeeCharge `json:"fees,omitempty"`
+}
+
+type ClearingBatchStatus string
+
+const (
+	BatchCreated   ClearingBatchStatus = "created"
+	BatchSent      ClearingBatchStatus = "sent"
+	BatchProcessed ClearingBatchStatus = "processed"
+)
+
+// ClearingBatch is the file of the transfers of one currency sent to the
+// clearing house.
+type ClearingBatch struct {
+	ID        int64               `json:"id"`
+	FileName  string              `json:"file_name"`
+	Currency  Currency            `json:"currency"`
+	Count     int64               `json:"count"`
+	Total     int64               `json:"total"`
+	Status    ClearingBatchStatus `json:"status"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Transfers []ExternalTransfer  `json:"transfers,omitempty"`
+}
+
+// ClearingBatchName returns the file name of the batch of the currency,
+// e.g. CLR-20230317-RUB-00000042 where the number is the id of the first
+// transfer of the batch.
+func ClearingBatchName(date time.Time, currency Currency, firstID int64) string {
+	return fmt.Sprintf("CLR-%s-%s-%08d", date.UTC().Format("20060102"), currency, firstID)
+}
+
+// ClearingResponse is the answer of the clearing house to the batch.
+type ClearingResponse struct {
+	FileName string           `json:"file_name"`
+	Results  []ClearingResult `json:"results"`
+}
+
+// ClearingResult is the outcome of one transfer of the batch, either
+// settled or returned with the reason.
+type ClearingResult struct {
+	TransferID int64                  `json:"transfer_id"`
+	Status     ExternalTransferStatus `json:"status"`
+	Reason     string                 `json:"reason,omitempty"`
+}
+
+// ClearingReport sums up the processed responses.
+type ClearingReport struct {
+	Responses int `json:"responses"`
+	Settled   int `json:"settled"`
+	Returned  int `json:"returned"`
+	// Skipped are the results of the transfers that aren't waiting for
+	// the response, e.g. the repeated ones.
+	Skipped int `json:"skipped"`
+}
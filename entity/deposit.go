@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DepositStatus string
+
+const (
+	DepositActive    DepositStatus = "active"
+	DepositMatured   DepositStatus = "matured"
+	DepositWithdrawn DepositStatus = "withdrawn"
+)
+
+// MaturityAction is what happens to the deposit at its maturity.
+type MaturityAction string
+
+const (
+	// MaturityPayout pays the principal and the interest to the payout
+	// account.
+	MaturityPayout MaturityAction = "payout"
+	// MaturityRollover adds the interest to the principal and opens the
+	// next term with the same rate.
+	MaturityRollover MaturityAction = "rollover"
+)
+
+func (a MaturityAction) Valid() bool {
+	return a == MaturityPayout || a == MaturityRollover
+}
+
+// EarlyWithdrawal is whether the deposit can be withdrawn before its
+// maturity.
+type EarlyWithdrawal string
+
+const (
+	EarlyWithdrawalBlocked EarlyWithdrawal = "blocked"
+	// EarlyWithdrawalPenalty pays the interest of the elapsed days at the
+	// rate less the penalty.
+	EarlyWithdrawalPenalty EarlyWithdrawal = "penalty"
+)
+
+func (w EarlyWithdrawal) Valid() bool {
+	return w == EarlyWithdrawalBlocked || w == EarlyWithdrawalPenalty
+}
+
+// TermDeposit is the principal taken from the customer account for the
+// term. It is the liability of the bank in the term deposits GL account
+// until it is paid out to the payout account with the interest of the
+// term.
+type TermDeposit struct {
+	ID              int64     `json:"id"`
+	AccountID       uuid.UUID `json:"account_id"`
+	PayoutAccountID uuid.UUID `json:"payout_account_id"`
+	Principal       int64     `json:"principal"`
+	Currency        Currency  `json:"currency"`
+	// AnnualRateBP is the annual rate in basis points, the interest is
+	// ACT/365 of the days of the term.
+	AnnualRateBP    int32           `json:"annual_rate_bp"`
+	TermMonths      int32           `json:"term_months"`
+	StartDate       time.Time       `json:"start_date"`
+	MaturityDate    time.Time       `json:"maturity_date"`
+	MaturityAction  MaturityAction  `json:"maturity_action"`
+	EarlyWithdrawal EarlyWithdrawal `json:"early_withdrawal"`
+	// EarlyPenaltyBP is taken off the annual rate of the early
+	// withdrawal.
+	EarlyPenaltyBP int32         `json:"early_penalty_bp"`
+	Status         DepositStatus `json:"status"`
+	Rollovers      int32         `json:"rollovers"`
+	// InterestPaid is the interest paid out or added to the principal.
+	InterestPaid int64      `json:"interest_paid"`
+	CreatedBy    string     `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+}
+
+// Interest returns the interest of the current term if the deposit is
+// closed on the date. The whole term earns the annual rate, the early
+// withdrawal earns the rate less the penalty for the elapsed days.
+func (d TermDeposit) Interest(date time.Time) int64 {
+	if !date.Before(d.MaturityDate) {
+		return simpleInterest(d.Principal, d.AnnualRateBP, d.StartDate, d.MaturityDate)
+	}
+	return simpleInterest(d.Principal, d.AnnualRateBP-d.EarlyPenaltyBP, d.StartDate, date)
+}
+
+// DepositMaturity returns the maturity date of the term from the start,
+// the same day or the last day of the shorter month.
+func DepositMaturity(start time.Time, months int32) time.Time {
+	return addMonths(start, int(months))
+}
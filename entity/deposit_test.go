@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTermDepositInterest(t *testing.T) {
+	start := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	d := TermDeposit{
+		Principal:      1000000,
+		AnnualRateBP:   730,
+		TermMonths:     3,
+		StartDate:      start,
+		MaturityDate:   DepositMaturity(start, 3),
+		EarlyPenaltyBP: 365,
+	}
+	if want := time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC); !d.MaturityDate.Equal(want) {
+		t.Fatalf("maturity is %s, want %s", d.MaturityDate, want)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int64
+	}{
+		// 89 days at 7.3%
+		{"maturity", d.MaturityDate, 17800},
+		{"after maturity", d.MaturityDate.AddDate(0, 0, 10), 17800},
+		// 10 days at 3.65%
+		{"early", start.AddDate(0, 0, 10), 1000},
+		{"start", start, 0},
+	}
+	for _, tt := range tests {
+		if got := d.Interest(tt.date); got != tt.want {
+			t.Errorf("%s: Interest = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// the penalty doesn't take the principal
+	d.EarlyPenaltyBP = 1000
+	if got := d.Interest(start.AddDate(0, 1, 0)); got != 0 {
+		t.Errorf("Interest with the penalty above the rate = %d, want 0", got)
+	}
+}
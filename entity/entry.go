@@ -7,8 +7,11 @@ import (
 )
 
 type Entry struct {
-	ID        int64     `json:"id"`
-	AccountID uuid.UUID `json:"account_id"`
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          int64     `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	Amount      int64     `json:"amount"`
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description,omitempty"`
+	Reference   string    `json:"reference,omitempty"`
+	Metadata    Metadata  `json:"metadata,omitempty"`
 }
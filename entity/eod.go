@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusinessDate is the open date of the bank. It is frozen while the EOD
+// run of the date is in progress and advanced by its last step.
+type BusinessDate struct {
+	Date      time.Time `json:"date"`
+	Frozen    bool      `json:"frozen"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EODStep is a step of the end-of-day run. Each step is safe to repeat.
+type EODStep string
+
+const (
+	EODFreeze          EODStep = "freeze"
+	EODSnapshot        EODStep = "balance_snapshot"
+	EODInterestAccrual EODStep = "interest_accrual"
+	EODInterestPosting EODStep = "interest_posting"
+	EODFees            EODStep = "fee_charging"
+	EODLoanRepayment   EODStep = "loan_repayment"
+	EODDepositMaturity EODStep = "deposit_maturity"
+	EODReconciliation  EODStep = "reconciliation"
+	EODStatements      EODStep = "statements"
+	EODAdvance         EODStep = "advance"
+)
+
+// EODSteps are the steps of the run in their order.
+var EODSteps = []EODStep{
+	EODFreeze,
+	EODSnapshot,
+	EODInterestAccrual,
+	EODInterestPosting,
+	EODFees,
+	EODLoanRepayment,
+	EODDepositMaturity,
+	EODReconciliation,
+	EODStatements,
+	EODAdvance,
+}
+
+type EODStatus string
+
+const (
+	EODRunning   EODStatus = "running"
+	EODCompleted EODStatus = "completed"
+	EODFailed    EODStatus = "failed"
+)
+
+type EODStepRun struct {
+	Step       EODStep    `json:"step"`
+	Status     EODStatus  `json:"status"`
+	Result     BatchRun   `json:"result"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// EODRun is the run log of the business date. The failed or interrupted
+// run is resumed from its first step that hasn't completed.
+type EODRun struct {
+	ID           int64        `json:"id"`
+	BusinessDate time.Time    `json:"business_date"`
+	Status       EODStatus    `json:"status"`
+	Error        string       `json:"error,omitempty"`
+	StartedBy    string       `json:"started_by"`
+	StartedAt    time.Time    `json:"started_at"`
+	FinishedAt   *time.Time   `json:"finished_at,omitempty"`
+	Steps        []EODStepRun `json:"steps,omitempty"`
+}
+
+// Completed reports whether the step has completed in the run.
+func (r EODRun) Completed(step EODStep) bool {
+	for _, v := range r.Steps {
+		if v.Step == step {
+			return v.Status == EODCompleted
+		}
+	}
+	return false
+}
+
+// DailyStatement is the summary of the account entries of the date.
+type DailyStatement struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	Date           time.Time `json:"date"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	Debits         int64     `json:"debits"`
+	Credits        int64     `json:"credits"`
+	Entries        int64     `json:"entries"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	// EventEntry is sent for every entry posted to an account. Its ID is
+	// the entry ID, so clients can resume the stream from it.
+	EventEntry EventType = "entry"
+	// EventBalance carries the current account balance. It has no ID.
+	EventBalance EventType = "balance"
+)
+
+type AccountEvent struct {
+	ID        int64     `json:"id,omitempty"`
+	Type      EventType `json:"type"`
+	AccountID uuid.UUID `json:"account_id"`
+	Entry     *Entry    `json:"entry,omitempty"`
+	Balance   *int64    `json:"balance,omitempty"`
+	Currency  Currency  `json:"currency,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewEntryEvent(e Entry) AccountEvent {
+	return AccountEvent{
+		ID:        e.ID,
+		Type:      EventEntry,
+		AccountID: e.AccountID,
+		Entry:     &e,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func NewBalanceEvent(a Account) AccountEvent {
+	balance := a.Balance
+	return AccountEvent{
+		Type:      EventBalance,
+		AccountID: a.ID,
+		Balance:   &balance,
+		Currency:  a.Currency,
+		CreatedAt: time.Now(),
+	}
+}
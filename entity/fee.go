@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeeKind is the way the fee is calculated.
+type FeeKind string
+
+const (
+	FeeFixed      FeeKind = "fixed"
+	FeePercentage FeeKind = "percentage"
+	FeeTiered     FeeKind = "tiered"
+)
+
+// FeeAppliesTo is the operation charged by the fee.
+type FeeAppliesTo string
+
+const (
+	// FeeTransferOwn charges the transfers between the accounts of the
+	// same owner.
+	FeeTransferOwn FeeAppliesTo = "transfer_own"
+	// FeeTransferP2P charges the transfers to the other owners.
+	FeeTransferP2P FeeAppliesTo = "transfer_p2p"
+	// FeeExternal charges the transfers to the other banks.
+	FeeExternal FeeAppliesTo = "external"
+	// FeeMaintenance charges the accounts of the product monthly.
+	FeeMaintenance FeeAppliesTo = "maintenance"
+)
+
+// FeeTier is the fee of the amounts up to UpTo, a zero UpTo is the tier
+// of all larger amounts.
+type FeeTier struct {
+	UpTo   int64 `json:"up_to"`
+	Fixed  int64 `json:"fixed"`
+	RateBP int32 `json:"rate_bp"`
+}
+
+// FeeTiers are the tiers of the fee stored as a JSON array, ascending
+// by UpTo.
+type FeeTiers []FeeTier
+
+func (t FeeTiers) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(t)
+}
+
+func (t *FeeTiers) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("fee tiers: unsupported type %T", src)
+	}
+
+	var result FeeTiers
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("fee tiers: %w", err)
+	}
+	if len(result) == 0 {
+		result = nil
+	}
+	*t = result
+	return nil
+}
+
+// FeeSchedule is the fee of the operation. The schedule of the account
+// product replaces the ones without a product. The fee is paid to the
+// revenue account of the bank in the schedule currency.
+type FeeSchedule struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Kind      FeeKind      `json:"kind"`
+	AppliesTo FeeAppliesTo `json:"applies_to"`
+	// ProductID is zero for the schedules of all accounts.
+	ProductID int64    `json:"product_id,omitempty"`
+	Currency  Currency `json:"currency"`
+	Fixed     int64    `json:"fixed,omitempty"`
+	RateBP    int32    `json:"rate_bp,omitempty"`
+	// Min and Max bound the percentage and tiered fees, a zero Max is
+	// not enforced.
+	Min              int64     `json:"min,omitempty"`
+	Max              int64     `json:"max,omitempty"`
+	Tiers            FeeTiers  `json:"tiers,omitempty"`
+	RevenueAccountID uuid.UUID `json:"revenue_account_id"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Calculate returns the fee of the amount. The percentage is rounded
+// half up to the minor unit.
+func (s FeeSchedule) Calculate(amount int64) int64 {
+	var fee int64
+	switch s.Kind {
+	case FeeFixed:
+		return s.Fixed
+	case FeePercentage:
+		fee = percentOf(amount, s.RateBP)
+	case FeeTiered:
+		for _, t := range s.Tiers {
+			if t.UpTo == 0 || amount <= t.UpTo {
+				fee = t.Fixed + percentOf(amount, t.RateBP)
+				break
+			}
+		}
+	}
+
+	if fee < s.Min {
+		fee = s.Min
+	}
+	if s.Max > 0 && fee > s.Max {
+		fee = s.Max
+	}
+	return fee
+}
+
+// percentOf returns the basis points of the amount rounded half up.
+func percentOf(amount int64, rateBP int32) int64 {
+	v := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(rateBP)))
+	v.Add(v, big.NewInt(5000))
+	return v.Quo(v, big.NewInt(10000)).Int64()
+}
+
+// FeeCharge is the fee paid by the account. The fee is posted as the
+// debit entry of the account and the credit entry of the revenue
+// account.
+type FeeCharge struct {
+	ID               int64     `json:"id"`
+	ScheduleID       int64     `json:"schedule_id"`
+	Name             string    `json:"name"`
+	AccountID        uuid.UUID `json:"account_id"`
+	RevenueAccountID uuid.UUID `json:"revenue_account_id"`
+	Amount           int64     `json:"amount"`
+	// TransferID is the charged transfer, ExternalTransferID is the
+	// charged transfer to the other bank, Period is the first day of the
+	// charged month of the maintenance fees.
+	TransferID         int64      `json:"transfer_id,omitempty"`
+	ExternalTransferID int64      `json:"external_transfer_id,omitempty"`
+	Period             *time.Time `json:"period,omitempty"`
+	// Entry is the debit entry of the account.
+	Entry     Entry     `json:"entry"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "testing"
+
+func TestFeeScheduleCalculate(t *testing.T) {
+	tiers := FeeTiers{
+		{UpTo: 100000, Fixed: 1000},
+		{UpTo: 1000000, RateBP: 100},
+		{Fixed: 5000, RateBP: 50},
+	}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int64
+		want     int64
+	}{
+		{"fixed", FeeSchedule{Kind: FeeFixed, Fixed: 300}, 123456, 300},
+		{"percentage", FeeSchedule{Kind: FeePercentage, RateBP: 150}, 123456, 1852},
+		{"percentage rounds half up", FeeSchedule{Kind: FeePercentage, RateBP: 50}, 1100, 6},
+		{"percentage min", FeeSchedule{Kind: FeePercentage, RateBP: 150, Min: 2000}, 123456, 2000},
+		{"percentage max", FeeSchedule{Kind: FeePercentage, RateBP: 150, Max: 1500}, 123456, 1500},
+		{"first tier", FeeSchedule{Kind: FeeTiered, Tiers: tiers}, 100000, 1000},
+		{"second tier", FeeSchedule{Kind: FeeTiered, Tiers: tiers}, 500000, 5000},
+		{"last tier", FeeSchedule{Kind: FeeTiered, Tiers: tiers, Max: 9000}, 2000000, 9000},
+	}
+	for _, tt := range tests {
+		if got := tt.schedule.Calculate(tt.amount); got != tt.want {
+			t.Errorf("%s: Calculate(%d) = %d, want %d", tt.name, tt.amount, got, tt.want)
+		}
+	}
+}
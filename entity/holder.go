@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HolderRole is the role of the account holder. The owner of the account
+// is its primary holder.
+type HolderRole string
+
+const (
+	// HolderPrimary can do anything with the account, there is one
+	// primary holder of each account.
+	HolderPrimary HolderRole = "primary"
+	// HolderJoint co-owns the account and manages its holders but the
+	// primary one, the account is handed over by the primary holder only.
+	HolderJoint HolderRole = "joint"
+	// HolderSignatory transfers from the account on behalf of its owners.
+	HolderSignatory HolderRole = "signatory"
+	// HolderViewer sees the account, its entries and statements only.
+	HolderViewer HolderRole = "viewer"
+)
+
+// Valid reports whether the role can be given to an invited holder, the
+// primary holder changes with the account owner only.
+func (r HolderRole) Valid() bool {
+	return r == HolderJoint || r == HolderSignatory || r == HolderViewer
+}
+
+// Permission is an action on the account allowed to the holder roles.
+type Permission string
+
+const (
+	PermissionView          Permission = "view"
+	PermissionTransfer      Permission = "transfer"
+	PermissionManageHolders Permission = "manage_holders"
+	// PermissionChangeOwner hands the account over to the new owner, who
+	// replaces the primary holder.
+	PermissionChangeOwner Permission = "change_owner"
+)
+
+// Can reports whether the role allows the action.
+func (r HolderRole) Can(p Permission) bool {
+	switch p {
+	case PermissionView:
+		return r == HolderPrimary || r == HolderJoint || r == HolderSignatory || r == HolderViewer
+	case PermissionTransfer:
+		return r == HolderPrimary || r == HolderJoint || r == HolderSignatory
+	case PermissionManageHolders:
+		return r == HolderPrimary || r == HolderJoint
+	case PermissionChangeOwner:
+		return r == HolderPrimary
+	}
+	return false
+}
+
+type HolderStatus string
+
+const (
+	// HolderInvited has no access to the account until the invitation is
+	// accepted.
+	HolderInvited HolderStatus = "invited"
+	HolderActive  HolderStatus = "active"
+)
+
+// AccountHolder is the user holding the account in the role.
+type AccountHolder struct {
+	AccountID  uuid.UUID    `json:"account_id"`
+	Holder     string       `json:"holder"`
+	Role       HolderRole   `json:"role"`
+	Status     HolderStatus `json:"status"`
+	InvitedBy  string       `json:"invited_by,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	AcceptedAt *time.Time   `json:"accepted_at,omitempty"`
+}
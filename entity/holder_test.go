@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolderRoleCan(t *testing.T) {
+	tests := []struct {
+		role HolderRole
+		want []Permission
+	}{
+		{HolderPrimary, []Permission{PermissionView, PermissionTransfer, PermissionManageHolders, PermissionChangeOwner}},
+		{HolderJoint, []Permission{PermissionView, PermissionTransfer, PermissionManageHolders}},
+		{HolderSignatory, []Permission{PermissionView, PermissionTransfer}},
+		{HolderViewer, []Permission{PermissionView}},
+		{"owner", nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			var got []Permission
+			for _, p := range []Permission{PermissionView, PermissionTransfer, PermissionManageHolders,
+				PermissionChangeOwner} {
+				if tt.role.Can(p) {
+					got = append(got, p)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.False(t, HolderPrimary.Valid())
+	assert.True(t, HolderSignatory.Valid())
+}
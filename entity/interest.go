@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MicrosPerUnit is the number of the accrued interest micros in the
+// minor currency unit. The daily interest is mostly a fraction of the
+// unit, so it is accrued in micros and posted in units.
+const MicrosPerUnit = 1_000_000
+
+// DayCount is the day count convention of the interest product.
+type DayCount string
+
+const (
+	// DayCountACT365 accrues every calendar day as 1/365 of the year.
+	DayCountACT365 DayCount = "ACT/365"
+	// DayCount30360 treats every month as 30 days of the 360 day year.
+	DayCount30360 DayCount = "30/360"
+)
+
+func (c DayCount) Valid() bool {
+	return c == DayCountACT365 || c == DayCount30360
+}
+
+// Basis returns the days of the year of the convention.
+func (c DayCount) Basis() int64 {
+	if c == DayCount30360 {
+		return 360
+	}
+	return 365
+}
+
+// Days returns the days the calendar day accrues. Under 30/360 the 31st
+// accrues nothing and the last day of February accrues the rest of the
+// 30 day month, so every month accrues 30 days.
+func (c DayCount) Days(date time.Time) int64 {
+	if c != DayCount30360 {
+		return 1
+	}
+
+	switch day := date.Day(); {
+	case day == 31:
+		return 0
+	case date.Month() == time.February && date.AddDate(0, 0, 1).Month() == time.March:
+		return int64(30 - day + 1)
+	default:
+		return 1
+	}
+}
+
+// Accrue returns the interest micros of the balance for the calendar
+// day at the annual rate in basis points. The fraction of the micro is
+// dropped.
+func (c DayCount) Accrue(balance int64, rateBP int32, date time.Time) int64 {
+	// balance * rate / 10000 * days / basis * MicrosPerUnit
+	v := new(big.Int).Mul(big.NewInt(balance), big.NewInt(int64(rateBP)))
+	v.Mul(v, big.NewInt(c.Days(date)*MicrosPerUnit))
+	v.Quo(v, big.NewInt(10000*c.Basis()))
+	return v.Int64()
+}
+
+// InterestProduct is the savings product of the accounts. The interest
+// is paid from the expense account of the bank in the product currency.
+type InterestProduct struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Currency Currency `json:"currency"`
+	// AnnualRateBP is the annual rate in basis points, 1/100 of a percent.
+	AnnualRateBP     int32     `json:"annual_rate_bp"`
+	DayCount         DayCount  `json:"day_count"`
+	ExpenseAccountID uuid.UUID `json:"expense_account_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// InterestAccrual is the interest of the end-of-day balance of the
+// account.
+type InterestAccrual struct {
+	AccountID    uuid.UUID `json:"account_id"`
+	Date         time.Time `json:"date"`
+	ProductID    int64     `json:"product_id"`
+	Balance      int64     `json:"balance"`
+	AnnualRateBP int32     `json:"annual_rate_bp"`
+	DayCount     DayCount  `json:"day_count"`
+	Days         int64     `json:"days"`
+	AmountMicros int64     `json:"amount_micros"`
+}
+
+// InterestPosting is the monthly capitalization of the accrued interest.
+// The whole units are posted, the rest of the micros is carried to the
+// next month.
+type InterestPosting struct {
+	ID            int64     `json:"id"`
+	AccountID     uuid.UUID `json:"account_id"`
+	Period        time.Time `json:"period"`
+	AccruedMicros int64     `json:"accrued_micros"`
+	Amount        int64     `json:"amount"`
+	CarryMicros   int64     `json:"carry_micros"`
+	// TransferID is zero if nothing is posted.
+	TransferID int64     `json:"transfer_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
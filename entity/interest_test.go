@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayCountDays(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		c    DayCount
+		date string
+		want int64
+	}{
+		{DayCountACT365, "2023-01-31", 1},
+		{DayCountACT365, "2024-02-29", 1},
+		{DayCount30360, "2023-01-30", 1},
+		{DayCount30360, "2023-01-31", 0},
+		{DayCount30360, "2023-02-27", 1},
+		{DayCount30360, "2023-02-28", 3},
+		{DayCount30360, "2024-02-28", 1},
+		{DayCount30360, "2024-02-29", 2},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Days(date(tt.date)); got != tt.want {
+			t.Errorf("%s.Days(%s) = %d, want %d", tt.c, tt.date, got, tt.want)
+		}
+	}
+
+	// every month accrues 30 days under 30/360
+	for d := date("2024-01-01"); d.Year() == 2024; d = d.AddDate(0, 1, 0) {
+		var days int64
+		for day := d; day.Month() == d.Month(); day = day.AddDate(0, 0, 1) {
+			days += DayCount30360.Days(day)
+		}
+		if days != 30 {
+			t.Errorf("%s accrues %d days, want 30", d.Month(), days)
+		}
+	}
+}
+
+func TestDayCountAccrue(t *testing.T) {
+	day := time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		c       DayCount
+		balance int64
+		rateBP  int32
+		want    int64
+	}{
+		// 1000.00 at 5% is 0.136986... units a day
+		{DayCountACT365, 100000, 500, 13698630},
+		{DayCount30360, 100000, 500, 13888888},
+		{DayCountACT365, 0, 500, 0},
+		{DayCountACT365, 100000, 0, 0},
+		// doesn't overflow on the large balances
+		{DayCountACT365, 9e12, 10000, 24657534246575342},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Accrue(tt.balance, tt.rateBP, day); got != tt.want {
+			t.Errorf("%s.Accrue(%d, %d) = %d, want %d", tt.c, tt.balance, tt.rateBP, got, tt.want)
+		}
+	}
+}
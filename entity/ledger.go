@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GLAccountType is the section of the chart of accounts.
+type GLAccountType string
+
+const (
+	GLAsset     GLAccountType = "asset"
+	GLLiability GLAccountType = "liability"
+	GLEquity    GLAccountType = "equity"
+	GLIncome    GLAccountType = "income"
+	GLExpense   GLAccountType = "expense"
+)
+
+func (t GLAccountType) Valid() bool {
+	switch t {
+	case GLAsset, GLLiability, GLEquity, GLIncome, GLExpense:
+		return true
+	}
+	return false
+}
+
+// Codes of the default chart of accounts, each is opened in every
+// currency.
+const (
+	GLCodeCash            = "1000"
+	GLCodeATMCash         = "1010"
+	GLCodeClearing        = "1100"
+	GLCodeCardSettlement  = "1200"
+	GLCodeLoans           = "1300"
+	GLCodeSuspense        = "1900"
+	GLCodeOpening         = "3000"
+	GLCodeFeeIncome       = "4000"
+	GLCodeInterestIncome  = "4100"
+	GLCodeInterestExpense = "5000"
+	// GLCodeCustomerDeposits is the trial balance line of the customer
+	// accounts, it has no account of its own.
+	GLCodeCustomerDeposits = "2000"
+	GLCodeTermDeposits     = "2100"
+)
+
+// GLAccount is the bank owned account of the general ledger. Its balance
+// has the sign of the customer accounts: the credits are positive and
+// the debits are negative, so the assets and expenses are usually below
+// zero.
+type GLAccount struct {
+	AccountID uuid.UUID     `json:"account_id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      GLAccountType `json:"type"`
+	Currency  Currency      `json:"currency"`
+	Balance   int64         `json:"balance"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Posting moves the amount from the debited account to the credited one
+// with a pair of entries.
+type Posting struct {
+	DebitAccountID  uuid.UUID `json:"debit_account_id"`
+	CreditAccountID uuid.UUID `json:"credit_account_id"`
+	Amount          int64     `json:"amount"`
+	Description     string    `json:"description"`
+	Reference       string    `json:"reference,omitempty"`
+	Metadata        Metadata  `json:"metadata,omitempty"`
+}
+
+type PostingRes struct {
+	DebitEntry    Entry   `json:"debit_entry"`
+	DebitAccount  Account `json:"debit_account"`
+	CreditEntry   Entry   `json:"credit_entry"`
+	CreditAccount Account `json:"credit_account"`
+}
+
+type TrialBalanceLine struct {
+	Code   string        `json:"code"`
+	Name   string        `json:"name"`
+	Type   GLAccountType `json:"type"`
+	Debit  int64         `json:"debit"`
+	Credit int64         `json:"credit"`
+}
+
+// TrialBalance lists the balances of the ledger in one currency. The
+// books are balanced if the debits equal the credits.
+type TrialBalance struct {
+	Currency    Currency           `json:"currency"`
+	Lines       []TrialBalanceLine `json:"lines"`
+	TotalDebit  int64              `json:"total_debit"`
+	TotalCredit int64              `json:"total_credit"`
+	Balanced    bool               `json:"balanced"`
+}
+
+// Add adds the line of the balance to the debit or credit column.
+func (b *TrialBalance) Add(code, name string, t GLAccountType, balance int64) {
+	line := TrialBalanceLine{Code: code, Name: name, Type: t}
+	if balance < 0 {
+		line.Debit = -balance
+	} else {
+		line.Credit = balance
+	}
+	b.Lines = append(b.Lines, line)
+	b.TotalDebit += line.Debit
+	b.TotalCredit += line.Credit
+	b.Balanced = b.TotalDebit == b.TotalCredit
+}
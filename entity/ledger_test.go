@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrialBalanceAdd(t *testing.T) {
+	var b TrialBalance
+	b.Add(GLCodeCash, "Cash", GLAsset, -1500)
+	b.Add(GLCodeCustomerDeposits, "Customer deposits", GLLiability, 1450)
+	assert.False(t, b.Balanced)
+
+	b.Add(GLCodeFeeIncome, "Fee income", GLIncome, 50)
+	assert.True(t, b.Balanced)
+	assert.Equal(t, int64(1500), b.TotalDebit)
+	assert.Equal(t, int64(1500), b.TotalCredit)
+	assert.Equal(t, TrialBalanceLine{Code: GLCodeCash, Name: "Cash", Type: GLAsset, Debit: 1500}, b.Lines[0])
+	assert.Equal(t, int64(50), b.Lines[2].Credit)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferLimits are the outgoing transfer limits of an account. A zero
+// limit is not enforced.
+type TransferLimits struct {
+	Tier        string `json:"tier"`
+	MaxSingle   int64  `json:"max_single"`
+	Daily       int64  `json:"daily"`
+	Monthly     int64  `json:"monthly"`
+	HourlyCount int64  `json:"hourly_count"`
+}
+
+// TransferUsage is the outgoing transfers of an account within the
+// limit windows: the current UTC day and month and the last hour.
+type TransferUsage struct {
+	Daily       int64 `json:"daily"`
+	Monthly     int64 `json:"monthly"`
+	HourlyCount int64 `json:"hourly_count"`
+}
+
+// AccountLimits is the limits configuration of an account: its tier and
+// the overrides of the tier limits set by an admin. A nil override
+// means the tier limit.
+type AccountLimits struct {
+	AccountID   uuid.UUID      `json:"account_id"`
+	Tier        string         `json:"tier"`
+	MaxSingle   *int64         `json:"max_single,omitempty"`
+	Daily       *int64         `json:"daily,omitempty"`
+	Monthly     *int64         `json:"monthly,omitempty"`
+	HourlyCount *int64         `json:"hourly_count,omitempty"`
+	Effective   TransferLimits `json:"effective"`
+	UpdatedBy   string         `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time      `json:"updated_at,omitempty"`
+}
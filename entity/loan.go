@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AmortizationMethod is how the loan principal is spread over the
+// installments.
+type AmortizationMethod string
+
+const (
+	// AmortizationAnnuity repays the loan by equal installments, the
+	// share of the principal grows as the interest falls.
+	AmortizationAnnuity AmortizationMethod = "annuity"
+	// AmortizationLinear repays equal parts of the principal with the
+	// interest of the outstanding principal, so the installments fall.
+	AmortizationLinear AmortizationMethod = "linear"
+)
+
+func (m AmortizationMethod) Valid() bool {
+	return m == AmortizationAnnuity || m == AmortizationLinear
+}
+
+type LoanStatus string
+
+const (
+	LoanActive LoanStatus = "active"
+	LoanRepaid LoanStatus = "repaid"
+)
+
+type InstallmentStatus string
+
+const (
+	InstallmentScheduled InstallmentStatus = "scheduled"
+	// InstallmentLate is not collected on its due date, it accrues the
+	// penalty interest until it is paid.
+	InstallmentLate InstallmentStatus = "late"
+	InstallmentPaid InstallmentStatus = "paid"
+)
+
+// Loan is disbursed to the customer account and repaid from it by the
+// monthly installments. The principal is the asset of the bank in the
+// loans GL account, the interest is its income.
+type Loan struct {
+	ID        int64     `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Principal int64     `json:"principal"`
+	Currency  Currency  `json:"currency"`
+	// AnnualRateBP and PenaltyRateBP are the annual rates in basis
+	// points, the penalty rate applies to the late installments.
+	AnnualRateBP  int32              `json:"annual_rate_bp"`
+	PenaltyRateBP int32              `json:"penalty_rate_bp"`
+	TermMonths    int32              `json:"term_months"`
+	Method        AmortizationMethod `json:"method"`
+	Status        LoanStatus         `json:"status"`
+	// Outstanding is the principal not repaid yet.
+	Outstanding  int64         `json:"outstanding"`
+	DisbursedOn  time.Time     `json:"disbursed_on"`
+	CreatedBy    string        `json:"created_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ClosedAt     *time.Time    `json:"closed_at,omitempty"`
+	Installments []Installment `json:"installments,omitempty"`
+}
+
+// Installment is the monthly repayment of the loan. The penalty of the
+// late installment is its penalty interest as of today until it is paid.
+type Installment struct {
+	LoanID    int64             `json:"loan_id"`
+	Number    int32             `json:"number"`
+	DueDate   time.Time         `json:"due_date"`
+	Principal int64             `json:"principal"`
+	Interest  int64             `json:"interest"`
+	Penalty   int64             `json:"penalty"`
+	Status    InstallmentStatus `json:"status"`
+	PaidOn    *time.Time        `json:"paid_on,omitempty"`
+}
+
+// Amount returns the amount to pay.
+func (i Installment) Amount() int64 {
+	return i.Principal + i.Interest + i.Penalty
+}
+
+// AmortizationSchedule returns the monthly installments of the loan. The
+// installments are due on the day of the disbursement in the next months
+// or on the last day of the shorter ones. The interest of a month is
+// 1/12 of the annual rate of the outstanding principal, rounded half up.
+// The last installment repays the rest of the principal left by the
+// rounding.
+func AmortizationSchedule(principal int64, rateBP int32, months int32, method AmortizationMethod,
+	disbursed time.Time) []Installment {
+	if principal <= 0 || months <= 0 {
+		return nil
+	}
+
+	payment := principal / int64(months)
+	if method == AmortizationAnnuity {
+		payment = annuityPayment(principal, rateBP, months)
+	}
+
+	result := make([]Installment, 0, months)
+	outstanding := principal
+	for n := int32(1); n <= months; n++ {
+		i := Installment{
+			Number:   n,
+			DueDate:  addMonths(disbursed, int(n)),
+			Interest: mulDivRound(outstanding, int64(rateBP), 12*10000),
+			Status:   InstallmentScheduled,
+		}
+		switch {
+		case n == months:
+			i.Principal = outstanding
+		case method == AmortizationAnnuity:
+			i.Principal = payment - i.Interest
+		default:
+			i.Principal = payment
+		}
+		if i.Principal > outstanding {
+			i.Principal = outstanding
+		}
+		outstanding -= i.Principal
+		result = append(result, i)
+	}
+	return result
+}
+
+// annuityPayment returns the equal monthly payment P*r/(1-(1+r)^-n) of
+// the monthly rate r, rounded half up.
+func annuityPayment(principal int64, rateBP int32, months int32) int64 {
+	if rateBP == 0 {
+		return (principal + int64(months) - 1) / int64(months)
+	}
+
+	r := big.NewRat(int64(rateBP), 12*10000)
+	growth := new(big.Rat).Add(big.NewRat(1, 1), r)
+	pow := big.NewRat(1, 1)
+	for i := int32(0); i < months; i++ {
+		pow.Mul(pow, growth)
+	}
+
+	// P * r * (1+r)^n / ((1+r)^n - 1)
+	v := new(big.Rat).Mul(big.NewRat(principal, 1), r)
+	v.Mul(v, pow)
+	v.Quo(v, new(big.Rat).Sub(pow, big.NewRat(1, 1)))
+	v.Add(v, big.NewRat(1, 2))
+	return new(big.Int).Quo(v.Num(), v.Denom()).Int64()
+}
+
+// PenaltyInterest returns the penalty interest of the overdue amount for
+// the days after the due date until the date, ACT/365 at the annual
+// rate in basis points. The fraction of the unit is dropped.
+func PenaltyInterest(amount int64, rateBP int32, due, date time.Time) int64 {
+	return simpleInterest(amount, rateBP, due, date)
+}
+
+// simpleInterest returns the ACT/365 interest of the amount for the days
+// from the date until the other one at the annual rate in basis points.
+// The fraction of the unit is dropped.
+func simpleInterest(amount int64, rateBP int32, from, to time.Time) int64 {
+	days := int64(to.Sub(from).Hours() / 24)
+	if days <= 0 || amount <= 0 || rateBP <= 0 {
+		return 0
+	}
+	v := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(rateBP)))
+	v.Mul(v, big.NewInt(days))
+	v.Quo(v, big.NewInt(10000*365))
+	return v.Int64()
+}
+
+// mulDivRound returns a*b/c rounded half up for the positive values.
+func mulDivRound(a, b, c int64) int64 {
+	v := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	v.Add(v, big.NewInt(c/2))
+	v.Quo(v, big.NewInt(c))
+	return v.Int64()
+}
+
+// addMonths returns the same day n months later, or the last day of the
+// month if it is shorter.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
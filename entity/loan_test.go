@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAmortizationSchedule(t *testing.T) {
+	disbursed := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	annuity := AmortizationSchedule(1000000, 1200, 12, AmortizationAnnuity, disbursed)
+	if len(annuity) != 12 {
+		t.Fatalf("annuity has %d installments, want 12", len(annuity))
+	}
+	var principal, interest int64
+	for _, i := range annuity {
+		principal += i.Principal
+		interest += i.Interest
+		if i.Number < 12 && i.Amount() != 88849 {
+			t.Errorf("installment %d is %d, want 88849", i.Number, i.Amount())
+		}
+	}
+	if principal != 1000000 || interest != 66186 {
+		t.Errorf("annuity repays %d with %d interest, want 1000000 with 66186", principal, interest)
+	}
+	if first := annuity[0]; first.Interest != 10000 || first.Principal != 78849 {
+		t.Errorf("first installment is %d + %d, want 78849 + 10000", first.Principal, first.Interest)
+	}
+	// the due dates stay on the day of the disbursement or the month end
+	for _, tt := range []struct {
+		n    int
+		want string
+	}{{0, "2023-02-28"}, {1, "2023-03-31"}, {2, "2023-04-30"}, {11, "2024-01-31"}} {
+		if got := annuity[tt.n].DueDate.Format("2006-01-02"); got != tt.want {
+			t.Errorf("installment %d is due %s, want %s", tt.n+1, got, tt.want)
+		}
+	}
+
+	linear := AmortizationSchedule(1000000, 1200, 12, AmortizationLinear, disbursed)
+	principal = 0
+	for _, i := range linear {
+		principal += i.Principal
+	}
+	if principal != 1000000 || linear[0].Principal != 83333 || linear[11].Principal != 83337 {
+		t.Errorf("linear repays %d by %d to %d", principal, linear[0].Principal, linear[11].Principal)
+	}
+	if linear[0].Interest != 10000 || linear[11].Interest != 833 {
+		t.Errorf("linear interest is %d to %d, want 10000 to 833", linear[0].Interest, linear[11].Interest)
+	}
+
+	free := AmortizationSchedule(1000000, 0, 3, AmortizationAnnuity, disbursed)
+	if free[0].Amount() != 333334 || free[2].Amount() != 333332 {
+		t.Errorf("interest-free annuity is %d to %d", free[0].Amount(), free[2].Amount())
+	}
+	if s := AmortizationSchedule(0, 1200, 12, AmortizationAnnuity, disbursed); s != nil {
+		t.Errorf("zero principal has %d installments", len(s))
+	}
+}
+
+func TestPenaltyInterest(t *testing.T) {
+	due := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		days int
+		want int64
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, 2},
+		{30, 73},
+		{365, 900},
+	}
+	for _, tt := range tests {
+		// 10000 at 9% a year
+		if got := PenaltyInterest(10000, 900, due, due.AddDate(0, 0, tt.days)); got != tt.want {
+			t.Errorf("PenaltyInterest after %d days = %d, want %d", tt.days, got, tt.want)
+		}
+	}
+}
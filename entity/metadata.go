@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Metadata is the client defined key/value data attached to transfers
+// and their entries.
+type Metadata map[string]string
+
+// Value stores the metadata as a JSON object, nil metadata included.
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *Metadata) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("metadata: unsupported type %T", src)
+	}
+
+	var result Metadata
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("metadata: %w", err)
+	}
+	if len(result) == 0 {
+		result = nil
+	}
+	*m = result
+	return nil
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payee is a recipient account saved by the owner under a nickname.
+type Payee struct {
+	ID            int64     `json:"id"`
+	Owner         string    `json:"owner"`
+	Nickname      string    `json:"nickname"`
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountNumber string    `json:"account_number,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
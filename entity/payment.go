@@ -0,0 +1,72 @@
+package entity
+
+import "time"
+
+// PaymentStatus is the ISO 20022 status of a payment instruction or file.
+type PaymentStatus string
+
+const (
+	PaymentAccepted          PaymentStatus = "ACCP"
+	PaymentPartiallyAccepted PaymentStatus = "PART"
+	PaymentRejected          PaymentStatus = "RJCT"
+	PaymentPending           PaymentStatus = "PDNG"
+)
+
+// PaymentFile is a customer credit transfer initiation, e.g. pain.001.
+type PaymentFile struct {
+	MsgID     string
+	CreatedAt time.Time
+	NbOfTxs   int
+	CtrlSum   *int64
+	Batches   []PaymentBatch
+}
+
+// PaymentBatch is a set of instructions debiting the same account.
+type PaymentBatch struct {
+	ID            string
+	NbOfTxs       int
+	CtrlSum       *int64
+	DebtorAccount string
+	Instructions  []PaymentInstruction
+}
+
+type PaymentInstruction struct {
+	InstrID         string
+	EndToEndID      string
+	Amount          int64
+	Currency        Currency
+	CreditorAccount string
+	Description     string
+}
+
+// PaymentStatusReport is the result of a payment file processing, e.g. pain.002.
+type PaymentStatusReport struct {
+	MsgID        string
+	CreatedAt    time.Time
+	OrgnlMsgID   string
+	OrgnlNbOfTxs int
+	OrgnlCtrlSum *int64
+	Status       PaymentStatus
+	Reason       *PaymentStatusReason
+	Batches      []PaymentBatchStatus
+}
+
+type PaymentBatchStatus struct {
+	OrgnlBatchID string
+	Status       PaymentStatus
+	Transactions []PaymentTxStatus
+}
+
+type PaymentTxStatus struct {
+	OrgnlInstrID    string
+	OrgnlEndToEndID string
+	Status          PaymentStatus
+	Reason          *PaymentStatusReason
+	TransferID      int64
+}
+
+// PaymentStatusReason holds an ISO 20022 external status reason code.
+type PaymentStatusReason struct {
+	Code string
+	Info string
+}
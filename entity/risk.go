@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RiskDecision is the outcome of the risk evaluation of a transfer.
+type RiskDecision string
+
+const (
+	RiskAllow  RiskDecision = "allow"
+	RiskReview RiskDecision = "review"
+	RiskBlock  RiskDecision = "block"
+)
+
+// RiskSignal is a risk rule triggered by a transfer.
+type RiskSignal struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// RiskSignals are the triggered risk rules stored as a JSON array.
+type RiskSignals []RiskSignal
+
+func (s RiskSignals) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *RiskSignals) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("risk signals: unsupported type %T", src)
+	}
+
+	var result RiskSignals
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("risk signals: %w", err)
+	}
+	if len(result) == 0 {
+		result = nil
+	}
+	*s = result
+	return nil
+}
+
+type RiskAssessment struct {
+	Score    int          `json:"score"`
+	Decision RiskDecision `json:"decision"`
+	Signals  RiskSignals  `json:"signals,omitempty"`
+}
+
+// RiskHistory is the past activity of the debited account.
+type RiskHistory struct {
+	// PayeeTransfers is the number of transfers to the same recipient.
+	PayeeTransfers int64
+	TransferCount  int64
+	AverageAmount  int64
+	// RecentCount is the number of transfers within the recent window.
+	RecentCount int64
+}
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// TransferReview is a transfer held for the manual review of an operator.
+// The transfer is executed only when the review is approved.
+type TransferReview struct {
+	ID         int64        `json:"id"`
+	Transfer   Transfer     `json:"transfer"`
+	Score      int          `json:"score"`
+	Signals    RiskSignals  `json:"signals"`
+	Status     ReviewStatus `json:"status"`
+	ReviewedBy string       `json:"reviewed_by,omitempty"`
+	Comment    string       `json:"comment,omitempty"`
+	ReviewedAt *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
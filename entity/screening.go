@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WatchlistEntry is a sanctioned person or organization of a watchlist.
+type WatchlistEntry struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Program string   `json:"program,omitempty"`
+}
+
+// ScreeningMatch is a watchlist entry similar to the screened name. The
+// score is from 0 to 1, 1 means the names are equal after normalization.
+type ScreeningMatch struct {
+	EntryID string  `json:"entry_id"`
+	Name    string  `json:"name"`
+	Program string  `json:"program,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+// ScreeningMatches are the watchlist matches stored as a JSON array.
+type ScreeningMatches []ScreeningMatch
+
+func (m ScreeningMatches) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *ScreeningMatches) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("screening matches: unsupported type %T", src)
+	}
+
+	var result ScreeningMatches
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("screening matches: %w", err)
+	}
+	if len(result) == 0 {
+		result = nil
+	}
+	*m = result
+	return nil
+}
+
+// ScreeningTrigger is the event the name is screened on.
+type ScreeningTrigger string
+
+const (
+	ScreenAccountOpening ScreeningTrigger = "account_opening"
+	ScreenOwnerUpdate    ScreeningTrigger = "owner_update"
+	ScreenHolderInvite   ScreeningTrigger = "holder_invite"
+	ScreenTransfer       ScreeningTrigger = "transfer"
+)
+
+// ScreeningDecision is the outcome of the screening of a name.
+type ScreeningDecision string
+
+const (
+	ScreeningClear ScreeningDecision = "clear"
+	ScreeningFlag  ScreeningDecision = "flag"
+	ScreeningBlock ScreeningDecision = "block"
+)
+
+type AlertStatus string
+
+const (
+	AlertPending   AlertStatus = "pending"
+	AlertCleared   AlertStatus = "cleared"
+	AlertConfirmed AlertStatus = "confirmed"
+)
+
+// ScreeningAlert is a name matched against the watchlist, waiting for
+// the compliance officer to clear it as a false positive or confirm it.
+type ScreeningAlert struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Trigger   ScreeningTrigger  `json:"trigger"`
+	AccountID uuid.UUID         `json:"account_id"`
+	Score     float64           `json:"score"`
+	Matches   ScreeningMatches  `json:"matches"`
+	Decision  ScreeningDecision `json:"decision"`
+	Status    AlertStatus       `json:"status"`
+	// ReviewedBy, Comment and ReviewedAt are set when the alert is closed.
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	Comment    string     `json:"comment,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Statement struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	Owner          string          `json:"owner"`
+	Currency       Currency        `json:"currency"`
+	From           time.Time       `json:"from"`
+	To             time.Time       `json:"to"`
+	OpeningBalance int64           `json:"opening_balance"`
+	ClosingBalance int64           `json:"closing_balance"`
+	TotalDebits    int64           `json:"total_debits"`
+	TotalCredits   int64           `json:"total_credits"`
+	Lines          []StatementLine `json:"lines"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+type StatementLine struct {
+	EntryID      int64      `json:"entry_id"`
+	TransferID   int64      `json:"transfer_id,omitempty"`
+	Reference    string     `json:"reference,omitempty"`
+	Counterparty *uuid.UUID `json:"counterparty,omitempty"`
+	Description  string     `json:"description"`
+	Amount       int64      `json:"amount"`
+	Balance      int64      `json:"balance"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
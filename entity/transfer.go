@@ -6,14 +6,88 @@ import (
 	"github.com/google/uuid"
 )
 
+// TransferStatus is the stage of the transfer execution. The transfers
+// held for a review or an approval are pending, the executed ones are
+// processing until their entries are posted. The failed and cancelled
+// transfers have no entries.
+type TransferStatus string
+
+const (
+	TransferPending    TransferStatus = "pending"
+	TransferProcessing TransferStatus = "processing"
+	TransferCompleted  TransferStatus = "completed"
+	TransferFailed     TransferStatus = "failed"
+	TransferReversed   TransferStatus = "reversed"
+	TransferCancelled  TransferStatus = "cancelled"
+)
+
+// Failure reasons of the failed and cancelled transfers.
+const (
+	FailureInsufficientFunds = "insufficient_funds"
+	FailureLimitExceeded     = "limit_exceeded"
+	FailureRejected          = "rejected"
+	FailureExpired           = "expired"
+)
+
+// transferTransitions holds the statuses each status can move to, the
+// empty status is the one of a new transfer.
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	"":                 {TransferPending, TransferProcessing},
+	TransferPending:    {TransferProcessing, TransferCancelled},
+	TransferProcessing: {TransferCompleted, TransferFailed},
+	TransferCompleted:  {TransferReversed},
+}
+
+// Valid reports whether s is a known status.
+func (s TransferStatus) Valid() bool {
+	switch s {
+	case TransferPending, TransferProcessing, TransferCompleted, TransferFailed, TransferReversed, TransferCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransition reports whether the transfer in the status can move to
+// the next one.
+func (s TransferStatus) CanTransition(next TransferStatus) bool {
+	for _, v := range transferTransitions[s] {
+		if v == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Final reports whether the transfer in the status can't change anymore.
+func (s TransferStatus) Final() bool {
+	return s != "" && len(transferTransitions[s]) == 0
+}
+
 type Transfer struct {
-	ID            int64     `json:"id"`
-	FromAccountID uuid.UUID `json:"from_account_id"`
-	ToAccountID   uuid.UUID `json:"to_account_id"`
-	Amount        int64     `json:"amount"`
-	FromEntryID   int64     `json:"from_entry_id"`
-	ToEntryID     int64     `json:"to_entry_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            int64          `json:"id"`
+	FromAccountID uuid.UUID      `json:"from_account_id"`
+	ToAccountID   uuid.UUID      `json:"to_account_id"`
+	Amount        int64          `json:"amount"`
+	FromEntryID   int64          `json:"from_entry_id"`
+	ToEntryID     int64          `json:"to_entry_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Description   string         `json:"description,omitempty"`
+	Reference     string         `json:"reference,omitempty"`
+	Metadata      Metadata       `json:"metadata,omitempty"`
+	Status        TransferStatus `json:"status,omitempty"`
+	FailureReason string         `json:"failure_reason,omitempty"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// TransferTransition is a status change of the transfer.
+type TransferTransition struct {
+	ID         int64          `json:"id"`
+	TransferID int64          `json:"transfer_id"`
+	FromStatus TransferStatus `json:"from_status,omitempty"`
+	ToStatus   TransferStatus `json:"to_status"`
+	Reason     string         `json:"reason,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
 }
 
 type TransferRes struct {
@@ -22,4 +96,6 @@ type TransferRes struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fees are charged from the from account on top of the amount.
+	Fees []FeeCharge `json:"fees,omitempty"`
 }
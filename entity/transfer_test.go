@@ -0,0 +1,35 @@
+package entity
+
+import "testing"
+
+func TestTransferStatusCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to TransferStatus
+		want     bool
+	}{
+		{"", TransferPending, true},
+		{"", TransferProcessing, true},
+		{"", TransferCompleted, false},
+		{TransferPending, TransferProcessing, true},
+		{TransferPending, TransferCancelled, true},
+		{TransferPending, TransferFailed, false},
+		{TransferProcessing, TransferCompleted, true},
+		{TransferProcessing, TransferFailed, true},
+		{TransferProcessing, TransferCancelled, false},
+		{TransferCompleted, TransferReversed, true},
+		{TransferCompleted, TransferFailed, false},
+		{TransferFailed, TransferCompleted, false},
+		{TransferReversed, TransferCompleted, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransition(tt.to); got != tt.want {
+			t.Errorf("%q.CanTransition(%q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	for _, s := range []TransferStatus{TransferFailed, TransferReversed, TransferCancelled} {
+		if !s.Final() {
+			t.Errorf("%q must be final", s)
+		}
+	}
+}
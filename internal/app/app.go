@@ -1,19 +1,31 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"alukart32.com/bank/config"
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/clearing"
+	grpcv1 "alukart32.com/bank/internal/controller/grpc/v1"
 	v1 "alukart32.com/bank/internal/controller/http/v1"
+	"alukart32.com/bank/internal/iso20022"
+	"alukart32.com/bank/internal/nacha"
 	"alukart32.com/bank/internal/usecase"
 	"alukart32.com/bank/internal/usecase/repo"
+	"alukart32.com/bank/internal/watchlist"
+	"alukart32.com/bank/pkg/accnum"
 	"alukart32.com/bank/pkg/ginx"
+	"alukart32.com/bank/pkg/grpcserver"
 	"alukart32.com/bank/pkg/httpserver"
+	"alukart32.com/bank/pkg/middleware"
 	"alukart32.com/bank/pkg/postgres"
+	"alukart32.com/bank/pkg/pubsub"
 	"alukart32.com/bank/pkg/zerologx"
+	"google.golang.org/grpc"
 )
 
 func Run(cfg config.Config) {
@@ -23,19 +35,154 @@ func Run(cfg config.Config) {
 		logger.Fatal(err)
 	}
 
+	if cfg.Auth.JWTSecret == "" {
+		fail(fmt.Errorf("app - auth jwt secret is not set"))
+	}
+
 	// Prepare tools
 	db, err := postgres.New(cfg.DB)
 	if err != nil {
 		fail(fmt.Errorf("app - init db instance error: " + err.Error()))
 	}
 
-	accountService := usecase.NewAccountService(repo.NewAccountSQLRepo(db), &logger)
-	entryService := usecase.NewEntryService(repo.NewEntrySQLRepo(db), &logger)
-	transferService := usecase.NewTransferService(repo.NewTransferSQLRepo(db), &logger)
+	numbers, err := accnum.New(accnum.Format{
+		CountryCode:    cfg.Number.CountryCode,
+		BankCode:       cfg.Number.BankCode,
+		SequenceLength: cfg.Number.SequenceLength,
+	})
+	if err != nil {
+		fail(fmt.Errorf("app - init account number format error: " + err.Error()))
+	}
+
+	accountRepo := repo.NewAccountSQLRepo(db)
+	entryRepo := repo.NewEntrySQLRepo(db)
+	screeningRepo := repo.NewScreeningSQLRepo(db)
+	auditRepo := repo.NewAuditSQLRepo(db)
+	auditor := usecase.NewAuditor(auditRepo, repo.NewTransactor(db))
 
-	handler := v1.NewRouter(ginx.NewGinEngine(), &logger, accountService, entryService, transferService)
+	var screener *usecase.Screener
+	if len(cfg.Screening.Watchlists) > 0 {
+		list, err := watchlist.Load(cfg.Screening.Watchlists...)
+		if err != nil {
+			fail(fmt.Errorf("app - load watchlist error: " + err.Error()))
+		}
+		logger.Info("app - watchlist loaded: %d entries", list.Len())
+		screener = usecase.NewScreener(screeningRepo, accountRepo, list,
+			cfg.Screening.FlagScore, cfg.Screening.BlockScore)
+	}
+
+	holderRepo := repo.NewHolderSQLRepo(db)
+	authorizer := usecase.NewAuthorizer(holderRepo)
+	streamService := usecase.NewStreamService(accountRepo, entryRepo, pubsub.New(cfg.Stream.Buffer), authorizer,
+		cfg.Stream.ResumeWindow, &logger)
+	accountService := usecase.NewAccountService(accountRepo, holderRepo, numbers, screener, auditor, &logger)
+	entryService := usecase.NewEntryService(entryRepo, &logger)
+	transferRepo := repo.NewTransferSQLRepo(db)
+	reviewRepo := repo.NewTransferReviewSQLRepo(db, transferRepo)
+	approvalRepo := repo.NewApprovalSQLRepo(db, transferRepo)
+	riskEngine := usecase.NewRiskEngine(repo.NewRiskSQLRepo(db), cfg.Risk.RapidWindow,
+		cfg.Risk.ReviewScore, cfg.Risk.BlockScore,
+		usecase.NewPayeeRule{MinAmount: cfg.Risk.LargeAmount, Score: 40},
+		usecase.UnusualHourRule{From: cfg.Risk.QuietFrom, To: cfg.Risk.QuietTo, Score: 20},
+		usecase.RapidTransfersRule{MaxCount: cfg.Risk.RapidCount, Score: 40},
+		usecase.AverageAmountRule{Factor: cfg.Risk.AverageFactor, MinTransfers: 5, Score: 30},
+	)
+	feeRepo := repo.NewFeeSQLRepo(db)
+	feeEngine := usecase.NewFeeEngine(feeRepo)
+	transferService := usecase.NewTransferService(transferRepo, streamService, screener, riskEngine,
+		reviewRepo, approvalRepo, cfg.Approval.TTL, feeEngine, authorizer, auditor, &logger)
+	reviewService := usecase.NewReviewService(reviewRepo, streamService, feeEngine, auditor, &logger)
+	approvalService := usecase.NewApprovalService(approvalRepo, streamService, riskEngine, feeEngine,
+		auditor, &logger)
+	statementService := usecase.NewStatementService(repo.NewStatementSQLRepo(db), authorizer, &logger)
+	paymentService := usecase.NewPaymentService(accountRepo, repo.NewPaymentImportSQLRepo(db), transferService,
+		authorizer, &logger)
+	payeeService := usecase.NewPayeeService(repo.NewPayeeSQLRepo(db), accountService, transferService,
+		cfg.Payee.CoolingOff, cfg.Payee.CoolingOffLimit, &logger)
+	limitService := usecase.NewLimitService(repo.NewLimitSQLRepo(db), &logger)
+	screeningService := usecase.NewScreeningService(screeningRepo, &logger)
+	auditService := usecase.NewAuditService(auditRepo, &logger)
+	interestService := usecase.NewInterestService(repo.NewInterestSQLRepo(db, transferRepo), accountRepo,
+		streamService, &logger)
+	feeService := usecase.NewFeeService(feeRepo, accountRepo, streamService, &logger)
+	ledgerService := usecase.NewLedgerService(repo.NewLedgerSQLRepo(db), &logger)
+	cashService := usecase.NewCashService(repo.NewCashSQLRepo(db), streamService,
+		map[entity.CashChannel]entity.CashLimit{
+			entity.CashTeller:    {MaxSingle: cfg.Cash.TellerMaxSingle, Daily: cfg.Cash.TellerDaily},
+			entity.CashATM:       {MaxSingle: cfg.Cash.ATMMaxSingle, Daily: cfg.Cash.ATMDaily},
+			entity.CashCardTopUp: {MaxSingle: cfg.Cash.CardTopUpMaxSingle, Daily: cfg.Cash.CardTopUpDaily},
+		}, auditor, &logger)
+	balanceService := usecase.NewBalanceService(repo.NewBalanceSQLRepo(db), &logger)
+	loanService := usecase.NewLoanService(repo.NewLoanSQLRepo(db), streamService, &logger)
+	depositService := usecase.NewDepositService(repo.NewDepositSQLRepo(db), streamService, &logger)
+	eodService := usecase.NewEODService(repo.NewEODSQLRepo(db), balanceService, interestService, feeService,
+		loanService, depositService, ledgerService, cfg.EOD.Cutoff, &logger)
+	clearingDir, err := clearing.NewDir(cfg.Clearing.Dir)
+	if err != nil {
+		fail(fmt.Errorf("app - init clearing dir error: " + err.Error()))
+	}
+	clearingService := usecase.NewClearingService(repo.NewClearingSQLRepo(db), accountRepo, clearingDir,
+		screener, riskEngine, approvalRepo, feeEngine, authorizer, auditor, streamService, &logger)
+	clearingExporter := &clearing.Exporter{
+		NACHA: nacha.Options{
+			ImmediateDestination:     cfg.Clearing.OperatorRoutingNumber,
+			ImmediateDestinationName: cfg.Clearing.OperatorName,
+			ImmediateOrigin:          cfg.Clearing.RoutingNumber,
+			ImmediateOriginName:      cfg.Clearing.BankName,
+			CompanyName:              cfg.Clearing.BankName,
+			CompanyID:                cfg.Clearing.CompanyID,
+			EntryDescription:         "TRANSFER",
+		},
+		SEPA: iso20022.SEPADebtor{
+			Name: cfg.Clearing.BankName,
+			IBAN: cfg.Clearing.IBAN,
+			BIC:  cfg.Clearing.BIC,
+		},
+	}
+
+	handler := v1.NewRouter(ginx.NewGinEngine(), middleware.AuthJWT(cfg.Auth.JWTSecret), &logger, v1.Services{
+		Account:   accountService,
+		Entry:     entryService,
+		Transfer:  transferService,
+		Stream:    streamService,
+		Statement: statementService,
+		Payment:   paymentService,
+		Payee:     payeeService,
+		Limit:     limitService,
+		Review:    reviewService,
+		Approval:  approvalService,
+		Screening: screeningService,
+		Audit:     auditService,
+		Interest:  interestService,
+		Fee:       feeService,
+		Ledger:    ledgerService,
+		Cash:      cashService,
+		Balance:   balanceService,
+		EOD:       eodService,
+		Clearing:  clearingService,
+		Loan:      loanService,
+		Deposit:   depositService,
+		Exporter:  clearingExporter,
+		Heartbeat: cfg.Stream.Heartbeat,
+	})
 	httpServer := httpserver.New(handler, cfg.HTTP)
 
+	grpcHandler := grpcv1.NewServer(grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcserver.LoggingUnary(&logger),
+			grpcserver.MetricsUnary(),
+			middleware.AuthUnary(cfg.Auth.JWTSecret),
+			grpcv1.AuditUnary(),
+		),
+	), accountService, entryService, transferService, cashService)
+	grpcServer := grpcserver.New(grpcHandler, cfg.GRPC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if cfg.EOD.Schedule > 0 {
+		go scheduleEOD(ctx, eodService, cfg.EOD.Schedule, &logger)
+	}
+
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -45,13 +192,21 @@ func Run(cfg config.Config) {
 		logger.Info(s.String())
 	case err = <-httpServer.Notify():
 		logger.Error(fmt.Errorf("app - Run - httpServer.Notify: %v", err))
+	case err = <-grpcServer.Notify():
+		logger.Error(fmt.Errorf("app - Run - grpcServer.Notify: %v", err))
 	}
 
 	// Shutdown
+	cancel()
+
 	if err = httpServer.Shutdown(); err != nil {
 		logger.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %v", err))
 	}
 
+	if err = grpcServer.Shutdown(); err != nil {
+		logger.Error(fmt.Errorf("app - Run - grpcServer.Shutdown: %v", err))
+	}
+
 	if err = postgres.Close(); err != nil {
 		logger.Error(fmt.Errorf("app - Run - postgres.Close: %v", err))
 	}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/config"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo"
+	"alukart32.com/bank/pkg/postgres"
+	"alukart32.com/bank/pkg/pubsub"
+	"alukart32.com/bank/pkg/zerologx"
+)
+
+// eodOperator is the operator of the runs started by the scheduler and
+// the eod command.
+const eodOperator = "eod"
+
+// RunEOD closes the due business dates once, it is the eod command.
+func RunEOD(cfg config.Config) error {
+	logger := zerologx.New(cfg.Logger.Level, nil)
+
+	db, err := postgres.New(cfg.DB)
+	if err != nil {
+		return fmt.Errorf("app - init db instance error: %w", err)
+	}
+	defer postgres.Close()
+
+	accountRepo := repo.NewAccountSQLRepo(db)
+	streamService := usecase.NewStreamService(accountRepo, repo.NewEntrySQLRepo(db), pubsub.New(cfg.Stream.Buffer),
+		nil, cfg.Stream.ResumeWindow, &logger)
+	eodService := usecase.NewEODService(repo.NewEODSQLRepo(db),
+		usecase.NewBalanceService(repo.NewBalanceSQLRepo(db), &logger),
+		usecase.NewInterestService(repo.NewInterestSQLRepo(db, repo.NewTransferSQLRepo(db)), accountRepo,
+			streamService, &logger),
+		usecase.NewFeeService(repo.NewFeeSQLRepo(db), accountRepo, streamService, &logger),
+		usecase.NewLoanService(repo.NewLoanSQLRepo(db), streamService, &logger),
+		usecase.NewDepositService(repo.NewDepositSQLRepo(db), streamService, &logger),
+		usecase.NewLedgerService(repo.NewLedgerSQLRepo(db), &logger),
+		cfg.EOD.Cutoff, &logger)
+
+	runs, err := eodService.RunDue(context.Background(), eodOperator)
+	for _, v := range runs {
+		logger.Info("app - eod - %s %s", v.BusinessDate.Format("2006-01-02"), v.Status)
+	}
+	return err
+}
+
+// scheduleEOD closes the due business dates at each interval until the
+// context is done.
+func scheduleEOD(ctx context.Context, s usecase.EODService, interval time.Duration, l zerologx.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		runs, err := s.RunDue(ctx, eodOperator)
+		for _, v := range runs {
+			l.Info("app - eod scheduler - %s %s", v.BusinessDate.Format("2006-01-02"), v.Status)
+		}
+		if err != nil && !errors.Is(err, usecase.ErrEODRunning) {
+			l.Error(fmt.Errorf("app - eod scheduler: %w", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package clearing implements the files exchanged with the clearing
+// house and a local clearing house simulator.
+//
+// Both files are CSV with a header record (H), a detail record (D) per
+// transfer and a trailer record (T) with the control totals. The batch
+// file is
+//
+//	H,<file name>,<currency>
+//	D,<transfer id>,<amount>,<bank>,<account>,<name>,<reference>,<description>
+//	T,<count>,<total>
+//
+// and its response is
+//
+//	H,<batch file name>
+//	D,<transfer id>,<ACSC|RJCT>,<return reason>
+//	T,<count>
+//
+// The amounts are in minor units.
+package clearing
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+)
+
+const (
+	statusSettled  = "ACSC"
+	statusRejected = "RJCT"
+)
+
+var ErrMalformed = errors.New("malformed clearing file")
+
+// WriteBatch writes the batch file of the batch with its transfers.
+func WriteBatch(w io.Writer, b entity.ClearingBatch) error {
+	cw := csv.NewWriter(w)
+
+	var total int64
+	records := [][]string{{"H", b.FileName, string(b.Currency)}}
+	for _, t := range b.Transfers {
+		total += t.Amount
+		records = append(records, []string{
+			"D",
+			strconv.FormatInt(t.ID, 10),
+			strconv.FormatInt(t.Amount, 10),
+			t.BeneficiaryBank,
+			t.BeneficiaryAccount,
+			t.BeneficiaryName,
+			t.Reference,
+			t.Description,
+		})
+	}
+	records = append(records, []string{"T", strconv.Itoa(len(b.Transfers)), strconv.FormatInt(total, 10)})
+
+	return cw.WriteAll(records)
+}
+
+// ParseBatch reads the batch file and checks its control totals.
+func ParseBatch(r io.Reader) (entity.ClearingBatch, error) {
+	records, err := readRecords(r, 3, 8, 3)
+	if err != nil {
+		return entity.ClearingBatch{}, err
+	}
+
+	b := entity.ClearingBatch{
+		FileName: records[0][1],
+		Currency: entity.Currency(records[0][2]),
+	}
+	for _, v := range records[1 : len(records)-1] {
+		id, err := parseInt(v[1])
+		if err != nil {
+			return entity.ClearingBatch{}, err
+		}
+		amount, err := parseInt(v[2])
+		if err != nil {
+			return entity.ClearingBatch{}, err
+		}
+		b.Transfers = append(b.Transfers, entity.ExternalTransfer{
+			ID:                 id,
+			Amount:             amount,
+			Currency:           b.Currency,
+			BeneficiaryBank:    v[3],
+			BeneficiaryAccount: v[4],
+			BeneficiaryName:    v[5],
+			Reference:          v[6],
+			Description:        v[7],
+		})
+		b.Total += amount
+	}
+	b.Count = int64(len(b.Transfers))
+
+	trailer := records[len(records)-1]
+	if trailer[1] != strconv.FormatInt(b.Count, 10) || trailer[2] != strconv.FormatInt(b.Total, 10) {
+		return entity.ClearingBatch{}, fmt.Errorf("%w: trailer %s/%s, details %d/%d",
+			ErrMalformed, trailer[1], trailer[2], b.Count, b.Total)
+	}
+	return b, nil
+}
+
+// WriteResponse writes the response file.
+func WriteResponse(w io.Writer, r entity.ClearingResponse) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{{"H", r.FileName}}
+	for _, v := range r.Results {
+		status := statusSettled
+		if v.Status == entity.ExternalReturned {
+			status = statusRejected
+		}
+		records = append(records, []string{"D", strconv.FormatInt(v.TransferID, 10), status, v.Reason})
+	}
+	records = append(records, []string{"T", strconv.Itoa(len(r.Results))})
+
+	return cw.WriteAll(records)
+}
+
+// ParseResponse reads the response file and checks its control count.
+func ParseResponse(r io.Reader) (entity.ClearingResponse, error) {
+	records, err := readRecords(r, 2, 4, 2)
+	if err != nil {
+		return entity.ClearingResponse{}, err
+	}
+
+	response := entity.ClearingResponse{FileName: records[0][1]}
+	for _, v := range records[1 : len(records)-1] {
+		id, err := parseInt(v[1])
+		if err != nil {
+			return entity.ClearingResponse{}, err
+		}
+		result := entity.ClearingResult{TransferID: id, Reason: v[3]}
+		switch v[2] {
+		case statusSettled:
+			result.Status = entity.ExternalSettled
+		case statusRejected:
+			result.Status = entity.ExternalReturned
+		default:
+			return entity.ClearingResponse{}, fmt.Errorf("%w: unknown status %q", ErrMalformed, v[2])
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	if trailer := records[len(records)-1]; trailer[1] != strconv.Itoa(len(response.Results)) {
+		return entity.ClearingResponse{}, fmt.Errorf("%w: trailer %s, details %d",
+			ErrMalformed, trailer[1], len(response.Results))
+	}
+	return response, nil
+}
+
+// readRecords reads the header, the details and the trailer records with
+// the number of the fields of each.
+func readRecords(r io.Reader, header, detail, trailer int) ([][]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("%w: no header or trailer", ErrMalformed)
+	}
+
+	for i, v := range records {
+		kind, fields := "D", detail
+		switch i {
+		case 0:
+			kind, fields = "H", header
+		case len(records) - 1:
+			kind, fields = "T", trailer
+		}
+		if v[0] != kind || len(v) != fields {
+			return nil, fmt.Errorf("%w: record %d must be %s with %d fields", ErrMalformed, i+1, kind, fields)
+		}
+	}
+	return records, nil
+}
+
+func parseInt(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("%w: invalid number %q", ErrMalformed, s)
+	}
+	return v, nil
+}
@@ -0,0 +1,177 @@
+package clearing
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/iso20022"
+	"alukart32.com/bank/internal/nacha"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var batch = entity.ClearingBatch{
+	FileName: "CLR-20230317-USD-00000042",
+	Currency: entity.CurrencyUSD,
+	Count:    2,
+	Total:    15050,
+	Transfers: []entity.ExternalTransfer{
+		{
+			ID:                 42,
+			Amount:             10000,
+			Currency:           entity.CurrencyUSD,
+			BeneficiaryName:    "Max Mustermann",
+			BeneficiaryAccount: "DE89370400440532013000",
+			BeneficiaryBank:    "COBADEFFXXX",
+			Reference:          "INV-42",
+			Description:        "Invoice 42, March",
+		},
+		{
+			ID:                 43,
+			Amount:             5050,
+			Currency:           entity.CurrencyUSD,
+			BeneficiaryName:    "John Smith",
+			BeneficiaryAccount: "GB82WEST12345698765432",
+			BeneficiaryBank:    "WESTGB2L",
+		},
+	},
+}
+
+func TestWriteBatch(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteBatch(&buf, batch))
+
+	golden := filepath.Join("testdata", "batch.csv")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+
+	parsed, err := ParseBatch(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, batch, parsed)
+}
+
+func TestParseBatchMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"empty", ""},
+		{"no trailer", "H,CLR-1,USD\nD,1,100,WESTGB2L,GB82WEST12345698765432,John,,\n"},
+		{"short detail", "H,CLR-1,USD\nD,1,100\nT,1,100\n"},
+		{"invalid amount", "H,CLR-1,USD\nD,1,-100,WESTGB2L,GB82WEST12345698765432,John,,\nT,1,-100\n"},
+		{"wrong count", "H,CLR-1,USD\nD,1,100,WESTGB2L,GB82WEST12345698765432,John,,\nT,2,100\n"},
+		{"wrong total", "H,CLR-1,USD\nD,1,100,WESTGB2L,GB82WEST12345698765432,John,,\nT,1,101\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBatch(strings.NewReader(tt.file))
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	response := entity.ClearingResponse{
+		FileName: batch.FileName,
+		Results: []entity.ClearingResult{
+			{TransferID: 42, Status: entity.ExternalSettled},
+			{TransferID: 43, Status: entity.ExternalReturned, Reason: entity.ReturnClosedAccount},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteResponse(&buf, response))
+
+	golden := filepath.Join("testdata", "response.csv")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+
+	parsed, err := ParseResponse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, response, parsed)
+
+	_, err = ParseResponse(strings.NewReader("H,CLR-1\nD,1,PDNG,\nT,1\n"))
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestSimulator(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+
+	dir, err := NewDir(root)
+	require.NoError(t, err)
+	require.NoError(t, dir.Send(ctx, batch))
+
+	responses, err := dir.Receive(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+
+	sim := &Simulator{
+		Root:      root,
+		Returns:   map[string]string{"GB82WEST12345698765432": entity.ReturnClosedAccount},
+		MaxAmount: 5000,
+	}
+	n, err := sim.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.FileExists(t, filepath.Join(root, Archive, batch.FileName+".csv"))
+
+	responses, err = dir.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, entity.ClearingResponse{
+		FileName: batch.FileName,
+		Results: []entity.ClearingResult{
+			{TransferID: 42, Status: entity.ExternalReturned, Reason: entity.ReturnAmountExceeded},
+			{TransferID: 43, Status: entity.ExternalReturned, Reason: entity.ReturnClosedAccount},
+		},
+	}, responses[0])
+
+	require.NoError(t, dir.Done(ctx, responses[0]))
+	responses, err = dir.Receive(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, responses)
+	assert.FileExists(t, filepath.Join(root, Archive, batch.FileName+".response.csv"))
+
+	n, err = sim.Run()
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestExport(t *testing.T) {
+	e := &Exporter{}
+	friday := time.Date(2023, 3, 17, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 3, 20, 9, 30, 0, 0, time.UTC), nextBusinessDay(friday))
+
+	var buf bytes.Buffer
+	contentType, err := e.Export(&buf, FormatCSV, batch, friday)
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", contentType)
+	assert.True(t, strings.HasPrefix(buf.String(), "H,"+batch.FileName))
+
+	// the exporter without the originator details can't write the files
+	_, err = e.Export(io.Discard, FormatNACHA, batch, friday)
+	assert.ErrorIs(t, err, nacha.ErrInvalid)
+	_, err = e.Export(io.Discard, FormatSEPA, batch, friday)
+	assert.ErrorIs(t, err, iso20022.ErrMalformed)
+	_, err = e.Export(io.Discard, "mt101", batch, friday)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
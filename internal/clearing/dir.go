@@ -0,0 +1,126 @@
+package clearing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"alukart32.com/bank/entity"
+)
+
+// The subdirectories of the exchange directory: the bank writes the
+// batches to the outbox and reads the responses from the inbox, the done
+// files of both sides are moved to the archive.
+const (
+	Outbox  = "outbox"
+	Inbox   = "inbox"
+	Archive = "archive"
+
+	ext = ".csv"
+)
+
+// Dir exchanges the files with the clearing house through the
+// directories, like a mounted SFTP share.
+type Dir struct {
+	root string
+}
+
+// NewDir creates the subdirectories of the root if they don't exist.
+func NewDir(root string) (*Dir, error) {
+	if err := mkdirs(root); err != nil {
+		return nil, err
+	}
+	return &Dir{root: root}, nil
+}
+
+// Send writes the batch file to the outbox. The file appears complete or
+// not at all, so the clearing house never reads a partial one.
+func (d *Dir) Send(_ context.Context, b entity.ClearingBatch) error {
+	var buf bytes.Buffer
+	if err := WriteBatch(&buf, b); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(d.root, Outbox, b.FileName+ext), &buf)
+}
+
+// Receive reads the responses from the inbox in the order of the names.
+func (d *Dir) Receive(_ context.Context) ([]entity.ClearingResponse, error) {
+	names, err := listFiles(filepath.Join(d.root, Inbox))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.ClearingResponse, 0, len(names))
+	for _, name := range names {
+		r, err := readResponse(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// Done moves the response to the archive.
+func (d *Dir) Done(_ context.Context, r entity.ClearingResponse) error {
+	return os.Rename(filepath.Join(d.root, Inbox, r.FileName+ext),
+		filepath.Join(d.root, Archive, r.FileName+".response"+ext))
+}
+
+func readResponse(name string) (entity.ClearingResponse, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return entity.ClearingResponse{}, err
+	}
+	defer f.Close()
+
+	r, err := ParseResponse(f)
+	if err != nil {
+		return entity.ClearingResponse{}, fmt.Errorf("%s: %w", filepath.Base(name), err)
+	}
+	if r.FileName+ext != filepath.Base(name) {
+		return entity.ClearingResponse{}, fmt.Errorf("%s: %w: response to %s", filepath.Base(name), ErrMalformed, r.FileName)
+	}
+	return r, nil
+}
+
+func mkdirs(root string) error {
+	for _, dir := range []string{Outbox, Inbox, Archive} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o750); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listFiles returns the sorted paths of the files of the directory.
+func listFiles(dir string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// writeFile writes the file under a temporary name and renames it.
+func writeFile(name string, r io.Reader) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
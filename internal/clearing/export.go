@@ -0,0 +1,57 @@
+package clearing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/iso20022"
+	"alukart32.com/bank/internal/nacha"
+)
+
+// Format is the file format of the batch.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatNACHA Format = "nacha"
+	FormatSEPA  Format = "sepa"
+)
+
+var ErrUnknownFormat = errors.New("unknown batch file format")
+
+// Exporter writes the batches in the formats of the clearing houses. The
+// dates of the files are set on export.
+type Exporter struct {
+	NACHA nacha.Options
+	SEPA  iso20022.SEPADebtor
+}
+
+// Export writes the batch in the format and returns its content type.
+// The batches which don't fit the format fail with nacha.ErrInvalid or
+// iso20022.ErrMalformed.
+func (e *Exporter) Export(w io.Writer, f Format, b entity.ClearingBatch, now time.Time) (string, error) {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8", WriteBatch(w, b)
+	case FormatNACHA:
+		o := e.NACHA
+		o.CreatedAt, o.EffectiveDate = now, nextBusinessDay(now)
+		return "text/plain; charset=utf-8", nacha.Write(w, b, o)
+	case FormatSEPA:
+		return "application/xml; charset=utf-8", iso20022.WriteSEPACreditTransfer(w, b, e.SEPA, now, nextBusinessDay(now))
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, f)
+}
+
+// nextBusinessDay returns the next day which is not a weekend, the
+// holidays of the clearing houses are not known.
+func nextBusinessDay(t time.Time) time.Time {
+	t = t.UTC().AddDate(0, 0, 1)
+	for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
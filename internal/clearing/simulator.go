@@ -0,0 +1,76 @@
+package clearing
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"alukart32.com/bank/entity"
+)
+
+// Simulator plays the clearing house on the exchange directory: it
+// answers every batch in the outbox and archives it. The transfers are
+// settled unless they are returned by the rules below.
+type Simulator struct {
+	Root string
+	// Returns are the return reasons of the beneficiary accounts, e.g.
+	// entity.ReturnClosedAccount.
+	Returns map[string]string
+	// MaxAmount returns the larger transfers with
+	// entity.ReturnAmountExceeded. Zero is no limit.
+	MaxAmount int64
+}
+
+// Run answers the batches of the outbox and returns their number.
+func (s *Simulator) Run() (int, error) {
+	if err := mkdirs(s.Root); err != nil {
+		return 0, err
+	}
+	names, err := listFiles(filepath.Join(s.Root, Outbox))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, name := range names {
+		if err = s.answer(name); err != nil {
+			return i, err
+		}
+	}
+	return len(names), nil
+}
+
+func (s *Simulator) answer(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	b, err := ParseBatch(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = WriteResponse(&buf, s.Respond(b)); err != nil {
+		return err
+	}
+	if err = writeFile(filepath.Join(s.Root, Inbox, b.FileName+ext), &buf); err != nil {
+		return err
+	}
+	return os.Rename(name, filepath.Join(s.Root, Archive, filepath.Base(name)))
+}
+
+// Respond returns the response to the batch.
+func (s *Simulator) Respond(b entity.ClearingBatch) entity.ClearingResponse {
+	r := entity.ClearingResponse{FileName: b.FileName}
+	for _, t := range b.Transfers {
+		result := entity.ClearingResult{TransferID: t.ID, Status: entity.ExternalSettled}
+		if reason := s.Returns[t.BeneficiaryAccount]; reason != "" {
+			result.Status, result.Reason = entity.ExternalReturned, reason
+		} else if s.MaxAmount > 0 && t.Amount > s.MaxAmount {
+			result.Status, result.Reason = entity.ExternalReturned, entity.ReturnAmountExceeded
+		}
+		r.Results = append(r.Results, result)
+	}
+	return r
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type accountServer struct {
+	bankv1.UnimplementedAccountServiceServer
+	service usecase.AccountService
+	// cash posts the balance changes against the cash GL account.
+	cash usecase.CashService
+}
+
+// CreateAccount opens the account for the caller, an admin opens the
+// account for the owner of the request.
+func (s *accountServer) CreateAccount(ctx context.Context, req *bankv1.CreateAccountRequest) (*bankv1.CreateAccountResponse, error) {
+	currency, ok := toCurrency(req.GetCurrency())
+	if !ok {
+		return nil, invalidArgument("invalid currency")
+	}
+	owner := req.GetOwner()
+	if !middleware.HasRoleInContext(ctx, roleAdmin) {
+		owner = middleware.SubjectFromContext(ctx)
+	}
+
+	id, err := s.service.Create(ctx, entity.Account{
+		Owner:    owner,
+		Balance:  req.GetBalance(),
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return &bankv1.CreateAccountResponse{Id: id.String()}, nil
+}
+
+// GetAccount returns the account by its id or account number to its
+// holders or an admin.
+func (s *accountServer) GetAccount(ctx context.Context, req *bankv1.GetAccountRequest) (*bankv1.Account, error) {
+	var (
+		account entity.Account
+		err     error
+	)
+	if id, parseErr := uuid.Parse(req.GetId()); parseErr == nil {
+		account, err = s.service.Get(ctx, id)
+	} else {
+		account, err = s.service.GetByNumber(ctx, req.GetId())
+	}
+	if err == nil {
+		err = checkHolder(ctx, s.service, entity.PermissionView, account.ID)
+	}
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return toAccountPb(account), nil
+}
+
+// UpdateAccountOwner changes the owner of the account for its primary
+// holder or an admin.
+func (s *accountServer) UpdateAccountOwner(ctx context.Context, req *bankv1.UpdateAccountOwnerRequest) (*bankv1.Account, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+	subject := middleware.SubjectFromContext(ctx)
+	if middleware.HasRoleInContext(ctx, roleAdmin) {
+		subject = ""
+	}
+
+	account, err := s.service.UpdateOwner(ctx, subject, id, req.GetOwner())
+	if errors.Is(err, usecase.ErrAccessDenied) {
+		err = usecase.ErrNotFound
+	}
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return toAccountPb(account), nil
+}
+
+// AddAccountBalance deposits the positive amount at the teller of the
+// caller and withdraws the negative one. Only the tellers and admins
+// handle the cash.
+func (s *accountServer) AddAccountBalance(ctx context.Context, req *bankv1.AddAccountBalanceRequest) (*bankv1.Account, error) {
+	if err := requireRole(ctx, roleTeller, roleAdmin); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+
+	operator := middleware.SubjectFromContext(ctx)
+	op := entity.CashOperation{
+		Channel:   entity.CashTeller,
+		TellerID:  operator,
+		AccountID: id,
+		Amount:    req.GetAmount(),
+	}
+	if op.Amount < 0 {
+		op.Amount = -op.Amount
+		_, err = s.cash.Withdraw(ctx, operator, op)
+	} else {
+		_, err = s.cash.Deposit(ctx, operator, op)
+	}
+	if err != nil {
+		return nil, errorStatus(err, "cash service problems")
+	}
+
+	account, err := s.service.Get(ctx, id)
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return toAccountPb(account), nil
+}
+
+// DeleteAccount deletes the account for an admin.
+func (s *accountServer) DeleteAccount(ctx context.Context, req *bankv1.DeleteAccountRequest) (*emptypb.Empty, error) {
+	if err := requireRole(ctx, roleAdmin); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+
+	if err = s.service.Delete(ctx, id); err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListAccountEntries returns the entries of the account to its holders or an admin.
+func (s *accountServer) ListAccountEntries(ctx context.Context, req *bankv1.ListAccountEntriesRequest) (*bankv1.ListEntriesResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+	if err = checkHolder(ctx, s.service, entity.PermissionView, id); err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	entries, err := s.service.ListEntries(ctx, id)
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return toEntriesPb(entries), nil
+}
+
+// ListAccountTransfers returns the transfers of the account to its holders or an admin.
+func (s *accountServer) ListAccountTransfers(ctx context.Context, req *bankv1.ListAccountTransfersRequest) (*bankv1.ListTransfersResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+	if err = checkHolder(ctx, s.service, entity.PermissionView, id); err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	transfers, err := s.service.ListTransfers(ctx, id)
+	if err != nil {
+		return nil, errorStatus(err, "account service problems")
+	}
+
+	return toTransfersPb(transfers), nil
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"context"
+	"net"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const maxRequestIDLength = 64
+
+// AuditUnary stores the caller of the RPC for the audit records. It must
+// follow the auth interceptor. The request id is taken from the
+// x-request-id metadata or generated.
+func AuditUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		actor := entity.AuditActor{
+			Subject: middleware.SubjectFromContext(ctx),
+		}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get("x-request-id"); len(v) > 0 && len(v[0]) <= maxRequestIDLength {
+				actor.RequestID = v[0]
+			}
+		}
+		if actor.RequestID == "" {
+			actor.RequestID = uuid.NewString()
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				actor.ClientIP = host
+			}
+		}
+
+		return handler(usecase.WithActor(ctx, actor), req)
+	}
+}
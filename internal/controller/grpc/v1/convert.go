@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"alukart32.com/bank/entity"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var currencies = map[entity.Currency]bankv1.Currency{
+	entity.CurrencyRUB: bankv1.Currency_CURRENCY_RUB,
+	entity.CurrencyUSD: bankv1.Currency_CURRENCY_USD,
+}
+
+func toCurrency(c bankv1.Currency) (entity.Currency, bool) {
+	for k, v := range currencies {
+		if v == c {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func toAccountPb(a entity.Account) *bankv1.Account {
+	return &bankv1.Account{
+		Id:        a.ID.String(),
+		Owner:     a.Owner,
+		Balance:   a.Balance,
+		Currency:  currencies[a.Currency],
+		CreatedAt: timestamppb.New(a.CreatedAt),
+		Number:    a.Number,
+	}
+}
+
+func toEntryPb(e entity.Entry) *bankv1.Entry {
+	return &bankv1.Entry{
+		Id:          e.ID,
+		AccountId:   e.AccountID.String(),
+		Amount:      e.Amount,
+		CreatedAt:   timestamppb.New(e.CreatedAt),
+		Description: e.Description,
+		Reference:   e.Reference,
+		Metadata:    e.Metadata,
+	}
+}
+
+func toEntriesPb(entries []entity.Entry) *bankv1.ListEntriesResponse {
+	res := &bankv1.ListEntriesResponse{
+		Entries: make([]*bankv1.Entry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		res.Entries = append(res.Entries, toEntryPb(e))
+	}
+	return res
+}
+
+func toTransferPb(t entity.Transfer) *bankv1.Transfer {
+	return &bankv1.Transfer{
+		Id:            t.ID,
+		FromAccountId: t.FromAccountID.String(),
+		ToAccountId:   t.ToAccountID.String(),
+		Amount:        t.Amount,
+		FromEntryId:   t.FromEntryID,
+		ToEntryId:     t.ToEntryID,
+		CreatedAt:     timestamppb.New(t.CreatedAt),
+		Description:   t.Description,
+		Reference:     t.Reference,
+		Metadata:      t.Metadata,
+		Status:        string(t.Status),
+		FailureReason: t.FailureReason,
+		UpdatedAt:     timestamppb.New(t.UpdatedAt),
+	}
+}
+
+func toTransfersPb(transfers []entity.Transfer) *bankv1.ListTransfersResponse {
+	res := &bankv1.ListTransfersResponse{
+		Transfers: make([]*bankv1.Transfer, 0, len(transfers)),
+	}
+	for _, t := range transfers {
+		res.Transfers = append(res.Transfers, toTransferPb(t))
+	}
+	return res
+}
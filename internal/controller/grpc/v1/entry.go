@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"context"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type entryServer struct {
+	bankv1.UnimplementedEntryServiceServer
+	service  usecase.EntryService
+	accounts usecase.AccountService
+}
+
+// CreateEntry posts the entry to the account for an admin.
+func (s *entryServer) CreateEntry(ctx context.Context, req *bankv1.CreateEntryRequest) (*bankv1.Entry, error) {
+	if err := requireRole(ctx, roleAdmin); err != nil {
+		return nil, err
+	}
+
+	accountID, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+
+	entry, err := s.service.Create(ctx, entity.Entry{
+		AccountID: accountID,
+		Amount:    req.GetAmount(),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	return toEntryPb(entry), nil
+}
+
+// GetEntry returns the entry to the holders of its account or an admin.
+func (s *entryServer) GetEntry(ctx context.Context, req *bankv1.GetEntryRequest) (*bankv1.Entry, error) {
+	entry, err := s.service.Get(ctx, req.GetId())
+	if err == nil {
+		err = checkHolder(ctx, s.accounts, entity.PermissionView, entry.AccountID)
+	}
+	if err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	return toEntryPb(entry), nil
+}
+
+// UpdateEntryAmount corrects the amount of the entry for an admin.
+func (s *entryServer) UpdateEntryAmount(ctx context.Context, req *bankv1.UpdateEntryAmountRequest) (*bankv1.Entry, error) {
+	if err := requireRole(ctx, roleAdmin); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.service.UpdateAmount(ctx, entity.Entry{
+		ID:     req.GetId(),
+		Amount: req.GetAmount(),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	return toEntryPb(entry), nil
+}
+
+// ListEntries returns the entries of the account to its holders or an
+// admin.
+func (s *entryServer) ListEntries(ctx context.Context, req *bankv1.ListEntriesRequest) (*bankv1.ListEntriesResponse, error) {
+	accountID, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, invalidArgument("invalid account id")
+	}
+	if err = checkHolder(ctx, s.accounts, entity.PermissionView, accountID); err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	entries, err := s.service.List(ctx, accountID)
+	if err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	return toEntriesPb(entries), nil
+}
+
+// DeleteEntry deletes the entry for an admin.
+func (s *entryServer) DeleteEntry(ctx context.Context, req *bankv1.DeleteEntryRequest) (*emptypb.Empty, error) {
+	if err := requireRole(ctx, roleAdmin); err != nil {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, errorStatus(err, "entry service problems")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// errorStatus maps a domain error to the grpc status error. The transfer
+// held for approval or review carries the approval or the review in the
+// details, as the http api returns them with 202.
+func errorStatus(err error, msg string) error {
+	var (
+		reviewErr   *usecase.ReviewError
+		approvalErr *usecase.ApprovalError
+	)
+	switch {
+	case errors.As(err, &approvalErr):
+		return heldStatus(err, map[string]interface{}{
+			"status":   entity.ApprovalPending,
+			"approval": approvalErr.Approval,
+		})
+	case errors.As(err, &reviewErr):
+		return heldStatus(err, map[string]interface{}{
+			"status": entity.RiskReview,
+			"review": reviewErr.Review,
+		})
+	case errors.Is(err, usecase.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrInsufficientFunds):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, usecase.ErrLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, usecase.ErrTransferBlocked), errors.Is(err, usecase.ErrScreeningBlocked):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, usecase.ErrTransferHeld), errors.Is(err, usecase.ErrReviewClosed),
+		errors.Is(err, usecase.ErrApprovalRequired), errors.Is(err, usecase.ErrApprovalClosed),
+		errors.Is(err, usecase.ErrApprovalExpired), errors.Is(err, usecase.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// heldStatus is the FailedPrecondition status with the held object as
+// its json detail. The status goes without the detail if it can't be
+// converted.
+func heldStatus(err error, held map[string]interface{}) error {
+	st := status.New(codes.FailedPrecondition, err.Error())
+
+	b, jsonErr := json.Marshal(held)
+	if jsonErr != nil {
+		return st.Err()
+	}
+	var fields map[string]interface{}
+	if jsonErr = json.Unmarshal(b, &fields); jsonErr != nil {
+		return st.Err()
+	}
+	detail, detailErr := structpb.NewStruct(fields)
+	if detailErr != nil {
+		return st.Err()
+	}
+	if withDetail, detailErr := st.WithDetails(detail); detailErr == nil {
+		st = withDetail
+	}
+	return st.Err()
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles of the bank operators, the same as of the HTTP API.
+const (
+	roleAdmin  = "admin"
+	roleTeller = "teller"
+)
+
+func NewServer(server *grpc.Server, as usecase.AccountService,
+	es usecase.EntryService, ts usecase.TransferService, cs usecase.CashService) *grpc.Server {
+	bankv1.RegisterAccountServiceServer(server, &accountServer{service: as, cash: cs})
+	bankv1.RegisterEntryServiceServer(server, &entryServer{service: es, accounts: as})
+	bankv1.RegisterTransferServiceServer(server, &transferServer{service: ts, accounts: as})
+
+	return server
+}
+
+// requireRole returns the PermissionDenied status unless the caller holds
+// one of the roles.
+func requireRole(ctx context.Context, roles ...string) error {
+	for _, role := range roles {
+		if middleware.HasRoleInContext(ctx, role) {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "access denied")
+}
+
+// checkHolder returns ErrNotFound unless the caller is an admin or holds
+// one of the accounts in a role allowing the action, so the other
+// accounts stay hidden.
+func checkHolder(ctx context.Context, s usecase.AccountService, p entity.Permission, ids ...uuid.UUID) error {
+	if middleware.HasRoleInContext(ctx, roleAdmin) {
+		return nil
+	}
+	for _, id := range ids {
+		a, err := s.Get(ctx, id)
+		if errors.Is(err, usecase.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = s.Authorize(ctx, middleware.SubjectFromContext(ctx), a, p)
+		if !errors.Is(err, usecase.ErrAccessDenied) {
+			return err
+		}
+	}
+	return usecase.ErrNotFound
+}
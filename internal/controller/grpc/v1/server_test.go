@@ -0,0 +1,256 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type stubAccountService struct {
+	usecase.AccountService
+	accounts map[uuid.UUID]entity.Account
+	// viewers are the holders allowed to view the accounts besides the
+	// owners.
+	viewers map[uuid.UUID]string
+}
+
+func (s *stubAccountService) Get(_ context.Context, id uuid.UUID) (entity.Account, error) {
+	a, ok := s.accounts[id]
+	if !ok {
+		return entity.Account{}, usecase.ErrNotFound
+	}
+	return a, nil
+}
+
+func (s *stubAccountService) Authorize(_ context.Context, subject string, a entity.Account, p entity.Permission) error {
+	if a.Owner == subject || p == entity.PermissionView && s.viewers[a.ID] == subject {
+		return nil
+	}
+	return usecase.ErrAccessDenied
+}
+
+// UpdateOwner lets the owner and the empty subject of an admin hand the
+// account over.
+func (s *stubAccountService) UpdateOwner(_ context.Context, subject string, id uuid.UUID, owner string) (entity.Account, error) {
+	a, ok := s.accounts[id]
+	if !ok {
+		return entity.Account{}, usecase.ErrNotFound
+	}
+	if subject != "" && subject != a.Owner {
+		return entity.Account{}, usecase.ErrAccessDenied
+	}
+	a.Owner = owner
+	s.accounts[id] = a
+	return a, nil
+}
+
+type stubTransferService struct {
+	usecase.TransferService
+	transfers map[int64]entity.Transfer
+	// err fails the created transfers.
+	err error
+}
+
+func (s *stubTransferService) Transfer(_ context.Context, _ string, t entity.Transfer) (entity.TransferRes, error) {
+	if s.err != nil {
+		return entity.TransferRes{}, s.err
+	}
+	return entity.TransferRes{Transfer: t}, nil
+}
+
+func (s *stubTransferService) Get(_ context.Context, id int64) (entity.Transfer, error) {
+	t, ok := s.transfers[id]
+	if !ok {
+		return entity.Transfer{}, usecase.ErrNotFound
+	}
+	return t, nil
+}
+
+func newStubAccounts(accounts ...entity.Account) *stubAccountService {
+	s := &stubAccountService{
+		accounts: make(map[uuid.UUID]entity.Account),
+		viewers:  make(map[uuid.UUID]string),
+	}
+	for _, a := range accounts {
+		s.accounts[a.ID] = a
+	}
+	return s
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: usecase.ErrNotFound, code: codes.NotFound},
+		{err: usecase.ErrAccessDenied, code: codes.PermissionDenied},
+		{err: fmt.Errorf("%w: amount must be positive", usecase.ErrInvalidArgument), code: codes.InvalidArgument},
+		{err: errors.New("connection refused"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(errorStatus(tt.err, "service problems")))
+		})
+	}
+
+	st, _ := status.FromError(errorStatus(errors.New("connection refused"), "service problems"))
+	assert.Equal(t, "service problems", st.Message(), "internal errors are not exposed")
+}
+
+func TestAccountServerGetAccount(t *testing.T) {
+	account := entity.Account{ID: uuid.New(), Owner: "alice"}
+	accounts := newStubAccounts(account)
+	accounts.viewers[account.ID] = "carol"
+	s := &accountServer{service: accounts}
+	req := &bankv1.GetAccountRequest{Id: account.ID.String()}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{name: "owner", ctx: middleware.NewContext(context.Background(), "alice"), code: codes.OK},
+		{name: "admin", ctx: middleware.NewContext(context.Background(), "bob", roleAdmin), code: codes.OK},
+		{name: "viewer", ctx: middleware.NewContext(context.Background(), "carol"), code: codes.OK},
+		{name: "other", ctx: middleware.NewContext(context.Background(), "bob"), code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.GetAccount(tt.ctx, req)
+			require.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, account.ID.String(), res.GetId())
+			}
+		})
+	}
+}
+
+func TestAccountServerUpdateAccountOwner(t *testing.T) {
+	account := entity.Account{ID: uuid.New(), Owner: "alice"}
+	s := &accountServer{service: newStubAccounts(account)}
+
+	_, err := s.UpdateAccountOwner(middleware.NewContext(context.Background(), "joint"),
+		&bankv1.UpdateAccountOwnerRequest{Id: account.ID.String(), Owner: "joint"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the joint holder can't take the account over")
+
+	res, err := s.UpdateAccountOwner(middleware.NewContext(context.Background(), "bob", roleAdmin),
+		&bankv1.UpdateAccountOwnerRequest{Id: account.ID.String(), Owner: "carol"})
+	require.NoError(t, err)
+	assert.Equal(t, "carol", res.GetOwner())
+}
+
+func TestAdminOnlyHandlers(t *testing.T) {
+	ctx := middleware.NewContext(context.Background(), "alice")
+	accounts := &accountServer{service: newStubAccounts()}
+	entries := &entryServer{accounts: newStubAccounts()}
+	transfers := &transferServer{accounts: newStubAccounts()}
+
+	calls := map[string]func() error{
+		"AddAccountBalance": func() error {
+			_, err := accounts.AddAccountBalance(ctx, &bankv1.AddAccountBalanceRequest{Id: uuid.NewString(), Amount: 100})
+			return err
+		},
+		"DeleteAccount": func() error {
+			_, err := accounts.DeleteAccount(ctx, &bankv1.DeleteAccountRequest{Id: uuid.NewString()})
+			return err
+		},
+		"CreateEntry": func() error {
+			_, err := entries.CreateEntry(ctx, &bankv1.CreateEntryRequest{AccountId: uuid.NewString(), Amount: 100})
+			return err
+		},
+		"DeleteEntry": func() error {
+			_, err := entries.DeleteEntry(ctx, &bankv1.DeleteEntryRequest{Id: 1})
+			return err
+		},
+		"RollbackTransfer": func() error {
+			_, err := transfers.RollbackTransfer(ctx, &bankv1.RollbackTransferRequest{Id: 1})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, codes.PermissionDenied, status.Code(call()))
+		})
+	}
+}
+
+func TestTransferServerGetTransfer(t *testing.T) {
+	from := entity.Account{ID: uuid.New(), Owner: "alice"}
+	to := entity.Account{ID: uuid.New(), Owner: "bob"}
+	s := &transferServer{
+		service: &stubTransferService{transfers: map[int64]entity.Transfer{
+			1: {ID: 1, FromAccountID: from.ID, ToAccountID: to.ID, Amount: 100},
+		}},
+		accounts: newStubAccounts(from, to),
+	}
+
+	res, err := s.GetTransfer(middleware.NewContext(context.Background(), "bob"), &bankv1.GetTransferRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.GetId())
+
+	_, err = s.GetTransfer(middleware.NewContext(context.Background(), "carol"), &bankv1.GetTransferRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestTransferServerCreateTransferHeld(t *testing.T) {
+	transfer := entity.Transfer{FromAccountID: uuid.New(), ToAccountID: uuid.New(), Amount: 100}
+	req := &bankv1.CreateTransferRequest{
+		FromAccountId: transfer.FromAccountID.String(),
+		ToAccountId:   transfer.ToAccountID.String(),
+		Amount:        transfer.Amount,
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		status string
+		held   string
+	}{
+		{
+			name: "approval",
+			err: &usecase.ApprovalError{Approval: entity.TransferApproval{
+				ID: 7, Transfer: transfer, Status: entity.ApprovalPending, InitiatedBy: "alice",
+			}},
+			status: string(entity.ApprovalPending),
+			held:   "approval",
+		},
+		{
+			name: "review",
+			err: &usecase.ReviewError{Review: entity.TransferReview{
+				ID: 7, Transfer: transfer, Score: 80, Status: entity.ReviewPending,
+			}},
+			status: string(entity.RiskReview),
+			held:   "review",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &transferServer{service: &stubTransferService{err: tt.err}}
+
+			_, err := s.CreateTransfer(middleware.NewContext(context.Background(), "alice"), req)
+			st, _ := status.FromError(err)
+			require.Equal(t, codes.FailedPrecondition, st.Code())
+			require.Len(t, st.Details(), 1)
+
+			detail, ok := st.Details()[0].(*structpb.Struct)
+			require.True(t, ok)
+			fields := detail.AsMap()
+			assert.Equal(t, tt.status, fields["status"])
+			held, ok := fields[tt.held].(map[string]interface{})
+			require.True(t, ok)
+			assert.Equal(t, float64(7), held["id"])
+		})
+	}
+}
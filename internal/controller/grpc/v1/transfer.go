@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	bankv1 "alukart32.com/bank/pkg/api/bank/v1"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type transferServer struct {
+	bankv1.UnimplementedTransferServiceServer
+	service  usecase.TransferService
+	accounts usecase.AccountService
+}
+
+var listTransferOrders = map[bankv1.ListTransfersOrder]usecase.ListTransferOrder{
+	bankv1.ListTransfersOrder_LIST_TRANSFERS_ORDER_FROM_ACCOUNT: usecase.ListFromAccount,
+	bankv1.ListTransfersOrder_LIST_TRANSFERS_ORDER_TO_ACCOUNT:   usecase.ListToAccount,
+	bankv1.ListTransfersOrder_LIST_TRANSFERS_ORDER_BY_ACCOUNTS:  usecase.ListByAccounts,
+}
+
+// CreateTransfer moves the amount from the account held by the caller,
+// the transfer service checks the holder.
+func (s *transferServer) CreateTransfer(ctx context.Context, req *bankv1.CreateTransferRequest) (*bankv1.CreateTransferResponse, error) {
+	fromAccountID, err := uuid.Parse(req.GetFromAccountId())
+	if err != nil {
+		return nil, invalidArgument("invalid from account id")
+	}
+	toAccountID, err := uuid.Parse(req.GetToAccountId())
+	if err != nil {
+		return nil, invalidArgument("invalid to account id")
+	}
+
+	res, err := s.service.Transfer(ctx, middleware.SubjectFromContext(ctx), entity.Transfer{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        req.GetAmount(),
+		Description:   req.GetDescription(),
+		Reference:     req.GetReference(),
+		Metadata:      req.GetMetadata(),
+	})
+	if err != nil {
+		return nil, errorStatus(err, "transfer service problems")
+	}
+
+	return &bankv1.CreateTransferResponse{
+		Transfer:    toTransferPb(res.Transfer),
+		FromAccount: toAccountPb(res.FromAccount),
+		ToAccount:   toAccountPb(res.ToAccount),
+		FromEntry:   toEntryPb(res.FromEntry),
+		ToEntry:     toEntryPb(res.ToEntry),
+	}, nil
+}
+
+// GetTransfer returns the transfer to the holders of its accounts or an
+// admin.
+func (s *transferServer) GetTransfer(ctx context.Context, req *bankv1.GetTransferRequest) (*bankv1.Transfer, error) {
+	transfer, err := s.service.Get(ctx, req.GetId())
+	if err == nil {
+		err = checkHolder(ctx, s.accounts, entity.PermissionView, transfer.FromAccountID, transfer.ToAccountID)
+	}
+	if err != nil {
+		return nil, errorStatus(err, "transfer service problems")
+	}
+
+	return toTransferPb(transfer), nil
+}
+
+// ListTransfers returns the transfers of the caller's accounts with the
+// reference or the transfers of the listed accounts to their holders or
+// an admin.
+func (s *transferServer) ListTransfers(ctx context.Context, req *bankv1.ListTransfersRequest) (*bankv1.ListTransfersResponse, error) {
+	if req.GetReference() != "" {
+		transfers, err := s.service.ListByReference(ctx, middleware.SubjectFromContext(ctx), req.GetReference(),
+			entity.TransferStatus(req.GetStatus()))
+		if err != nil {
+			return nil, errorStatus(err, "transfer service problems")
+		}
+		return toTransfersPb(transfers), nil
+	}
+
+	var params usecase.ListTransferParams
+
+	order, ok := listTransferOrders[req.GetOrder()]
+	if !ok {
+		return nil, invalidArgument("invalid list order")
+	}
+	params.Order = order
+	params.Status = entity.TransferStatus(req.GetStatus())
+
+	var err error
+	if req.GetFromAccountId() != "" {
+		if params.FromAccountId, err = uuid.Parse(req.GetFromAccountId()); err != nil {
+			return nil, invalidArgument("invalid from account id")
+		}
+	}
+	if req.GetToAccountId() != "" {
+		if params.ToAccountId, err = uuid.Parse(req.GetToAccountId()); err != nil {
+			return nil, invalidArgument("invalid to account id")
+		}
+	}
+	params.Limit = req.GetLimit()
+	params.Offset = req.GetOffset()
+
+	accounts := []uuid.UUID{params.FromAccountId}
+	switch params.Order {
+	case usecase.ListToAccount:
+		accounts = []uuid.UUID{params.ToAccountId}
+	case usecase.ListByAccounts:
+		accounts = append(accounts, params.ToAccountId)
+	}
+	if err = checkHolder(ctx, s.accounts, entity.PermissionView, accounts...); err != nil {
+		return nil, errorStatus(err, "transfer service problems")
+	}
+
+	transfers, err := s.service.List(ctx, params)
+	if err != nil {
+		return nil, errorStatus(err, "transfer service problems")
+	}
+
+	return toTransfersPb(transfers), nil
+}
+
+// RollbackTransfer reverses the transfer for an admin.
+func (s *transferServer) RollbackTransfer(ctx context.Context, req *bankv1.RollbackTransferRequest) (*emptypb.Empty, error) {
+	if err := requireRole(ctx, roleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := s.service.Rollback(ctx, req.GetId()); err != nil {
+		return nil, errorStatus(err, "transfer service problems")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -1,24 +1,30 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"alukart32.com/bank/entity"
 	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
 	"alukart32.com/bank/pkg/zerologx"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type accountRoutes struct {
-	service usecase.AccountService
-	logger  zerologx.Logger
+	service   usecase.AccountService
+	transfers usecase.TransferService
+	logger    zerologx.Logger
 }
 
-func newAccountsRoutes(handler *gin.RouterGroup, s usecase.AccountService, l zerologx.Logger) {
+func newAccountsRoutes(handler *gin.RouterGroup, s usecase.AccountService, ts usecase.TransferService,
+	l zerologx.Logger) {
 	r := &accountRoutes{
-		service: s,
-		logger:  l,
+		service:   s,
+		transfers: ts,
+		logger:    l,
 	}
 
 	h := handler.Group("/accounts")
@@ -27,21 +33,40 @@ func newAccountsRoutes(handler *gin.RouterGroup, s usecase.AccountService, l zer
 		h.GET("/:id/entries", r.listEntries)
 		h.GET("/:id/transfers", r.listTransfers)
 		h.POST("/", r.create)
-		h.POST("/add", r.addBalance)
 		h.PATCH("/:id/owner", r.updateOwner)
+		h.GET("/:id/holders", r.listHolders)
+		h.POST("/:id/holders", r.inviteHolder)
+		h.POST("/:id/holders/accept", r.acceptHolder)
+		h.DELETE("/:id/holders/:holder", r.removeHolder)
 		h.DELETE("/:id", r.delete)
 	}
 }
 
+// getById returns the account by its id or account number to its holders
+// or an admin.
 func (r *accountRoutes) getById(c *gin.Context) {
-	id := c.Param("id")
-	account, err := r.service.Get(
-		c.Request.Context(),
-		uuid.MustParse(id),
+	var (
+		account entity.Account
+		err     error
 	)
+	if id, parseErr := uuid.Parse(c.Param("id")); parseErr == nil {
+		account, err = r.service.Get(c.Request.Context(), id)
+	} else {
+		account, err = r.service.GetByNumber(c.Request.Context(), c.Param("id"))
+	}
+	if err == nil && !middleware.HasRole(c, roleAdmin) {
+		err = checkHolder(c, r.service, account, entity.PermissionView)
+	}
 	if err != nil {
 		r.logger.Error(err, "http - v1 - account - getByID")
-		errorResponse(c, http.StatusInternalServerError, "account service problems")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidArgument):
+			errorResponse(c, http.StatusBadRequest, "invalid account number")
+		case errors.Is(err, usecase.ErrNotFound):
+			errorResponse(c, http.StatusNotFound, "account not found")
+		default:
+			errorResponse(c, http.StatusInternalServerError, "account service problems")
+		}
 
 		return
 	}
@@ -52,6 +77,8 @@ func (r *accountRoutes) getById(c *gin.Context) {
 type createAccountReq struct {
 }
 
+// create opens the account for the caller, an admin opens the account for
+// the owner of the request.
 func (r *accountRoutes) create(c *gin.Context) {
 	var accountToCreate entity.Account
 
@@ -61,11 +88,14 @@ func (r *accountRoutes) create(c *gin.Context) {
 		errorResponse(c, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	if !middleware.HasRole(c, roleAdmin) {
+		accountToCreate.Owner = middleware.Subject(c)
+	}
 
 	id, err := r.service.Create(c.Request.Context(), accountToCreate)
 	if err != nil {
 		r.logger.Error(err, "http - v1 - account - create")
-		errorResponse(c, http.StatusInternalServerError, "account service problems")
+		ownerErrorResponse(c, err)
 
 		return
 	}
@@ -73,22 +103,159 @@ func (r *accountRoutes) create(c *gin.Context) {
 	c.JSON(http.StatusOK, id)
 }
 
+type updateOwnerRequest struct {
+	Owner string `json:"owner" binding:"required"`
+}
+
+// updateOwner changes the owner of the account for its primary holder or
+// an admin.
 func (r *accountRoutes) updateOwner(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
 
-}
+	var request updateOwnerRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - account - updateOwner")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
 
-func (r *accountRoutes) addBalance(c *gin.Context) {
+	subject := middleware.Subject(c)
+	if middleware.HasRole(c, roleAdmin) {
+		subject = ""
+	}
 
+	account, err := r.service.UpdateOwner(c.Request.Context(), subject, id, request.Owner)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - updateOwner")
+		ownerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// ownerErrorResponse responds with the error of the account opening or
+// the owner update, the screened owner may be blocked.
+func ownerErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound), errors.Is(err, usecase.ErrAccessDenied):
+		errorResponse(c, http.StatusNotFound, "account not found")
+	case errors.Is(err, usecase.ErrScreeningBlocked):
+		errorResponse(c, http.StatusForbidden, "owner is blocked by sanctions screening")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "account service problems")
+	}
 }
 
 func (r *accountRoutes) listEntries(c *gin.Context) {
 
 }
 
+// listTransfers returns the transfers from the account to its holders or
+// an admin, the ones to it with the direction=in query param. The status
+// query param filters them, limit and offset page them.
 func (r *accountRoutes) listTransfers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
 
+	params := usecase.ListTransferParams{
+		FromAccountId: id,
+		Order:         usecase.ListFromAccount,
+		Status:        entity.TransferStatus(c.Query("status")),
+	}
+	switch c.Query("direction") {
+	case "", "out":
+	case "in":
+		params.FromAccountId, params.ToAccountId = uuid.Nil, id
+		params.Order = usecase.ListToAccount
+	default:
+		errorResponse(c, http.StatusBadRequest, "direction must be in or out")
+		return
+	}
+	for name, v := range map[string]*int32{"limit": &params.Limit, "offset": &params.Offset} {
+		q := c.Query(name)
+		if q == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(q, 10, 32)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "invalid "+name)
+			return
+		}
+		*v = int32(n)
+	}
+
+	account, err := r.service.Get(c.Request.Context(), id)
+	if err == nil && !middleware.HasRole(c, roleAdmin) {
+		err = checkHolder(c, r.service, account, entity.PermissionView)
+	}
+	var transfers []entity.Transfer
+	if err == nil {
+		transfers, err = r.transfers.List(c.Request.Context(), params)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - listTransfers")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidArgument):
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecase.ErrNotFound):
+			errorResponse(c, http.StatusNotFound, "account not found")
+		default:
+			errorResponse(c, http.StatusInternalServerError, "account service problems")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
 }
 
 func (r *accountRoutes) delete(c *gin.Context) {
 
 }
+
+// accountID returns the id of the account given either by id or by number.
+func accountID(c *gin.Context, s usecase.AccountService, id uuid.UUID, number string) (uuid.UUID, error) {
+	if (id == uuid.Nil) == (number == "") {
+		return uuid.Nil, usecase.ErrInvalidArgument
+	}
+	if number == "" {
+		return id, nil
+	}
+
+	account, err := s.GetByNumber(c.Request.Context(), number)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return account.ID, nil
+}
+
+// checkHolder returns ErrNotFound unless the caller holds the account in
+// a role allowing the action, so the other accounts stay hidden.
+func checkHolder(c *gin.Context, s usecase.AccountService, a entity.Account, p entity.Permission) error {
+	err := s.Authorize(c.Request.Context(), middleware.Subject(c), a, p)
+	if errors.Is(err, usecase.ErrAccessDenied) {
+		return usecase.ErrNotFound
+	}
+	return err
+}
+
+// accountErrorResponse responds with the error of the account resolution.
+func accountErrorResponse(c *gin.Context, err error, side string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, "invalid "+side+" account id or number")
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, side+" account not found")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "account service problems")
+	}
+}
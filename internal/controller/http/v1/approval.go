@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type approvalRoutes struct {
+	service usecase.ApprovalService
+	logger  zerologx.Logger
+}
+
+func newApprovalsRoutes(handler *gin.RouterGroup, s usecase.ApprovalService, l zerologx.Logger) {
+	r := &approvalRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/approvals")
+	{
+		h.GET("/", r.list)
+		h.GET("/:id", r.get)
+		h.POST("/:id/approve", r.approve)
+		h.POST("/:id/reject", r.reject)
+	}
+
+	a := handler.Group("/admin/accounts", middleware.RequireRole(roleAdmin))
+	{
+		a.GET("/:id/approval-policy", r.getPolicy)
+		a.PUT("/:id/approval-policy", r.setPolicy)
+		a.DELETE("/:id/approval-policy", r.deletePolicy)
+	}
+}
+
+// list returns the pending approvals the caller can decide.
+func (r *approvalRoutes) list(c *gin.Context) {
+	approvals, err := r.service.List(c.Request.Context(), middleware.Subject(c))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - list")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approvals)
+}
+
+func (r *approvalRoutes) get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid approval id")
+		return
+	}
+
+	approval, err := r.service.Get(c.Request.Context(), middleware.Subject(c), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - get")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+type decideApprovalRequest struct {
+	Comment string `json:"comment"`
+}
+
+func (r *approvalRoutes) approve(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	approval, err := r.service.Approve(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - approve")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+func (r *approvalRoutes) reject(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	approval, err := r.service.Reject(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - reject")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approval)
+}
+
+// decideRequest parses the approval id and the optional request body.
+func (r *approvalRoutes) decideRequest(c *gin.Context) (int64, decideApprovalRequest, bool) {
+	var request decideApprovalRequest
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid approval id")
+		return 0, request, false
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err = c.BindJSON(&request); err != nil {
+			r.logger.Error(err, "http - v1 - approval")
+			errorResponse(c, http.StatusBadRequest, "invalid request body")
+			return 0, request, false
+		}
+	}
+	return id, request, true
+}
+
+func (r *approvalRoutes) getPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	policy, err := r.service.GetPolicy(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - get policy")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+type setApprovalPolicyRequest struct {
+	Threshold int64    `json:"threshold" binding:"required"`
+	Approvers []string `json:"approvers" binding:"required"`
+}
+
+func (r *approvalRoutes) setPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	var request setApprovalPolicyRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - approval - set policy")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	policy, err := r.service.SetPolicy(c.Request.Context(), middleware.Subject(c), entity.ApprovalPolicy{
+		AccountID: id,
+		Threshold: request.Threshold,
+		Approvers: request.Approvers,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - approval - set policy")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+func (r *approvalRoutes) deletePolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.service.DeletePolicy(c.Request.Context(), id); err != nil {
+		r.logger.Error(err, "http - v1 - approval - delete policy")
+		approvalErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func approvalErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrApprovalClosed), errors.Is(err, usecase.ErrApprovalExpired):
+		errorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "approval or account not found")
+	case errors.Is(err, usecase.ErrAccessDenied):
+		errorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrInvalidArgument), errors.Is(err, usecase.ErrInsufficientFunds),
+		errors.Is(err, usecase.ErrLimitExceeded), errors.Is(err, usecase.ErrTransferHeld),
+		errors.Is(err, usecase.ErrTransferBlocked):
+		// the approval executes the transfer
+		transferErrorResponse(c, err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, "approval service problems")
+	}
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 64
+)
+
+// auditContext stores the caller of the request for the audit records.
+// The request id of the client is kept, a missing or too long one is
+// replaced by a new id. The id is echoed in the response.
+func auditContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		c.Request = c.Request.WithContext(usecase.WithActor(c.Request.Context(), entity.AuditActor{
+			Subject:   middleware.Subject(c),
+			RequestID: requestID,
+			ClientIP:  c.ClientIP(),
+		}))
+		c.Next()
+	}
+}
+
+type auditRoutes struct {
+	service usecase.AuditService
+	logger  zerologx.Logger
+}
+
+func newAuditRoutes(handler *gin.RouterGroup, s usecase.AuditService, l zerologx.Logger) {
+	r := &auditRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/audit", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/", r.list)
+		h.GET("/verify", r.verify)
+	}
+}
+
+// list returns the records filtered by the target_type, target_id and
+// actor query params, the newest first.
+func (r *auditRoutes) list(c *gin.Context) {
+	params := usecase.ListAuditParams{
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Actor:      c.Query("actor"),
+	}
+	for name, v := range map[string]*int32{"limit": &params.Limit, "offset": &params.Offset} {
+		q := c.Query(name)
+		if q == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(q, 10, 32)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "invalid "+name)
+			return
+		}
+		*v = int32(n)
+	}
+
+	records, err := r.service.List(c.Request.Context(), params)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - audit - list")
+		auditErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// verify checks the hash chain of the audit log.
+func (r *auditRoutes) verify(c *gin.Context) {
+	result, err := r.service.Verify(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - audit - verify")
+		auditErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func auditErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "audit service problems")
+	}
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const roleSupport = "support"
+
+type balanceRoutes struct {
+	service  usecase.BalanceService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
+}
+
+func newBalanceRoutes(handler *gin.RouterGroup, s usecase.BalanceService, as usecase.AccountService, l zerologx.Logger) {
+	r := &balanceRoutes{
+		service:  s,
+		accounts: as,
+		logger:   l,
+	}
+
+	h := handler.Group("/accounts")
+	{
+		h.GET("/:id/balance", r.asOf)
+	}
+
+	a := handler.Group("/admin/balances", middleware.RequireRole(roleAdmin))
+	{
+		a.POST("/snapshots", r.snapshot)
+		a.POST("/snapshots/rebuild", r.rebuild)
+	}
+}
+
+// asOf returns the balance of the account by its id or account number as
+// of the as_of query param. The date is taken as the end of the UTC day,
+// the RFC 3339 time as is, and no value as now. The balance is visible to
+// the holders of the account, the support staff and the admins only.
+func (r *balanceRoutes) asOf(c *gin.Context) {
+	asOf, err := parseAsOf(c.Query("as_of"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid as_of")
+		return
+	}
+
+	var account entity.Account
+	if id, parseErr := uuid.Parse(c.Param("id")); parseErr == nil {
+		account, err = r.accounts.Get(c.Request.Context(), id)
+	} else {
+		account, err = r.accounts.GetByNumber(c.Request.Context(), c.Param("id"))
+	}
+	if err == nil && !middleware.HasRole(c, roleAdmin) && !middleware.HasRole(c, roleSupport) {
+		err = checkHolder(c, r.accounts, account, entity.PermissionView)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - balance - asOf - account")
+		balanceErrorResponse(c, err)
+		return
+	}
+
+	balance, err := r.service.AsOf(c.Request.Context(), account.ID, asOf)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - balance - asOf")
+		balanceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+// parseAsOf parses the date or the RFC 3339 time, the zero time is now.
+func parseAsOf(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse(dateLayout, v); err == nil {
+		return date.AddDate(0, 0, 1), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+type snapshotRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+// snapshot saves the end-of-day balances of the date, it is safe to
+// repeat.
+func (r *balanceRoutes) snapshot(c *gin.Context) {
+	var request snapshotRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - balance - snapshot")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	run, err := r.service.Snapshot(c.Request.Context(), date)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - balance - snapshot")
+		balanceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// rebuild replaces the snapshots with the ones computed from the entries.
+func (r *balanceRoutes) rebuild(c *gin.Context) {
+	run, err := r.service.Rebuild(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - balance - rebuild")
+		balanceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func balanceErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "account not found")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "balance service problems")
+	}
+}
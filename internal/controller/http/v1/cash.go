@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const roleTeller = "teller"
+
+type cashRoutes struct {
+	service  usecase.CashService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
+}
+
+func newCashRoutes(handler *gin.RouterGroup, s usecase.CashService, as usecase.AccountService, l zerologx.Logger) {
+	r := &cashRoutes{
+		service:  s,
+		accounts: as,
+		logger:   l,
+	}
+
+	h := handler.Group("/cash", middleware.RequireRole(roleTeller))
+	{
+		h.POST("/deposits", r.deposit)
+		h.POST("/withdrawals", r.withdraw)
+		h.GET("/receipts/:reference", r.receipt)
+		h.GET("/accounts/:id/operations", r.list)
+	}
+}
+
+// cashRequest identifies the account either by id or by number.
+type cashRequest struct {
+	AccountID     uuid.UUID `json:"accountId"`
+	AccountNumber string    `json:"accountNumber"`
+	Amount        int64     `json:"amount"  binding:"required"`
+	Channel       string    `json:"channel" binding:"required"`
+	TellerID      string    `json:"tellerId"`
+}
+
+func (r *cashRoutes) deposit(c *gin.Context) {
+	op, ok := r.operation(c)
+	if !ok {
+		return
+	}
+
+	receipt, err := r.service.Deposit(c.Request.Context(), middleware.Subject(c), op)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - cash - deposit")
+		cashErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, receipt)
+}
+
+func (r *cashRoutes) withdraw(c *gin.Context) {
+	op, ok := r.operation(c)
+	if !ok {
+		return
+	}
+
+	receipt, err := r.service.Withdraw(c.Request.Context(), middleware.Subject(c), op)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - cash - withdraw")
+		cashErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, receipt)
+}
+
+// operation parses the request body and resolves the account.
+func (r *cashRoutes) operation(c *gin.Context) (entity.CashOperation, bool) {
+	var request cashRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - cash")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return entity.CashOperation{}, false
+	}
+
+	id, err := accountID(c, r.accounts, request.AccountID, request.AccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - cash - account")
+		accountErrorResponse(c, err, "cash")
+		return entity.CashOperation{}, false
+	}
+
+	return entity.CashOperation{
+		AccountID: id,
+		Amount:    request.Amount,
+		Channel:   entity.CashChannel(request.Channel),
+		TellerID:  request.TellerID,
+	}, true
+}
+
+// receipt returns the operation by its receipt reference.
+func (r *cashRoutes) receipt(c *gin.Context) {
+	receipt, err := r.service.Receipt(c.Request.Context(), c.Param("reference"))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - cash - receipt")
+		if errors.Is(err, usecase.ErrNotFound) {
+			errorResponse(c, http.StatusNotFound, "receipt not found")
+		} else {
+			errorResponse(c, http.StatusInternalServerError, "cash service problems")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+// list returns the latest operations of the account.
+func (r *cashRoutes) list(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	operations, err := r.service.List(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - cash - list")
+		errorResponse(c, http.StatusInternalServerError, "cash service problems")
+		return
+	}
+
+	c.JSON(http.StatusOK, operations)
+}
+
+func cashErrorResponse(c *gin.Context, err error) {
+	var limitErr *usecase.LimitError
+	switch {
+	case errors.As(err, &limitErr):
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, limitResponse{
+			Error:     limitErr.Error(),
+			Limit:     limitErr.Limit,
+			Remaining: limitErr.Remaining,
+		})
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "account not found")
+	case errors.Is(err, usecase.ErrInsufficientFunds), errors.Is(err, usecase.ErrDuplicate):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "cash service problems")
+	}
+}
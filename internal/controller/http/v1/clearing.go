@@ -0,0 +1,364 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/clearing"
+	"alukart32.com/bank/internal/iso20022"
+	"alukart32.com/bank/internal/nacha"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type clearingRoutes struct {
+	service  usecase.ClearingService
+	accounts usecase.AccountService
+	exporter *clearing.Exporter
+	logger   zerologx.Logger
+}
+
+func newClearingRoutes(handler *gin.RouterGroup, s usecase.ClearingService, as usecase.AccountService,
+	e *clearing.Exporter, l zerologx.Logger) {
+	r := &clearingRoutes{
+		service:  s,
+		accounts: as,
+		exporter: e,
+		logger:   l,
+	}
+
+	h := handler.Group("/external-transfers")
+	{
+		h.POST("", r.transfer)
+		h.GET("/:id", r.get)
+		h.POST("/:id/approve", r.approve)
+		h.POST("/:id/reject", r.decline)
+	}
+	handler.GET("/accounts/:id/external-transfers", r.list)
+
+	a := handler.Group("/admin/clearing", middleware.RequireRole(roleAdmin))
+	{
+		a.GET("/batches", r.listBatches)
+		a.POST("/batches", r.sendBatches)
+		a.GET("/batches/:name/file", r.batchFile)
+		a.POST("/responses", r.processResponses)
+		a.GET("/held", r.listHeld)
+		a.POST("/held/:id/release", r.release)
+		a.POST("/held/:id/reject", r.reject)
+	}
+}
+
+// externalTransferRequest identifies the debited account either by id or
+// by number.
+type externalTransferRequest struct {
+	AccountID          uuid.UUID `json:"accountId"`
+	AccountNumber      string    `json:"accountNumber"`
+	Amount             int64     `json:"amount"             binding:"required"`
+	BeneficiaryName    string    `json:"beneficiaryName"    binding:"required"`
+	BeneficiaryAccount string    `json:"beneficiaryAccount" binding:"required"`
+	BeneficiaryBank    string    `json:"beneficiaryBank"    binding:"required"`
+	Description        string    `json:"description"`
+	Reference          string    `json:"reference"`
+}
+
+// transfer debits the account of the caller, the transfer is pending
+// until the next batch or accepted held for the approval or the review.
+func (r *clearingRoutes) transfer(c *gin.Context) {
+	var request externalTransferRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - clearing - transfer")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	id, err := accountID(c, r.accounts, request.AccountID, request.AccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - transfer - account")
+		accountErrorResponse(c, err, "debited")
+		return
+	}
+
+	t, err := r.service.Transfer(c.Request.Context(), middleware.Subject(c), entity.ExternalTransfer{
+		AccountID:          id,
+		Amount:             request.Amount,
+		BeneficiaryName:    request.BeneficiaryName,
+		BeneficiaryAccount: request.BeneficiaryAccount,
+		BeneficiaryBank:    request.BeneficiaryBank,
+		Description:        request.Description,
+		Reference:          request.Reference,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - transfer")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	if t.Status == entity.ExternalHeld {
+		c.JSON(http.StatusAccepted, t)
+		return
+	}
+	c.JSON(http.StatusCreated, t)
+}
+
+// get returns the transfer to its account holder or an admin.
+func (r *clearingRoutes) get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+
+	t, err := r.service.Get(c.Request.Context(), id)
+	if err == nil {
+		err = r.checkOwner(c, t.AccountID)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - get")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// list returns the latest transfers of the account to its holder or an
+// admin.
+func (r *clearingRoutes) list(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.checkOwner(c, id); err != nil {
+		r.logger.Error(err, "http - v1 - clearing - list - account")
+		accountErrorResponse(c, err, "debited")
+		return
+	}
+
+	transfers, err := r.service.List(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - list")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+// checkOwner hides the accounts of the other holders from the non-admins.
+func (r *clearingRoutes) checkOwner(c *gin.Context, accountID uuid.UUID) error {
+	if middleware.HasRole(c, roleAdmin) {
+		return nil
+	}
+	account, err := r.accounts.Get(c.Request.Context(), accountID)
+	if err != nil {
+		return err
+	}
+	return checkHolder(c, r.accounts, account, entity.PermissionView)
+}
+
+type decideExternalRequest struct {
+	Comment string `json:"comment"`
+}
+
+// approve submits the transfer held for the approval on behalf of an
+// approver of the account.
+func (r *clearingRoutes) approve(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	t, err := r.service.Approve(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - approve")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// decline refunds the transfer held for the approval on behalf of an
+// approver of the account.
+func (r *clearingRoutes) decline(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	t, err := r.service.Decline(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - decline")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// listHeld returns the transfers held for the hold of the query, the
+// review by default.
+func (r *clearingRoutes) listHeld(c *gin.Context) {
+	transfers, err := r.service.ListHeld(c.Request.Context(), entity.ExternalHold(c.Query("hold")))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - listHeld")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
+}
+
+// release submits the transfer held for the review.
+func (r *clearingRoutes) release(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	t, err := r.service.Release(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - release")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// reject refunds the transfer held for the review.
+func (r *clearingRoutes) reject(c *gin.Context) {
+	id, request, ok := r.decideRequest(c)
+	if !ok {
+		return
+	}
+
+	t, err := r.service.Reject(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - reject")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+func (r *clearingRoutes) decideRequest(c *gin.Context) (int64, decideExternalRequest, bool) {
+	var request decideExternalRequest
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid transfer id")
+		return 0, request, false
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err = c.BindJSON(&request); err != nil {
+			r.logger.Error(err, "http - v1 - clearing")
+			errorResponse(c, http.StatusBadRequest, "invalid request body")
+			return 0, request, false
+		}
+	}
+	return id, request, true
+}
+
+func (r *clearingRoutes) listBatches(c *gin.Context) {
+	batches, err := r.service.ListBatches(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - listBatches")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batches)
+}
+
+// sendBatches delivers the pending transfers to the clearing house.
+func (r *clearingRoutes) sendBatches(c *gin.Context) {
+	batches, err := r.service.SendBatches(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - sendBatches")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batches)
+}
+
+// batchFile exports the batch in the format of the query, csv of the
+// clearing house by default, nacha or sepa.
+func (r *clearingRoutes) batchFile(c *gin.Context) {
+	batch, err := r.service.GetBatch(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - batchFile")
+		if errors.Is(err, usecase.ErrNotFound) {
+			errorResponse(c, http.StatusNotFound, "batch not found")
+			return
+		}
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	format := clearing.Format(c.DefaultQuery("format", string(clearing.FormatCSV)))
+	contentType, err := r.exporter.Export(&buf, format, batch, time.Now())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - batchFile - export")
+		switch {
+		case errors.Is(err, clearing.ErrUnknownFormat):
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, nacha.ErrInvalid), errors.Is(err, iso20022.ErrMalformed):
+			errorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			errorResponse(c, http.StatusInternalServerError, "clearing service problems")
+		}
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// processResponses settles and returns the transfers by the received
+// responses.
+func (r *clearingRoutes) processResponses(c *gin.Context) {
+	report, err := r.service.ProcessResponses(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - clearing - processResponses")
+		clearingErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func clearingErrorResponse(c *gin.Context, err error) {
+	var limitErr *usecase.LimitError
+	switch {
+	case errors.As(err, &limitErr):
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, limitResponse{
+			Error:     limitErr.Error(),
+			Limit:     limitErr.Limit,
+			Remaining: limitErr.Remaining,
+		})
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "transfer not found")
+	case errors.Is(err, usecase.ErrAccessDenied), errors.Is(err, usecase.ErrScreeningBlocked),
+		errors.Is(err, usecase.ErrTransferBlocked):
+		errorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrInsufficientFunds), errors.Is(err, usecase.ErrInvalidTransition):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "clearing service problems")
+	}
+}
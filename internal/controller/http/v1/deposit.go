@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type depositRoutes struct {
+	service  usecase.DepositService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
+}
+
+func newDepositRoutes(handler *gin.RouterGroup, s usecase.DepositService, as usecase.AccountService,
+	l zerologx.Logger) {
+	r := &depositRoutes{
+		service:  s,
+		accounts: as,
+		logger:   l,
+	}
+
+	handler.GET("/deposits/:id", r.get)
+	handler.POST("/deposits/:id/withdrawal", r.withdraw)
+	handler.GET("/accounts/:id/deposits", r.list)
+
+	a := handler.Group("/admin/deposits", middleware.RequireRole(roleAdmin))
+	{
+		a.POST("", r.open)
+		a.POST("/maturities", r.mature)
+	}
+}
+
+// openDepositRequest identifies the account of the deposit either by id
+// or by number. The deposit is paid out to the account itself if no
+// payout account is set.
+type openDepositRequest struct {
+	AccountID           uuid.UUID `json:"accountId"`
+	AccountNumber       string    `json:"accountNumber"`
+	PayoutAccountID     uuid.UUID `json:"payoutAccountId"`
+	PayoutAccountNumber string    `json:"payoutAccountNumber"`
+	Principal           int64     `json:"principal" binding:"required"`
+	AnnualRateBP        int32     `json:"annualRateBp"`
+	TermMonths          int32     `json:"termMonths" binding:"required"`
+	MaturityAction      string    `json:"maturityAction" binding:"required"`
+	EarlyWithdrawal     string    `json:"earlyWithdrawal"`
+	EarlyPenaltyBP      int32     `json:"earlyPenaltyBp"`
+}
+
+// open takes the principal of the deposit from the account.
+func (r *depositRoutes) open(c *gin.Context) {
+	var request openDepositRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - deposit - open")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	id, err := accountID(c, r.accounts, request.AccountID, request.AccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - open - account")
+		accountErrorResponse(c, err, "deposit")
+		return
+	}
+	var payoutID uuid.UUID
+	if request.PayoutAccountID != uuid.Nil || request.PayoutAccountNumber != "" {
+		payoutID, err = accountID(c, r.accounts, request.PayoutAccountID, request.PayoutAccountNumber)
+		if err != nil {
+			r.logger.Error(err, "http - v1 - deposit - open - payout account")
+			accountErrorResponse(c, err, "payout")
+			return
+		}
+	}
+
+	deposit, err := r.service.Open(c.Request.Context(), middleware.Subject(c), entity.TermDeposit{
+		AccountID:       id,
+		PayoutAccountID: payoutID,
+		Principal:       request.Principal,
+		AnnualRateBP:    request.AnnualRateBP,
+		TermMonths:      request.TermMonths,
+		MaturityAction:  entity.MaturityAction(request.MaturityAction),
+		EarlyWithdrawal: entity.EarlyWithdrawal(request.EarlyWithdrawal),
+		EarlyPenaltyBP:  request.EarlyPenaltyBP,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - open")
+		depositErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, deposit)
+}
+
+// get returns the deposit to its account holder or an admin.
+func (r *depositRoutes) get(c *gin.Context) {
+	deposit, ok := r.deposit(c, "get", entity.PermissionView)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, deposit)
+}
+
+// withdraw pays out the deposit before its maturity for the account
+// holder allowed to transfer or an admin.
+func (r *depositRoutes) withdraw(c *gin.Context) {
+	deposit, ok := r.deposit(c, "withdraw", entity.PermissionTransfer)
+	if !ok {
+		return
+	}
+
+	deposit, err := r.service.Withdraw(c.Request.Context(), deposit.ID)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - withdraw")
+		depositErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deposit)
+}
+
+// list returns the latest deposits of the account to its holder or an
+// admin.
+func (r *depositRoutes) list(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.checkOwner(c, id, entity.PermissionView); err != nil {
+		r.logger.Error(err, "http - v1 - deposit - list - account")
+		accountErrorResponse(c, err, "deposit")
+		return
+	}
+
+	deposits, err := r.service.List(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - list")
+		depositErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deposits)
+}
+
+type matureRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+// mature pays out or rolls over the deposits maturing on or before the
+// date, it is safe to repeat.
+func (r *depositRoutes) mature(c *gin.Context) {
+	var request matureRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - deposit - mature")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	run, err := r.service.Mature(c.Request.Context(), date)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - mature")
+		depositErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// deposit returns the deposit of the id param if the caller is allowed
+// the action on its account, otherwise it writes the error response.
+func (r *depositRoutes) deposit(c *gin.Context, action string, p entity.Permission) (entity.TermDeposit, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid deposit id")
+		return entity.TermDeposit{}, false
+	}
+
+	deposit, err := r.service.Get(c.Request.Context(), id)
+	if err == nil {
+		err = r.checkOwner(c, deposit.AccountID, p)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - deposit - "+action)
+		depositErrorResponse(c, err)
+		return entity.TermDeposit{}, false
+	}
+	return deposit, true
+}
+
+// checkOwner hides the accounts of the other holders from the non-admins.
+func (r *depositRoutes) checkOwner(c *gin.Context, accountID uuid.UUID, p entity.Permission) error {
+	if middleware.HasRole(c, roleAdmin) {
+		return nil
+	}
+	account, err := r.accounts.Get(c.Request.Context(), accountID)
+	if err != nil {
+		return err
+	}
+	return checkHolder(c, r.accounts, account, p)
+}
+
+func depositErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "deposit not found")
+	case errors.Is(err, usecase.ErrDepositLocked), errors.Is(err, usecase.ErrDepositClosed),
+		errors.Is(err, usecase.ErrInsufficientFunds):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "deposit service problems")
+	}
+}
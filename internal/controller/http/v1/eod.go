@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type eodRoutes struct {
+	service usecase.EODService
+	logger  zerologx.Logger
+}
+
+func newEODRoutes(handler *gin.RouterGroup, s usecase.EODService, l zerologx.Logger) {
+	r := &eodRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/eod", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/business-date", r.businessDate)
+		h.GET("/runs", r.list)
+		h.POST("/runs", r.run)
+		h.GET("/runs/:date", r.get)
+	}
+
+	a := handler.Group("/admin/accounts", middleware.RequireRole(roleAdmin))
+	{
+		a.GET("/:id/daily-statements", r.statements)
+	}
+}
+
+func (r *eodRoutes) businessDate(c *gin.Context) {
+	date, err := r.service.BusinessDate(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - eod - businessDate")
+		eodErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, date)
+}
+
+// run closes the business date, the failed run is resumed by the next
+// one.
+func (r *eodRoutes) run(c *gin.Context) {
+	run, err := r.service.Run(c.Request.Context(), middleware.Subject(c))
+	if errors.Is(err, usecase.ErrEODFailed) {
+		r.logger.Error(err, "http - v1 - eod - run")
+		c.JSON(http.StatusUnprocessableEntity, run)
+		return
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - eod - run")
+		eodErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (r *eodRoutes) list(c *gin.Context) {
+	runs, err := r.service.List(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - eod - list")
+		eodErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// get returns the run of the business date with its steps.
+func (r *eodRoutes) get(c *gin.Context) {
+	date, err := time.Parse(dateLayout, c.Param("date"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	run, err := r.service.Get(c.Request.Context(), date)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - eod - get")
+		eodErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (r *eodRoutes) statements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	statements, err := r.service.Statements(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - eod - statements")
+		eodErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+func eodErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "EOD run not found")
+	case errors.Is(err, usecase.ErrEODRunning):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "EOD service problems")
+	}
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type feeRoutes struct {
+	service usecase.FeeService
+	logger  zerologx.Logger
+}
+
+func newFeeRoutes(handler *gin.RouterGroup, s usecase.FeeService, l zerologx.Logger) {
+	r := &feeRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/fees", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/schedules", r.listSchedules)
+		h.POST("/schedules", r.createSchedule)
+		h.GET("/schedules/:id", r.getSchedule)
+		h.POST("/schedules/:id/deactivate", r.deactivateSchedule)
+		h.POST("/maintenance", r.chargeMaintenance)
+	}
+
+	a := handler.Group("/admin/accounts", middleware.RequireRole(roleAdmin))
+	{
+		a.GET("/:id/fees", r.charges)
+	}
+}
+
+type feeTierRequest struct {
+	UpTo   int64 `json:"upTo"`
+	Fixed  int64 `json:"fixed"`
+	RateBP int32 `json:"rateBp"`
+}
+
+type createScheduleRequest struct {
+	Name             string           `json:"name" binding:"required"`
+	Kind             string           `json:"kind" binding:"required"`
+	AppliesTo        string           `json:"appliesTo" binding:"required"`
+	ProductID        int64            `json:"productId"`
+	Currency         string           `json:"currency" binding:"required"`
+	Fixed            int64            `json:"fixed"`
+	RateBP           int32            `json:"rateBp"`
+	Min              int64            `json:"min"`
+	Max              int64            `json:"max"`
+	Tiers            []feeTierRequest `json:"tiers"`
+	RevenueAccountID uuid.UUID        `json:"revenueAccountId" binding:"required"`
+}
+
+func (r *feeRoutes) createSchedule(c *gin.Context) {
+	var request createScheduleRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - fee - createSchedule")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var tiers entity.FeeTiers
+	for _, t := range request.Tiers {
+		tiers = append(tiers, entity.FeeTier{UpTo: t.UpTo, Fixed: t.Fixed, RateBP: t.RateBP})
+	}
+	schedule, err := r.service.CreateSchedule(c.Request.Context(), entity.FeeSchedule{
+		Name:             request.Name,
+		Kind:             entity.FeeKind(request.Kind),
+		AppliesTo:        entity.FeeAppliesTo(request.AppliesTo),
+		ProductID:        request.ProductID,
+		Currency:         entity.Currency(request.Currency),
+		Fixed:            request.Fixed,
+		RateBP:           request.RateBP,
+		Min:              request.Min,
+		Max:              request.Max,
+		Tiers:            tiers,
+		RevenueAccountID: request.RevenueAccountID,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - createSchedule")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func (r *feeRoutes) listSchedules(c *gin.Context) {
+	schedules, err := r.service.ListSchedules(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - listSchedules")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+func (r *feeRoutes) getSchedule(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid schedule id")
+		return
+	}
+
+	schedule, err := r.service.GetSchedule(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - getSchedule")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// deactivateSchedule stops the charges of the schedule, the past charges
+// keep referring to it.
+func (r *feeRoutes) deactivateSchedule(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid schedule id")
+		return
+	}
+
+	schedule, err := r.service.DeactivateSchedule(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - deactivateSchedule")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+type chargeMaintenanceRequest struct {
+	Period string `json:"period" binding:"required"`
+}
+
+// chargeMaintenance charges the maintenance fees of the month, it is safe
+// to repeat.
+func (r *feeRoutes) chargeMaintenance(c *gin.Context) {
+	var request chargeMaintenanceRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - fee - chargeMaintenance")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	period, err := time.Parse(monthLayout, request.Period)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	run, err := r.service.ChargeMaintenance(c.Request.Context(), period)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - chargeMaintenance")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (r *feeRoutes) charges(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	charges, err := r.service.Charges(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - fee - charges")
+		feeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, charges)
+}
+
+func feeErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "fee schedule not found")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "fee service problems")
+	}
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// listHolders returns the holders of the account to its holders or an
+// admin.
+func (r *accountRoutes) listHolders(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if !middleware.HasRole(c, roleAdmin) {
+		account, err := r.service.Get(c.Request.Context(), id)
+		if err == nil {
+			err = checkHolder(c, r.service, account, entity.PermissionView)
+		}
+		if err != nil {
+			r.logger.Error(err, "http - v1 - account - listHolders - account")
+			holderErrorResponse(c, err)
+			return
+		}
+	}
+
+	holders, err := r.service.Holders(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - listHolders")
+		holderErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holders)
+}
+
+type inviteHolderRequest struct {
+	Holder string `json:"holder" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// inviteHolder invites the holder to the account on behalf of the caller.
+func (r *accountRoutes) inviteHolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	var request inviteHolderRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - account - inviteHolder")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	holder, err := r.service.InviteHolder(c.Request.Context(), middleware.Subject(c), entity.AccountHolder{
+		AccountID: id,
+		Holder:    request.Holder,
+		Role:      entity.HolderRole(request.Role),
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - inviteHolder")
+		holderErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, holder)
+}
+
+// acceptHolder accepts the invitation of the caller to the account.
+func (r *accountRoutes) acceptHolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	holder, err := r.service.AcceptHolder(c.Request.Context(), middleware.Subject(c), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - acceptHolder")
+		holderErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holder)
+}
+
+// removeHolder removes the holder from the account, the holders may
+// remove themselves.
+func (r *accountRoutes) removeHolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	err = r.service.RemoveHolder(c.Request.Context(), middleware.Subject(c), id, c.Param("holder"))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - account - removeHolder")
+		holderErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func holderErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "account holder not found")
+	case errors.Is(err, usecase.ErrAccessDenied):
+		errorResponse(c, http.StatusForbidden, "holder role doesn't allow managing the holders")
+	case errors.Is(err, usecase.ErrDuplicate):
+		errorResponse(c, http.StatusConflict, "account holder already exists")
+	case errors.Is(err, usecase.ErrScreeningBlocked):
+		errorResponse(c, http.StatusForbidden, "holder is blocked by sanctions screening")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "account service problems")
+	}
+}
@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const monthLayout = "2006-01"
+
+type interestRoutes struct {
+	service usecase.InterestService
+	logger  zerologx.Logger
+}
+
+func newInterestRoutes(handler *gin.RouterGroup, s usecase.InterestService, l zerologx.Logger) {
+	r := &interestRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/interest", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/products", r.listProducts)
+		h.POST("/products", r.createProduct)
+		h.GET("/products/:id", r.getProduct)
+		h.POST("/accruals", r.accrue)
+		h.POST("/postings", r.post)
+	}
+
+	a := handler.Group("/admin/accounts", middleware.RequireRole(roleAdmin))
+	{
+		a.GET("/:id/product", r.getAccountProduct)
+		a.PUT("/:id/product", r.setAccountProduct)
+		a.DELETE("/:id/product", r.removeAccountProduct)
+		a.GET("/:id/interest/accruals", r.accruals)
+		a.GET("/:id/interest/postings", r.postings)
+	}
+}
+
+type createProductRequest struct {
+	Name             string    `json:"name" binding:"required"`
+	Currency         string    `json:"currency" binding:"required"`
+	AnnualRateBP     int32     `json:"annualRateBp"`
+	DayCount         string    `json:"dayCount" binding:"required"`
+	ExpenseAccountID uuid.UUID `json:"expenseAccountId" binding:"required"`
+}
+
+func (r *interestRoutes) createProduct(c *gin.Context) {
+	var request createProductRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - interest - createProduct")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	product, err := r.service.CreateProduct(c.Request.Context(), entity.InterestProduct{
+		Name:             request.Name,
+		Currency:         entity.Currency(request.Currency),
+		AnnualRateBP:     request.AnnualRateBP,
+		DayCount:         entity.DayCount(request.DayCount),
+		ExpenseAccountID: request.ExpenseAccountID,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - createProduct")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, product)
+}
+
+func (r *interestRoutes) listProducts(c *gin.Context) {
+	products, err := r.service.ListProducts(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - listProducts")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+func (r *interestRoutes) getProduct(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	product, err := r.service.GetProduct(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - getProduct")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+type accrueRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+// accrue runs the accrual of the date, it is safe to repeat.
+func (r *interestRoutes) accrue(c *gin.Context) {
+	var request accrueRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - interest - accrue")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	run, err := r.service.Accrue(c.Request.Context(), date)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - accrue")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+type postRequest struct {
+	Period string `json:"period" binding:"required"`
+}
+
+// post runs the posting of the month, it is safe to repeat.
+func (r *interestRoutes) post(c *gin.Context) {
+	var request postRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - interest - post")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	period, err := time.Parse(monthLayout, request.Period)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	run, err := r.service.Post(c.Request.Context(), period)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - post")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (r *interestRoutes) getAccountProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	product, err := r.service.GetAccountProduct(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - getAccountProduct")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+type setAccountProductRequest struct {
+	ProductID int64 `json:"productId" binding:"required"`
+}
+
+func (r *interestRoutes) setAccountProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	var request setAccountProductRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - interest - setAccountProduct")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	product, err := r.service.SetAccountProduct(c.Request.Context(), id, request.ProductID)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - setAccountProduct")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+func (r *interestRoutes) removeAccountProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.service.RemoveAccountProduct(c.Request.Context(), id); err != nil {
+		r.logger.Error(err, "http - v1 - interest - removeAccountProduct")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// accruals returns the accruals of the account for the dates [from, to],
+// both inclusive.
+func (r *interestRoutes) accruals(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+	from, err := time.Parse(dateLayout, c.Query("from"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid from")
+		return
+	}
+	to, err := time.Parse(dateLayout, c.Query("to"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid to")
+		return
+	}
+
+	accruals, err := r.service.Accruals(c.Request.Context(), id, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - accruals")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accruals)
+}
+
+func (r *interestRoutes) postings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	postings, err := r.service.Postings(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - interest - postings")
+		interestErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, postings)
+}
+
+func interestErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "not found")
+	case errors.Is(err, usecase.ErrDuplicate):
+		errorResponse(c, http.StatusConflict, "product already exists")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "interest service problems")
+	}
+}
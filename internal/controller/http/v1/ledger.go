@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ledgerRoutes struct {
+	service usecase.LedgerService
+	logger  zerologx.Logger
+}
+
+func newLedgerRoutes(handler *gin.RouterGroup, s usecase.LedgerService, l zerologx.Logger) {
+	r := &ledgerRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/ledger", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/accounts", r.listAccounts)
+		h.POST("/accounts", r.createAccount)
+		h.GET("/accounts/:id", r.getAccount)
+		h.GET("/trial-balance", r.trialBalance)
+	}
+}
+
+type createGLAccountRequest struct {
+	Code     string `json:"code" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Type     string `json:"type" binding:"required"`
+	Currency string `json:"currency" binding:"required"`
+}
+
+func (r *ledgerRoutes) createAccount(c *gin.Context) {
+	var request createGLAccountRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - ledger - createAccount")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	account, err := r.service.CreateAccount(c.Request.Context(), entity.GLAccount{
+		Code:     request.Code,
+		Name:     request.Name,
+		Type:     entity.GLAccountType(request.Type),
+		Currency: entity.Currency(request.Currency),
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - ledger - createAccount")
+		ledgerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, account)
+}
+
+func (r *ledgerRoutes) listAccounts(c *gin.Context) {
+	accounts, err := r.service.ListAccounts(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - ledger - listAccounts")
+		ledgerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (r *ledgerRoutes) getAccount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	account, err := r.service.GetAccount(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - ledger - getAccount")
+		ledgerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (r *ledgerRoutes) trialBalance(c *gin.Context) {
+	balances, err := r.service.TrialBalance(c.Request.Context())
+	if err != nil {
+		r.logger.Error(err, "http - v1 - ledger - trialBalance")
+		ledgerErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+func ledgerErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "GL account not found")
+	case errors.Is(err, usecase.ErrDuplicate):
+		errorResponse(c, http.StatusConflict, "GL account code already exists")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "ledger service problems")
+	}
+}
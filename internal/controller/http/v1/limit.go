@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// roleAdmin is the token role of the bank staff allowed to manage
+// accounts of any customer.
+const roleAdmin = "admin"
+
+type limitRoutes struct {
+	service usecase.LimitService
+	logger  zerologx.Logger
+}
+
+func newLimitsRoutes(handler *gin.RouterGroup, s usecase.LimitService, l zerologx.Logger) {
+	r := &limitRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/accounts", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/:id/limits", r.get)
+		h.PUT("/:id/limits", r.set)
+		h.DELETE("/:id/limits", r.reset)
+	}
+}
+
+func (r *limitRoutes) get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	limits, err := r.service.Get(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - limit - get")
+		limitErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+// setLimitsRequest holds the tier of the account and the overrides of
+// its limits. An omitted override means the tier limit, a zero one
+// disables the limit.
+type setLimitsRequest struct {
+	Tier        string `json:"tier" binding:"required"`
+	MaxSingle   *int64 `json:"maxSingle"`
+	Daily       *int64 `json:"daily"`
+	Monthly     *int64 `json:"monthly"`
+	HourlyCount *int64 `json:"hourlyCount"`
+}
+
+func (r *limitRoutes) set(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	var request setLimitsRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - limit - set")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	limits, err := r.service.Set(c.Request.Context(), middleware.Subject(c), entity.AccountLimits{
+		AccountID:   id,
+		Tier:        request.Tier,
+		MaxSingle:   request.MaxSingle,
+		Daily:       request.Daily,
+		Monthly:     request.Monthly,
+		HourlyCount: request.HourlyCount,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - limit - set")
+		limitErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+func (r *limitRoutes) reset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.service.Reset(c.Request.Context(), id); err != nil {
+		r.logger.Error(err, "http - v1 - limit - reset")
+		limitErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func limitErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "account not found")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "limit service problems")
+	}
+}
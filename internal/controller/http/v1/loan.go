@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type loanRoutes struct {
+	service  usecase.LoanService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
+}
+
+func newLoanRoutes(handler *gin.RouterGroup, s usecase.LoanService, as usecase.AccountService, l zerologx.Logger) {
+	r := &loanRoutes{
+		service:  s,
+		accounts: as,
+		logger:   l,
+	}
+
+	handler.GET("/loans/:id", r.get)
+	handler.GET("/accounts/:id/loans", r.list)
+
+	a := handler.Group("/admin/loans", middleware.RequireRole(roleAdmin))
+	{
+		a.POST("", r.disburse)
+		a.POST("/repayments", r.repay)
+	}
+}
+
+// disburseRequest identifies the account of the loan either by id or by
+// number.
+type disburseRequest struct {
+	AccountID     uuid.UUID `json:"accountId"`
+	AccountNumber string    `json:"accountNumber"`
+	Principal     int64     `json:"principal" binding:"required"`
+	AnnualRateBP  int32     `json:"annualRateBp"`
+	PenaltyRateBP int32     `json:"penaltyRateBp"`
+	TermMonths    int32     `json:"termMonths" binding:"required"`
+	Method        string    `json:"method" binding:"required"`
+}
+
+// disburse credits the loan to the account, it is repaid from the
+// account by the schedule.
+func (r *loanRoutes) disburse(c *gin.Context) {
+	var request disburseRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - loan - disburse")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	id, err := accountID(c, r.accounts, request.AccountID, request.AccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - loan - disburse - account")
+		accountErrorResponse(c, err, "loan")
+		return
+	}
+
+	loan, err := r.service.Disburse(c.Request.Context(), middleware.Subject(c), entity.Loan{
+		AccountID:     id,
+		Principal:     request.Principal,
+		AnnualRateBP:  request.AnnualRateBP,
+		PenaltyRateBP: request.PenaltyRateBP,
+		TermMonths:    request.TermMonths,
+		Method:        entity.AmortizationMethod(request.Method),
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - loan - disburse")
+		loanErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, loan)
+}
+
+// get returns the loan with its schedule and outstanding principal to
+// its account holder or an admin.
+func (r *loanRoutes) get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid loan id")
+		return
+	}
+
+	loan, err := r.service.Get(c.Request.Context(), id)
+	if err == nil {
+		err = r.checkOwner(c, loan.AccountID)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - loan - get")
+		loanErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// list returns the latest loans of the account to its holder or an admin.
+func (r *loanRoutes) list(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	if err = r.checkOwner(c, id); err != nil {
+		r.logger.Error(err, "http - v1 - loan - list - account")
+		accountErrorResponse(c, err, "loan")
+		return
+	}
+
+	loans, err := r.service.List(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - loan - list")
+		loanErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// checkOwner hides the accounts of the other holders from the non-admins.
+func (r *loanRoutes) checkOwner(c *gin.Context, accountID uuid.UUID) error {
+	if middleware.HasRole(c, roleAdmin) {
+		return nil
+	}
+	account, err := r.accounts.Get(c.Request.Context(), accountID)
+	if err != nil {
+		return err
+	}
+	return checkHolder(c, r.accounts, account, entity.PermissionView)
+}
+
+type repayRequest struct {
+	Date string `json:"date" binding:"required"`
+}
+
+// repay collects the installments due on or before the date, it is safe
+// to repeat.
+func (r *loanRoutes) repay(c *gin.Context) {
+	var request repayRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - loan - repay")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	run, err := r.service.Repay(c.Request.Context(), date)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - loan - repay")
+		loanErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func loanErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "loan not found")
+	default:
+		errorResponse(c, http.StatusInternalServerError, "loan service problems")
+	}
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type payeeRoutes struct {
+	service  usecase.PayeeService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
+}
+
+func newPayeesRoutes(handler *gin.RouterGroup, s usecase.PayeeService, as usecase.AccountService, l zerologx.Logger) {
+	r := &payeeRoutes{
+		service:  s,
+		accounts: as,
+		logger:   l,
+	}
+
+	h := handler.Group("/payees")
+	{
+		h.GET("/", r.list)
+		h.GET("/:id", r.getById)
+		h.POST("/", r.create)
+		h.PATCH("/:id", r.rename)
+		h.DELETE("/:id", r.delete)
+		h.POST("/:id/transfers", r.transfer)
+	}
+}
+
+func (r *payeeRoutes) list(c *gin.Context) {
+	payees, err := r.service.List(c.Request.Context(), middleware.Subject(c))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - list")
+		payeeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payees)
+}
+
+func (r *payeeRoutes) getById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid payee id")
+		return
+	}
+
+	payee, err := r.service.Get(c.Request.Context(), middleware.Subject(c), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - getByID")
+		payeeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// createPayeeRequest identifies the recipient account either by id or by number.
+type createPayeeRequest struct {
+	Nickname      string    `json:"nickname" binding:"required"`
+	AccountID     uuid.UUID `json:"accountID"`
+	AccountNumber string    `json:"accountNumber"`
+}
+
+func (r *payeeRoutes) create(c *gin.Context) {
+	var request createPayeeRequest
+	if err := c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - payee - create")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	payee, err := r.service.Create(c.Request.Context(), middleware.Subject(c), entity.Payee{
+		Nickname:      request.Nickname,
+		AccountID:     request.AccountID,
+		AccountNumber: request.AccountNumber,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - create")
+		payeeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, payee)
+}
+
+type renamePayeeRequest struct {
+	Nickname string `json:"nickname" binding:"required"`
+}
+
+func (r *payeeRoutes) rename(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid payee id")
+		return
+	}
+
+	var request renamePayeeRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - payee - rename")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	payee, err := r.service.Rename(c.Request.Context(), middleware.Subject(c), id, request.Nickname)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - rename")
+		payeeErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+func (r *payeeRoutes) delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid payee id")
+		return
+	}
+
+	if err = r.service.Delete(c.Request.Context(), middleware.Subject(c), id); err != nil {
+		r.logger.Error(err, "http - v1 - payee - delete")
+		payeeErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// payeeTransferRequest identifies the debited account either by id or by number.
+type payeeTransferRequest struct {
+	FromAccountID     uuid.UUID         `json:"fromAccountID"`
+	FromAccountNumber string            `json:"fromAccountNumber"`
+	Amount            int64             `json:"amount" binding:"required"`
+	Description       string            `json:"description"`
+	Reference         string            `json:"reference"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+func (r *payeeRoutes) transfer(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid payee id")
+		return
+	}
+
+	var request payeeTransferRequest
+	if err = c.BindJSON(&request); err != nil {
+		r.logger.Error(err, "http - v1 - payee - transfer")
+		errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	from, err := accountID(c, r.accounts, request.FromAccountID, request.FromAccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - transfer - from account")
+		accountErrorResponse(c, err, "from")
+		return
+	}
+
+	res, err := r.service.Transfer(c.Request.Context(), middleware.Subject(c), id, entity.Transfer{
+		FromAccountID: from,
+		Amount:        request.Amount,
+		Description:   request.Description,
+		Reference:     request.Reference,
+		Metadata:      request.Metadata,
+	})
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payee - transfer")
+		if errors.Is(err, usecase.ErrLimitExceeded) || errors.Is(err, usecase.ErrTransferHeld) ||
+			errors.Is(err, usecase.ErrTransferBlocked) || errors.Is(err, usecase.ErrApprovalRequired) {
+			transferErrorResponse(c, err)
+		} else {
+			payeeErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func payeeErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "payee or account not found")
+	case errors.Is(err, usecase.ErrAccessDenied):
+		errorResponse(c, http.StatusForbidden, "access denied")
+	case errors.Is(err, usecase.ErrDuplicate):
+		errorResponse(c, http.StatusConflict, "payee nickname already exists")
+	case errors.Is(err, usecase.ErrCoolingOff), errors.Is(err, usecase.ErrInsufficientFunds):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "payee service problems")
+	}
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"alukart32.com/bank/internal/iso20022"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+)
+
+// maxPaymentFileSize is the limit of an uploaded payment file.
+const maxPaymentFileSize = 10 << 20
+
+type paymentRoutes struct {
+	service usecase.PaymentService
+	logger  zerologx.Logger
+}
+
+func newPaymentsRoutes(handler *gin.RouterGroup, s usecase.PaymentService, l zerologx.Logger) {
+	r := &paymentRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/payments")
+	{
+		h.POST("/pain001", r.importPain001)
+	}
+}
+
+// importPain001 executes the credit transfers of the pain.001 file in
+// the request body and responds with the pain.002 status report.
+func (r *paymentRoutes) importPain001(c *gin.Context) {
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxPaymentFileSize)
+	file, err := iso20022.ParsePain001(body)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payment - importPain001")
+		if errors.Is(err, iso20022.ErrMalformed) {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		} else {
+			errorResponse(c, http.StatusBadRequest, "invalid request body")
+		}
+		return
+	}
+
+	report, err := r.service.Import(c.Request.Context(), middleware.Subject(c), file)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - payment - importPain001")
+		errorResponse(c, http.StatusInternalServerError, "payment service problems")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = iso20022.WritePain002(&buf, report); err != nil {
+		r.logger.Error(err, "http - v1 - payment - importPain001")
+		errorResponse(c, http.StatusInternalServerError, "payment report problems")
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+)
+
+type reviewRoutes struct {
+	service usecase.ReviewService
+	logger  zerologx.Logger
+}
+
+func newReviewsRoutes(handler *gin.RouterGroup, s usecase.ReviewService, l zerologx.Logger) {
+	r := &reviewRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/reviews", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/", r.list)
+		h.GET("/:id", r.get)
+		h.POST("/:id/approve", r.approve)
+		h.POST("/:id/reject", r.reject)
+	}
+}
+
+// list returns the reviews with the status query param, pending by default.
+func (r *reviewRoutes) list(c *gin.Context) {
+	reviews, err := r.service.List(c.Request.Context(), entity.ReviewStatus(c.Query("status")))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - review - list")
+		reviewErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+func (r *reviewRoutes) get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid review id")
+		return
+	}
+
+	review, err := r.service.Get(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - review - get")
+		reviewErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+type closeReviewRequest struct {
+	Comment string `json:"comment"`
+}
+
+func (r *reviewRoutes) approve(c *gin.Context) {
+	id, request, ok := r.closeRequest(c)
+	if !ok {
+		return
+	}
+
+	review, err := r.service.Approve(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - review - approve")
+		reviewErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func (r *reviewRoutes) reject(c *gin.Context) {
+	id, request, ok := r.closeRequest(c)
+	if !ok {
+		return
+	}
+
+	review, err := r.service.Reject(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - review - reject")
+		reviewErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// closeRequest parses the review id and the optional request body.
+func (r *reviewRoutes) closeRequest(c *gin.Context) (int64, closeReviewRequest, bool) {
+	var request closeReviewRequest
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid review id")
+		return 0, request, false
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err = c.BindJSON(&request); err != nil {
+			r.logger.Error(err, "http - v1 - review")
+			errorResponse(c, http.StatusBadRequest, "invalid request body")
+			return 0, request, false
+		}
+	}
+	return id, request, true
+}
+
+func reviewErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrReviewClosed):
+		errorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrInvalidArgument), errors.Is(err, usecase.ErrNotFound),
+		errors.Is(err, usecase.ErrInsufficientFunds), errors.Is(err, usecase.ErrLimitExceeded):
+		// the approval executes the transfer
+		transferErrorResponse(c, err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, "review service problems")
+	}
+}
@@ -2,22 +2,69 @@ package v1
 
 import (
 	"net/http"
+	"time"
 
+	"alukart32.com/bank/internal/clearing"
 	"alukart32.com/bank/internal/usecase"
-	"alukart32.com/bank/pkg/middleware"
 	"alukart32.com/bank/pkg/zerologx"
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(handler *gin.Engine, l zerologx.Logger, as usecase.AccountService,
-	es usecase.EntryService, ts usecase.TransferService) http.Handler {
+// Services are the use cases served by the router.
+type Services struct {
+	Account   usecase.AccountService
+	Entry     usecase.EntryService
+	Transfer  usecase.TransferService
+	Stream    usecase.StreamService
+	Statement usecase.StatementService
+	Payment   usecase.PaymentService
+	Payee     usecase.PayeeService
+	Limit     usecase.LimitService
+	Review    usecase.ReviewService
+	Approval  usecase.ApprovalService
+	Screening usecase.ScreeningService
+	Audit     usecase.AuditService
+	Interest  usecase.InterestService
+	Fee       usecase.FeeService
+	Ledger    usecase.LedgerService
+	Cash      usecase.CashService
+	Balance   usecase.BalanceService
+	EOD       usecase.EODService
+	Clearing  usecase.ClearingService
+	Loan      usecase.LoanService
+	Deposit   usecase.DepositService
+	// Exporter writes the clearing files of the external transfers.
+	Exporter *clearing.Exporter
+	// Heartbeat is the interval of the stream heartbeats.
+	Heartbeat time.Duration
+}
+
+func NewRouter(handler *gin.Engine, auth gin.HandlerFunc, l zerologx.Logger, s Services) http.Handler {
 	// Routes
 	h := handler.Group("/v1")
-	h.Use(middleware.AuthJWT())
+	h.Use(auth, auditContext())
 	{
-		newAccountsRoutes(h, as, l)
-		newEntriesRoutes(h, es, l)
-		newTransfersRoutes(h, ts, l)
+		newAccountsRoutes(h, s.Account, s.Transfer, l)
+		newEntriesRoutes(h, s.Entry, l)
+		newTransfersRoutes(h, s.Transfer, s.Account, l)
+		newStreamRoutes(h, s.Stream, s.Heartbeat, l)
+		newStatementsRoutes(h, s.Statement, l)
+		newPaymentsRoutes(h, s.Payment, l)
+		newPayeesRoutes(h, s.Payee, s.Account, l)
+		newLimitsRoutes(h, s.Limit, l)
+		newReviewsRoutes(h, s.Review, l)
+		newApprovalsRoutes(h, s.Approval, l)
+		newScreeningRoutes(h, s.Screening, l)
+		newAuditRoutes(h, s.Audit, l)
+		newInterestRoutes(h, s.Interest, l)
+		newFeeRoutes(h, s.Fee, l)
+		newLedgerRoutes(h, s.Ledger, l)
+		newCashRoutes(h, s.Cash, s.Account, l)
+		newBalanceRoutes(h, s.Balance, s.Account, l)
+		newEODRoutes(h, s.EOD, l)
+		newClearingRoutes(h, s.Clearing, s.Account, s.Exporter, l)
+		newLoanRoutes(h, s.Loan, s.Account, l)
+		newDepositRoutes(h, s.Deposit, s.Account, l)
 	}
 
 	return handler
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+)
+
+type screeningRoutes struct {
+	service usecase.ScreeningService
+	logger  zerologx.Logger
+}
+
+func newScreeningRoutes(handler *gin.RouterGroup, s usecase.ScreeningService, l zerologx.Logger) {
+	r := &screeningRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/admin/screening/alerts", middleware.RequireRole(roleAdmin))
+	{
+		h.GET("/", r.list)
+		h.GET("/:id", r.get)
+		h.POST("/:id/clear", r.clear)
+		h.POST("/:id/confirm", r.confirm)
+	}
+}
+
+// list returns the alerts with the status query param, pending by default.
+func (r *screeningRoutes) list(c *gin.Context) {
+	alerts, err := r.service.List(c.Request.Context(), entity.AlertStatus(c.Query("status")))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - screening - list")
+		screeningErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+func (r *screeningRoutes) get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid alert id")
+		return
+	}
+
+	alert, err := r.service.Get(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - screening - get")
+		screeningErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+type closeAlertRequest struct {
+	Comment string `json:"comment"`
+}
+
+// clear closes the alert as a false positive.
+func (r *screeningRoutes) clear(c *gin.Context) {
+	id, request, ok := r.closeRequest(c)
+	if !ok {
+		return
+	}
+
+	alert, err := r.service.Clear(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - screening - clear")
+		screeningErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+func (r *screeningRoutes) confirm(c *gin.Context) {
+	id, request, ok := r.closeRequest(c)
+	if !ok {
+		return
+	}
+
+	alert, err := r.service.Confirm(c.Request.Context(), middleware.Subject(c), id, request.Comment)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - screening - confirm")
+		screeningErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// closeRequest parses the alert id and the optional request body.
+func (r *screeningRoutes) closeRequest(c *gin.Context) (int64, closeAlertRequest, bool) {
+	var request closeAlertRequest
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid alert id")
+		return 0, request, false
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err = c.BindJSON(&request); err != nil {
+			r.logger.Error(err, "http - v1 - screening")
+			errorResponse(c, http.StatusBadRequest, "invalid request body")
+			return 0, request, false
+		}
+	}
+	return id, request, true
+}
+
+func screeningErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "alert not found")
+	case errors.Is(err, usecase.ErrAlertClosed):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "screening service problems")
+	}
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"alukart32.com/bank/internal/render"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+type statementRoutes struct {
+	service usecase.StatementService
+	logger  zerologx.Logger
+}
+
+func newStatementsRoutes(handler *gin.RouterGroup, s usecase.StatementService, l zerologx.Logger) {
+	r := &statementRoutes{
+		service: s,
+		logger:  l,
+	}
+
+	h := handler.Group("/accounts")
+	{
+		h.GET("/:id/statements", r.get)
+		h.GET("/:id/statements/:format", r.get)
+	}
+}
+
+// get returns the account statement for the period [from, to]. The
+// bounds are either dates, where to is inclusive, or RFC3339 timestamps.
+// The format path param or query selects json (default), csv, pdf,
+// camt053 or mt940 output.
+func (r *statementRoutes) get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	from, _, err := parsePeriodBound(c.Query("from"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid from")
+		return
+	}
+	to, isDate, err := parsePeriodBound(c.Query("to"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid to")
+		return
+	}
+	if isDate {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	format := c.Param("format")
+	if format == "" {
+		format = c.DefaultQuery("format", "json")
+	}
+	renderer, ok := render.ForFormat(format)
+	if !ok {
+		errorResponse(c, http.StatusBadRequest, "unsupported format")
+		return
+	}
+
+	statement, err := r.service.Get(c.Request.Context(), middleware.Subject(c), id, from, to)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - statement - get")
+		switch {
+		case errors.Is(err, usecase.ErrInvalidArgument):
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecase.ErrNotFound):
+			errorResponse(c, http.StatusNotFound, "account not found")
+		case errors.Is(err, usecase.ErrAccessDenied):
+			errorResponse(c, http.StatusForbidden, "access denied")
+		default:
+			errorResponse(c, http.StatusInternalServerError, "statement service problems")
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = renderer.Render(&buf, statement); err != nil {
+		r.logger.Error(err, "http - v1 - statement - render")
+		errorResponse(c, http.StatusInternalServerError, "statement render problems")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=statement-%s-%s.%s",
+		id, from.Format(dateLayout), renderer.Extension()))
+	c.Data(http.StatusOK, renderer.ContentType(), buf.Bytes())
+}
+
+// parsePeriodBound parses a date or RFC3339 timestamp and reports
+// whether a date was given.
+func parsePeriodBound(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, v); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	return t, false, err
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/httpserver"
+	"alukart32.com/bank/pkg/middleware"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type streamRoutes struct {
+	service   usecase.StreamService
+	heartbeat time.Duration
+	logger    zerologx.Logger
+}
+
+func newStreamRoutes(handler *gin.RouterGroup, s usecase.StreamService, heartbeat time.Duration, l zerologx.Logger) {
+	r := &streamRoutes{
+		service:   s,
+		heartbeat: heartbeat,
+		logger:    l,
+	}
+
+	h := handler.Group("/accounts")
+	{
+		h.GET("/:id/stream", r.stream)
+	}
+}
+
+// stream pushes the account events as server-sent events, starting from
+// the current balance. A reconnecting client resumes from the
+// Last-Event-ID header or the last_event_id query, the entries of the
+// resume window are sent again and must be skipped by their ids. The
+// stream isn't limited by the server write timeout.
+func (r *streamRoutes) stream(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid account id")
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		after, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || after <= 0 {
+			errorResponse(c, http.StatusBadRequest, "invalid last event id")
+			return
+		}
+	}
+
+	events, err := r.service.Subscribe(c.Request.Context(), middleware.Subject(c), id, after)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - stream")
+		switch {
+		case errors.Is(err, usecase.ErrNotFound):
+			errorResponse(c, http.StatusNotFound, "account not found")
+		case errors.Is(err, usecase.ErrAccessDenied):
+			errorResponse(c, http.StatusForbidden, "access denied")
+		default:
+			errorResponse(c, http.StatusInternalServerError, "stream service problems")
+		}
+		return
+	}
+
+	if err = httpserver.DisableWriteTimeout(c.Request); err != nil {
+		r.logger.Warn("http - v1 - stream - write timeout: %s", err)
+	}
+
+	heartbeat := time.NewTicker(r.heartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+
+			event := sse.Event{Event: string(e.Type), Data: e}
+			if e.ID != 0 {
+				event.Id = strconv.FormatInt(e.ID, 10)
+			}
+			c.Render(-1, event)
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
@@ -1,24 +1,29 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"alukart32.com/bank/entity"
 	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/pkg/middleware"
 	"alukart32.com/bank/pkg/zerologx"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type transferRoutes struct {
-	service usecase.TransferService
-	logger  zerologx.Logger
+	service  usecase.TransferService
+	accounts usecase.AccountService
+	logger   zerologx.Logger
 }
 
-func newTransfersRoutes(handler *gin.RouterGroup, s usecase.TransferService, l zerologx.Logger) {
+func newTransfersRoutes(handler *gin.RouterGroup, s usecase.TransferService, as usecase.AccountService, l zerologx.Logger) {
 	r := transferRoutes{
-		service: s,
-		logger:  l,
+		service:  s,
+		accounts: as,
+		logger:   l,
 	}
 
 	h := handler.Group("/transfers")
@@ -26,25 +31,101 @@ func newTransfersRoutes(handler *gin.RouterGroup, s usecase.TransferService, l z
 		h.GET("/:id", r.getById)
 		h.GET("/", r.list)
 		h.POST("/transfer", r.transfer)
-		h.DELETE("/rollback/:id", r.rollback)
+		h.DELETE("/rollback/:id", middleware.RequireRole(roleAdmin), r.rollback)
 	}
 }
 
+type transferResponse struct {
+	entity.Transfer
+	Transitions []entity.TransferTransition `json:"transitions"`
+}
+
+// getById returns the transfer with its status transitions. The transfer
+// is visible to the holders of its accounts and the admins only.
 func (r *transferRoutes) getById(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
+
+	transfer, err := r.service.Get(c.Request.Context(), id)
+	if err == nil && !middleware.HasRole(c, roleAdmin) {
+		err = r.checkOwner(c, transfer)
+	}
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - getById")
+		transferStatusErrorResponse(c, err)
+		return
+	}
+
+	transitions, err := r.service.Transitions(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - getById - transitions")
+		transferStatusErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transferResponse{Transfer: transfer, Transitions: transitions})
+}
 
+// checkOwner returns ErrNotFound unless the caller holds one of the
+// transfer accounts.
+func (r *transferRoutes) checkOwner(c *gin.Context, t entity.Transfer) error {
+	for _, id := range []uuid.UUID{t.FromAccountID, t.ToAccountID} {
+		a, err := r.accounts.Get(c.Request.Context(), id)
+		if errors.Is(err, usecase.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = checkHolder(c, r.accounts, a, entity.PermissionView); !errors.Is(err, usecase.ErrNotFound) {
+			return err
+		}
+	}
+	return usecase.ErrNotFound
 }
 
+// list returns the transfers of the caller's accounts with the reference
+// and the optional status query params.
 func (r *transferRoutes) list(c *gin.Context) {
+	reference := c.Query("reference")
+	if reference == "" {
+		errorResponse(c, http.StatusBadRequest, "reference is required")
+		return
+	}
 
+	transfers, err := r.service.ListByReference(c.Request.Context(), middleware.Subject(c), reference,
+		entity.TransferStatus(c.Query("status")))
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - list")
+		if errors.Is(err, usecase.ErrInvalidArgument) {
+			errorResponse(c, http.StatusBadRequest, err.Error())
+		} else {
+			errorResponse(c, http.StatusInternalServerError, "transfer service problems")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, transfers)
 }
 
+// doTransferRequest identifies each account either by id or by number.
+// The amount is in the currency of the accounts, they must share it.
 type doTransferRequest struct {
-	FromAccountID uuid.UUID `json:"fromAccountID" binding:"required"`
-	ToAccountID   uuid.UUID `json:"toAccountID"  binding:"required"`
-	Amount        int64     `json:"amount"     binding:"required"`
-	Currency      string    `json:"currency"  binding:"required" validate:"required,oneof=rub,usd"`
+	FromAccountID     uuid.UUID         `json:"fromAccountID"`
+	FromAccountNumber string            `json:"fromAccountNumber"`
+	ToAccountID       uuid.UUID         `json:"toAccountID"`
+	ToAccountNumber   string            `json:"toAccountNumber"`
+	Amount            int64             `json:"amount"     binding:"required"`
+	Description       string            `json:"description"`
+	Reference         string            `json:"reference"`
+	Metadata          map[string]string `json:"metadata"`
 }
 
+// transfer moves the amount from the account held by the caller, the
+// transfer service checks the holder.
 func (r *transferRoutes) transfer(c *gin.Context) {
 	var request doTransferRequest
 	// TODO: Add business validation
@@ -54,17 +135,34 @@ func (r *transferRoutes) transfer(c *gin.Context) {
 		return
 	}
 
+	from, err := accountID(c, r.accounts, request.FromAccountID, request.FromAccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - from account")
+		accountErrorResponse(c, err, "from")
+		return
+	}
+	to, err := accountID(c, r.accounts, request.ToAccountID, request.ToAccountNumber)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - to account")
+		accountErrorResponse(c, err, "to")
+		return
+	}
+
 	translation, err := r.service.Transfer(
 		c.Request.Context(),
+		middleware.Subject(c),
 		entity.Transfer{
-			FromAccountID: request.FromAccountID,
-			ToAccountID:   request.ToAccountID,
+			FromAccountID: from,
+			ToAccountID:   to,
 			Amount:        request.Amount,
+			Description:   request.Description,
+			Reference:     request.Reference,
+			Metadata:      request.Metadata,
 		},
 	)
 	if err != nil {
 		r.logger.Error(err, "http - v1 - doTranslate")
-		errorResponse(c, http.StatusInternalServerError, "translation service problems")
+		transferErrorResponse(c, err)
 
 		return
 	}
@@ -72,6 +170,91 @@ func (r *transferRoutes) transfer(c *gin.Context) {
 	c.JSON(http.StatusOK, translation)
 }
 
+type limitResponse struct {
+	Error     string `json:"error"`
+	Limit     string `json:"limit"`
+	Remaining int64  `json:"remaining"`
+}
+
+type heldResponse struct {
+	Status string                `json:"status"`
+	Review entity.TransferReview `json:"review"`
+}
+
+type pendingApprovalResponse struct {
+	Status   string                  `json:"status"`
+	Approval entity.TransferApproval `json:"approval"`
+}
+
+// transferErrorResponse responds with the error of a transfer execution.
+// A transfer held for review or approval is accepted, not failed.
+func transferErrorResponse(c *gin.Context, err error) {
+	var (
+		limitErr    *usecase.LimitError
+		reviewErr   *usecase.ReviewError
+		approvalErr *usecase.ApprovalError
+	)
+	switch {
+	case errors.As(err, &approvalErr):
+		c.JSON(http.StatusAccepted, pendingApprovalResponse{
+			Status:   string(entity.ApprovalPending),
+			Approval: approvalErr.Approval,
+		})
+	case errors.As(err, &reviewErr):
+		c.JSON(http.StatusAccepted, heldResponse{
+			Status: string(entity.RiskReview),
+			Review: reviewErr.Review,
+		})
+	case errors.Is(err, usecase.ErrTransferBlocked), errors.Is(err, usecase.ErrScreeningBlocked):
+		errorResponse(c, http.StatusForbidden, err.Error())
+	case errors.As(err, &limitErr):
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, limitResponse{
+			Error:     limitErr.Error(),
+			Limit:     limitErr.Limit,
+			Remaining: limitErr.Remaining,
+		})
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "account not found")
+	case errors.Is(err, usecase.ErrAccessDenied):
+		errorResponse(c, http.StatusForbidden, "access denied")
+	case errors.Is(err, usecase.ErrInsufficientFunds), errors.Is(err, usecase.ErrInvalidTransition):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "translation service problems")
+	}
+}
+
+// rollback reverses the completed transfer.
 func (r *transferRoutes) rollback(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "invalid transfer id")
+		return
+	}
 
+	res, err := r.service.Rollback(c.Request.Context(), id)
+	if err != nil {
+		r.logger.Error(err, "http - v1 - transfer - rollback")
+		transferStatusErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// transferStatusErrorResponse responds with the error of a change of the
+// existing transfer.
+func transferStatusErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidArgument):
+		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrNotFound):
+		errorResponse(c, http.StatusNotFound, "transfer not found")
+	case errors.Is(err, usecase.ErrInvalidTransition), errors.Is(err, usecase.ErrInsufficientFunds):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		errorResponse(c, http.StatusInternalServerError, "transfer service problems")
+	}
 }
@@ -0,0 +1,48 @@
+// Package iso20022 implements the ISO 20022 payment messages exchanged
+// with corporate clients: pain.001 credit transfer initiations and
+// pain.002 payment status reports, and the SEPA pain.001 batches of the
+// external transfers sent to the clearing house.
+package iso20022
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errInvalidAmount = errors.New("invalid amount")
+
+// parseAmount parses a decimal amount with at most two fraction digits
+// into minor units.
+func parseAmount(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	units, cents, found := strings.Cut(s, ".")
+	if units == "" || len(cents) > 2 || (found && cents == "") {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+	cents += strings.Repeat("0", 2-len(cents))
+
+	u, err := strconv.ParseUint(units, 10, 62)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+	c, err := strconv.ParseUint(cents, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+	if u > (1<<62)/100 {
+		return 0, fmt.Errorf("%w: %q", errInvalidAmount, s)
+	}
+	return int64(u*100 + c), nil
+}
+
+// formatAmount formats the amount in minor units as a decimal number.
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
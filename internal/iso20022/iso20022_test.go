@@ -0,0 +1,188 @@
+package iso20022
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestParsePain001(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "pain001.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	file, err := ParsePain001(f)
+	require.NoError(t, err)
+
+	ctrlSum := int64(15050)
+	expected := entity.PaymentFile{
+		MsgID:     "MSG-20221020-01",
+		CreatedAt: time.Date(2022, 10, 20, 10, 15, 0, 0, time.UTC),
+		NbOfTxs:   2,
+		CtrlSum:   &ctrlSum,
+		Batches: []entity.PaymentBatch{{
+			ID:            "BATCH-1",
+			NbOfTxs:       2,
+			CtrlSum:       &ctrlSum,
+			DebtorAccount: "0c9c6a526f0e4a8e9d0a2f8f6f0c1a11",
+			Instructions: []entity.PaymentInstruction{
+				{
+					InstrID:         "INSTR-1",
+					EndToEndID:      "E2E-1",
+					Amount:          10000,
+					Currency:        entity.CurrencyRUB,
+					CreditorAccount: "5b1e8b7e-1f43-4f4e-9a55-5b0c7a3e2f10",
+					Description:     "Invoice 42",
+				},
+				{
+					EndToEndID:      "E2E-2",
+					Amount:          5050,
+					Currency:        entity.CurrencyRUB,
+					CreditorAccount: "RU0204452560040702810412345678901",
+				},
+			},
+		}},
+	}
+	assert.Equal(t, expected, file)
+}
+
+func TestParsePain001Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"not xml", "payments"},
+		{"namespace", `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.008.001.02"></Document>`},
+		{"amount", `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn>
+			<GrpHdr><MsgId>1</MsgId><CreDtTm>2022-10-20T10:15:00</CreDtTm><NbOfTxs>1</NbOfTxs></GrpHdr>
+			<PmtInf><PmtMtd>TRF</PmtMtd><CdtTrfTxInf><Amt><InstdAmt Ccy="RUB">1.005</InstdAmt></Amt></CdtTrfTxInf></PmtInf>
+			</CstmrCdtTrfInitn></Document>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePain001(strings.NewReader(tt.doc))
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
+
+func TestWritePain002(t *testing.T) {
+	ctrlSum := int64(15050)
+	report := entity.PaymentStatusReport{
+		MsgID:        "8f14e45fceea167a5a36dedd4bea2543",
+		CreatedAt:    time.Date(2022, 10, 20, 10, 16, 0, 0, time.UTC),
+		OrgnlMsgID:   "MSG-20221020-01",
+		OrgnlNbOfTxs: 2,
+		OrgnlCtrlSum: &ctrlSum,
+		Status:       entity.PaymentPartiallyAccepted,
+		Batches: []entity.PaymentBatchStatus{{
+			OrgnlBatchID: "BATCH-1",
+			Status:       entity.PaymentPartiallyAccepted,
+			Transactions: []entity.PaymentTxStatus{
+				{OrgnlInstrID: "INSTR-1", OrgnlEndToEndID: "E2E-1", Status: entity.PaymentAccepted, TransferID: 7},
+				{OrgnlEndToEndID: "E2E-2", Status: entity.PaymentRejected,
+					Reason: &entity.PaymentStatusReason{Code: "AC01", Info: "creditor account not found"}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePain002(&buf, report))
+
+	golden := filepath.Join("testdata", "pain002.xml")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestWriteSEPACreditTransfer(t *testing.T) {
+	debtor := SEPADebtor{Name: "Alukart Bank", IBAN: "DE89370400440532013000", BIC: "COBADEFFXXX"}
+	batch := entity.ClearingBatch{
+		FileName: "CLR-20230317-EUR-00000042",
+		Currency: "EUR",
+		Transfers: []entity.ExternalTransfer{
+			{
+				ID:                 42,
+				Amount:             10000,
+				BeneficiaryName:    "John Smith",
+				BeneficiaryAccount: "GB82WEST12345698765432",
+				BeneficiaryBank:    "WESTGB2L",
+				Reference:          "INV-42",
+				Description:        "Invoice 42, March",
+			},
+			{
+				ID:                 43,
+				Amount:             5050,
+				BeneficiaryName:    "Max Mustermann",
+				BeneficiaryAccount: "DE89370400440532013000",
+				BeneficiaryBank:    "COBADEFF",
+			},
+		},
+	}
+	createdAt := time.Date(2023, 3, 17, 9, 30, 0, 0, time.UTC)
+	execution := time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSEPACreditTransfer(&buf, batch, debtor, createdAt, execution))
+
+	golden := filepath.Join("testdata", "sepa_pain001.xml")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+
+	// the message is a valid pain.001 with the control sum of the
+	// transfers
+	file, err := ParsePain001(&buf)
+	require.NoError(t, err)
+	require.NotNil(t, file.CtrlSum)
+	assert.Equal(t, int64(15050), *file.CtrlSum)
+	assert.Equal(t, 2, file.NbOfTxs)
+	assert.Equal(t, "NOTPROVIDED", file.Batches[0].Instructions[1].EndToEndID)
+
+	invalid := []func(b *entity.ClearingBatch, d *SEPADebtor){
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Currency = entity.CurrencyUSD },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Transfers = nil },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { d.BIC = "" },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Transfers[0].BeneficiaryName = "Jörg Müller" },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Transfers[0].BeneficiaryBank = "021000021" },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Transfers[0].Amount = 1e11 },
+		func(b *entity.ClearingBatch, d *SEPADebtor) { b.Transfers[0].Description = strings.Repeat("a", 141) },
+	}
+	for i, modify := range invalid {
+		b, d := batch, debtor
+		b.Transfers = append([]entity.ExternalTransfer(nil), batch.Transfers...)
+		modify(&b, &d)
+		err := WriteSEPACreditTransfer(io.Discard, b, d, createdAt, execution)
+		assert.ErrorIs(t, err, ErrMalformed, i)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for in, expected := range map[string]int64{"0": 0, "1": 100, "1.5": 150, "12.34": 1234} {
+		v, err := parseAmount(in)
+		require.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+	for _, in := range []string{"", ".5", "1.", "1.234", "-1", "1e3", "a.00"} {
+		_, err := parseAmount(in)
+		assert.Error(t, err, in)
+	}
+}
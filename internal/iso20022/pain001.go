@@ -0,0 +1,153 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+)
+
+const Pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+var ErrMalformed = errors.New("malformed payment message")
+
+type (
+	pain001Document struct {
+		XMLName xml.Name          `xml:"Document"`
+		Initn   pain001Initiation `xml:"CstmrCdtTrfInitn"`
+	}
+
+	pain001Initiation struct {
+		GrpHdr pain001GrpHdr   `xml:"GrpHdr"`
+		PmtInf []pain001PmtInf `xml:"PmtInf"`
+	}
+
+	pain001GrpHdr struct {
+		MsgId   string `xml:"MsgId"`
+		CreDtTm string `xml:"CreDtTm"`
+		NbOfTxs string `xml:"NbOfTxs"`
+		CtrlSum string `xml:"CtrlSum"`
+	}
+
+	pain001PmtInf struct {
+		PmtInfId    string            `xml:"PmtInfId"`
+		PmtMtd      string            `xml:"PmtMtd"`
+		NbOfTxs     string            `xml:"NbOfTxs"`
+		CtrlSum     string            `xml:"CtrlSum"`
+		DbtrAcct    pain001Acct       `xml:"DbtrAcct"`
+		CdtTrfTxInf []pain001CdtTrfTx `xml:"CdtTrfTxInf"`
+	}
+
+	pain001Acct struct {
+		IBAN string `xml:"Id>IBAN"`
+		Othr string `xml:"Id>Othr>Id"`
+	}
+
+	pain001CdtTrfTx struct {
+		InstrId    string      `xml:"PmtId>InstrId"`
+		EndToEndId string      `xml:"PmtId>EndToEndId"`
+		InstdAmt   pain001Amt  `xml:"Amt>InstdAmt"`
+		CdtrAcct   pain001Acct `xml:"CdtrAcct"`
+		Ustrd      []string    `xml:"RmtInf>Ustrd"`
+	}
+
+	pain001Amt struct {
+		Ccy   string `xml:"Ccy,attr"`
+		Value string `xml:",chardata"`
+	}
+)
+
+// ParsePain001 reads a pain.001.001.03 customer credit transfer initiation.
+func ParsePain001(r io.Reader) (entity.PaymentFile, error) {
+	var doc pain001Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return entity.PaymentFile{}, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if doc.XMLName.Space != Pain001Namespace {
+		return entity.PaymentFile{}, fmt.Errorf("%w: unsupported namespace %q", ErrMalformed, doc.XMLName.Space)
+	}
+
+	hdr := doc.Initn.GrpHdr
+	if hdr.MsgId == "" {
+		return entity.PaymentFile{}, fmt.Errorf("%w: missing MsgId", ErrMalformed)
+	}
+
+	var (
+		f   = entity.PaymentFile{MsgID: hdr.MsgId}
+		err error
+	)
+	if f.CreatedAt, err = time.Parse(time.RFC3339, hdr.CreDtTm); err != nil {
+		// ISODateTime may come without a time zone
+		if f.CreatedAt, err = time.Parse("2006-01-02T15:04:05", hdr.CreDtTm); err != nil {
+			return entity.PaymentFile{}, fmt.Errorf("%w: invalid CreDtTm", ErrMalformed)
+		}
+	}
+	if f.NbOfTxs, err = strconv.Atoi(hdr.NbOfTxs); err != nil {
+		return entity.PaymentFile{}, fmt.Errorf("%w: invalid NbOfTxs", ErrMalformed)
+	}
+	if f.CtrlSum, err = parseOptionalAmount(hdr.CtrlSum); err != nil {
+		return entity.PaymentFile{}, fmt.Errorf("%w: invalid CtrlSum", ErrMalformed)
+	}
+
+	for _, p := range doc.Initn.PmtInf {
+		if p.PmtMtd != "TRF" {
+			return entity.PaymentFile{}, fmt.Errorf("%w: unsupported payment method %q", ErrMalformed, p.PmtMtd)
+		}
+
+		b := entity.PaymentBatch{
+			ID:            p.PmtInfId,
+			NbOfTxs:       len(p.CdtTrfTxInf),
+			DebtorAccount: p.DbtrAcct.identification(),
+		}
+		if p.NbOfTxs != "" {
+			if b.NbOfTxs, err = strconv.Atoi(p.NbOfTxs); err != nil {
+				return entity.PaymentFile{}, fmt.Errorf("%w: invalid NbOfTxs of %s", ErrMalformed, p.PmtInfId)
+			}
+		}
+		if b.CtrlSum, err = parseOptionalAmount(p.CtrlSum); err != nil {
+			return entity.PaymentFile{}, fmt.Errorf("%w: invalid CtrlSum of %s", ErrMalformed, p.PmtInfId)
+		}
+
+		for _, tx := range p.CdtTrfTxInf {
+			amount, err := parseAmount(tx.InstdAmt.Value)
+			if err != nil {
+				return entity.PaymentFile{}, fmt.Errorf("%w: %v of %s", ErrMalformed, err, tx.EndToEndId)
+			}
+
+			b.Instructions = append(b.Instructions, entity.PaymentInstruction{
+				InstrID:         tx.InstrId,
+				EndToEndID:      tx.EndToEndId,
+				Amount:          amount,
+				Currency:        entity.Currency(tx.InstdAmt.Ccy),
+				CreditorAccount: tx.CdtrAcct.identification(),
+				Description:     strings.Join(tx.Ustrd, " "),
+			})
+		}
+		f.Batches = append(f.Batches, b)
+	}
+
+	return f, nil
+}
+
+func (a pain001Acct) identification() string {
+	if a.IBAN != "" {
+		return a.IBAN
+	}
+	return a.Othr
+}
+
+func parseOptionalAmount(s string) (*int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := parseAmount(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,124 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"alukart32.com/bank/entity"
+)
+
+const (
+	Pain002Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.03"
+
+	pain001MsgName = "pain.001.001.03"
+	isoDateTime    = "2006-01-02T15:04:05Z07:00"
+)
+
+type (
+	pain002Document struct {
+		XMLName xml.Name      `xml:"Document"`
+		Xmlns   string        `xml:"xmlns,attr"`
+		Rpt     pain002Report `xml:"CstmrPmtStsRpt"`
+	}
+
+	pain002Report struct {
+		GrpHdr   pain002GrpHdr     `xml:"GrpHdr"`
+		OrgnlGrp pain002OrgnlGrp   `xml:"OrgnlGrpInfAndSts"`
+		OrgnlPmt []pain002OrgnlPmt `xml:"OrgnlPmtInfAndSts"`
+	}
+
+	pain002GrpHdr struct {
+		MsgId   string `xml:"MsgId"`
+		CreDtTm string `xml:"CreDtTm"`
+	}
+
+	pain002OrgnlGrp struct {
+		OrgnlMsgId   string         `xml:"OrgnlMsgId"`
+		OrgnlMsgNmId string         `xml:"OrgnlMsgNmId"`
+		OrgnlNbOfTxs string         `xml:"OrgnlNbOfTxs"`
+		OrgnlCtrlSum string         `xml:"OrgnlCtrlSum,omitempty"`
+		GrpSts       string         `xml:"GrpSts"`
+		StsRsnInf    *pain002RsnInf `xml:"StsRsnInf,omitempty"`
+	}
+
+	pain002OrgnlPmt struct {
+		OrgnlPmtInfId string         `xml:"OrgnlPmtInfId"`
+		PmtInfSts     string         `xml:"PmtInfSts"`
+		TxInfAndSts   []pain002TxSts `xml:"TxInfAndSts"`
+	}
+
+	pain002TxSts struct {
+		OrgnlInstrId    string         `xml:"OrgnlInstrId,omitempty"`
+		OrgnlEndToEndId string         `xml:"OrgnlEndToEndId"`
+		TxSts           string         `xml:"TxSts"`
+		StsRsnInf       *pain002RsnInf `xml:"StsRsnInf,omitempty"`
+		AcctSvcrRef     string         `xml:"AcctSvcrRef,omitempty"`
+	}
+
+	pain002RsnInf struct {
+		Cd       string `xml:"Rsn>Cd"`
+		AddtlInf string `xml:"AddtlInf,omitempty"`
+	}
+)
+
+// WritePain002 writes the report as a pain.002.001.03 payment status report.
+func WritePain002(w io.Writer, r entity.PaymentStatusReport) error {
+	doc := pain002Document{
+		Xmlns: Pain002Namespace,
+		Rpt: pain002Report{
+			GrpHdr: pain002GrpHdr{
+				MsgId:   r.MsgID,
+				CreDtTm: r.CreatedAt.Format(isoDateTime),
+			},
+			OrgnlGrp: pain002OrgnlGrp{
+				OrgnlMsgId:   r.OrgnlMsgID,
+				OrgnlMsgNmId: pain001MsgName,
+				OrgnlNbOfTxs: strconv.Itoa(r.OrgnlNbOfTxs),
+				GrpSts:       string(r.Status),
+				StsRsnInf:    newPain002RsnInf(r.Reason),
+			},
+		},
+	}
+	if r.OrgnlCtrlSum != nil {
+		doc.Rpt.OrgnlGrp.OrgnlCtrlSum = formatAmount(*r.OrgnlCtrlSum)
+	}
+
+	for _, b := range r.Batches {
+		pmt := pain002OrgnlPmt{
+			OrgnlPmtInfId: b.OrgnlBatchID,
+			PmtInfSts:     string(b.Status),
+		}
+		for _, tx := range b.Transactions {
+			sts := pain002TxSts{
+				OrgnlInstrId:    tx.OrgnlInstrID,
+				OrgnlEndToEndId: tx.OrgnlEndToEndID,
+				TxSts:           string(tx.Status),
+				StsRsnInf:       newPain002RsnInf(tx.Reason),
+			}
+			if tx.TransferID != 0 {
+				sts.AcctSvcrRef = strconv.FormatInt(tx.TransferID, 10)
+			}
+			pmt.TxInfAndSts = append(pmt.TxInfAndSts, sts)
+		}
+		doc.Rpt.OrgnlPmt = append(doc.Rpt.OrgnlPmt, pmt)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newPain002RsnInf(r *entity.PaymentStatusReason) *pain002RsnInf {
+	if r == nil {
+		return nil
+	}
+	return &pain002RsnInf{Cd: r.Code, AddtlInf: r.Info}
+}
@@ -0,0 +1,214 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/accnum"
+)
+
+// The limits of the SEPA credit transfer scheme, the EPC implementation
+// guidelines of pain.001.001.03.
+const (
+	sepaCurrency     = "EUR"
+	sepaMaxAmount    = 99999999999
+	sepaMaxIDLength  = 35
+	sepaMaxName      = 70
+	sepaMaxRemitInfo = 140
+	// sepaCharset is the Latin character set the SEPA messages are
+	// restricted to, besides the letters and digits.
+	sepaCharset = "/-?:().,'+ "
+	notProvided = "NOTPROVIDED"
+)
+
+// SEPADebtor is the bank sending the credit transfers, the debited
+// account is its account at the clearing house.
+type SEPADebtor struct {
+	Name string
+	IBAN string
+	BIC  string
+}
+
+type (
+	sepaDocument struct {
+		XMLName xml.Name       `xml:"Document"`
+		Xmlns   string         `xml:"xmlns,attr"`
+		Initn   sepaInitiation `xml:"CstmrCdtTrfInitn"`
+	}
+
+	sepaInitiation struct {
+		GrpHdr sepaGrpHdr `xml:"GrpHdr"`
+		PmtInf sepaPmtInf `xml:"PmtInf"`
+	}
+
+	sepaGrpHdr struct {
+		MsgId    string `xml:"MsgId"`
+		CreDtTm  string `xml:"CreDtTm"`
+		NbOfTxs  string `xml:"NbOfTxs"`
+		CtrlSum  string `xml:"CtrlSum"`
+		InitgPty string `xml:"InitgPty>Nm"`
+	}
+
+	sepaPmtInf struct {
+		PmtInfId    string         `xml:"PmtInfId"`
+		PmtMtd      string         `xml:"PmtMtd"`
+		BtchBookg   bool           `xml:"BtchBookg"`
+		NbOfTxs     string         `xml:"NbOfTxs"`
+		CtrlSum     string         `xml:"CtrlSum"`
+		SvcLvl      string         `xml:"PmtTpInf>SvcLvl>Cd"`
+		ReqdExctnDt string         `xml:"ReqdExctnDt"`
+		Dbtr        string         `xml:"Dbtr>Nm"`
+		DbtrAcct    string         `xml:"DbtrAcct>Id>IBAN"`
+		DbtrAgt     string         `xml:"DbtrAgt>FinInstnId>BIC"`
+		ChrgBr      string         `xml:"ChrgBr"`
+		CdtTrfTxInf []sepaCdtTrfTx `xml:"CdtTrfTxInf"`
+	}
+
+	sepaCdtTrfTx struct {
+		InstrId    string      `xml:"PmtId>InstrId"`
+		EndToEndId string      `xml:"PmtId>EndToEndId"`
+		InstdAmt   pain001Amt  `xml:"Amt>InstdAmt"`
+		CdtrAgt    string      `xml:"CdtrAgt>FinInstnId>BIC"`
+		Cdtr       string      `xml:"Cdtr>Nm"`
+		CdtrAcct   string      `xml:"CdtrAcct>Id>IBAN"`
+		RmtInf     *sepaRmtInf `xml:"RmtInf,omitempty"`
+	}
+
+	// sepaRmtInf is left out without the description, the empty one is
+	// invalid.
+	sepaRmtInf struct {
+		Ustrd string `xml:"Ustrd"`
+	}
+)
+
+// WriteSEPACreditTransfer writes the batch as a SEPA pain.001.001.03
+// credit transfer initiation with one payment of all the transfers. The
+// batch file name is the message and payment id, the transfer id is the
+// instruction id and its reference is the end-to-end id.
+func WriteSEPACreditTransfer(w io.Writer, b entity.ClearingBatch, d SEPADebtor, createdAt, execution time.Time) error {
+	if err := d.validate(); err != nil {
+		return err
+	}
+	if b.Currency != sepaCurrency {
+		return fmt.Errorf("%w: currency %s, SEPA transfers are in %s", ErrMalformed, b.Currency, sepaCurrency)
+	}
+	if len(b.Transfers) == 0 {
+		return fmt.Errorf("%w: no transfers", ErrMalformed)
+	}
+	if !isSEPAText(b.FileName, sepaMaxIDLength) {
+		return fmt.Errorf("%w: message id %q", ErrMalformed, b.FileName)
+	}
+
+	pmt := sepaPmtInf{
+		PmtInfId:    b.FileName,
+		PmtMtd:      "TRF",
+		BtchBookg:   true,
+		SvcLvl:      "SEPA",
+		ReqdExctnDt: execution.Format("2006-01-02"),
+		Dbtr:        d.Name,
+		DbtrAcct:    d.IBAN,
+		DbtrAgt:     d.BIC,
+		ChrgBr:      "SLEV",
+	}
+	var total int64
+	for _, t := range b.Transfers {
+		tx, err := newSEPACdtTrfTx(t)
+		if err != nil {
+			return err
+		}
+		pmt.CdtTrfTxInf = append(pmt.CdtTrfTxInf, tx)
+		total += t.Amount
+	}
+	pmt.NbOfTxs = strconv.Itoa(len(b.Transfers))
+	pmt.CtrlSum = formatAmount(total)
+
+	doc := sepaDocument{
+		Xmlns: Pain001Namespace,
+		Initn: sepaInitiation{
+			GrpHdr: sepaGrpHdr{
+				MsgId:    b.FileName,
+				CreDtTm:  createdAt.UTC().Format(isoDateTime),
+				NbOfTxs:  pmt.NbOfTxs,
+				CtrlSum:  pmt.CtrlSum,
+				InitgPty: d.Name,
+			},
+			PmtInf: pmt,
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func newSEPACdtTrfTx(t entity.ExternalTransfer) (sepaCdtTrfTx, error) {
+	if t.Amount <= 0 || t.Amount > sepaMaxAmount {
+		return sepaCdtTrfTx{}, fmt.Errorf("%w: transfer %d: amount %d out of range", ErrMalformed, t.ID, t.Amount)
+	}
+	if accnum.Validate(t.BeneficiaryAccount) != nil || !accnum.IsBIC(t.BeneficiaryBank) {
+		return sepaCdtTrfTx{}, fmt.Errorf("%w: transfer %d: creditor needs an IBAN and a BIC", ErrMalformed, t.ID)
+	}
+	if !isSEPAText(t.BeneficiaryName, sepaMaxName) || t.BeneficiaryName == "" {
+		return sepaCdtTrfTx{}, fmt.Errorf("%w: transfer %d: creditor name must have 1 to %d SEPA characters",
+			ErrMalformed, t.ID, sepaMaxName)
+	}
+	if !isSEPAText(t.Description, sepaMaxRemitInfo) || !isSEPAText(t.Reference, sepaMaxIDLength) {
+		return sepaCdtTrfTx{}, fmt.Errorf("%w: transfer %d: description and reference must have SEPA characters",
+			ErrMalformed, t.ID)
+	}
+
+	endToEnd := t.Reference
+	if endToEnd == "" {
+		endToEnd = notProvided
+	}
+	var remittance *sepaRmtInf
+	if t.Description != "" {
+		remittance = &sepaRmtInf{Ustrd: t.Description}
+	}
+	return sepaCdtTrfTx{
+		InstrId:    strconv.FormatInt(t.ID, 10),
+		EndToEndId: endToEnd,
+		InstdAmt:   pain001Amt{Ccy: sepaCurrency, Value: formatAmount(t.Amount)},
+		CdtrAgt:    t.BeneficiaryBank,
+		Cdtr:       t.BeneficiaryName,
+		CdtrAcct:   t.BeneficiaryAccount,
+		RmtInf:     remittance,
+	}, nil
+}
+
+func (d SEPADebtor) validate() error {
+	if d.Name == "" || !isSEPAText(d.Name, sepaMaxName) {
+		return fmt.Errorf("%w: debtor name must have 1 to %d SEPA characters", ErrMalformed, sepaMaxName)
+	}
+	if accnum.Validate(d.IBAN) != nil || !accnum.IsBIC(d.BIC) {
+		return fmt.Errorf("%w: debtor needs an IBAN and a BIC", ErrMalformed)
+	}
+	return nil
+}
+
+// isSEPAText reports whether s fits the length and the SEPA character
+// set.
+func isSEPAText(s string, max int) bool {
+	if utf8.RuneCountInString(s) > max {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && !strings.ContainsRune(sepaCharset, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,256 @@
+// Package nacha implements the NACHA ACH files of the credit transfers to
+// the accounts at the US banks.
+//
+// The file is a sequence of fixed-width 94 character records: the file
+// header (1), the batch header (5), an entry detail (6) per transfer,
+// the batch control (8) and the file control (9). The records are
+// blocked by ten, the last block is padded with the records of nines.
+package nacha
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/accnum"
+)
+
+const (
+	recordSize     = 94
+	blockingFactor = 10
+
+	// serviceCredits is the service class code of the batch of credits
+	// only.
+	serviceCredits = "220"
+	// checkingCredit is the transaction code of the credit to a checking
+	// account.
+	checkingCredit = "22"
+
+	maxAmount        = 9999999999
+	maxAccountLength = 17
+)
+
+var ErrInvalid = errors.New("invalid NACHA file")
+
+// Options are the originator of the file and its dates.
+type Options struct {
+	// ImmediateDestination is the routing number of the ACH operator or
+	// the receiving point of the file.
+	ImmediateDestination     string
+	ImmediateDestinationName string
+	// ImmediateOrigin is the routing number of the bank, its first eight
+	// digits are the originating DFI of the entries.
+	ImmediateOrigin     string
+	ImmediateOriginName string
+	CompanyName         string
+	// CompanyID identifies the originator to the receiving banks, usually
+	// 1 followed by the tax id.
+	CompanyID string
+	// SECCode is the standard entry class of the batch, PPD for the
+	// consumer accounts and CCD for the corporate ones. PPD if empty.
+	SECCode          string
+	EntryDescription string
+	// FileIDModifier tells apart the files created on the same date, from
+	// A to Z and 0 to 9. A if zero.
+	FileIDModifier byte
+	CreatedAt      time.Time
+	EffectiveDate  time.Time
+}
+
+// Write writes the file of one batch with an entry per transfer. The
+// transfers must be in USD to the accounts at the routing numbers, the
+// names are upper-cased and cut to the field widths.
+func Write(w io.Writer, b entity.ClearingBatch, o Options) error {
+	if err := o.validate(); err != nil {
+		return err
+	}
+	if b.Currency != entity.CurrencyUSD {
+		return fmt.Errorf("%w: currency %s, ACH transfers are in USD", ErrInvalid, b.Currency)
+	}
+	if len(b.Transfers) == 0 {
+		return fmt.Errorf("%w: no transfers", ErrInvalid)
+	}
+
+	odfi := o.ImmediateOrigin[:8]
+	modifier := o.FileIDModifier
+	if modifier == 0 {
+		modifier = 'A'
+	}
+	sec := o.SECCode
+	if sec == "" {
+		sec = "PPD"
+	}
+
+	var records []string
+	records = append(records, "1"+
+		"01"+
+		" "+o.ImmediateDestination+
+		" "+o.ImmediateOrigin+
+		o.CreatedAt.Format("0601021504")+
+		string(modifier)+
+		"094"+
+		"10"+
+		"1"+
+		alpha(o.ImmediateDestinationName, 23)+
+		alpha(o.ImmediateOriginName, 23)+
+		alpha("", 8))
+	records = append(records, "5"+
+		serviceCredits+
+		alpha(o.CompanyName, 16)+
+		alpha("", 20)+
+		alpha(o.CompanyID, 10)+
+		sec+
+		alpha(o.EntryDescription, 10)+
+		alpha("", 6)+
+		o.EffectiveDate.Format("060102")+
+		"   "+
+		"1"+
+		odfi+
+		numeric(1, 7))
+
+	var hash, total int64
+	for i, t := range b.Transfers {
+		if err := validateTransfer(t); err != nil {
+			return err
+		}
+		hash += parseDigits(t.BeneficiaryBank[:8])
+		total += t.Amount
+
+		id := t.Reference
+		if id == "" {
+			id = fmt.Sprint(t.ID)
+		}
+		records = append(records, "6"+
+			checkingCredit+
+			t.BeneficiaryBank+
+			alpha(t.BeneficiaryAccount, maxAccountLength)+
+			numeric(t.Amount, 10)+
+			alpha(id, 15)+
+			alpha(t.BeneficiaryName, 22)+
+			"  "+
+			"0"+
+			odfi+numeric(int64(i+1), 7))
+	}
+	if total > 999999999999 {
+		return fmt.Errorf("%w: total %d overflows the control", ErrInvalid, total)
+	}
+
+	// the hash is the sum of the receiving DFIs cut to the rightmost ten
+	// digits
+	hash %= 10000000000
+	count := int64(len(b.Transfers))
+	records = append(records, "8"+
+		serviceCredits+
+		numeric(count, 6)+
+		numeric(hash, 10)+
+		numeric(0, 12)+
+		numeric(total, 12)+
+		alpha(o.CompanyID, 10)+
+		alpha("", 19)+
+		alpha("", 6)+
+		odfi+
+		numeric(1, 7))
+
+	blocks := (len(records) + 1 + blockingFactor - 1) / blockingFactor
+	records = append(records, "9"+
+		numeric(1, 6)+
+		numeric(int64(blocks), 6)+
+		numeric(count, 8)+
+		numeric(hash, 10)+
+		numeric(0, 12)+
+		numeric(total, 12)+
+		alpha("", 39))
+	for len(records)%blockingFactor != 0 {
+		records = append(records, strings.Repeat("9", recordSize))
+	}
+
+	for _, r := range records {
+		if len(r) != recordSize {
+			// the fields are cut to their widths, so it is a bug
+			return fmt.Errorf("%w: record %q has %d characters", ErrInvalid, r[:1], len(r))
+		}
+		if _, err := io.WriteString(w, r+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o Options) validate() error {
+	if !accnum.IsRoutingNumber(o.ImmediateDestination) {
+		return fmt.Errorf("%w: immediate destination %q is not a routing number", ErrInvalid, o.ImmediateDestination)
+	}
+	if !accnum.IsRoutingNumber(o.ImmediateOrigin) {
+		return fmt.Errorf("%w: immediate origin %q is not a routing number", ErrInvalid, o.ImmediateOrigin)
+	}
+	if o.CompanyName == "" || o.CompanyID == "" || o.EntryDescription == "" {
+		return fmt.Errorf("%w: company name, id and entry description are required", ErrInvalid)
+	}
+	switch o.SECCode {
+	case "", "PPD", "CCD":
+	default:
+		return fmt.Errorf("%w: unsupported SEC code %q", ErrInvalid, o.SECCode)
+	}
+	m := o.FileIDModifier
+	if m != 0 && (m < 'A' || m > 'Z') && (m < '0' || m > '9') {
+		return fmt.Errorf("%w: file id modifier %q", ErrInvalid, m)
+	}
+	if o.CreatedAt.IsZero() || o.EffectiveDate.IsZero() {
+		return fmt.Errorf("%w: creation and effective dates are required", ErrInvalid)
+	}
+	return nil
+}
+
+func validateTransfer(t entity.ExternalTransfer) error {
+	if !accnum.IsRoutingNumber(t.BeneficiaryBank) {
+		return fmt.Errorf("%w: transfer %d: bank %q is not a routing number", ErrInvalid, t.ID, t.BeneficiaryBank)
+	}
+	if t.BeneficiaryAccount == "" || len(t.BeneficiaryAccount) > maxAccountLength ||
+		!isPrintable(t.BeneficiaryAccount) {
+		return fmt.Errorf("%w: transfer %d: account must have 1 to %d characters", ErrInvalid, t.ID, maxAccountLength)
+	}
+	if t.Amount <= 0 || t.Amount > maxAmount {
+		return fmt.Errorf("%w: transfer %d: amount %d out of range", ErrInvalid, t.ID, t.Amount)
+	}
+	if !isPrintable(t.BeneficiaryName) || !isPrintable(t.Reference) {
+		return fmt.Errorf("%w: transfer %d: name and reference must be ASCII", ErrInvalid, t.ID)
+	}
+	return nil
+}
+
+// alpha upper-cases the value and pads it with spaces or cuts it to the
+// width.
+func alpha(s string, width int) string {
+	s = strings.ToUpper(s)
+	if len(s) > width {
+		return s[:width]
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// numeric pads the value with zeros to the width.
+func numeric(v int64, width int) string {
+	return fmt.Sprintf("%0*d", width, v)
+}
+
+func parseDigits(s string) int64 {
+	var v int64
+	for _, r := range s {
+		v = v*10 + int64(r-'0')
+	}
+	return v
+}
+
+// isPrintable reports whether s has the printable ASCII characters only,
+// the only ones allowed in the files.
+func isPrintable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
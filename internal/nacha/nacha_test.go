@@ -0,0 +1,122 @@
+package nacha
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var options = Options{
+	ImmediateDestination:     "011000015",
+	ImmediateDestinationName: "Federal Reserve Bank",
+	ImmediateOrigin:          "121000248",
+	ImmediateOriginName:      "Alukart Bank",
+	CompanyName:              "Alukart Bank",
+	CompanyID:                "1234567890",
+	EntryDescription:         "Payment",
+	CreatedAt:                time.Date(2023, 3, 17, 9, 30, 0, 0, time.UTC),
+	EffectiveDate:            time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC),
+}
+
+var batch = entity.ClearingBatch{
+	FileName: "CLR-20230317-USD-00000042",
+	Currency: entity.CurrencyUSD,
+	Transfers: []entity.ExternalTransfer{
+		{
+			ID:                 42,
+			Amount:             10000,
+			BeneficiaryName:    "Jane Doe",
+			BeneficiaryAccount: "000123456789",
+			BeneficiaryBank:    "021000021",
+			Reference:          "INV-42",
+		},
+		{
+			ID:                 43,
+			Amount:             5050,
+			BeneficiaryName:    "Acme Incorporated Holdings Limited",
+			BeneficiaryAccount: "98765",
+			BeneficiaryBank:    "026009593",
+		},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, batch, options))
+
+	golden := filepath.Join("testdata", "credits.ach")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), buf.String())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 10)
+	for _, line := range lines {
+		assert.Len(t, line, recordSize)
+	}
+
+	// 02100002 + 02600959
+	assert.Equal(t, "0004700961", lines[4][10:20])
+	assert.Equal(t, "000000015050", lines[4][32:44])
+	assert.Equal(t, "000001", lines[5][7:13], "block count")
+	assert.Equal(t, strings.Repeat("9", recordSize), lines[9])
+}
+
+func TestWriteBlocks(t *testing.T) {
+	b := entity.ClearingBatch{Currency: entity.CurrencyUSD}
+	for i := 0; i < 7; i++ {
+		b.Transfers = append(b.Transfers, batch.Transfers[0])
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, b, options))
+
+	// 4 records around the 7 entries fill two blocks
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 20)
+	assert.Equal(t, "9000001000002", lines[10][:13])
+	assert.Equal(t, strings.Repeat("9", recordSize), lines[11])
+}
+
+func TestWriteInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *entity.ClearingBatch, o *Options)
+	}{
+		{"currency", func(b *entity.ClearingBatch, o *Options) { b.Currency = entity.CurrencyRUB }},
+		{"no transfers", func(b *entity.ClearingBatch, o *Options) { b.Transfers = nil }},
+		{"origin", func(b *entity.ClearingBatch, o *Options) { o.ImmediateOrigin = "121000249" }},
+		{"company id", func(b *entity.ClearingBatch, o *Options) { o.CompanyID = "" }},
+		{"SEC code", func(b *entity.ClearingBatch, o *Options) { o.SECCode = "WEB" }},
+		{"modifier", func(b *entity.ClearingBatch, o *Options) { o.FileIDModifier = 'a' }},
+		{"bank", func(b *entity.ClearingBatch, o *Options) { b.Transfers[0].BeneficiaryBank = "COBADEFF" }},
+		{"account", func(b *entity.ClearingBatch, o *Options) {
+			b.Transfers[0].BeneficiaryAccount = "DE89370400440532013000"
+		}},
+		{"amount", func(b *entity.ClearingBatch, o *Options) { b.Transfers[0].Amount = 1e10 }},
+		{"name", func(b *entity.ClearingBatch, o *Options) { b.Transfers[0].BeneficiaryName = "Jörg" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, o := batch, options
+			b.Transfers = append([]entity.ExternalTransfer(nil), batch.Transfers...)
+			tt.modify(&b, &o)
+
+			var buf bytes.Buffer
+			assert.ErrorIs(t, Write(&buf, b, o), ErrInvalid)
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package render
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"alukart32.com/bank/entity"
+	"github.com/google/uuid"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// camt053Renderer writes the statement as an ISO 20022 camt.053.001.02
+// bank to customer statement.
+type camt053Renderer struct{}
+
+func (camt053Renderer) ContentType() string { return "application/xml; charset=utf-8" }
+
+func (camt053Renderer) Extension() string { return "xml" }
+
+func (camt053Renderer) Render(w io.Writer, s entity.Statement) error {
+	accountID := compactID(s.AccountID)
+	doc := camtDocument{
+		Xmlns: camt053Namespace,
+		Stmt: camtBkToCstmrStmt{
+			GrpHdr: camtGrpHdr{
+				MsgId:    accountID[:16] + s.GeneratedAt.Format("20060102150405"),
+				CreDtTm:  s.GeneratedAt.Format(isoDateTime),
+				MsgPgntn: camtPgntn{PgNb: "1", LastPgInd: true},
+			},
+			Stmt: camtStmt{
+				Id:      accountID[:16] + s.From.Format("20060102"),
+				CreDtTm: s.GeneratedAt.Format(isoDateTime),
+				FrToDt: camtFrToDt{
+					FrDtTm: s.From.Format(isoDateTime),
+					ToDtTm: s.To.Format(isoDateTime),
+				},
+				Acct: camtAcct{
+					Id:   camtAcctId{Othr: camtOthr{Id: accountID}},
+					Ccy:  string(s.Currency),
+					Ownr: camtParty{Nm: truncate(s.Owner, 70)},
+				},
+				Bal: []camtBal{
+					newCamtBal("OPBD", s.OpeningBalance, s.Currency, s.From.Format(isoDate)),
+					newCamtBal("CLBD", s.ClosingBalance, s.Currency, lastDay(s).Format(isoDate)),
+				},
+			},
+		},
+	}
+
+	var credits, debits int
+	for _, l := range s.Lines {
+		if l.Amount < 0 {
+			debits++
+		} else {
+			credits++
+		}
+	}
+	doc.Stmt.Stmt.TxsSummry = camtTxsSummry{
+		TtlNtries: camtTtlNtries{
+			NbOfNtries:    strconv.Itoa(len(s.Lines)),
+			Sum:           formatAmount(s.TotalCredits + s.TotalDebits),
+			TtlNetNtryAmt: formatAmount(abs(s.TotalCredits - s.TotalDebits)),
+			CdtDbtInd:     creditDebit(s.TotalCredits - s.TotalDebits),
+		},
+		TtlCdtNtries: camtNbSum{NbOfNtries: strconv.Itoa(credits), Sum: formatAmount(s.TotalCredits)},
+		TtlDbtNtries: camtNbSum{NbOfNtries: strconv.Itoa(debits), Sum: formatAmount(s.TotalDebits)},
+	}
+
+	for _, l := range s.Lines {
+		ref := strconv.FormatInt(l.EntryID, 10)
+		ntry := camtNtry{
+			NtryRef:     ref,
+			Amt:         newCamtAmt(abs(l.Amount), s.Currency),
+			CdtDbtInd:   creditDebit(l.Amount),
+			Sts:         "BOOK",
+			BookgDt:     camtDtTm{DtTm: l.CreatedAt.Format(isoDateTime)},
+			ValDt:       camtDt{Dt: l.CreatedAt.Format(isoDate)},
+			AcctSvcrRef: ref,
+			BkTxCd:      newCamtBkTxCd(l),
+		}
+
+		tx := camtTxDtls{
+			Refs: camtRefs{AcctSvcrRef: ref},
+		}
+		if l.TransferID != 0 {
+			tx.Refs.TxId = strconv.FormatInt(l.TransferID, 10)
+		}
+		if l.Reference != "" {
+			tx.Refs.EndToEndId = l.Reference
+		}
+		if l.Counterparty != nil {
+			acct := &camtPartyAcct{Id: camtAcctId{Othr: camtOthr{Id: compactID(*l.Counterparty)}}}
+			if l.Amount < 0 {
+				tx.RltdPties = &camtRltdPties{CdtrAcct: acct}
+			} else {
+				tx.RltdPties = &camtRltdPties{DbtrAcct: acct}
+			}
+		}
+		if l.Description != "" {
+			tx.RmtInf = &camtRmtInf{Ustrd: truncate(l.Description, 140)}
+		}
+		ntry.NtryDtls = camtNtryDtls{TxDtls: tx}
+
+		doc.Stmt.Stmt.Ntry = append(doc.Stmt.Stmt.Ntry, ntry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+const (
+	isoDate     = "2006-01-02"
+	isoDateTime = "2006-01-02T15:04:05Z07:00"
+)
+
+type (
+	camtDocument struct {
+		XMLName xml.Name          `xml:"Document"`
+		Xmlns   string            `xml:"xmlns,attr"`
+		Stmt    camtBkToCstmrStmt `xml:"BkToCstmrStmt"`
+	}
+
+	camtBkToCstmrStmt struct {
+		GrpHdr camtGrpHdr `xml:"GrpHdr"`
+		Stmt   camtStmt   `xml:"Stmt"`
+	}
+
+	camtGrpHdr struct {
+		MsgId    string    `xml:"MsgId"`
+		CreDtTm  string    `xml:"CreDtTm"`
+		MsgPgntn camtPgntn `xml:"MsgPgntn"`
+	}
+
+	camtPgntn struct {
+		PgNb      string `xml:"PgNb"`
+		LastPgInd bool   `xml:"LastPgInd"`
+	}
+
+	camtStmt struct {
+		Id        string        `xml:"Id"`
+		CreDtTm   string        `xml:"CreDtTm"`
+		FrToDt    camtFrToDt    `xml:"FrToDt"`
+		Acct      camtAcct      `xml:"Acct"`
+		Bal       []camtBal     `xml:"Bal"`
+		TxsSummry camtTxsSummry `xml:"TxsSummry"`
+		Ntry      []camtNtry    `xml:"Ntry"`
+	}
+
+	camtFrToDt struct {
+		FrDtTm string `xml:"FrDtTm"`
+		ToDtTm string `xml:"ToDtTm"`
+	}
+
+	camtAcct struct {
+		Id   camtAcctId `xml:"Id"`
+		Ccy  string     `xml:"Ccy"`
+		Ownr camtParty  `xml:"Ownr"`
+	}
+
+	camtAcctId struct {
+		Othr camtOthr `xml:"Othr"`
+	}
+
+	camtOthr struct {
+		Id string `xml:"Id"`
+	}
+
+	camtParty struct {
+		Nm string `xml:"Nm"`
+	}
+
+	camtPartyAcct struct {
+		Id camtAcctId `xml:"Id"`
+	}
+
+	camtBal struct {
+		Tp        camtBalTp `xml:"Tp"`
+		Amt       camtAmt   `xml:"Amt"`
+		CdtDbtInd string    `xml:"CdtDbtInd"`
+		Dt        camtDt    `xml:"Dt"`
+	}
+
+	camtBalTp struct {
+		Cd string `xml:"CdOrPrtry>Cd"`
+	}
+
+	camtAmt struct {
+		Ccy   string `xml:"Ccy,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	camtDt struct {
+		Dt string `xml:"Dt"`
+	}
+
+	camtDtTm struct {
+		DtTm string `xml:"DtTm"`
+	}
+
+	camtTxsSummry struct {
+		TtlNtries    camtTtlNtries `xml:"TtlNtries"`
+		TtlCdtNtries camtNbSum     `xml:"TtlCdtNtries"`
+		TtlDbtNtries camtNbSum     `xml:"TtlDbtNtries"`
+	}
+
+	camtTtlNtries struct {
+		NbOfNtries    string `xml:"NbOfNtries"`
+		Sum           string `xml:"Sum"`
+		TtlNetNtryAmt string `xml:"TtlNetNtryAmt"`
+		CdtDbtInd     string `xml:"CdtDbtInd"`
+	}
+
+	camtNbSum struct {
+		NbOfNtries string `xml:"NbOfNtries"`
+		Sum        string `xml:"Sum"`
+	}
+
+	camtNtry struct {
+		NtryRef     string       `xml:"NtryRef"`
+		Amt         camtAmt      `xml:"Amt"`
+		CdtDbtInd   string       `xml:"CdtDbtInd"`
+		Sts         string       `xml:"Sts"`
+		BookgDt     camtDtTm     `xml:"BookgDt"`
+		ValDt       camtDt       `xml:"ValDt"`
+		AcctSvcrRef string       `xml:"AcctSvcrRef"`
+		BkTxCd      camtBkTxCd   `xml:"BkTxCd"`
+		NtryDtls    camtNtryDtls `xml:"NtryDtls"`
+	}
+
+	camtBkTxCd struct {
+		Cd        string `xml:"Domn>Cd"`
+		FmlyCd    string `xml:"Domn>Fmly>Cd"`
+		SubFmlyCd string `xml:"Domn>Fmly>SubFmlyCd"`
+	}
+
+	camtNtryDtls struct {
+		TxDtls camtTxDtls `xml:"TxDtls"`
+	}
+
+	camtTxDtls struct {
+		Refs      camtRefs       `xml:"Refs"`
+		RltdPties *camtRltdPties `xml:"RltdPties,omitempty"`
+		RmtInf    *camtRmtInf    `xml:"RmtInf,omitempty"`
+	}
+
+	camtRefs struct {
+		AcctSvcrRef string `xml:"AcctSvcrRef"`
+		EndToEndId  string `xml:"EndToEndId,omitempty"`
+		TxId        string `xml:"TxId,omitempty"`
+	}
+
+	camtRltdPties struct {
+		DbtrAcct *camtPartyAcct `xml:"DbtrAcct,omitempty"`
+		CdtrAcct *camtPartyAcct `xml:"CdtrAcct,omitempty"`
+	}
+
+	camtRmtInf struct {
+		Ustrd string `xml:"Ustrd"`
+	}
+)
+
+func newCamtAmt(amount int64, currency entity.Currency) camtAmt {
+	return camtAmt{Ccy: string(currency), Value: formatAmount(amount)}
+}
+
+func newCamtBal(code string, balance int64, currency entity.Currency, date string) camtBal {
+	return camtBal{
+		Tp:        camtBalTp{Cd: code},
+		Amt:       newCamtAmt(abs(balance), currency),
+		CdtDbtInd: creditDebit(balance),
+		Dt:        camtDt{Dt: date},
+	}
+}
+
+// newCamtBkTxCd returns the ISO bank transaction code of the line:
+// received or issued credit transfers and cash deposits or withdrawals.
+func newCamtBkTxCd(l entity.StatementLine) camtBkTxCd {
+	switch {
+	case l.Counterparty != nil && l.Amount < 0:
+		return camtBkTxCd{Cd: "PMNT", FmlyCd: "ICDT", SubFmlyCd: "DMCT"}
+	case l.Counterparty != nil:
+		return camtBkTxCd{Cd: "PMNT", FmlyCd: "RCDT", SubFmlyCd: "DMCT"}
+	case l.Amount < 0:
+		return camtBkTxCd{Cd: "PMNT", FmlyCd: "CNTR", SubFmlyCd: "CWDL"}
+	default:
+		return camtBkTxCd{Cd: "PMNT", FmlyCd: "CNTR", SubFmlyCd: "CDPT"}
+	}
+}
+
+func creditDebit(amount int64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// compactID returns the account id without dashes, so that it fits the
+// 34 characters limit of account identifiers.
+func compactID(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
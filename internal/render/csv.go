@@ -0,0 +1,54 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+)
+
+type csvRenderer struct{}
+
+func (csvRenderer) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (csvRenderer) Extension() string { return "csv" }
+
+// Render writes a header row, the opening balance, a row per line and
+// the totals with the closing balance.
+func (csvRenderer) Render(w io.Writer, s entity.Statement) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{
+		{"date", "entry_id", "description", "reference", "counterparty", "debit", "credit", "balance"},
+		{s.From.Format(time.RFC3339), "", "Opening balance", "", "", "", "", formatAmount(s.OpeningBalance)},
+	}
+	for _, l := range s.Lines {
+		var debit, credit, counterparty string
+		if l.Amount < 0 {
+			debit = formatAmount(-l.Amount)
+		} else {
+			credit = formatAmount(l.Amount)
+		}
+		if l.Counterparty != nil {
+			counterparty = l.Counterparty.String()
+		}
+		records = append(records, []string{
+			l.CreatedAt.Format(time.RFC3339),
+			strconv.FormatInt(l.EntryID, 10),
+			l.Description,
+			l.Reference,
+			counterparty,
+			debit,
+			credit,
+			formatAmount(l.Balance),
+		})
+	}
+	records = append(records, []string{
+		s.To.Format(time.RFC3339), "", "Closing balance", "", "",
+		formatAmount(s.TotalDebits), formatAmount(s.TotalCredits), formatAmount(s.ClosingBalance),
+	})
+
+	return cw.WriteAll(records)
+}
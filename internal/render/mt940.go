@@ -0,0 +1,119 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+)
+
+// mt940Renderer writes the statement as the text block of a SWIFT MT940
+// customer statement message.
+type mt940Renderer struct{}
+
+func (mt940Renderer) ContentType() string { return "text/plain; charset=us-ascii" }
+
+func (mt940Renderer) Extension() string { return "sta" }
+
+func (mt940Renderer) Render(w io.Writer, s entity.Statement) error {
+	accountID := compactID(s.AccountID)
+
+	var b strings.Builder
+	field := func(tag, value string) {
+		b.WriteString(":" + tag + ":" + value + "\r\n")
+	}
+
+	field("20", accountID[:8]+s.From.Format("20060102"))
+	field("25", accountID)
+	field("28C", "00001/001")
+	field("60F", mt940Balance(s.OpeningBalance, s.From, s.Currency))
+	for _, l := range s.Lines {
+		mark, code := "C", "NMSC"
+		if l.Amount < 0 {
+			mark = "D"
+		}
+		if l.Counterparty != nil {
+			code = "NTRF"
+		}
+
+		ref := "NONREF"
+		switch {
+		case l.Reference != "":
+			ref = swiftX(l.Reference)
+		case l.TransferID != 0:
+			ref = strconv.FormatInt(l.TransferID, 10)
+		}
+
+		field("61", fmt.Sprintf("%s%s%s%s%s%s//%s",
+			l.CreatedAt.Format("060102"),
+			l.CreatedAt.Format("0102"),
+			mark,
+			mt940Amount(abs(l.Amount)),
+			code,
+			truncate(ref, 16),
+			truncate(strconv.FormatInt(l.EntryID, 10), 16),
+		))
+		if narrative := mt940Narrative(l); narrative != "" {
+			field("86", narrative)
+		}
+	}
+	field("62F", mt940Balance(s.ClosingBalance, lastDay(s), s.Currency))
+	b.WriteString("-\r\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mt940Balance formats the balance as D/C mark, YYMMDD date, currency and amount.
+func mt940Balance(balance int64, date time.Time, currency entity.Currency) string {
+	mark := "C"
+	if balance < 0 {
+		mark = "D"
+	}
+	return mark + date.Format("060102") + string(currency) + mt940Amount(abs(balance))
+}
+
+// mt940Amount formats the amount with a decimal comma as required by SWIFT.
+func mt940Amount(amount int64) string {
+	return strings.Replace(formatAmount(amount), ".", ",", 1)
+}
+
+// mt940Narrative returns the :86: field of the line split into lines
+// of 65 characters limited to the SWIFT X character set.
+func mt940Narrative(l entity.StatementLine) string {
+	text := l.Description
+	if l.Counterparty != nil {
+		text = fmt.Sprintf("%s /ACC/%s", text, compactID(*l.Counterparty))
+	}
+	text = swiftX(text)
+
+	var lines []string
+	for len(text) > 0 && len(lines) < 6 {
+		n := 65
+		if len(text) < n {
+			n = len(text)
+		}
+		lines = append(lines, text[:n])
+		text = text[n:]
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// swiftX replaces the characters outside of the SWIFT X character set.
+func swiftX(s string) string {
+	const allowed = "/-?:().,'+ "
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune(allowed, r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
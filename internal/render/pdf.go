@@ -0,0 +1,50 @@
+package render
+
+import (
+	"io"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/pdf"
+)
+
+type pdfRenderer struct{}
+
+func (pdfRenderer) ContentType() string { return "application/pdf" }
+
+func (pdfRenderer) Extension() string { return "pdf" }
+
+func (pdfRenderer) Render(w io.Writer, s entity.Statement) error {
+	const (
+		dateLayout = "2006-01-02 15:04"
+		row        = "%-16s  %-44s  %12s  %12s"
+	)
+
+	doc := pdf.New()
+	doc.Line("ACCOUNT STATEMENT")
+	doc.Line("")
+	doc.Line("Account:  %s", s.AccountID)
+	doc.Line("Owner:    %s", s.Owner)
+	doc.Line("Currency: %s", s.Currency)
+	doc.Line("Period:   %s - %s", s.From.Format(dateLayout), s.To.Format(dateLayout))
+	doc.Line("")
+	doc.Line(row, "Date", "Description", "Amount", "Balance")
+	doc.Line(row, s.From.Format(dateLayout), "Opening balance", "", formatAmount(s.OpeningBalance))
+	for _, l := range s.Lines {
+		doc.Line(row, l.CreatedAt.Format(dateLayout), truncate(l.Description, 44),
+			formatAmount(l.Amount), formatAmount(l.Balance))
+	}
+	doc.Line(row, s.To.Format(dateLayout), "Closing balance", "", formatAmount(s.ClosingBalance))
+	doc.Line("")
+	doc.Line("Total debits:  %s", formatAmount(s.TotalDebits))
+	doc.Line("Total credits: %s", formatAmount(s.TotalCredits))
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
@@ -0,0 +1,58 @@
+// Package render implements the output formats of account statements.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"alukart32.com/bank/entity"
+)
+
+// Renderer writes a statement in some output format.
+type Renderer interface {
+	ContentType() string
+	Extension() string
+	Render(w io.Writer, s entity.Statement) error
+}
+
+var renderers = map[string]Renderer{
+	"json": jsonRenderer{},
+	"csv":  csvRenderer{},
+	"pdf":  pdfRenderer{},
+
+	"camt053": camt053Renderer{},
+	"mt940":   mt940Renderer{},
+}
+
+// ForFormat returns the renderer of the format.
+func ForFormat(format string) (Renderer, bool) {
+	r, ok := renderers[format]
+	return r, ok
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) ContentType() string { return "application/json; charset=utf-8" }
+
+func (jsonRenderer) Extension() string { return "json" }
+
+func (jsonRenderer) Render(w io.Writer, s entity.Statement) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// formatAmount formats the amount in minor units as a decimal number.
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// lastDay returns the last instant of the statement period.
+func lastDay(s entity.Statement) time.Time {
+	return s.To.Add(-time.Nanosecond)
+}
@@ -0,0 +1,99 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testStatement() entity.Statement {
+	from := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	counterparty := uuid.MustParse("5b1e8b7e-1f43-4f4e-9a55-5b0c7a3e2f10")
+	return entity.Statement{
+		AccountID:      uuid.MustParse("0c9c6a52-6f0e-4a8e-9d0a-2f8f6f0c1a11"),
+		Owner:          "owner",
+		Currency:       entity.CurrencyRUB,
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		OpeningBalance: 10000,
+		ClosingBalance: 12550,
+		TotalDebits:    450,
+		TotalCredits:   3000,
+		Lines: []entity.StatementLine{
+			{EntryID: 1, Amount: 3000, Balance: 13000, Description: "Deposit", CreatedAt: from.Add(time.Hour)},
+			{EntryID: 2, TransferID: 1, Reference: "INV-2022/10", Counterparty: &counterparty, Amount: -450,
+				Balance: 12550, Description: "Rent for October", CreatedAt: from.Add(2 * time.Hour)},
+		},
+		GeneratedAt: from.AddDate(0, 1, 1),
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	r, ok := ForFormat("csv")
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(&buf, testStatement()))
+
+	expected := "date,entry_id,description,reference,counterparty,debit,credit,balance\n" +
+		"2022-10-01T00:00:00Z,,Opening balance,,,,,100.00\n" +
+		"2022-10-01T01:00:00Z,1,Deposit,,,,30.00,130.00\n" +
+		"2022-10-01T02:00:00Z,2,Rent for October,INV-2022/10," +
+		"5b1e8b7e-1f43-4f4e-9a55-5b0c7a3e2f10,4.50,,125.50\n" +
+		"2022-11-01T00:00:00Z,,Closing balance,,,4.50,30.00,125.50\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderPDF(t *testing.T) {
+	r, ok := ForFormat("pdf")
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(&buf, testStatement()))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4")))
+	assert.Contains(t, buf.String(), "Closing balance")
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "0.00", formatAmount(0))
+	assert.Equal(t, "0.05", formatAmount(5))
+	assert.Equal(t, "-1234.56", formatAmount(-123456))
+}
+
+func TestRenderGolden(t *testing.T) {
+	tests := []struct {
+		format string
+		golden string
+	}{
+		{"camt053", "statement.camt053.xml"},
+		{"mt940", "statement.mt940"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			r, ok := ForFormat(tt.format)
+			require.True(t, ok)
+
+			var buf bytes.Buffer
+			require.NoError(t, r.Render(&buf, testStatement()))
+
+			golden := filepath.Join("testdata", tt.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), buf.String())
+		})
+	}
+}
@@ -3,38 +3,238 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/accnum"
 	"alukart32.com/bank/pkg/zerologx"
 	"github.com/google/uuid"
 )
 
 type accountService struct {
-	db AccountRepo
-	l  zerologx.Logger
+	db      AccountRepo
+	holders HolderRepo
+	authz   *Authorizer
+	numbers *accnum.Generator
+	// screener screens the owners of the opened accounts, the new owners
+	// and the invited holders. A nil screener disables the screening.
+	screener *Screener
+	audit    *Auditor
+	l        zerologx.Logger
 }
 
-func NewAccountService(r AccountRepo, l zerologx.Logger) AccountService {
+func NewAccountService(r AccountRepo, hr HolderRepo, g *accnum.Generator, screener *Screener, audit *Auditor,
+	l zerologx.Logger) AccountService {
 	return &accountService{
-		db: r,
-		l:  l,
+		db:       r,
+		holders:  hr,
+		authz:    NewAuthorizer(hr),
+		numbers:  g,
+		screener: screener,
+		audit:    audit,
+		l:        l,
 	}
 }
 
+// Create opens the account under the next account number.
 func (s *accountService) Create(ctx context.Context, a entity.Account) (uuid.UUID, error) {
-	return [16]byte{}, errors.New("not implemented yet")
+	if a.Owner == "" {
+		return uuid.Nil, fmt.Errorf("%w: owner is required", ErrInvalidArgument)
+	}
+	if a.Balance != 0 {
+		return uuid.Nil, fmt.Errorf("%w: account opens with zero balance, the cash is deposited", ErrInvalidArgument)
+	}
+	if a.Currency != entity.CurrencyRUB && a.Currency != entity.CurrencyUSD {
+		return uuid.Nil, fmt.Errorf("%w: unsupported currency %q", ErrInvalidArgument, a.Currency)
+	}
+
+	seq, err := s.db.NextNumber(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	a.Number, err = s.numbers.Generate(seq)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	if err := s.screen(ctx, entity.ScreenAccountOpening, a.ID, a.Owner); err != nil {
+		return uuid.Nil, err
+	}
+
+	var account entity.Account
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if account, err = s.db.Create(ctx, a); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditAccountCreate, entity.AuditTargetAccount, account.ID.String(), nil, account)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return account.ID, nil
 }
 
 func (s *accountService) Get(ctx context.Context, id uuid.UUID) (entity.Account, error) {
-	return entity.Account{}, errors.New("not implemented yet")
+	return s.db.Get(ctx, id)
+}
+
+// GetByNumber looks the account up by its number. Mistyped numbers are
+// rejected with ErrInvalidArgument without querying the repo.
+func (s *accountService) GetByNumber(ctx context.Context, number string) (entity.Account, error) {
+	number = accnum.Normalize(number)
+	if err := s.numbers.Validate(number); err != nil {
+		return entity.Account{}, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	return s.db.GetByNumber(ctx, number)
+}
+
+// UpdateOwner screens the new owner before the account is handed over.
+// The subject must be the primary holder unless it is empty.
+func (s *accountService) UpdateOwner(ctx context.Context, subject string, id uuid.UUID, owner string) (entity.Account, error) {
+	if owner == "" {
+		return entity.Account{}, fmt.Errorf("%w: owner is required", ErrInvalidArgument)
+	}
+
+	a, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.Account{}, err
+	}
+	if subject != "" {
+		if err = s.authz.Authorize(ctx, subject, a, entity.PermissionChangeOwner); err != nil {
+			return entity.Account{}, err
+		}
+	}
+	if a.Owner == owner {
+		return a, nil
+	}
+	if err = s.screen(ctx, entity.ScreenOwnerUpdate, id, owner); err != nil {
+		return entity.Account{}, err
+	}
+
+	var updated entity.Account
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.db.UpdateOwner(ctx, id, owner); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditAccountUpdateOwner, entity.AuditTargetAccount, id.String(), a, updated)
+	})
+	if err != nil {
+		return entity.Account{}, err
+	}
+	return updated, nil
+}
+
+func (s *accountService) Authorize(ctx context.Context, subject string, a entity.Account, p entity.Permission) error {
+	return s.authz.Authorize(ctx, subject, a, p)
 }
 
-func (s *accountService) UpdateOwner(ctx context.Context, id uuid.UUID, owner string) (entity.Account, error) {
-	return entity.Account{}, errors.New("not implemented yet")
+func (s *accountService) Holders(ctx context.Context, id uuid.UUID) ([]entity.AccountHolder, error) {
+	return s.holders.List(ctx, id)
 }
 
-func (s *accountService) AddBalance(ctx context.Context, id uuid.UUID, amount int64) (entity.Account, error) {
-	return entity.Account{}, errors.New("not implemented yet")
+// InviteHolder invites the holder to the account in the role. The
+// inviter must be allowed to manage the holders, the invited holder is
+// screened and has no access until the invitation is accepted.
+func (s *accountService) InviteHolder(ctx context.Context, inviter string, h entity.AccountHolder) (entity.AccountHolder, error) {
+	if h.Holder == "" {
+		return entity.AccountHolder{}, fmt.Errorf("%w: holder is required", ErrInvalidArgument)
+	}
+	if !h.Role.Valid() {
+		return entity.AccountHolder{}, fmt.Errorf("%w: unknown holder role %q", ErrInvalidArgument, h.Role)
+	}
+
+	a, err := s.db.Get(ctx, h.AccountID)
+	if err != nil {
+		return entity.AccountHolder{}, err
+	}
+	if err = s.authz.Authorize(ctx, inviter, a, entity.PermissionManageHolders); err != nil {
+		return entity.AccountHolder{}, err
+	}
+	if h.Holder == a.Owner {
+		return entity.AccountHolder{}, ErrDuplicate
+	}
+	if err = s.screen(ctx, entity.ScreenHolderInvite, a.ID, h.Holder); err != nil {
+		return entity.AccountHolder{}, err
+	}
+
+	h.InvitedBy = inviter
+	var result entity.AccountHolder
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = s.holders.Create(ctx, h); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditHolderInvite, entity.AuditTargetAccount, a.ID.String(), nil, result)
+	})
+	if err != nil {
+		return entity.AccountHolder{}, err
+	}
+	return result, nil
+}
+
+// AcceptHolder accepts the invitation of the holder to the account.
+func (s *accountService) AcceptHolder(ctx context.Context, holder string, id uuid.UUID) (entity.AccountHolder, error) {
+	var result entity.AccountHolder
+	err := s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, err = s.holders.Accept(ctx, id, holder); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditHolderAccept, entity.AuditTargetAccount, id.String(), nil, result)
+	})
+	if err != nil {
+		return entity.AccountHolder{}, err
+	}
+	return result, nil
+}
+
+// RemoveHolder removes the holder or declines the invitation. The
+// primary holder is replaced by the change of the account owner only.
+func (s *accountService) RemoveHolder(ctx context.Context, subject string, id uuid.UUID, holder string) error {
+	if subject != holder {
+		a, err := s.db.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err = s.authz.Authorize(ctx, subject, a, entity.PermissionManageHolders); err != nil {
+			return err
+		}
+	}
+
+	h, err := s.holders.Get(ctx, id, holder)
+	if err != nil {
+		return err
+	}
+	if h.Role == entity.HolderPrimary {
+		return fmt.Errorf("%w: primary holder changes with the account owner", ErrInvalidArgument)
+	}
+	return s.audit.Do(ctx, func(ctx context.Context) error {
+		if err := s.holders.Delete(ctx, id, holder); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditHolderRemove, entity.AuditTargetAccount, id.String(), h, nil)
+	})
+}
+
+// screen returns ErrScreeningBlocked if the owner is blocked. The flagged
+// owners are only logged, the alert waits for the compliance review.
+func (s *accountService) screen(ctx context.Context, trigger entity.ScreeningTrigger, id uuid.UUID, owner string) error {
+	if s.screener == nil {
+		return nil
+	}
+
+	alert, err := s.screener.Screen(ctx, trigger, id, owner)
+	if err != nil {
+		return err
+	}
+	if alert.Decision == entity.ScreeningFlag {
+		s.l.Warn("usecase - account - %s of %s flagged by screening alert %d", trigger, id, alert.ID)
+	}
+	return nil
 }
 
 func (s *accountService) Delete(ctx context.Context, id uuid.UUID) error {
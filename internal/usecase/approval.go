@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+// maxApprovers is the limit of the approvers of an account.
+const maxApprovers = 20
+
+var (
+	ErrApprovalRequired = errors.New("transfer requires approval")
+	ErrApprovalClosed   = errors.New("approval is already closed")
+	ErrApprovalExpired  = errors.New("approval is expired")
+)
+
+// ApprovalError is the ErrApprovalRequired with the approval the transfer
+// waits for.
+type ApprovalError struct {
+	Approval entity.TransferApproval
+}
+
+func (e *ApprovalError) Error() string {
+	return fmt.Sprintf("%v: approval %d", ErrApprovalRequired, e.Approval.ID)
+}
+
+func (e *ApprovalError) Unwrap() error {
+	return ErrApprovalRequired
+}
+
+type approvalService struct {
+	db     ApprovalRepo
+	events EventPublisher
+	// risk evaluates the approved transfers before the execution. A nil
+	// engine allows all transfers.
+	risk  *RiskEngine
+	fees  *FeeEngine
+	audit *Auditor
+	l     zerologx.Logger
+}
+
+func NewApprovalService(r ApprovalRepo, p EventPublisher, risk *RiskEngine, fees *FeeEngine, audit *Auditor,
+	l zerologx.Logger) ApprovalService {
+	return &approvalService{
+		db:     r,
+		events: p,
+		risk:   risk,
+		fees:   fees,
+		audit:  audit,
+		l:      l,
+	}
+}
+
+func (s *approvalService) GetPolicy(ctx context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, error) {
+	return s.db.GetPolicy(ctx, accountID)
+}
+
+// SetPolicy sets the approval policy of the account on behalf of the admin.
+func (s *approvalService) SetPolicy(ctx context.Context, admin string, p entity.ApprovalPolicy) (entity.ApprovalPolicy, error) {
+	if p.Threshold <= 0 {
+		return entity.ApprovalPolicy{}, fmt.Errorf("%w: threshold must be positive", ErrInvalidArgument)
+	}
+
+	approvers := make([]string, 0, len(p.Approvers))
+	seen := make(map[string]bool, len(p.Approvers))
+	for _, a := range p.Approvers {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			return entity.ApprovalPolicy{}, fmt.Errorf("%w: empty approver", ErrInvalidArgument)
+		}
+		if !seen[a] {
+			seen[a] = true
+			approvers = append(approvers, a)
+		}
+	}
+	if len(approvers) == 0 || len(approvers) > maxApprovers {
+		return entity.ApprovalPolicy{}, fmt.Errorf("%w: policy must have 1 to %d approvers", ErrInvalidArgument, maxApprovers)
+	}
+
+	p.Approvers = approvers
+	p.UpdatedBy = admin
+	return s.db.SetPolicy(ctx, p)
+}
+
+func (s *approvalService) DeletePolicy(ctx context.Context, accountID uuid.UUID) error {
+	return s.db.DeletePolicy(ctx, accountID)
+}
+
+// List returns the pending approvals the approver can decide.
+func (s *approvalService) List(ctx context.Context, approver string) ([]entity.TransferApproval, error) {
+	return s.db.List(ctx, approver)
+}
+
+// Get returns the approval to its initiator or approvers.
+func (s *approvalService) Get(ctx context.Context, user string, id int64) (entity.TransferApproval, error) {
+	a, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.TransferApproval{}, err
+	}
+	if a.InitiatedBy == user {
+		return a, nil
+	}
+
+	if err = s.checkApprover(ctx, a, user); err != nil {
+		return entity.TransferApproval{}, err
+	}
+	return a, nil
+}
+
+// Approve executes the transfer on behalf of the approver. The limits,
+// the balance, the risk and the fees are of the approval time. The
+// blocked transfer leaves the approval pending, the one assessed for
+// review is held with a *ReviewError.
+func (s *approvalService) Approve(ctx context.Context, approver string, id int64, comment string) (entity.TransferApproval, error) {
+	a, err := s.decide(ctx, approver, id, comment)
+	if err != nil {
+		return entity.TransferApproval{}, err
+	}
+	if err = s.assess(ctx, approver, a, comment); err != nil {
+		return entity.TransferApproval{}, err
+	}
+	fees, err := s.fees.Charges(ctx, a.Transfer)
+	if err != nil {
+		return entity.TransferApproval{}, err
+	}
+
+	before := a
+	var res entity.TransferRes
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if a, res, err = s.db.Approve(ctx, id, approver, comment, fees); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditTransferApprove, entity.AuditTargetTransfer,
+			strconv.FormatInt(res.Transfer.ID, 10), before, res)
+	})
+	if err != nil {
+		return entity.TransferApproval{}, err
+	}
+
+	publishTransfer(s.events, res)
+	return a, nil
+}
+
+// assess returns ErrTransferBlocked for the blocked transfer and a
+// *ReviewError for the one held for review, the approval is decided then.
+func (s *approvalService) assess(ctx context.Context, approver string, a entity.TransferApproval, comment string) error {
+	if s.risk == nil {
+		return nil
+	}
+
+	assessment, err := s.risk.Assess(ctx, a.Transfer)
+	if err != nil {
+		return err
+	}
+
+	switch assessment.Decision {
+	case entity.RiskBlock:
+		return blockedError(assessment)
+	case entity.RiskReview:
+		_, review, err := s.db.Hold(ctx, a.ID, approver, comment, entity.TransferReview{
+			Transfer: a.Transfer,
+			Score:    assessment.Score,
+			Signals:  assessment.Signals,
+		})
+		if err != nil {
+			return err
+		}
+		return &ReviewError{Review: review}
+	}
+	return nil
+}
+
+func (s *approvalService) Reject(ctx context.Context, approver string, id int64, comment string) (entity.TransferApproval, error) {
+	if _, err := s.decide(ctx, approver, id, comment); err != nil {
+		return entity.TransferApproval{}, err
+	}
+	return s.db.Reject(ctx, id, approver, comment)
+}
+
+// decide returns the approval if the approver can decide on it.
+func (s *approvalService) decide(ctx context.Context, approver string, id int64,
+	comment string) (entity.TransferApproval, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.TransferApproval{}, err
+	}
+
+	a, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.TransferApproval{}, err
+	}
+	if a.InitiatedBy == approver {
+		return entity.TransferApproval{}, fmt.Errorf("%w: transfer can't be approved by its initiator", ErrAccessDenied)
+	}
+	return a, s.checkApprover(ctx, a, approver)
+}
+
+func (s *approvalService) checkApprover(ctx context.Context, a entity.TransferApproval, user string) error {
+	p, err := s.db.GetPolicy(ctx, a.Transfer.FromAccountID)
+	if errors.Is(err, ErrNotFound) {
+		return ErrAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, approver := range p.Approvers {
+		if approver == user {
+			return nil
+		}
+	}
+	return ErrAccessDenied
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubApprovalRepo struct {
+	ApprovalRepo
+	approval entity.TransferApproval
+	executed bool
+	held     bool
+}
+
+func (r *stubApprovalRepo) Get(_ context.Context, _ int64) (entity.TransferApproval, error) {
+	return r.approval, nil
+}
+
+func (r *stubApprovalRepo) GetPolicy(_ context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, error) {
+	return entity.ApprovalPolicy{AccountID: accountID, Approvers: []string{"approver"}}, nil
+}
+
+func (r *stubApprovalRepo) Approve(_ context.Context, _ int64, _, _ string,
+	_ []entity.FeeCharge) (entity.TransferApproval, entity.TransferRes, error) {
+	r.executed = true
+	a := r.approval
+	a.Status = entity.ApprovalApproved
+	return a, entity.TransferRes{Transfer: entity.Transfer{ID: 7, Amount: a.Transfer.Amount}}, nil
+}
+
+func (r *stubApprovalRepo) Hold(_ context.Context, _ int64, _, _ string,
+	review entity.TransferReview) (entity.TransferApproval, entity.TransferReview, error) {
+	r.held = true
+	review.ID = 3
+	a := r.approval
+	a.Status = entity.ApprovalApproved
+	return a, review, nil
+}
+
+type stubRiskRepo struct {
+	RiskRepo
+}
+
+func (stubRiskRepo) History(_ context.Context, _, _ uuid.UUID, _ time.Time) (entity.RiskHistory, error) {
+	return entity.RiskHistory{}, nil
+}
+
+func TestApprovalApprove(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	engine := NewRiskEngine(stubRiskRepo{}, 10*time.Minute, 50, 100, NewPayeeRule{MinAmount: 1000, Score: 60})
+
+	tests := []struct {
+		name     string
+		amount   int64
+		executed bool
+		held     bool
+	}{
+		{name: "allowed", amount: 500, executed: true},
+		{name: "held for review", amount: 2000, held: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubApprovalRepo{approval: entity.TransferApproval{
+				ID:          1,
+				Transfer:    entity.Transfer{FromAccountID: uuid.New(), ToAccountID: uuid.New(), Amount: tt.amount},
+				InitiatedBy: "initiator",
+				Status:      entity.ApprovalPending,
+			}}
+			audits := &stubAuditRepo{}
+			service := NewApprovalService(repo, &stubPublisher{}, engine, nil,
+				NewAuditor(audits, stubTransactor{}), &logger)
+
+			_, err := service.Approve(context.Background(), "approver", 1, "ok")
+			if tt.held {
+				var reviewErr *ReviewError
+				require.ErrorAs(t, err, &reviewErr)
+				assert.Equal(t, int64(3), reviewErr.Review.ID)
+				assert.Empty(t, audits.records)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, audits.records, 1)
+				assert.Equal(t, entity.AuditTransferApprove, audits.records[0].Action)
+				assert.Equal(t, "7", audits.records[0].TargetID)
+			}
+			assert.Equal(t, tt.executed, repo.executed)
+			assert.Equal(t, tt.held, repo.held)
+		})
+	}
+}
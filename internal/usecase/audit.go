@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+)
+
+// Limits of the audit log page and of the chain batch of the check.
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+	auditChainBatch   = 1000
+)
+
+type actorKey struct{}
+
+// WithActor stores the caller of the request in the context for the
+// audit records.
+func WithActor(ctx context.Context, a entity.AuditActor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFromContext returns the caller stored by WithActor.
+func ActorFromContext(ctx context.Context) entity.AuditActor {
+	a, _ := ctx.Value(actorKey{}).(entity.AuditActor)
+	return a
+}
+
+// Auditor appends the state changes to the audit log. A nil auditor
+// records nothing.
+type Auditor struct {
+	db AuditRepo
+	tx Transactor
+}
+
+func NewAuditor(r AuditRepo, tx Transactor) *Auditor {
+	return &Auditor{
+		db: r,
+		tx: tx,
+	}
+}
+
+// Do runs the change and its records in one tx, the change isn't made
+// if it can't be recorded. A nil auditor runs the change alone.
+func (a *Auditor) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if a == nil {
+		return fn(ctx)
+	}
+	return a.tx.InTx(ctx, fn)
+}
+
+// Record appends the change of the target by the actor of the context.
+// Called in Do, the record is committed with the change.
+func (a *Auditor) Record(ctx context.Context, action, targetType, targetID string, before, after interface{}) error {
+	if a == nil {
+		return nil
+	}
+
+	r := entity.AuditRecord{
+		AuditActor: ActorFromContext(ctx),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+	var err error
+	if r.Before, err = json.Marshal(before); err != nil {
+		return fmt.Errorf("audit %s of %s %s: %w", action, targetType, targetID, err)
+	}
+	if r.After, err = json.Marshal(after); err != nil {
+		return fmt.Errorf("audit %s of %s %s: %w", action, targetType, targetID, err)
+	}
+	if _, err = a.db.Append(ctx, r); err != nil {
+		return fmt.Errorf("audit %s of %s %s: %w", action, targetType, targetID, err)
+	}
+	return nil
+}
+
+type auditService struct {
+	db AuditRepo
+	l  zerologx.Logger
+}
+
+func NewAuditService(r AuditRepo, l zerologx.Logger) AuditService {
+	return &auditService{
+		db: r,
+		l:  l,
+	}
+}
+
+func (s *auditService) List(ctx context.Context, params ListAuditParams) ([]entity.AuditRecord, error) {
+	switch {
+	case params.Limit < 0 || params.Limit > maxAuditLimit:
+		return nil, fmt.Errorf("%w: limit must be from 1 to %d", ErrInvalidArgument, maxAuditLimit)
+	case params.Offset < 0:
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidArgument)
+	case params.Limit == 0:
+		params.Limit = defaultAuditLimit
+	}
+	return s.db.List(ctx, params)
+}
+
+// Verify walks the chain from the first record and stops at the first
+// one that doesn't match its hash or the hash of the previous record.
+func (s *auditService) Verify(ctx context.Context) (entity.AuditVerification, error) {
+	result := entity.AuditVerification{Valid: true}
+
+	var lastID int64
+	prevHash := entity.AuditGenesisHash
+	for {
+		records, err := s.db.Chain(ctx, lastID, auditChainBatch)
+		if err != nil {
+			return entity.AuditVerification{}, err
+		}
+
+		for _, r := range records {
+			if r.PrevHash != prevHash || r.ComputeHash() != r.Hash {
+				s.l.Warn("usecase - audit - verify: chain broken at record %d", r.ID)
+				result.Valid = false
+				result.BrokenID = r.ID
+				return result, nil
+			}
+			result.Records++
+			prevHash = r.Hash
+			lastID = r.ID
+		}
+		if len(records) < auditChainBatch {
+			return result, nil
+		}
+	}
+}
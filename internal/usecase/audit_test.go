@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAuditRepo struct {
+	AuditRepo
+	records []entity.AuditRecord
+}
+
+func (r *stubAuditRepo) Append(_ context.Context, a entity.AuditRecord) (entity.AuditRecord, error) {
+	a.ID = int64(len(r.records) + 1)
+	a.PrevHash = entity.AuditGenesisHash
+	if len(r.records) > 0 {
+		a.PrevHash = r.records[len(r.records)-1].Hash
+	}
+	a.CreatedAt = time.Now().UTC()
+	a.Hash = a.ComputeHash()
+	r.records = append(r.records, a)
+	return a, nil
+}
+
+func (r *stubAuditRepo) Chain(_ context.Context, afterID int64, limit int32) ([]entity.AuditRecord, error) {
+	var result []entity.AuditRecord
+	for _, v := range r.records {
+		if v.ID > afterID && len(result) < int(limit) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+type stubTransactor struct{}
+
+func (stubTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestAuditVerify(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	ctx := WithActor(context.Background(), entity.AuditActor{Subject: "admin", RequestID: "req-1"})
+	tests := []struct {
+		name   string
+		tamper func(records []entity.AuditRecord)
+		broken int64
+	}{
+		{name: "intact"},
+		{
+			name:   "changed snapshot",
+			tamper: func(records []entity.AuditRecord) { records[1].After = []byte(`{"owner":"intruder"}`) },
+			broken: 2,
+		},
+		{
+			name: "removed record",
+			tamper: func(records []entity.AuditRecord) {
+				records[1] = records[2]
+				records[2].ID = 4
+			},
+			broken: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubAuditRepo{}
+			auditor := NewAuditor(repo, stubTransactor{})
+			for _, owner := range []string{"a", "b", "c"} {
+				err := auditor.Record(ctx, entity.AuditAccountUpdateOwner, entity.AuditTargetAccount, "1",
+					nil, entity.Account{Owner: owner})
+				require.NoError(t, err)
+			}
+			require.Len(t, repo.records, 3)
+			assert.Equal(t, "admin", repo.records[0].Subject)
+			if tt.tamper != nil {
+				tt.tamper(repo.records)
+			}
+
+			result, err := NewAuditService(repo, &logger).Verify(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.broken == 0, result.Valid)
+			assert.Equal(t, tt.broken, result.BrokenID)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+type balanceService struct {
+	db BalanceRepo
+	l  zerologx.Logger
+}
+
+func NewBalanceService(r BalanceRepo, l zerologx.Logger) BalanceService {
+	return &balanceService{
+		db: r,
+		l:  l,
+	}
+}
+
+// AsOf returns the balance of the account holding the entries made before
+// the time. The time in the future returns the current balance.
+func (s *balanceService) AsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (entity.AccountBalance, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	return s.db.AsOf(ctx, accountID, asOf.UTC())
+}
+
+// Snapshot saves the end-of-day balances of the accounts for the UTC
+// date. The date must have ended. The accounts snapshotted by the
+// previous runs for the date are skipped, so the run can be repeated.
+func (s *balanceService) Snapshot(ctx context.Context, date time.Time) (entity.BatchRun, error) {
+	date = truncateDay(date)
+	if !date.Before(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not ended yet", ErrInvalidArgument, date.Format("2006-01-02"))
+	}
+
+	n, err := s.db.Snapshot(ctx, date.AddDate(0, 0, 1))
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+	return entity.BatchRun{Date: date, Processed: int(n)}, nil
+}
+
+// Rebuild replaces the snapshots with the ones computed from the entries
+// of each day until yesterday.
+func (s *balanceService) Rebuild(ctx context.Context) (entity.BatchRun, error) {
+	today := truncateDay(time.Now())
+
+	n, err := s.db.Rebuild(ctx, today)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+	s.l.Info("usecase - balance - rebuild: %d snapshots", n)
+	return entity.BatchRun{Date: today.AddDate(0, 0, -1), Processed: int(n)}, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBalanceRepo struct {
+	BalanceRepo
+	asOf []time.Time
+}
+
+func (r *stubBalanceRepo) AsOf(_ context.Context, accountID uuid.UUID, asOf time.Time) (entity.AccountBalance, error) {
+	return entity.AccountBalance{AccountID: accountID, AsOf: asOf}, nil
+}
+
+func (r *stubBalanceRepo) Snapshot(_ context.Context, asOf time.Time) (int64, error) {
+	r.asOf = append(r.asOf, asOf)
+	return 3, nil
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubBalanceRepo{}
+	s := NewBalanceService(repo, &logger)
+
+	_, err := s.Snapshot(context.Background(), time.Now())
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	date := time.Date(2023, 3, 31, 15, 0, 0, 0, time.UTC)
+	run, err := s.Snapshot(context.Background(), date)
+	require.NoError(t, err)
+	assert.Equal(t, 3, run.Processed)
+	assert.Equal(t, time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC), run.Date)
+	// the snapshot holds the entries of the whole date
+	assert.Equal(t, []time.Time{time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)}, repo.asOf)
+}
+
+func TestBalanceAsOf(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	s := NewBalanceService(&stubBalanceRepo{}, &logger)
+
+	balance, err := s.AsOf(context.Background(), uuid.New(), time.Time{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), balance.AsOf, time.Minute)
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const (
+	maxTellerIDLength = 70
+	// maxCashOperations is the limit of the listed operations of an
+	// account.
+	maxCashOperations = 100
+)
+
+// CheckCashLimit returns a *LimitError if the operation of the amount on
+// top of the daily total used exceeds the limit.
+func CheckCashLimit(l entity.CashLimit, used, amount int64) error {
+	if l.MaxSingle > 0 && amount > l.MaxSingle {
+		return &LimitError{Limit: LimitMaxSingle, Max: l.MaxSingle, Remaining: l.MaxSingle}
+	}
+	if l.Daily > 0 && used+amount > l.Daily {
+		remaining := l.Daily - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		return &LimitError{Limit: LimitDaily, Max: l.Daily, Remaining: remaining}
+	}
+	return nil
+}
+
+type cashService struct {
+	db     CashRepo
+	events EventPublisher
+	// limits are the limits of each channel, the deposits and the
+	// withdrawals are limited separately. The channel without a limit is
+	// not limited.
+	limits map[entity.CashChannel]entity.CashLimit
+	audit  *Auditor
+	l      zerologx.Logger
+}
+
+func NewCashService(r CashRepo, p EventPublisher, limits map[entity.CashChannel]entity.CashLimit,
+	audit *Auditor, l zerologx.Logger) CashService {
+	return &cashService{
+		db:     r,
+		events: p,
+		limits: limits,
+		audit:  audit,
+		l:      l,
+	}
+}
+
+// Deposit credits the account with the cash taken through the channel.
+func (s *cashService) Deposit(ctx context.Context, operator string, op entity.CashOperation) (entity.CashOperation, error) {
+	op.Direction = entity.CashDeposit
+	return s.execute(ctx, operator, op)
+}
+
+// Withdraw debits the account with the cash given out through the
+// channel.
+func (s *cashService) Withdraw(ctx context.Context, operator string, op entity.CashOperation) (entity.CashOperation, error) {
+	if op.Channel == entity.CashCardTopUp {
+		return entity.CashOperation{}, fmt.Errorf("%w: card top-up takes deposits only", ErrInvalidArgument)
+	}
+	op.Direction = entity.CashWithdrawal
+	return s.execute(ctx, operator, op)
+}
+
+func (s *cashService) execute(ctx context.Context, operator string, op entity.CashOperation) (entity.CashOperation, error) {
+	if op.Amount <= 0 {
+		return entity.CashOperation{}, fmt.Errorf("%w: amount must be positive", ErrInvalidArgument)
+	}
+	if !op.Channel.Valid() {
+		return entity.CashOperation{}, fmt.Errorf("%w: unknown channel %q", ErrInvalidArgument, op.Channel)
+	}
+	if err := checkTellerID(op); err != nil {
+		return entity.CashOperation{}, err
+	}
+
+	seq, err := s.db.NextReference(ctx)
+	if err != nil {
+		return entity.CashOperation{}, err
+	}
+	op.Reference = entity.CashReference(op.Direction, time.Now(), seq)
+	op.CreatedBy = operator
+
+	action := entity.AuditCashDeposit
+	if op.Direction == entity.CashWithdrawal {
+		action = entity.AuditCashWithdrawal
+	}
+	var (
+		result entity.CashOperation
+		entry  entity.Entry
+	)
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, entry, err = s.db.Create(ctx, op, s.limits[op.Channel]); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, action, entity.AuditTargetAccount, result.AccountID.String(), nil, result)
+	})
+	if err != nil {
+		return entity.CashOperation{}, err
+	}
+
+	s.events.Publish(
+		entity.NewEntryEvent(entry),
+		entity.NewBalanceEvent(entity.Account{
+			ID:       result.AccountID,
+			Balance:  result.Balance,
+			Currency: result.Currency,
+		}),
+	)
+	return result, nil
+}
+
+// Receipt returns the operation by the reference of its receipt.
+func (s *cashService) Receipt(ctx context.Context, reference string) (entity.CashOperation, error) {
+	return s.db.Get(ctx, reference)
+}
+
+func (s *cashService) List(ctx context.Context, accountID uuid.UUID) ([]entity.CashOperation, error) {
+	return s.db.List(ctx, accountID, maxCashOperations)
+}
+
+// checkTellerID requires the teller id of the teller operations only.
+func checkTellerID(op entity.CashOperation) error {
+	if op.Channel != entity.CashTeller {
+		if op.TellerID != "" {
+			return fmt.Errorf("%w: teller id is only for the teller channel", ErrInvalidArgument)
+		}
+		return nil
+	}
+	if op.TellerID == "" || utf8.RuneCountInString(op.TellerID) > maxTellerIDLength || !isPrintable(op.TellerID) {
+		return fmt.Errorf("%w: teller id must have 1 to %d printable characters", ErrInvalidArgument, maxTellerIDLength)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCashRepo struct {
+	CashRepo
+	seq    int64
+	limits []entity.CashLimit
+}
+
+func (r *stubCashRepo) NextReference(context.Context) (int64, error) {
+	r.seq++
+	return r.seq, nil
+}
+
+func (r *stubCashRepo) Create(_ context.Context, op entity.CashOperation,
+	limit entity.CashLimit) (entity.CashOperation, entity.Entry, error) {
+	r.limits = append(r.limits, limit)
+	op.ID = r.seq
+	op.Currency = entity.CurrencyRUB
+	op.Balance = op.Amount
+	op.EntryID = r.seq
+	return op, entity.Entry{ID: r.seq, AccountID: op.AccountID, Amount: op.Amount}, nil
+}
+
+func TestCheckCashLimit(t *testing.T) {
+	limit := entity.CashLimit{MaxSingle: 1000, Daily: 2500}
+
+	require.NoError(t, CheckCashLimit(limit, 1500, 1000))
+	require.NoError(t, CheckCashLimit(entity.CashLimit{}, 1e9, 1e9))
+
+	var limitErr *LimitError
+	err := CheckCashLimit(limit, 0, 1001)
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitMaxSingle, limitErr.Limit)
+
+	err = CheckCashLimit(limit, 2000, 1000)
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitDaily, limitErr.Limit)
+	assert.Equal(t, int64(500), limitErr.Remaining)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestCashDeposit(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubCashRepo{}
+	events := &stubPublisher{}
+	atm := entity.CashLimit{MaxSingle: 500, Daily: 1000}
+	s := NewCashService(repo, events, map[entity.CashChannel]entity.CashLimit{entity.CashATM: atm}, nil, &logger)
+
+	receipt, err := s.Deposit(context.Background(), "atm-42", entity.CashOperation{
+		AccountID: uuid.New(),
+		Amount:    300,
+		Channel:   entity.CashATM,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.CashDeposit, receipt.Direction)
+	assert.True(t, strings.HasPrefix(receipt.Reference, "DEP-"))
+	assert.True(t, strings.HasSuffix(receipt.Reference, "-00000001"))
+	assert.Equal(t, "atm-42", receipt.CreatedBy)
+	assert.Equal(t, []entity.CashLimit{atm}, repo.limits)
+	assert.Len(t, events.events, 2)
+
+	receipt, err = s.Withdraw(context.Background(), "teller", entity.CashOperation{
+		AccountID: uuid.New(),
+		Amount:    100,
+		Channel:   entity.CashTeller,
+		TellerID:  "T-007",
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(receipt.Reference, "WDL-"))
+	// the channel without a limit isn't limited
+	assert.Equal(t, entity.CashLimit{}, repo.limits[1])
+}
+
+func TestCashValidation(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	s := NewCashService(&stubCashRepo{}, &stubPublisher{}, nil, nil, &logger)
+
+	tests := []struct {
+		name     string
+		withdraw bool
+		op       entity.CashOperation
+	}{
+		{name: "zero amount", op: entity.CashOperation{Channel: entity.CashATM}},
+		{name: "unknown channel", op: entity.CashOperation{Amount: 100, Channel: "branch"}},
+		{name: "no teller id", op: entity.CashOperation{Amount: 100, Channel: entity.CashTeller}},
+		{name: "teller id of atm", op: entity.CashOperation{Amount: 100, Channel: entity.CashATM, TellerID: "T-1"}},
+		{name: "card top-up withdrawal", withdraw: true,
+			op: entity.CashOperation{Amount: 100, Channel: entity.CashCardTopUp}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.withdraw {
+				_, err = s.Withdraw(context.Background(), "teller", tt.op)
+			} else {
+				_, err = s.Deposit(context.Background(), "teller", tt.op)
+			}
+			require.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
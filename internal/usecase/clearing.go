@@ -0,0 +1,523 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/accnum"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const (
+	maxBeneficiaryNameLength = 70
+	// maxExternalTransfers is the limit of the listed transfers of an
+	// account and of the listed batches.
+	maxExternalTransfers     = 100
+	maxReturnReasonLength    = 4
+	maxDomesticAccountLength = 17
+)
+
+type clearingService struct {
+	db       ClearingRepo
+	accounts AccountRepo
+	gateway  ClearingGateway
+	// screener screens the beneficiaries, the transfers to the flagged
+	// ones are held for review. A nil screener disables the screening.
+	screener *Screener
+	// risk evaluates the transfers before the submission, the
+	// beneficiaries are new payees to the rules. A nil engine allows all
+	// transfers.
+	risk *RiskEngine
+	// approvals holds the transfers above the threshold of the account
+	// approval policy for its approvers. A nil repo disables the policies.
+	approvals ApprovalRepo
+	// fees calculates the fees charged with the submitted transfer. A nil
+	// engine charges nothing.
+	fees   *FeeEngine
+	authz  *Authorizer
+	audit  *Auditor
+	events EventPublisher
+	l      zerologx.Logger
+}
+
+func NewClearingService(r ClearingRepo, ar AccountRepo, g ClearingGateway, screener *Screener, risk *RiskEngine,
+	approvals ApprovalRepo, fees *FeeEngine, authz *Authorizer, audit *Auditor, p EventPublisher,
+	l zerologx.Logger) ClearingService {
+	return &clearingService{
+		db:        r,
+		accounts:  ar,
+		gateway:   g,
+		screener:  screener,
+		risk:      risk,
+		approvals: approvals,
+		fees:      fees,
+		authz:     authz,
+		audit:     audit,
+		events:    p,
+		l:         l,
+	}
+}
+
+// Transfer debits the account of the owner into the suspense account.
+// The transfer passes the screening, the approval policy, the risk rules
+// and the limits of the account like the transfers between the accounts.
+// The pending transfer is charged the fees and leaves with the next
+// batch, the held one keeps the amount until it is decided.
+func (s *clearingService) Transfer(ctx context.Context, owner string, t entity.ExternalTransfer) (entity.ExternalTransfer, error) {
+	t.BeneficiaryName = strings.TrimSpace(t.BeneficiaryName)
+	t.BeneficiaryAccount = accnum.Normalize(t.BeneficiaryAccount)
+	t.BeneficiaryBank = strings.ToUpper(strings.TrimSpace(t.BeneficiaryBank))
+	if err := checkExternalTransfer(t); err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	from, err := s.accounts.Get(ctx, t.AccountID)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	if err = s.authz.Authorize(ctx, owner, from, entity.PermissionTransfer); err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	t.CreatedBy = owner
+	t.Status = entity.ExternalPending
+	if err = s.hold(ctx, &t); err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	var fees []entity.FeeCharge
+	if t.Status == entity.ExternalPending {
+		if fees, err = s.fees.ExternalCharges(ctx, t); err != nil {
+			return entity.ExternalTransfer{}, err
+		}
+	}
+
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+		entry   entity.Entry
+	)
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, account, entry, err = s.db.Create(ctx, t, fees); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditExternalCreate, entity.AuditTargetExternalTransfer,
+			strconv.FormatInt(result.ID, 10), nil, result)
+	})
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	s.events.Publish(entity.NewEntryEvent(entry))
+	s.publishBalance(account, result.Fees)
+	return result, nil
+}
+
+// hold holds the transfer to the beneficiary flagged by the screening
+// and the one assessed for review for the review, the one above the
+// threshold of the approval policy for the approval, the risk of which
+// is assessed when it is approved. The blocked transfers return
+// ErrScreeningBlocked or ErrTransferBlocked.
+func (s *clearingService) hold(ctx context.Context, t *entity.ExternalTransfer) error {
+	if s.screener != nil {
+		alert, err := s.screener.Screen(ctx, entity.ScreenTransfer, t.AccountID, t.BeneficiaryName)
+		if err != nil {
+			return err
+		}
+		if alert.Decision == entity.ScreeningFlag {
+			match := alert.Matches[0]
+			t.Status, t.Hold, t.Score = entity.ExternalHeld, entity.HoldReview, int(alert.Score*100)
+			t.Signals = entity.RiskSignals{{
+				Rule:   "sanctions_screening",
+				Score:  t.Score,
+				Reason: fmt.Sprintf("beneficiary matches %q of %s, alert %d", match.Name, match.Program, alert.ID),
+			}}
+			return nil
+		}
+	}
+
+	p, ok, err := s.policy(ctx, t.AccountID)
+	if err != nil {
+		return err
+	}
+	if ok && t.Amount >= p.Threshold {
+		t.Status, t.Hold = entity.ExternalHeld, entity.HoldApproval
+		return nil
+	}
+
+	a, err := s.assess(ctx, *t)
+	if err != nil {
+		return err
+	}
+	switch a.Decision {
+	case entity.RiskBlock:
+		return blockedError(a)
+	case entity.RiskReview:
+		t.Status, t.Hold, t.Score, t.Signals = entity.ExternalHeld, entity.HoldReview, a.Score, a.Signals
+	}
+	return nil
+}
+
+// assess evaluates the transfer by the risk rules. A nil engine allows
+// all transfers.
+func (s *clearingService) assess(ctx context.Context, t entity.ExternalTransfer) (entity.RiskAssessment, error) {
+	if s.risk == nil {
+		return entity.RiskAssessment{Decision: entity.RiskAllow}, nil
+	}
+	return s.risk.Assess(ctx, entity.Transfer{
+		FromAccountID: t.AccountID,
+		Amount:        t.Amount,
+		Description:   t.Description,
+		Reference:     t.Reference,
+	})
+}
+
+// policy returns the approval policy of the account, false if there is
+// none.
+func (s *clearingService) policy(ctx context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, bool, error) {
+	if s.approvals == nil {
+		return entity.ApprovalPolicy{}, false, nil
+	}
+	p, err := s.approvals.GetPolicy(ctx, accountID)
+	if errors.Is(err, ErrNotFound) {
+		return entity.ApprovalPolicy{}, false, nil
+	}
+	return p, err == nil, err
+}
+
+func (s *clearingService) Get(ctx context.Context, id int64) (entity.ExternalTransfer, error) {
+	return s.db.Get(ctx, id)
+}
+
+func (s *clearingService) List(ctx context.Context, accountID uuid.UUID) ([]entity.ExternalTransfer, error) {
+	return s.db.List(ctx, accountID, maxExternalTransfers)
+}
+
+func (s *clearingService) ListBatches(ctx context.Context) ([]entity.ClearingBatch, error) {
+	return s.db.ListBatches(ctx, maxExternalTransfers)
+}
+
+func (s *clearingService) GetBatch(ctx context.Context, fileName string) (entity.ClearingBatch, error) {
+	return s.db.GetBatch(ctx, fileName)
+}
+
+// ListHeld returns the transfers waiting for the hold, for the review by
+// default.
+func (s *clearingService) ListHeld(ctx context.Context, hold entity.ExternalHold) ([]entity.ExternalTransfer, error) {
+	switch hold {
+	case "":
+		hold = entity.HoldReview
+	case entity.HoldApproval, entity.HoldReview:
+	default:
+		return nil, fmt.Errorf("%w: unknown hold %q", ErrInvalidArgument, hold)
+	}
+	return s.db.ListHeld(ctx, hold)
+}
+
+// Approve submits the transfer held for the approval on behalf of an
+// approver of the account other than its initiator. The risk and the
+// fees are of the approval time, the blocked transfer stays held for the
+// approval and the one assessed for review is held for the review.
+func (s *clearingService) Approve(ctx context.Context, approver string, id int64, comment string) (entity.ExternalTransfer, error) {
+	t, err := s.decide(ctx, approver, id, comment)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	a, err := s.assess(ctx, t)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	switch a.Decision {
+	case entity.RiskBlock:
+		return entity.ExternalTransfer{}, blockedError(a)
+	case entity.RiskReview:
+		return s.db.Hold(ctx, id, approver, comment, a)
+	}
+	return s.release(ctx, t, entity.HoldApproval, approver, comment, entity.AuditExternalApprove)
+}
+
+// Decline refunds the transfer held for the approval on behalf of an
+// approver of the account other than its initiator.
+func (s *clearingService) Decline(ctx context.Context, approver string, id int64, comment string) (entity.ExternalTransfer, error) {
+	t, err := s.decide(ctx, approver, id, comment)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	return s.reject(ctx, t, entity.HoldApproval, approver, comment)
+}
+
+// Release submits the transfer held for the review on behalf of the
+// operator, the fees are of the release time.
+func (s *clearingService) Release(ctx context.Context, operator string, id int64, comment string) (entity.ExternalTransfer, error) {
+	t, err := s.held(ctx, id, entity.HoldReview, comment)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	return s.release(ctx, t, entity.HoldReview, operator, comment, entity.AuditExternalRelease)
+}
+
+// Reject refunds the transfer held for the review on behalf of the
+// operator.
+func (s *clearingService) Reject(ctx context.Context, operator string, id int64, comment string) (entity.ExternalTransfer, error) {
+	t, err := s.held(ctx, id, entity.HoldReview, comment)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	return s.reject(ctx, t, entity.HoldReview, operator, comment)
+}
+
+// decide returns the transfer held for the approval if the approver can
+// decide on it.
+func (s *clearingService) decide(ctx context.Context, approver string, id int64,
+	comment string) (entity.ExternalTransfer, error) {
+	t, err := s.held(ctx, id, entity.HoldApproval, comment)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	if t.CreatedBy == approver {
+		return entity.ExternalTransfer{}, fmt.Errorf("%w: transfer can't be approved by its initiator", ErrAccessDenied)
+	}
+
+	p, ok, err := s.policy(ctx, t.AccountID)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	if ok {
+		for _, a := range p.Approvers {
+			if a == approver {
+				return t, nil
+			}
+		}
+	}
+	return entity.ExternalTransfer{}, ErrAccessDenied
+}
+
+// held returns the transfer if it waits for the hold.
+func (s *clearingService) held(ctx context.Context, id int64, hold entity.ExternalHold,
+	comment string) (entity.ExternalTransfer, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	t, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+	if t.Status != entity.ExternalHeld || t.Hold != hold {
+		return entity.ExternalTransfer{}, fmt.Errorf("%w: transfer %d is not held for %s", ErrInvalidTransition, id, hold)
+	}
+	return t, nil
+}
+
+// release submits the held transfer with the fees of the release time.
+func (s *clearingService) release(ctx context.Context, t entity.ExternalTransfer, hold entity.ExternalHold,
+	reviewer, comment, action string) (entity.ExternalTransfer, error) {
+	fees, err := s.fees.ExternalCharges(ctx, t)
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+	)
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, account, err = s.db.Release(ctx, t.ID, hold, reviewer, comment, fees); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, action, entity.AuditTargetExternalTransfer, strconv.FormatInt(t.ID, 10), t, result)
+	})
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	if len(result.Fees) > 0 {
+		s.publishBalance(account, result.Fees)
+	}
+	return result, nil
+}
+
+// reject refunds the held transfer.
+func (s *clearingService) reject(ctx context.Context, t entity.ExternalTransfer, hold entity.ExternalHold,
+	reviewer, comment string) (entity.ExternalTransfer, error) {
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+		entry   entity.Entry
+	)
+	err := s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if result, account, entry, err = s.db.Reject(ctx, t.ID, hold, reviewer, comment); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditExternalReject, entity.AuditTargetExternalTransfer,
+			strconv.FormatInt(t.ID, 10), t, result)
+	})
+	if err != nil {
+		return entity.ExternalTransfer{}, err
+	}
+
+	s.events.Publish(entity.NewEntryEvent(entry), entity.NewBalanceEvent(account))
+	return result, nil
+}
+
+// publishBalance publishes the fee entries and the balance of the account
+// after them.
+func (s *clearingService) publishBalance(account entity.Account, fees []entity.FeeCharge) {
+	for _, f := range fees {
+		s.events.Publish(entity.NewEntryEvent(f.Entry))
+	}
+	s.events.Publish(entity.NewBalanceEvent(account))
+}
+
+// SendBatches delivers the batches of the pending transfers to the
+// clearing house. The batches which failed to deliver are sent again by
+// the next call.
+func (s *clearingService) SendBatches(ctx context.Context) ([]entity.ClearingBatch, error) {
+	batches, err := s.db.CreateBatches(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for i, b := range batches {
+		if err = s.gateway.Send(ctx, b); err != nil {
+			return batches[:i], fmt.Errorf("send batch %s: %w", b.FileName, err)
+		}
+		sent, err := s.db.UpdateBatch(ctx, b.ID, entity.BatchSent)
+		if err != nil {
+			return batches[:i], err
+		}
+		batches[i].Status, batches[i].UpdatedAt = sent.Status, sent.UpdatedAt
+	}
+	return batches, nil
+}
+
+// ProcessResponses settles and returns the transfers by the responses of
+// the clearing house. The response is archived once all its results are
+// done, so the interrupted one is processed again and its done results
+// are skipped.
+func (s *clearingService) ProcessResponses(ctx context.Context) (entity.ClearingReport, error) {
+	var report entity.ClearingReport
+
+	responses, err := s.gateway.Receive(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	for _, r := range responses {
+		batch, err := s.db.GetBatch(ctx, r.FileName)
+		if errors.Is(err, ErrNotFound) {
+			s.l.Error(fmt.Errorf("response to batch %s: %w", r.FileName, err), "usecase - clearing - process responses")
+			continue
+		}
+		if err != nil {
+			return report, err
+		}
+
+		for _, v := range r.Results {
+			err = s.process(ctx, batch, v, &report)
+			if errors.Is(err, ErrInvalidTransition) || errors.Is(err, ErrInvalidArgument) {
+				s.l.Error(fmt.Errorf("transfer %d of %s: %w", v.TransferID, r.FileName, err),
+					"usecase - clearing - process responses")
+				report.Skipped++
+				continue
+			}
+			if err != nil {
+				return report, err
+			}
+		}
+
+		if batch.Status != entity.BatchProcessed {
+			if _, err = s.db.UpdateBatch(ctx, batch.ID, entity.BatchProcessed); err != nil {
+				return report, err
+			}
+		}
+		if err = s.gateway.Done(ctx, r); err != nil {
+			return report, err
+		}
+		report.Responses++
+	}
+
+	s.l.Info("usecase - clearing - processed %d responses: %d settled, %d returned, %d skipped",
+		report.Responses, report.Settled, report.Returned, report.Skipped)
+	return report, nil
+}
+
+func (s *clearingService) process(ctx context.Context, batch entity.ClearingBatch, v entity.ClearingResult,
+	report *entity.ClearingReport) error {
+	switch v.Status {
+	case entity.ExternalSettled:
+		if _, err := s.db.Settle(ctx, batch, v.TransferID); err != nil {
+			return err
+		}
+		report.Settled++
+	case entity.ExternalReturned:
+		if v.Reason == "" || len(v.Reason) > maxReturnReasonLength || !isReference(v.Reason) {
+			return fmt.Errorf("%w: invalid return reason %q", ErrInvalidArgument, v.Reason)
+		}
+		_, account, entry, err := s.db.Return(ctx, batch, v.TransferID, v.Reason)
+		if err != nil {
+			return err
+		}
+		s.events.Publish(entity.NewEntryEvent(entry), entity.NewBalanceEvent(account))
+		report.Returned++
+	default:
+		return fmt.Errorf("%w: unknown result status %q", ErrInvalidArgument, v.Status)
+	}
+	return nil
+}
+
+// checkExternalTransfer validates the normalized transfer.
+func checkExternalTransfer(t entity.ExternalTransfer) error {
+	if t.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidArgument)
+	}
+	if t.BeneficiaryName == "" || utf8.RuneCountInString(t.BeneficiaryName) > maxBeneficiaryNameLength ||
+		!isPrintable(t.BeneficiaryName) {
+		return fmt.Errorf("%w: beneficiary name must have 1 to %d printable characters",
+			ErrInvalidArgument, maxBeneficiaryNameLength)
+	}
+	if accnum.IsRoutingNumber(t.BeneficiaryBank) {
+		// the US domestic transfers go by the account number at the bank
+		// of the routing number
+		if !isDomesticAccount(t.BeneficiaryAccount) {
+			return fmt.Errorf("%w: beneficiary account must have 1 to %d letters or digits",
+				ErrInvalidArgument, maxDomesticAccountLength)
+		}
+	} else {
+		if err := accnum.Validate(t.BeneficiaryAccount); err != nil {
+			return fmt.Errorf("%w: beneficiary account: %v", ErrInvalidArgument, err)
+		}
+		if !accnum.IsBIC(t.BeneficiaryBank) {
+			return fmt.Errorf("%w: beneficiary bank must be a BIC of 8 or 11 characters or a routing number",
+				ErrInvalidArgument)
+		}
+	}
+	return checkDetails(entity.Transfer{
+		Description: t.Description,
+		Reference:   t.Reference,
+	})
+}
+
+// isDomesticAccount reports whether s is the account number of a US
+// bank, it fits the DFI account number of the NACHA entries.
+func isDomesticAccount(s string) bool {
+	if s == "" || len(s) > maxDomesticAccountLength {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
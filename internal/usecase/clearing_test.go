@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubClearingAccounts struct {
+	AccountRepo
+	accounts map[uuid.UUID]entity.Account
+}
+
+func (r *stubClearingAccounts) Get(_ context.Context, id uuid.UUID) (entity.Account, error) {
+	a, ok := r.accounts[id]
+	if !ok {
+		return entity.Account{}, ErrNotFound
+	}
+	return a, nil
+}
+
+type stubClearingRepo struct {
+	ClearingRepo
+	created  []entity.ExternalTransfer
+	batches  []entity.ClearingBatch
+	statuses map[int64]entity.ClearingBatchStatus
+	// transfers are the statuses of the transfers by id.
+	transfers map[int64]entity.ExternalTransferStatus
+}
+
+func (r *stubClearingRepo) Create(_ context.Context, t entity.ExternalTransfer,
+	fees []entity.FeeCharge) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	t.ID = int64(len(r.created) + 1)
+	t.Fees = fees
+	r.created = append(r.created, t)
+	return t, entity.Account{ID: t.AccountID}, entity.Entry{AccountID: t.AccountID, Amount: -t.Amount}, nil
+}
+
+func (r *stubClearingRepo) Get(_ context.Context, id int64) (entity.ExternalTransfer, error) {
+	if id < 1 || int(id) > len(r.created) {
+		return entity.ExternalTransfer{}, ErrNotFound
+	}
+	return r.created[id-1], nil
+}
+
+func (r *stubClearingRepo) Release(_ context.Context, id int64, _ entity.ExternalHold, reviewer, comment string,
+	_ []entity.FeeCharge) (entity.ExternalTransfer, entity.Account, error) {
+	t := &r.created[id-1]
+	t.Status, t.ReviewedBy, t.Comment = entity.ExternalPending, reviewer, comment
+	return *t, entity.Account{}, nil
+}
+
+func (r *stubClearingRepo) Hold(_ context.Context, id int64, approver, comment string,
+	a entity.RiskAssessment) (entity.ExternalTransfer, error) {
+	t := &r.created[id-1]
+	t.Hold, t.Score, t.Signals, t.ReviewedBy, t.Comment = entity.HoldReview, a.Score, a.Signals, approver, comment
+	return *t, nil
+}
+
+func (r *stubClearingRepo) Reject(_ context.Context, id int64, _ entity.ExternalHold, reviewer,
+	comment string) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	t := &r.created[id-1]
+	t.Status, t.ReviewedBy, t.Comment = entity.ExternalRejected, reviewer, comment
+	return *t, entity.Account{ID: t.AccountID}, entity.Entry{AccountID: t.AccountID, Amount: t.Amount}, nil
+}
+
+func (r *stubClearingRepo) CreateBatches(context.Context, time.Time) ([]entity.ClearingBatch, error) {
+	return append([]entity.ClearingBatch(nil), r.batches...), nil
+}
+
+func (r *stubClearingRepo) UpdateBatch(_ context.Context, id int64,
+	status entity.ClearingBatchStatus) (entity.ClearingBatch, error) {
+	r.statuses[id] = status
+	return entity.ClearingBatch{ID: id, Status: status}, nil
+}
+
+func (r *stubClearingRepo) GetBatch(_ context.Context, fileName string) (entity.ClearingBatch, error) {
+	for _, b := range r.batches {
+		if b.FileName == fileName {
+			b.Status = r.statuses[b.ID]
+			return b, nil
+		}
+	}
+	return entity.ClearingBatch{}, ErrNotFound
+}
+
+func (r *stubClearingRepo) Settle(_ context.Context, _ entity.ClearingBatch, id int64) (entity.ExternalTransfer, error) {
+	if r.transfers[id] != entity.ExternalSent {
+		return entity.ExternalTransfer{}, ErrInvalidTransition
+	}
+	r.transfers[id] = entity.ExternalSettled
+	return entity.ExternalTransfer{ID: id, Status: entity.ExternalSettled}, nil
+}
+
+func (r *stubClearingRepo) Return(_ context.Context, _ entity.ClearingBatch, id int64,
+	reason string) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	if r.transfers[id] != entity.ExternalSent {
+		return entity.ExternalTransfer{}, entity.Account{}, entity.Entry{}, ErrInvalidTransition
+	}
+	r.transfers[id] = entity.ExternalReturned
+	return entity.ExternalTransfer{ID: id, Status: entity.ExternalReturned, ReturnReason: reason},
+		entity.Account{}, entity.Entry{}, nil
+}
+
+type stubClearingGateway struct {
+	sent      []string
+	fail      string
+	responses []entity.ClearingResponse
+	done      []string
+}
+
+func (g *stubClearingGateway) Send(_ context.Context, b entity.ClearingBatch) error {
+	if b.FileName == g.fail {
+		return errors.New("connection refused")
+	}
+	g.sent = append(g.sent, b.FileName)
+	return nil
+}
+
+func (g *stubClearingGateway) Receive(context.Context) ([]entity.ClearingResponse, error) {
+	return g.responses, nil
+}
+
+func (g *stubClearingGateway) Done(_ context.Context, r entity.ClearingResponse) error {
+	g.done = append(g.done, r.FileName)
+	return nil
+}
+
+func TestClearingTransfer(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	id := uuid.New()
+	accounts := &stubClearingAccounts{accounts: map[uuid.UUID]entity.Account{id: {ID: id, Owner: "alice"}}}
+	repo := &stubClearingRepo{}
+	events := &stubPublisher{}
+	s := NewClearingService(repo, accounts, &stubClearingGateway{}, nil, nil, nil, nil, nil, nil, events, &logger)
+
+	valid := entity.ExternalTransfer{
+		AccountID:          id,
+		Amount:             10000,
+		BeneficiaryName:    " Max Mustermann ",
+		BeneficiaryAccount: "de89 3704 0044 0532 0130 00",
+		BeneficiaryBank:    "cobadeff",
+		Reference:          "INV-42",
+	}
+	res, err := s.Transfer(context.Background(), "alice", valid)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ExternalPending, res.Status)
+	assert.Equal(t, "Max Mustermann", res.BeneficiaryName)
+	assert.Equal(t, "DE89370400440532013000", res.BeneficiaryAccount)
+	assert.Equal(t, "COBADEFF", res.BeneficiaryBank)
+	assert.Equal(t, "alice", res.CreatedBy)
+	assert.Len(t, events.events, 2)
+
+	domestic := valid
+	domestic.BeneficiaryAccount, domestic.BeneficiaryBank = "000123456789", "021000021"
+	res, err = s.Transfer(context.Background(), "alice", domestic)
+	require.NoError(t, err)
+	assert.Equal(t, "021000021", res.BeneficiaryBank)
+
+	_, err = s.Transfer(context.Background(), "bob", valid)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+
+	tests := []struct {
+		name   string
+		modify func(t *entity.ExternalTransfer)
+	}{
+		{"zero amount", func(t *entity.ExternalTransfer) { t.Amount = 0 }},
+		{"no name", func(t *entity.ExternalTransfer) { t.BeneficiaryName = " " }},
+		{"wrong check digits", func(t *entity.ExternalTransfer) { t.BeneficiaryAccount = "DE88370400440532013000" }},
+		{"short BIC", func(t *entity.ExternalTransfer) { t.BeneficiaryBank = "COBADE" }},
+		{"BIC country digits", func(t *entity.ExternalTransfer) { t.BeneficiaryBank = "COBA12FF" }},
+		{"routing number checksum", func(t *entity.ExternalTransfer) {
+			t.BeneficiaryAccount, t.BeneficiaryBank = "000123456789", "021000022"
+		}},
+		{"long domestic account", func(t *entity.ExternalTransfer) {
+			t.BeneficiaryAccount, t.BeneficiaryBank = "123456789012345678", "021000021"
+		}},
+		{"invalid reference", func(t *entity.ExternalTransfer) { t.Reference = "INV 42" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valid
+			tt.modify(&v)
+			_, err := s.Transfer(context.Background(), "alice", v)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+	assert.Len(t, repo.created, 2)
+}
+
+func TestClearingTransferHold(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	id := uuid.New()
+	accounts := &stubClearingAccounts{accounts: map[uuid.UUID]entity.Account{id: {ID: id, Owner: "alice"}}}
+	engine := NewRiskEngine(stubRiskRepo{}, 10*time.Minute, 50, 100, NewPayeeRule{MinAmount: 1000, Score: 60})
+	transfer := entity.ExternalTransfer{
+		AccountID:          id,
+		BeneficiaryName:    "Max Mustermann",
+		BeneficiaryAccount: "DE89370400440532013000",
+		BeneficiaryBank:    "COBADEFF",
+	}
+
+	tests := []struct {
+		name      string
+		amount    int64
+		approvals ApprovalRepo
+		status    entity.ExternalTransferStatus
+		hold      entity.ExternalHold
+	}{
+		{name: "allowed", amount: 500, status: entity.ExternalPending},
+		{name: "held for review", amount: 2000, status: entity.ExternalHeld, hold: entity.HoldReview},
+		{name: "held for approval", amount: 2000, approvals: &stubApprovalRepo{}, status: entity.ExternalHeld,
+			hold: entity.HoldApproval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubClearingRepo{}
+			s := NewClearingService(repo, accounts, &stubClearingGateway{}, nil, engine, tt.approvals, nil, nil,
+				nil, &stubPublisher{}, &logger)
+
+			v := transfer
+			v.Amount = tt.amount
+			res, err := s.Transfer(context.Background(), "alice", v)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.Status)
+			assert.Equal(t, tt.hold, res.Hold)
+			require.Len(t, repo.created, 1)
+		})
+	}
+
+	s := NewClearingService(&stubClearingRepo{}, accounts, &stubClearingGateway{}, nil,
+		NewRiskEngine(stubRiskRepo{}, 10*time.Minute, 50, 60, NewPayeeRule{MinAmount: 1000, Score: 60}),
+		nil, nil, nil, nil, &stubPublisher{}, &logger)
+	v := transfer
+	v.Amount = 2000
+	_, err := s.Transfer(context.Background(), "alice", v)
+	assert.ErrorIs(t, err, ErrTransferBlocked)
+}
+
+func TestClearingDecide(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	id := uuid.New()
+	engine := NewRiskEngine(stubRiskRepo{}, 10*time.Minute, 50, 100, NewPayeeRule{MinAmount: 1000, Score: 60})
+	held := func(amount int64, hold entity.ExternalHold) *stubClearingRepo {
+		return &stubClearingRepo{created: []entity.ExternalTransfer{{
+			ID:        1,
+			AccountID: id,
+			Amount:    amount,
+			Status:    entity.ExternalHeld,
+			Hold:      hold,
+			CreatedBy: "initiator",
+		}}}
+	}
+
+	repo := held(500, entity.HoldApproval)
+	audits := &stubAuditRepo{}
+	s := NewClearingService(repo, nil, nil, nil, engine, &stubApprovalRepo{}, nil, nil,
+		NewAuditor(audits, stubTransactor{}), &stubPublisher{}, &logger)
+
+	_, err := s.Approve(context.Background(), "initiator", 1, "")
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	_, err = s.Approve(context.Background(), "stranger", 1, "")
+	assert.ErrorIs(t, err, ErrAccessDenied)
+	_, err = s.Release(context.Background(), "operator", 1, "")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	res, err := s.Approve(context.Background(), "approver", 1, "ok")
+	require.NoError(t, err)
+	assert.Equal(t, entity.ExternalPending, res.Status)
+	require.Len(t, audits.records, 1)
+	assert.Equal(t, entity.AuditExternalApprove, audits.records[0].Action)
+	_, err = s.Decline(context.Background(), "approver", 1, "")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	repo = held(2000, entity.HoldApproval)
+	s = NewClearingService(repo, nil, nil, nil, engine, &stubApprovalRepo{}, nil, nil, nil, &stubPublisher{}, &logger)
+	res, err = s.Approve(context.Background(), "approver", 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, entity.ExternalHeld, res.Status)
+	assert.Equal(t, entity.HoldReview, res.Hold)
+
+	res, err = s.Reject(context.Background(), "operator", 1, "fraud")
+	require.NoError(t, err)
+	assert.Equal(t, entity.ExternalRejected, res.Status)
+	assert.Equal(t, "operator", res.ReviewedBy)
+}
+
+func TestClearingSendBatches(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubClearingRepo{
+		batches: []entity.ClearingBatch{
+			{ID: 1, FileName: "CLR-1", Status: entity.BatchCreated},
+			{ID: 2, FileName: "CLR-2", Status: entity.BatchCreated},
+		},
+		statuses: map[int64]entity.ClearingBatchStatus{},
+	}
+	gateway := &stubClearingGateway{fail: "CLR-2"}
+	s := NewClearingService(repo, nil, gateway, nil, nil, nil, nil, nil, nil, &stubPublisher{}, &logger)
+
+	batches, err := s.SendBatches(context.Background())
+	require.Error(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, entity.BatchSent, batches[0].Status)
+	assert.Equal(t, map[int64]entity.ClearingBatchStatus{1: entity.BatchSent}, repo.statuses)
+
+	gateway.fail = ""
+	batches, err = s.SendBatches(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, []string{"CLR-1", "CLR-1", "CLR-2"}, gateway.sent)
+}
+
+func TestClearingProcessResponses(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubClearingRepo{
+		batches:  []entity.ClearingBatch{{ID: 1, FileName: "CLR-1"}},
+		statuses: map[int64]entity.ClearingBatchStatus{1: entity.BatchSent},
+		transfers: map[int64]entity.ExternalTransferStatus{
+			1: entity.ExternalSent,
+			2: entity.ExternalSent,
+			3: entity.ExternalSent,
+		},
+	}
+	response := entity.ClearingResponse{
+		FileName: "CLR-1",
+		Results: []entity.ClearingResult{
+			{TransferID: 1, Status: entity.ExternalSettled},
+			{TransferID: 2, Status: entity.ExternalReturned, Reason: entity.ReturnClosedAccount},
+			{TransferID: 3, Status: entity.ExternalReturned},
+			{TransferID: 1, Status: entity.ExternalReturned, Reason: entity.ReturnInvalidAccount},
+		},
+	}
+	gateway := &stubClearingGateway{responses: []entity.ClearingResponse{
+		response,
+		{FileName: "CLR-unknown"},
+	}}
+	events := &stubPublisher{}
+	s := NewClearingService(repo, nil, gateway, nil, nil, nil, nil, nil, nil, events, &logger)
+
+	report, err := s.ProcessResponses(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.ClearingReport{Responses: 1, Settled: 1, Returned: 1, Skipped: 2}, report)
+	assert.Equal(t, entity.ExternalSent, repo.transfers[3])
+	assert.Equal(t, entity.BatchProcessed, repo.statuses[1])
+	assert.Equal(t, []string{"CLR-1"}, gateway.done)
+	assert.Len(t, events.events, 2)
+
+	gateway.responses = []entity.ClearingResponse{response}
+	report, err = s.ProcessResponses(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.ClearingReport{Responses: 1, Skipped: 4}, report)
+}
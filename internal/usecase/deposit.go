@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxDeposits is the limit of the listed deposits of an account.
+	maxDeposits          = 100
+	maxDepositTermMonths = 120
+)
+
+var (
+	ErrDepositLocked = errors.New("deposit can't be withdrawn before maturity")
+	ErrDepositClosed = errors.New("deposit is already closed")
+)
+
+type depositService struct {
+	db     DepositRepo
+	events EventPublisher
+	l      zerologx.Logger
+}
+
+func NewDepositService(r DepositRepo, p EventPublisher, l zerologx.Logger) DepositService {
+	return &depositService{
+		db:     r,
+		events: p,
+		l:      l,
+	}
+}
+
+// Open takes the principal from the account today for the term. The
+// deposit is paid out to the account itself if no payout account is set.
+func (s *depositService) Open(ctx context.Context, operator string, d entity.TermDeposit) (entity.TermDeposit, error) {
+	if d.PayoutAccountID == uuid.Nil {
+		d.PayoutAccountID = d.AccountID
+	}
+	if d.EarlyWithdrawal == "" {
+		d.EarlyWithdrawal = entity.EarlyWithdrawalBlocked
+	}
+	if err := checkDeposit(d); err != nil {
+		return entity.TermDeposit{}, err
+	}
+
+	d.StartDate = truncateDay(time.Now())
+	d.MaturityDate = entity.DepositMaturity(d.StartDate, d.TermMonths)
+	d.CreatedBy = operator
+	result, account, entry, err := s.db.Create(ctx, d)
+	if err != nil {
+		return entity.TermDeposit{}, err
+	}
+
+	s.events.Publish(entity.NewEntryEvent(entry), entity.NewBalanceEvent(account))
+	s.l.Info("usecase - deposit - opened deposit %d of %d from %s until %s", result.ID, result.Principal,
+		result.AccountID, result.MaturityDate.Format("2006-01-02"))
+	return result, nil
+}
+
+func (s *depositService) Get(ctx context.Context, id int64) (entity.TermDeposit, error) {
+	return s.db.Get(ctx, id)
+}
+
+func (s *depositService) List(ctx context.Context, accountID uuid.UUID) ([]entity.TermDeposit, error) {
+	return s.db.List(ctx, accountID, maxDeposits)
+}
+
+// Withdraw pays out the deposit before its maturity with the interest of
+// the elapsed days at the rate less the penalty. The deposits blocked
+// until the maturity fail with ErrDepositLocked. The matured deposit not
+// paid out by the maturity job yet is paid out with the whole interest.
+func (s *depositService) Withdraw(ctx context.Context, id int64) (entity.TermDeposit, error) {
+	d, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.TermDeposit{}, err
+	}
+	if d.Status != entity.DepositActive {
+		return entity.TermDeposit{}, ErrDepositClosed
+	}
+
+	today := truncateDay(time.Now())
+	status := entity.DepositWithdrawn
+	switch {
+	case !today.Before(d.MaturityDate):
+		status = entity.DepositMatured
+	case d.EarlyWithdrawal == entity.EarlyWithdrawalBlocked:
+		return entity.TermDeposit{}, fmt.Errorf("%w: deposit %d matures on %s", ErrDepositLocked, d.ID,
+			d.MaturityDate.Format("2006-01-02"))
+	}
+
+	result, account, entries, err := s.db.Close(ctx, d.ID, status, d.Interest(today))
+	if errors.Is(err, ErrInvalidTransition) {
+		return entity.TermDeposit{}, ErrDepositClosed
+	}
+	if err != nil {
+		return entity.TermDeposit{}, err
+	}
+
+	s.publish(account, entries)
+	s.l.Info("usecase - deposit - withdrew deposit %d to %s", result.ID, result.PayoutAccountID)
+	return result, nil
+}
+
+// Mature handles the deposits maturing on or before the date. The payout
+// deposits are paid out with the interest of the term to their payout
+// accounts, the rollover ones add it to the principal and start the next
+// terms until one of them ends after the date. The deposits handled by
+// the previous runs are not matured again, so the run can be repeated.
+func (s *depositService) Mature(ctx context.Context, date time.Time) (entity.BatchRun, error) {
+	date = truncateDay(date)
+	if date.After(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not started yet", ErrInvalidArgument, date.Format("2006-01-02"))
+	}
+
+	deposits, err := s.db.Matured(ctx, date)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: date}
+	for _, d := range deposits {
+		total, err := s.mature(ctx, d, date)
+		switch {
+		case errors.Is(err, ErrInvalidTransition):
+			run.Skipped++
+		case err != nil:
+			s.l.Error(fmt.Errorf("mature deposit %d: %w", d.ID, err), "usecase - deposit - mature")
+			run.Failed++
+		default:
+			run.Processed++
+			run.Total += total
+		}
+	}
+
+	s.l.Info("usecase - deposit - matured deposits due %s: %d matured, %d skipped, %d failed",
+		date.Format("2006-01-02"), run.Processed, run.Skipped, run.Failed)
+	return run, nil
+}
+
+// mature pays out or rolls over the deposit and returns the posted amount.
+func (s *depositService) mature(ctx context.Context, d entity.TermDeposit, date time.Time) (int64, error) {
+	if d.MaturityAction == entity.MaturityPayout {
+		interest := d.Interest(d.MaturityDate)
+		result, account, entries, err := s.db.Close(ctx, d.ID, entity.DepositMatured, interest)
+		if err != nil {
+			return 0, err
+		}
+		s.publish(account, entries)
+		s.l.Info("usecase - deposit - paid out deposit %d to %s", result.ID, result.PayoutAccountID)
+		return result.Principal + interest, nil
+	}
+
+	var total int64
+	for !d.MaturityDate.After(date) {
+		interest := d.Interest(d.MaturityDate)
+		next := entity.DepositMaturity(d.MaturityDate, d.TermMonths)
+		v, err := s.db.RollOver(ctx, d.ID, d.MaturityDate, next, interest)
+		if err != nil {
+			if total > 0 && errors.Is(err, ErrInvalidTransition) {
+				break
+			}
+			return total, err
+		}
+		d, total = v, total+interest
+	}
+	s.l.Info("usecase - deposit - rolled over deposit %d until %s", d.ID, d.MaturityDate.Format("2006-01-02"))
+	return total, nil
+}
+
+func (s *depositService) publish(account entity.Account, entries []entity.Entry) {
+	events := make([]entity.AccountEvent, 0, len(entries)+1)
+	for _, e := range entries {
+		events = append(events, entity.NewEntryEvent(e))
+	}
+	s.events.Publish(append(events, entity.NewBalanceEvent(account))...)
+}
+
+func checkDeposit(d entity.TermDeposit) error {
+	if d.Principal <= 0 {
+		return fmt.Errorf("%w: principal must be positive", ErrInvalidArgument)
+	}
+	if d.AnnualRateBP < 0 || d.AnnualRateBP > 10000 || d.EarlyPenaltyBP < 0 || d.EarlyPenaltyBP > 10000 {
+		return fmt.Errorf("%w: rates must be from 0 to 10000 bp", ErrInvalidArgument)
+	}
+	if d.TermMonths < 1 || d.TermMonths > maxDepositTermMonths {
+		return fmt.Errorf("%w: term must be from 1 to %d months", ErrInvalidArgument, maxDepositTermMonths)
+	}
+	if !d.MaturityAction.Valid() {
+		return fmt.Errorf("%w: unknown maturity action %q", ErrInvalidArgument, d.MaturityAction)
+	}
+	if !d.EarlyWithdrawal.Valid() {
+		return fmt.Errorf("%w: unknown early withdrawal %q", ErrInvalidArgument, d.EarlyWithdrawal)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDepositRepo struct {
+	DepositRepo
+	deposits map[int64]*entity.TermDeposit
+	// balances are the balances of the customer accounts.
+	balances map[uuid.UUID]int64
+}
+
+func (r *stubDepositRepo) Create(_ context.Context, d entity.TermDeposit) (entity.TermDeposit, entity.Account,
+	entity.Entry, error) {
+	if r.balances[d.AccountID] < d.Principal {
+		return entity.TermDeposit{}, entity.Account{}, entity.Entry{}, ErrInsufficientFunds
+	}
+	d.ID = int64(len(r.deposits) + 1)
+	d.Status = entity.DepositActive
+	r.deposits[d.ID] = &d
+	r.balances[d.AccountID] -= d.Principal
+	return d, entity.Account{ID: d.AccountID, Balance: r.balances[d.AccountID]},
+		entity.Entry{AccountID: d.AccountID, Amount: -d.Principal}, nil
+}
+
+func (r *stubDepositRepo) Get(_ context.Context, id int64) (entity.TermDeposit, error) {
+	d, ok := r.deposits[id]
+	if !ok {
+		return entity.TermDeposit{}, ErrNotFound
+	}
+	return *d, nil
+}
+
+func (r *stubDepositRepo) Matured(_ context.Context, date time.Time) ([]entity.TermDeposit, error) {
+	var result []entity.TermDeposit
+	for id := int64(1); id <= int64(len(r.deposits)); id++ {
+		if d := r.deposits[id]; d.Status == entity.DepositActive && !d.MaturityDate.After(date) {
+			result = append(result, *d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDepositRepo) Close(_ context.Context, id int64, status entity.DepositStatus,
+	interest int64) (entity.TermDeposit, entity.Account, []entity.Entry, error) {
+	d := r.deposits[id]
+	if d.Status != entity.DepositActive {
+		return entity.TermDeposit{}, entity.Account{}, nil, ErrInvalidTransition
+	}
+	now := time.Now()
+	d.Status, d.InterestPaid, d.ClosedAt = status, d.InterestPaid+interest, &now
+	r.balances[d.PayoutAccountID] += d.Principal + interest
+	return *d, entity.Account{ID: d.PayoutAccountID, Balance: r.balances[d.PayoutAccountID]},
+		[]entity.Entry{{AccountID: d.PayoutAccountID, Amount: d.Principal + interest}}, nil
+}
+
+func (r *stubDepositRepo) RollOver(_ context.Context, id int64, maturity, next time.Time,
+	interest int64) (entity.TermDeposit, error) {
+	d := r.deposits[id]
+	if d.Status != entity.DepositActive || !d.MaturityDate.Equal(maturity) {
+		return entity.TermDeposit{}, ErrInvalidTransition
+	}
+	d.Principal, d.InterestPaid = d.Principal+interest, d.InterestPaid+interest
+	d.StartDate, d.MaturityDate = maturity, next
+	d.Rollovers++
+	return *d, nil
+}
+
+func TestDepositOpen(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := uuid.New()
+	repo := &stubDepositRepo{
+		deposits: map[int64]*entity.TermDeposit{},
+		balances: map[uuid.UUID]int64{account: 1000},
+	}
+	events := &stubPublisher{}
+	s := NewDepositService(repo, events, &logger)
+
+	valid := entity.TermDeposit{
+		AccountID:      account,
+		Principal:      1000,
+		AnnualRateBP:   500,
+		TermMonths:     12,
+		MaturityAction: entity.MaturityPayout,
+	}
+	deposit, err := s.Open(context.Background(), "admin", valid)
+	require.NoError(t, err)
+	today := truncateDay(time.Now())
+	assert.Equal(t, today, deposit.StartDate)
+	assert.Equal(t, entity.DepositMaturity(today, 12), deposit.MaturityDate)
+	// the deposit is paid out to its account and blocked by default
+	assert.Equal(t, account, deposit.PayoutAccountID)
+	assert.Equal(t, entity.EarlyWithdrawalBlocked, deposit.EarlyWithdrawal)
+	assert.Len(t, events.events, 2)
+
+	_, err = s.Open(context.Background(), "admin", valid)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	tests := []struct {
+		name   string
+		modify func(d *entity.TermDeposit)
+	}{
+		{"zero principal", func(d *entity.TermDeposit) { d.Principal = 0 }},
+		{"negative rate", func(d *entity.TermDeposit) { d.AnnualRateBP = -1 }},
+		{"penalty above 100%", func(d *entity.TermDeposit) { d.EarlyPenaltyBP = 10001 }},
+		{"no term", func(d *entity.TermDeposit) { d.TermMonths = 0 }},
+		{"long term", func(d *entity.TermDeposit) { d.TermMonths = maxDepositTermMonths + 1 }},
+		{"unknown maturity action", func(d *entity.TermDeposit) { d.MaturityAction = "renew" }},
+		{"unknown early withdrawal", func(d *entity.TermDeposit) { d.EarlyWithdrawal = "free" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valid
+			tt.modify(&v)
+			_, err := s.Open(context.Background(), "admin", v)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+	assert.Len(t, repo.deposits, 1)
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account, payout := uuid.New(), uuid.New()
+	today := truncateDay(time.Now())
+	repo := &stubDepositRepo{
+		deposits: map[int64]*entity.TermDeposit{
+			1: {
+				ID:              1,
+				AccountID:       account,
+				PayoutAccountID: payout,
+				Principal:       36500,
+				AnnualRateBP:    1000,
+				StartDate:       today.AddDate(0, 0, -10),
+				MaturityDate:    today.AddDate(0, 0, 20),
+				EarlyWithdrawal: entity.EarlyWithdrawalBlocked,
+				Status:          entity.DepositActive,
+			},
+			2: {
+				ID:              2,
+				AccountID:       account,
+				PayoutAccountID: payout,
+				Principal:       36500,
+				AnnualRateBP:    1000,
+				StartDate:       today.AddDate(0, 0, -10),
+				MaturityDate:    today.AddDate(0, 0, 20),
+				EarlyWithdrawal: entity.EarlyWithdrawalPenalty,
+				EarlyPenaltyBP:  400,
+				Status:          entity.DepositActive,
+			},
+		},
+		balances: map[uuid.UUID]int64{},
+	}
+	events := &stubPublisher{}
+	s := NewDepositService(repo, events, &logger)
+
+	_, err := s.Withdraw(context.Background(), 1)
+	require.ErrorIs(t, err, ErrDepositLocked)
+
+	// 10 days of 6% a year
+	deposit, err := s.Withdraw(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DepositWithdrawn, deposit.Status)
+	assert.Equal(t, int64(60), deposit.InterestPaid)
+	assert.Equal(t, int64(36560), repo.balances[payout])
+	assert.Len(t, events.events, 2)
+
+	_, err = s.Withdraw(context.Background(), 2)
+	require.ErrorIs(t, err, ErrDepositClosed)
+
+	_, err = s.Withdraw(context.Background(), 3)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDepositMature(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := uuid.New()
+	start := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	maturity := entity.DepositMaturity(start, 1)
+	repo := &stubDepositRepo{
+		deposits: map[int64]*entity.TermDeposit{
+			1: {
+				ID:              1,
+				AccountID:       account,
+				PayoutAccountID: account,
+				Principal:       36500,
+				AnnualRateBP:    1000,
+				TermMonths:      1,
+				StartDate:       start,
+				MaturityDate:    maturity,
+				MaturityAction:  entity.MaturityPayout,
+				Status:          entity.DepositActive,
+			},
+			2: {
+				ID:              2,
+				AccountID:       account,
+				PayoutAccountID: account,
+				Principal:       36500,
+				AnnualRateBP:    1000,
+				TermMonths:      1,
+				StartDate:       start,
+				MaturityDate:    maturity,
+				MaturityAction:  entity.MaturityRollover,
+				Status:          entity.DepositActive,
+			},
+		},
+		balances: map[uuid.UUID]int64{},
+	}
+	events := &stubPublisher{}
+	s := NewDepositService(repo, events, &logger)
+
+	_, err := s.Mature(context.Background(), time.Now().AddDate(0, 0, 2))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	run, err := s.Mature(context.Background(), maturity.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchRun{Date: maturity.AddDate(0, 0, -1)}, run)
+
+	// 28 days of 10% a year, the payout deposit is paid out with the
+	// interest and the rollover one adds it to the principal
+	run, err = s.Mature(context.Background(), maturity)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Processed)
+	assert.Equal(t, int64(36500+280+280), run.Total)
+	assert.Equal(t, int64(36780), repo.balances[account])
+	assert.Len(t, events.events, 2)
+	assert.Equal(t, entity.DepositMatured, repo.deposits[1].Status)
+	rolled := repo.deposits[2]
+	assert.Equal(t, int64(36780), rolled.Principal)
+	assert.Equal(t, maturity, rolled.StartDate)
+	assert.Equal(t, time.Date(2023, 3, 28, 0, 0, 0, 0, time.UTC), rolled.MaturityDate)
+
+	// the repeated run finds nothing to mature
+	run, err = s.Mature(context.Background(), maturity)
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchRun{Date: maturity}, run)
+
+	// the missed terms are rolled over by one run
+	run, err = s.Mature(context.Background(), time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, run.Processed)
+	assert.Equal(t, int32(3), rolled.Rollovers)
+	assert.Equal(t, time.Date(2023, 5, 28, 0, 0, 0, 0, time.UTC), rolled.MaturityDate)
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEODRunning = errors.New("EOD run is already in progress")
+	ErrEODFailed  = errors.New("EOD run failed")
+	ErrUnbalanced = errors.New("trial balance is not balanced")
+)
+
+const (
+	maxEODRuns         = 100
+	maxDailyStatements = 100
+)
+
+type eodService struct {
+	db       EODRepo
+	balances BalanceService
+	interest InterestService
+	fees     FeeService
+	loans    LoanService
+	deposits DepositService
+	ledger   LedgerService
+	// cutoff is the time after the end of the business date its run is
+	// due at.
+	cutoff time.Duration
+	l      zerologx.Logger
+}
+
+func NewEODService(r EODRepo, bs BalanceService, is InterestService, fs FeeService, lns LoanService,
+	ds DepositService, ls LedgerService, cutoff time.Duration, l zerologx.Logger) EODService {
+	return &eodService{
+		db:       r,
+		balances: bs,
+		interest: is,
+		fees:     fs,
+		loans:    lns,
+		deposits: ds,
+		ledger:   ls,
+		cutoff:   cutoff,
+		l:        l,
+	}
+}
+
+func (s *eodService) BusinessDate(ctx context.Context) (entity.BusinessDate, error) {
+	return s.db.BusinessDate(ctx)
+}
+
+// Run closes the business date if it has ended. The steps completed by
+// the previous runs of the date are skipped, a failed step stops the run
+// with ErrEODFailed, so it is resumed from the step by the next run. The
+// step with failed items of its batch fails too, its processed items are
+// skipped by the retry.
+func (s *eodService) Run(ctx context.Context, operator string) (entity.EODRun, error) {
+	unlock, err := s.db.Lock(ctx)
+	if err != nil {
+		return entity.EODRun{}, err
+	}
+	defer unlock()
+
+	bd, err := s.db.BusinessDate(ctx)
+	if err != nil {
+		return entity.EODRun{}, err
+	}
+	if !bd.Date.Before(truncateDay(time.Now())) {
+		return entity.EODRun{}, fmt.Errorf("%w: business date %s has not ended yet",
+			ErrInvalidArgument, bd.Date.Format("2006-01-02"))
+	}
+	return s.run(ctx, operator, bd.Date)
+}
+
+// RunDue closes the business dates until the one which isn't due yet. It
+// stops at the first failed run.
+func (s *eodService) RunDue(ctx context.Context, operator string) ([]entity.EODRun, error) {
+	var result []entity.EODRun
+	for {
+		bd, err := s.db.BusinessDate(ctx)
+		if err != nil {
+			return result, err
+		}
+		if time.Now().Before(bd.Date.AddDate(0, 0, 1).Add(s.cutoff)) {
+			return result, nil
+		}
+
+		run, err := s.Run(ctx, operator)
+		if run.ID != 0 {
+			result = append(result, run)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+}
+
+func (s *eodService) run(ctx context.Context, operator string, date time.Time) (entity.EODRun, error) {
+	run, err := s.db.Start(ctx, date, operator)
+	if err != nil {
+		return entity.EODRun{}, err
+	}
+
+	for _, step := range entity.EODSteps {
+		if run.Completed(step) {
+			continue
+		}
+		if err = s.db.StartStep(ctx, run.ID, step); err != nil {
+			return entity.EODRun{}, err
+		}
+
+		result, stepErr := s.step(ctx, step, date)
+		if stepErr == nil && result.Failed > 0 {
+			stepErr = fmt.Errorf("%d items failed", result.Failed)
+		}
+		if stepErr != nil {
+			s.l.Error(fmt.Errorf("EOD %s step %s: %w", date.Format("2006-01-02"), step, stepErr), "usecase - eod - run")
+			if err = s.db.FinishStep(ctx, run.ID, step, entity.EODFailed, result, stepErr.Error()); err != nil {
+				return entity.EODRun{}, err
+			}
+			if err = s.db.Finish(ctx, run.ID, entity.EODFailed, fmt.Sprintf("%s: %v", step, stepErr)); err != nil {
+				return entity.EODRun{}, err
+			}
+			if run, err = s.db.Get(ctx, date); err != nil {
+				return entity.EODRun{}, err
+			}
+			return run, fmt.Errorf("%w: %s: %v", ErrEODFailed, step, stepErr)
+		}
+
+		if err = s.db.FinishStep(ctx, run.ID, step, entity.EODCompleted, result, ""); err != nil {
+			return entity.EODRun{}, err
+		}
+	}
+
+	if err = s.db.Finish(ctx, run.ID, entity.EODCompleted, ""); err != nil {
+		return entity.EODRun{}, err
+	}
+	return s.db.Get(ctx, date)
+}
+
+// step runs the step of the date. The steps of the month run on its last
+// or first date only, the other dates return an empty batch run.
+func (s *eodService) step(ctx context.Context, step entity.EODStep, date time.Time) (entity.BatchRun, error) {
+	switch step {
+	case entity.EODFreeze:
+		return entity.BatchRun{Date: date}, s.db.Freeze(ctx, date)
+	case entity.EODSnapshot:
+		return s.balances.Snapshot(ctx, date)
+	case entity.EODInterestAccrual:
+		return s.interest.Accrue(ctx, date)
+	case entity.EODInterestPosting:
+		if date.AddDate(0, 0, 1).Day() != 1 {
+			return entity.BatchRun{Date: date}, nil
+		}
+		return s.interest.Post(ctx, date)
+	case entity.EODFees:
+		if date.Day() != 1 {
+			return entity.BatchRun{Date: date}, nil
+		}
+		return s.fees.ChargeMaintenance(ctx, date)
+	case entity.EODLoanRepayment:
+		return s.loans.Repay(ctx, date)
+	case entity.EODDepositMaturity:
+		return s.deposits.Mature(ctx, date)
+	case entity.EODReconciliation:
+		return s.reconcile(ctx, date)
+	case entity.EODStatements:
+		n, err := s.db.CreateStatements(ctx, date)
+		return entity.BatchRun{Date: date, Processed: int(n)}, err
+	case entity.EODAdvance:
+		return entity.BatchRun{Date: date}, s.db.Advance(ctx, date)
+	}
+	return entity.BatchRun{}, fmt.Errorf("unknown EOD step %q", step)
+}
+
+// reconcile checks the trial balance of each currency. The unbalanced
+// currencies are counted as failed and fail the step.
+func (s *eodService) reconcile(ctx context.Context, date time.Time) (entity.BatchRun, error) {
+	balances, err := s.ledger.TrialBalance(ctx)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: date}
+	for _, b := range balances {
+		if b.Balanced {
+			run.Processed++
+			continue
+		}
+		run.Failed++
+		err = fmt.Errorf("%w: %s debits %d, credits %d", ErrUnbalanced, b.Currency, b.TotalDebit, b.TotalCredit)
+	}
+	return run, err
+}
+
+// Get returns the run of the business date with its steps.
+func (s *eodService) Get(ctx context.Context, date time.Time) (entity.EODRun, error) {
+	return s.db.Get(ctx, truncateDay(date))
+}
+
+func (s *eodService) List(ctx context.Context) ([]entity.EODRun, error) {
+	return s.db.List(ctx, maxEODRuns)
+}
+
+// Statements returns the latest daily statements of the account.
+func (s *eodService) Statements(ctx context.Context, accountID uuid.UUID) ([]entity.DailyStatement, error) {
+	return s.db.Statements(ctx, accountID, maxDailyStatements)
+}
@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEODRepo struct {
+	EODRepo
+	date   entity.BusinessDate
+	run    entity.EODRun
+	locked bool
+}
+
+func (r *stubEODRepo) Lock(context.Context) (func(), error) {
+	if r.locked {
+		return nil, ErrEODRunning
+	}
+	r.locked = true
+	return func() { r.locked = false }, nil
+}
+
+func (r *stubEODRepo) BusinessDate(context.Context) (entity.BusinessDate, error) {
+	return r.date, nil
+}
+
+func (r *stubEODRepo) Freeze(context.Context, time.Time) error {
+	r.date.Frozen = true
+	return nil
+}
+
+func (r *stubEODRepo) Advance(_ context.Context, date time.Time) error {
+	if r.date.Date.Equal(date) {
+		r.date = entity.BusinessDate{Date: date.AddDate(0, 0, 1)}
+	}
+	return nil
+}
+
+func (r *stubEODRepo) Start(_ context.Context, date time.Time, operator string) (entity.EODRun, error) {
+	if !r.run.BusinessDate.Equal(date) {
+		r.run = entity.EODRun{ID: r.run.ID + 1, BusinessDate: date}
+	}
+	r.run.Status, r.run.StartedBy = entity.EODRunning, operator
+	return r.run, nil
+}
+
+func (r *stubEODRepo) Finish(_ context.Context, _ int64, status entity.EODStatus, msg string) error {
+	r.run.Status, r.run.Error = status, msg
+	return nil
+}
+
+func (r *stubEODRepo) StartStep(_ context.Context, _ int64, step entity.EODStep) error {
+	for i, v := range r.run.Steps {
+		if v.Step == step {
+			r.run.Steps[i].Status = entity.EODRunning
+			return nil
+		}
+	}
+	r.run.Steps = append(r.run.Steps, entity.EODStepRun{Step: step, Status: entity.EODRunning})
+	return nil
+}
+
+func (r *stubEODRepo) FinishStep(_ context.Context, _ int64, step entity.EODStep, status entity.EODStatus,
+	result entity.BatchRun, msg string) error {
+	for i, v := range r.run.Steps {
+		if v.Step == step {
+			r.run.Steps[i].Status, r.run.Steps[i].Result, r.run.Steps[i].Error = status, result, msg
+		}
+	}
+	return nil
+}
+
+func (r *stubEODRepo) Get(context.Context, time.Time) (entity.EODRun, error) {
+	return r.run, nil
+}
+
+func (r *stubEODRepo) CreateStatements(context.Context, time.Time) (int64, error) {
+	return 2, nil
+}
+
+type stubEODBalances struct {
+	BalanceService
+	dates []time.Time
+}
+
+func (s *stubEODBalances) Snapshot(_ context.Context, date time.Time) (entity.BatchRun, error) {
+	s.dates = append(s.dates, date)
+	return entity.BatchRun{Date: date, Processed: 2}, nil
+}
+
+type stubEODInterest struct {
+	InterestService
+	posted []time.Time
+	failed int
+}
+
+func (s *stubEODInterest) Accrue(_ context.Context, date time.Time) (entity.BatchRun, error) {
+	return entity.BatchRun{Date: date}, nil
+}
+
+func (s *stubEODInterest) Post(_ context.Context, period time.Time) (entity.BatchRun, error) {
+	s.posted = append(s.posted, period)
+	return entity.BatchRun{Date: period, Failed: s.failed}, nil
+}
+
+type stubEODFees struct {
+	FeeService
+	charged []time.Time
+}
+
+func (s *stubEODFees) ChargeMaintenance(_ context.Context, period time.Time) (entity.BatchRun, error) {
+	s.charged = append(s.charged, period)
+	return entity.BatchRun{Date: period}, nil
+}
+
+type stubEODLoans struct {
+	LoanService
+	repaid []time.Time
+}
+
+func (s *stubEODLoans) Repay(_ context.Context, date time.Time) (entity.BatchRun, error) {
+	s.repaid = append(s.repaid, date)
+	return entity.BatchRun{Date: date}, nil
+}
+
+type stubEODDeposits struct {
+	DepositService
+	matured []time.Time
+}
+
+func (s *stubEODDeposits) Mature(_ context.Context, date time.Time) (entity.BatchRun, error) {
+	s.matured = append(s.matured, date)
+	return entity.BatchRun{Date: date}, nil
+}
+
+type stubEODLedger struct {
+	LedgerService
+	balanced bool
+}
+
+func (s *stubEODLedger) TrialBalance(context.Context) ([]entity.TrialBalance, error) {
+	return []entity.TrialBalance{{Currency: entity.CurrencyRUB, TotalDebit: 10, TotalCredit: 10, Balanced: s.balanced}}, nil
+}
+
+func TestEODRun(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	month := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	repo := &stubEODRepo{date: entity.BusinessDate{Date: month}}
+	balances := &stubEODBalances{}
+	interest := &stubEODInterest{}
+	fees := &stubEODFees{}
+	loans := &stubEODLoans{}
+	deposits := &stubEODDeposits{}
+	ledger := &stubEODLedger{}
+	s := NewEODService(repo, balances, interest, fees, loans, deposits, ledger, 0, &logger)
+
+	// the unbalanced ledger fails the run before the date is advanced
+	run, err := s.Run(context.Background(), "admin")
+	require.ErrorIs(t, err, ErrEODFailed)
+	assert.Equal(t, entity.EODFailed, run.Status)
+	assert.True(t, repo.date.Frozen)
+	assert.False(t, run.Completed(entity.EODReconciliation))
+	assert.Equal(t, month, repo.date.Date)
+	assert.False(t, repo.locked)
+
+	// the next run resumes from the failed step
+	ledger.balanced = true
+	run, err = s.Run(context.Background(), "admin")
+	require.NoError(t, err)
+	assert.Equal(t, entity.EODCompleted, run.Status)
+	for _, step := range entity.EODSteps {
+		assert.True(t, run.Completed(step), step)
+	}
+	assert.Len(t, balances.dates, 1)
+	// the loans are repaid once, before the failed step
+	assert.Equal(t, []time.Time{month}, loans.repaid)
+	assert.Equal(t, []time.Time{month}, deposits.matured)
+	// the interest of the month is posted on its last date
+	assert.Equal(t, []time.Time{month}, interest.posted)
+	assert.Empty(t, fees.charged)
+	assert.Equal(t, month.AddDate(0, 0, 1), repo.date.Date)
+	assert.False(t, repo.date.Frozen)
+
+	// the maintenance fees are charged on the first date
+	_, err = s.Run(context.Background(), "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{month.AddDate(0, 0, 1)}, fees.charged)
+	assert.Len(t, interest.posted, 1)
+}
+
+func TestEODRunPartiallyFailed(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	month := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	repo := &stubEODRepo{date: entity.BusinessDate{Date: month}}
+	interest := &stubEODInterest{failed: 1}
+	loans := &stubEODLoans{}
+	s := NewEODService(repo, &stubEODBalances{}, interest, &stubEODFees{}, loans, &stubEODDeposits{},
+		&stubEODLedger{balanced: true}, 0, &logger)
+
+	// the posting failed for an account, the step fails with its result
+	run, err := s.Run(context.Background(), "admin")
+	require.ErrorIs(t, err, ErrEODFailed)
+	assert.Equal(t, entity.EODFailed, run.Status)
+	assert.True(t, run.Completed(entity.EODInterestAccrual))
+	assert.False(t, run.Completed(entity.EODInterestPosting))
+	for _, v := range run.Steps {
+		if v.Step == entity.EODInterestPosting {
+			assert.Equal(t, 1, v.Result.Failed)
+		}
+	}
+	assert.Empty(t, loans.repaid)
+	assert.Equal(t, month, repo.date.Date)
+
+	// the next run retries the posting and goes on
+	interest.failed = 0
+	run, err = s.Run(context.Background(), "admin")
+	require.NoError(t, err)
+	assert.Equal(t, entity.EODCompleted, run.Status)
+	assert.Equal(t, []time.Time{month, month}, interest.posted)
+	assert.Equal(t, []time.Time{month}, loans.repaid)
+	assert.Equal(t, month.AddDate(0, 0, 1), repo.date.Date)
+}
+
+func TestEODRunDue(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	today := truncateDay(time.Now())
+	repo := &stubEODRepo{date: entity.BusinessDate{Date: today.AddDate(0, 0, -3)}}
+	s := NewEODService(repo, &stubEODBalances{}, &stubEODInterest{}, &stubEODFees{}, &stubEODLoans{},
+		&stubEODDeposits{}, &stubEODLedger{balanced: true}, 0, &logger)
+
+	runs, err := s.RunDue(context.Background(), "eod")
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+	assert.Equal(t, today, repo.date.Date)
+
+	// the open date hasn't ended
+	_, err = s.Run(context.Background(), "admin")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// the other runner holds the lock
+	repo.date.Date, repo.locked = today.AddDate(0, 0, -1), true
+	_, err = s.RunDue(context.Background(), "eod")
+	require.ErrorIs(t, err, ErrEODRunning)
+}
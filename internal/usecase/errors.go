@@ -0,0 +1,12 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrAccessDenied      = errors.New("access denied")
+	ErrInvalidArgument   = errors.New("invalid argument")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrDuplicate         = errors.New("already exists")
+	ErrCoolingOff        = errors.New("payee is in cooling-off period")
+)
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+// maxFeeCharges is the limit of the listed charges of an account.
+const maxFeeCharges = 100
+
+// FeeEngine calculates the fees of the transfers by the schedules of the
+// transfer type and the product of the from account.
+type FeeEngine struct {
+	db FeeRepo
+}
+
+func NewFeeEngine(r FeeRepo) *FeeEngine {
+	return &FeeEngine{db: r}
+}
+
+// Charges returns the fees of the transfer. The schedules of the account
+// product replace the ones of all accounts. A nil engine charges nothing.
+func (e *FeeEngine) Charges(ctx context.Context, t entity.Transfer) ([]entity.FeeCharge, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	schedules, err := e.db.TransferSchedules(ctx, t.FromAccountID, t.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+	return charges(productSchedules(schedules), t.FromAccountID, t.Amount), nil
+}
+
+// ExternalCharges returns the fees of the transfer to the other bank like
+// Charges.
+func (e *FeeEngine) ExternalCharges(ctx context.Context, t entity.ExternalTransfer) ([]entity.FeeCharge, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	schedules, err := e.db.ExternalSchedules(ctx, t.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return charges(productSchedules(schedules), t.AccountID, t.Amount), nil
+}
+
+// charges calculates the fees of the amount charged from the account.
+func charges(schedules []entity.FeeSchedule, accountID uuid.UUID, amount int64) []entity.FeeCharge {
+	var result []entity.FeeCharge
+	for _, s := range schedules {
+		fee := s.Calculate(amount)
+		if fee <= 0 {
+			continue
+		}
+		result = append(result, entity.FeeCharge{
+			ScheduleID:       s.ID,
+			Name:             s.Name,
+			AccountID:        accountID,
+			RevenueAccountID: s.RevenueAccountID,
+			Amount:           fee,
+		})
+	}
+	return result
+}
+
+// productSchedules drops the schedules of all accounts if there are
+// schedules of the product.
+func productSchedules(schedules []entity.FeeSchedule) []entity.FeeSchedule {
+	var product []entity.FeeSchedule
+	for _, s := range schedules {
+		if s.ProductID != 0 {
+			product = append(product, s)
+		}
+	}
+	if len(product) > 0 {
+		return product
+	}
+	return schedules
+}
+
+type feeService struct {
+	db       FeeRepo
+	accounts AccountRepo
+	events   EventPublisher
+	l        zerologx.Logger
+}
+
+func NewFeeService(r FeeRepo, ar AccountRepo, p EventPublisher, l zerologx.Logger) FeeService {
+	return &feeService{
+		db:       r,
+		accounts: ar,
+		events:   p,
+		l:        l,
+	}
+}
+
+// CreateSchedule adds the fee paid to the revenue account of the
+// schedule currency.
+func (s *feeService) CreateSchedule(ctx context.Context, fs entity.FeeSchedule) (entity.FeeSchedule, error) {
+	if err := checkSchedule(fs); err != nil {
+		return entity.FeeSchedule{}, err
+	}
+
+	revenue, err := s.accounts.Get(ctx, fs.RevenueAccountID)
+	if errors.Is(err, ErrNotFound) {
+		return entity.FeeSchedule{}, fmt.Errorf("%w: revenue account not found", ErrInvalidArgument)
+	}
+	if err != nil {
+		return entity.FeeSchedule{}, err
+	}
+	if revenue.Currency != fs.Currency {
+		return entity.FeeSchedule{}, fmt.Errorf("%w: revenue account currency is %s",
+			ErrInvalidArgument, revenue.Currency)
+	}
+
+	result, err := s.db.CreateSchedule(ctx, fs)
+	if errors.Is(err, ErrNotFound) {
+		return entity.FeeSchedule{}, fmt.Errorf("%w: product %d not found", ErrInvalidArgument, fs.ProductID)
+	}
+	return result, err
+}
+
+func (s *feeService) GetSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error) {
+	return s.db.GetSchedule(ctx, id)
+}
+
+func (s *feeService) ListSchedules(ctx context.Context) ([]entity.FeeSchedule, error) {
+	return s.db.ListSchedules(ctx)
+}
+
+func (s *feeService) DeactivateSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error) {
+	return s.db.DeactivateSchedule(ctx, id)
+}
+
+// ChargeMaintenance charges the maintenance fees of the month of the
+// period from the accounts of the schedule products. The percentage
+// fees are of the current balance. The accounts charged by the previous
+// runs are skipped, so the run can be repeated.
+func (s *feeService) ChargeMaintenance(ctx context.Context, period time.Time) (entity.BatchRun, error) {
+	period = truncateDay(period).AddDate(0, 0, 1-period.UTC().Day())
+	if period.After(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not started yet", ErrInvalidArgument, period.Format("2006-01"))
+	}
+
+	fees, err := s.db.MaintenanceFees(ctx, period)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: period}
+	for _, f := range fees {
+		amount := f.Schedule.Calculate(f.Balance)
+		if amount <= 0 {
+			run.Skipped++
+			continue
+		}
+
+		c, err := s.db.ChargeMaintenance(ctx, entity.FeeCharge{
+			ScheduleID:       f.Schedule.ID,
+			Name:             f.Schedule.Name,
+			AccountID:        f.AccountID,
+			RevenueAccountID: f.Schedule.RevenueAccountID,
+			Amount:           amount,
+			Period:           &period,
+		})
+		switch {
+		case errors.Is(err, ErrDuplicate):
+			run.Skipped++
+		case err != nil:
+			s.l.Error(fmt.Errorf("charge %d of %s for %s: %w", f.Schedule.ID, f.AccountID, period.Format("2006-01"), err),
+				"usecase - fee - charge maintenance")
+			run.Failed++
+		default:
+			run.Processed++
+			run.Total += amount
+			s.events.Publish(entity.NewEntryEvent(c.Entry))
+		}
+	}
+
+	s.l.Info("usecase - fee - charged maintenance %s: %d accounts, %d skipped, %d failed",
+		period.Format("2006-01"), run.Processed, run.Skipped, run.Failed)
+	return run, nil
+}
+
+func (s *feeService) Charges(ctx context.Context, accountID uuid.UUID) ([]entity.FeeCharge, error) {
+	return s.db.Charges(ctx, accountID, maxFeeCharges)
+}
+
+func checkSchedule(fs entity.FeeSchedule) error {
+	if fs.Name == "" || len(fs.Name) > maxProductNameLength {
+		return fmt.Errorf("%w: name must have 1 to %d characters", ErrInvalidArgument, maxProductNameLength)
+	}
+	switch fs.AppliesTo {
+	case entity.FeeTransferOwn, entity.FeeTransferP2P, entity.FeeExternal:
+	case entity.FeeMaintenance:
+		if fs.ProductID == 0 {
+			return fmt.Errorf("%w: maintenance fee requires a product", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf("%w: unknown fee operation %q", ErrInvalidArgument, fs.AppliesTo)
+	}
+	if fs.Fixed < 0 || fs.Min < 0 || fs.Max < 0 || fs.RateBP < 0 || fs.RateBP > 10000 {
+		return fmt.Errorf("%w: amounts must not be negative, rate must be from 0 to 10000 bp", ErrInvalidArgument)
+	}
+	if fs.Max > 0 && fs.Max < fs.Min {
+		return fmt.Errorf("%w: max must not be less than min", ErrInvalidArgument)
+	}
+
+	switch fs.Kind {
+	case entity.FeeFixed, entity.FeePercentage:
+		if len(fs.Tiers) > 0 {
+			return fmt.Errorf("%w: %s fee has no tiers", ErrInvalidArgument, fs.Kind)
+		}
+	case entity.FeeTiered:
+		if len(fs.Tiers) == 0 {
+			return fmt.Errorf("%w: tiered fee requires tiers", ErrInvalidArgument)
+		}
+		for i, t := range fs.Tiers {
+			last := i == len(fs.Tiers)-1
+			if t.Fixed < 0 || t.RateBP < 0 || t.RateBP > 10000 {
+				return fmt.Errorf("%w: tier %d amounts must not be negative", ErrInvalidArgument, i+1)
+			}
+			if (t.UpTo == 0) != last || (i > 0 && !last && t.UpTo <= fs.Tiers[i-1].UpTo) || t.UpTo < 0 {
+				return fmt.Errorf("%w: tiers must ascend by up_to, only the last one is unbounded", ErrInvalidArgument)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown fee kind %q", ErrInvalidArgument, fs.Kind)
+	}
+	return nil
+}
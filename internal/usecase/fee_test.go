@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFeeRepo struct {
+	FeeRepo
+	schedules []entity.FeeSchedule
+	fees      []MaintenanceFee
+	charges   map[uuid.UUID]entity.FeeCharge
+}
+
+func (r *stubFeeRepo) TransferSchedules(context.Context, uuid.UUID, uuid.UUID) ([]entity.FeeSchedule, error) {
+	return r.schedules, nil
+}
+
+func (r *stubFeeRepo) MaintenanceFees(context.Context, time.Time) ([]MaintenanceFee, error) {
+	return r.fees, nil
+}
+
+func (r *stubFeeRepo) ChargeMaintenance(_ context.Context, c entity.FeeCharge) (entity.FeeCharge, error) {
+	if _, ok := r.charges[c.AccountID]; ok {
+		return entity.FeeCharge{}, ErrDuplicate
+	}
+	r.charges[c.AccountID] = c
+	return c, nil
+}
+
+func TestFeeEngineCharges(t *testing.T) {
+	from, to, revenue := uuid.New(), uuid.New(), uuid.New()
+	generic := entity.FeeSchedule{ID: 1, Name: "p2p", Kind: entity.FeeFixed, Fixed: 50, RevenueAccountID: revenue}
+	product := entity.FeeSchedule{ID: 2, Name: "premium p2p", Kind: entity.FeePercentage, RateBP: 100,
+		ProductID: 7, RevenueAccountID: revenue}
+	free := entity.FeeSchedule{ID: 3, Name: "free", Kind: entity.FeeFixed, ProductID: 7}
+	transfer := entity.Transfer{FromAccountID: from, ToAccountID: to, Amount: 1000}
+
+	charges, err := NewFeeEngine(&stubFeeRepo{schedules: []entity.FeeSchedule{generic}}).
+		Charges(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Len(t, charges, 1)
+	assert.Equal(t, entity.FeeCharge{ScheduleID: 1, Name: "p2p", AccountID: from, RevenueAccountID: revenue,
+		Amount: 50}, charges[0])
+
+	// the product schedules replace the generic one, the zero fees are
+	// not charged
+	charges, err = NewFeeEngine(&stubFeeRepo{schedules: []entity.FeeSchedule{generic, product, free}}).
+		Charges(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Len(t, charges, 1)
+	assert.Equal(t, int64(2), charges[0].ScheduleID)
+	assert.Equal(t, int64(10), charges[0].Amount)
+
+	var engine *FeeEngine
+	charges, err = engine.Charges(context.Background(), transfer)
+	require.NoError(t, err)
+	assert.Empty(t, charges)
+}
+
+func TestFeeChargeMaintenance(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	charged, free := uuid.New(), uuid.New()
+	schedule := entity.FeeSchedule{ID: 1, Name: "maintenance", Kind: entity.FeePercentage, RateBP: 10, Min: 5,
+		Max: 100}
+	repo := &stubFeeRepo{
+		fees: []MaintenanceFee{
+			{Schedule: schedule, AccountID: charged, Balance: 500_000},
+			{Schedule: entity.FeeSchedule{ID: 2, Kind: entity.FeeFixed}, AccountID: free},
+		},
+		charges: map[uuid.UUID]entity.FeeCharge{},
+	}
+	events := &stubPublisher{}
+	s := NewFeeService(repo, nil, events, &logger)
+
+	_, err := s.ChargeMaintenance(context.Background(), time.Now().AddDate(0, 1, 0))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	run, err := s.ChargeMaintenance(context.Background(), time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, run.Processed)
+	assert.Equal(t, 1, run.Skipped)
+	assert.Equal(t, int64(100), run.Total)
+	assert.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), *repo.charges[charged].Period)
+	assert.Len(t, events.events, 1)
+
+	run, err = s.ChargeMaintenance(context.Background(), time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 0, run.Processed)
+	assert.Equal(t, 2, run.Skipped)
+}
+
+func TestCheckSchedule(t *testing.T) {
+	valid := entity.FeeSchedule{Name: "p2p", Kind: entity.FeeTiered, AppliesTo: entity.FeeTransferP2P,
+		Tiers: entity.FeeTiers{{UpTo: 1000, Fixed: 10}, {RateBP: 50}}}
+	require.NoError(t, checkSchedule(valid))
+
+	tests := map[string]func(s *entity.FeeSchedule){
+		"no name":                func(s *entity.FeeSchedule) { s.Name = "" },
+		"unknown kind":           func(s *entity.FeeSchedule) { s.Kind = "flat" },
+		"maintenance no product": func(s *entity.FeeSchedule) { s.AppliesTo = entity.FeeMaintenance },
+		"rate above 100%":        func(s *entity.FeeSchedule) { s.RateBP = 10001 },
+		"max below min":          func(s *entity.FeeSchedule) { s.Min, s.Max = 10, 5 },
+		"bounded last tier":      func(s *entity.FeeSchedule) { s.Tiers = entity.FeeTiers{{UpTo: 1000}} },
+		"descending tiers": func(s *entity.FeeSchedule) {
+			s.Tiers = entity.FeeTiers{{UpTo: 1000}, {UpTo: 500}, {}}
+		},
+		"fixed with tiers": func(s *entity.FeeSchedule) { s.Kind = entity.FeeFixed },
+	}
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := valid
+			s.Tiers = append(entity.FeeTiers(nil), valid.Tiers...)
+			change(&s)
+			require.ErrorIs(t, checkSchedule(s), ErrInvalidArgument)
+		})
+	}
+}
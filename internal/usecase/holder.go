@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"alukart32.com/bank/entity"
+)
+
+// Authorizer checks the actions of the account holders against their
+// roles. A nil authorizer allows the account owners only.
+type Authorizer struct {
+	db HolderRepo
+}
+
+func NewAuthorizer(r HolderRepo) *Authorizer {
+	return &Authorizer{
+		db: r,
+	}
+}
+
+// Authorize returns ErrAccessDenied unless the subject owns the account
+// or is its active holder with the role allowing the action. The owner
+// of the account is its primary holder, so the owner is allowed without
+// the lookup of the holder.
+func (a *Authorizer) Authorize(ctx context.Context, subject string, account entity.Account,
+	p entity.Permission) error {
+	if subject == "" {
+		return ErrAccessDenied
+	}
+	if account.Owner == subject {
+		return nil
+	}
+	if a == nil {
+		return ErrAccessDenied
+	}
+
+	h, err := a.db.Get(ctx, account.ID, subject)
+	if errors.Is(err, ErrNotFound) {
+		return ErrAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+	if h.Status != entity.HolderActive || !h.Role.Can(p) {
+		return ErrAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubHolderRepo struct {
+	HolderRepo
+	holders map[string]*entity.AccountHolder
+}
+
+func (r *stubHolderRepo) Create(_ context.Context, h entity.AccountHolder) (entity.AccountHolder, error) {
+	if _, ok := r.holders[h.Holder]; ok {
+		return entity.AccountHolder{}, ErrDuplicate
+	}
+	h.Status = entity.HolderInvited
+	r.holders[h.Holder] = &h
+	return h, nil
+}
+
+func (r *stubHolderRepo) Get(_ context.Context, _ uuid.UUID, holder string) (entity.AccountHolder, error) {
+	h, ok := r.holders[holder]
+	if !ok {
+		return entity.AccountHolder{}, ErrNotFound
+	}
+	return *h, nil
+}
+
+func (r *stubHolderRepo) Accept(_ context.Context, _ uuid.UUID, holder string) (entity.AccountHolder, error) {
+	h, ok := r.holders[holder]
+	if !ok || h.Status != entity.HolderInvited {
+		return entity.AccountHolder{}, ErrNotFound
+	}
+	h.Status = entity.HolderActive
+	return *h, nil
+}
+
+func (r *stubHolderRepo) Delete(_ context.Context, _ uuid.UUID, holder string) error {
+	if _, ok := r.holders[holder]; !ok {
+		return ErrNotFound
+	}
+	delete(r.holders, holder)
+	return nil
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	account := entity.Account{ID: uuid.New(), Owner: "owner"}
+	authz := NewAuthorizer(&stubHolderRepo{holders: map[string]*entity.AccountHolder{
+		"joint":     {Holder: "joint", Role: entity.HolderJoint, Status: entity.HolderActive},
+		"signatory": {Holder: "signatory", Role: entity.HolderSignatory, Status: entity.HolderActive},
+		"viewer":    {Holder: "viewer", Role: entity.HolderViewer, Status: entity.HolderActive},
+		"invited":   {Holder: "invited", Role: entity.HolderJoint, Status: entity.HolderInvited},
+	}})
+
+	tests := []struct {
+		subject string
+		p       entity.Permission
+		allowed bool
+	}{
+		{"owner", entity.PermissionManageHolders, true},
+		{"joint", entity.PermissionManageHolders, true},
+		{"signatory", entity.PermissionTransfer, true},
+		{"signatory", entity.PermissionManageHolders, false},
+		{"viewer", entity.PermissionView, true},
+		{"viewer", entity.PermissionTransfer, false},
+		{"invited", entity.PermissionView, false},
+		{"stranger", entity.PermissionView, false},
+		{"", entity.PermissionView, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject+" "+string(tt.p), func(t *testing.T) {
+			err := authz.Authorize(context.Background(), tt.subject, account, tt.p)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAccessDenied)
+			}
+		})
+	}
+
+	// the nil authorizer allows the owner only
+	var none *Authorizer
+	require.NoError(t, none.Authorize(context.Background(), "owner", account, entity.PermissionTransfer))
+	require.ErrorIs(t, none.Authorize(context.Background(), "joint", account, entity.PermissionView), ErrAccessDenied)
+}
+
+func TestAccountHolders(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := entity.Account{ID: uuid.New(), Owner: "owner"}
+	holders := &stubHolderRepo{holders: map[string]*entity.AccountHolder{
+		"owner": {AccountID: account.ID, Holder: "owner", Role: entity.HolderPrimary, Status: entity.HolderActive},
+	}}
+	s := NewAccountService(&stubClearingAccounts{accounts: map[uuid.UUID]entity.Account{account.ID: account}},
+		holders, nil, nil, nil, &logger)
+	ctx := context.Background()
+
+	invite := func(inviter, holder string, role entity.HolderRole) error {
+		_, err := s.InviteHolder(ctx, inviter, entity.AccountHolder{AccountID: account.ID, Holder: holder, Role: role})
+		return err
+	}
+
+	require.ErrorIs(t, invite("owner", "joint", entity.HolderPrimary), ErrInvalidArgument)
+	require.ErrorIs(t, invite("owner", "", entity.HolderJoint), ErrInvalidArgument)
+	require.ErrorIs(t, invite("owner", "owner", entity.HolderJoint), ErrDuplicate)
+	require.ErrorIs(t, invite("stranger", "joint", entity.HolderJoint), ErrAccessDenied)
+
+	require.NoError(t, invite("owner", "joint", entity.HolderJoint))
+	assert.Equal(t, "owner", holders.holders["joint"].InvitedBy)
+	require.ErrorIs(t, invite("owner", "joint", entity.HolderViewer), ErrDuplicate)
+	// the invited holder has no access until the invitation is accepted
+	require.ErrorIs(t, invite("joint", "viewer", entity.HolderViewer), ErrAccessDenied)
+
+	h, err := s.AcceptHolder(ctx, "joint", account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.HolderActive, h.Status)
+	_, err = s.AcceptHolder(ctx, "joint", account.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, invite("joint", "viewer", entity.HolderViewer))
+	_, err = s.AcceptHolder(ctx, "viewer", account.ID)
+	require.NoError(t, err)
+	require.NoError(t, s.Authorize(ctx, "viewer", account, entity.PermissionView))
+	require.ErrorIs(t, s.Authorize(ctx, "viewer", account, entity.PermissionTransfer), ErrAccessDenied)
+
+	// the viewer can't remove the others but can leave the account
+	require.ErrorIs(t, s.RemoveHolder(ctx, "viewer", account.ID, "joint"), ErrAccessDenied)
+	require.ErrorIs(t, s.RemoveHolder(ctx, "joint", account.ID, "owner"), ErrInvalidArgument)
+	require.NoError(t, s.RemoveHolder(ctx, "viewer", account.ID, "viewer"))
+	require.NoError(t, s.RemoveHolder(ctx, "owner", account.ID, "joint"))
+	require.ErrorIs(t, s.RemoveHolder(ctx, "owner", account.ID, "joint"), ErrNotFound)
+	assert.Len(t, holders.holders, 1)
+}
+
+type stubOwnerAccounts struct {
+	stubClearingAccounts
+}
+
+func (r *stubOwnerAccounts) UpdateOwner(_ context.Context, id uuid.UUID, owner string) (entity.Account, error) {
+	a := r.accounts[id]
+	a.Owner = owner
+	r.accounts[id] = a
+	return a, nil
+}
+
+func TestAccountUpdateOwner(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := entity.Account{ID: uuid.New(), Owner: "owner"}
+	accounts := &stubOwnerAccounts{stubClearingAccounts{accounts: map[uuid.UUID]entity.Account{account.ID: account}}}
+	holders := &stubHolderRepo{holders: map[string]*entity.AccountHolder{
+		"owner": {AccountID: account.ID, Holder: "owner", Role: entity.HolderPrimary, Status: entity.HolderActive},
+		"joint": {AccountID: account.ID, Holder: "joint", Role: entity.HolderJoint, Status: entity.HolderActive},
+	}}
+	s := NewAccountService(accounts, holders, nil, nil, nil, &logger)
+	ctx := context.Background()
+
+	// the joint holder manages the holders but can't take the account over
+	_, err := s.UpdateOwner(ctx, "joint", account.ID, "joint")
+	require.ErrorIs(t, err, ErrAccessDenied)
+	_, err = s.UpdateOwner(ctx, "joint", account.ID, "stranger")
+	require.ErrorIs(t, err, ErrAccessDenied)
+	assert.Equal(t, "owner", accounts.accounts[account.ID].Owner)
+
+	updated, err := s.UpdateOwner(ctx, "owner", account.ID, "joint")
+	require.NoError(t, err)
+	assert.Equal(t, "joint", updated.Owner)
+
+	// the admin changes the owner on behalf of the bank
+	updated, err = s.UpdateOwner(ctx, "", account.ID, "owner")
+	require.NoError(t, err)
+	assert.Equal(t, "owner", updated.Owner)
+}
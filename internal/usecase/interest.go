@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const maxProductNameLength = 70
+
+type interestService struct {
+	db       InterestRepo
+	accounts AccountRepo
+	events   EventPublisher
+	l        zerologx.Logger
+}
+
+func NewInterestService(r InterestRepo, ar AccountRepo, p EventPublisher, l zerologx.Logger) InterestService {
+	return &interestService{
+		db:       r,
+		accounts: ar,
+		events:   p,
+		l:        l,
+	}
+}
+
+// CreateProduct adds the product paid from the expense account of its
+// currency.
+func (s *interestService) CreateProduct(ctx context.Context, p entity.InterestProduct) (entity.InterestProduct, error) {
+	if p.Name == "" || len(p.Name) > maxProductNameLength {
+		return entity.InterestProduct{}, fmt.Errorf("%w: name must have 1 to %d characters",
+			ErrInvalidArgument, maxProductNameLength)
+	}
+	if p.AnnualRateBP < 0 || p.AnnualRateBP > 10000 {
+		return entity.InterestProduct{}, fmt.Errorf("%w: annual rate must be from 0 to 10000 bp", ErrInvalidArgument)
+	}
+	if !p.DayCount.Valid() {
+		return entity.InterestProduct{}, fmt.Errorf("%w: unknown day count %q", ErrInvalidArgument, p.DayCount)
+	}
+
+	expense, err := s.accounts.Get(ctx, p.ExpenseAccountID)
+	if errors.Is(err, ErrNotFound) {
+		return entity.InterestProduct{}, fmt.Errorf("%w: expense account not found", ErrInvalidArgument)
+	}
+	if err != nil {
+		return entity.InterestProduct{}, err
+	}
+	if expense.Currency != p.Currency {
+		return entity.InterestProduct{}, fmt.Errorf("%w: expense account currency is %s",
+			ErrInvalidArgument, expense.Currency)
+	}
+	return s.db.CreateProduct(ctx, p)
+}
+
+func (s *interestService) GetProduct(ctx context.Context, id int64) (entity.InterestProduct, error) {
+	return s.db.GetProduct(ctx, id)
+}
+
+func (s *interestService) ListProducts(ctx context.Context) ([]entity.InterestProduct, error) {
+	return s.db.ListProducts(ctx)
+}
+
+// SetAccountProduct moves the account to the product of its currency.
+// The accrued interest is kept, the next accruals use the new terms.
+func (s *interestService) SetAccountProduct(ctx context.Context, accountID uuid.UUID,
+	productID int64) (entity.InterestProduct, error) {
+	a, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return entity.InterestProduct{}, err
+	}
+	p, err := s.db.GetProduct(ctx, productID)
+	if errors.Is(err, ErrNotFound) {
+		return entity.InterestProduct{}, fmt.Errorf("%w: product %d not found", ErrInvalidArgument, productID)
+	}
+	if err != nil {
+		return entity.InterestProduct{}, err
+	}
+	if a.Currency != p.Currency {
+		return entity.InterestProduct{}, fmt.Errorf("%w: product currency is %s", ErrInvalidArgument, p.Currency)
+	}
+	if a.ID == p.ExpenseAccountID {
+		return entity.InterestProduct{}, fmt.Errorf("%w: the expense account can't earn interest", ErrInvalidArgument)
+	}
+
+	if err = s.db.SetAccountProduct(ctx, accountID, productID); err != nil {
+		return entity.InterestProduct{}, err
+	}
+	return p, nil
+}
+
+func (s *interestService) GetAccountProduct(ctx context.Context, accountID uuid.UUID) (entity.InterestProduct, error) {
+	return s.db.GetAccountProduct(ctx, accountID)
+}
+
+// RemoveAccountProduct stops the accruals of the account. The accrued
+// interest is posted at the end of the month.
+func (s *interestService) RemoveAccountProduct(ctx context.Context, accountID uuid.UUID) error {
+	return s.db.DeleteAccountProduct(ctx, accountID)
+}
+
+// Accrue accrues the interest of the end-of-day balances for the UTC
+// date. The date must have ended. The accounts accrued by the previous
+// runs for the date are skipped, so the run can be repeated.
+func (s *interestService) Accrue(ctx context.Context, date time.Time) (entity.BatchRun, error) {
+	date = truncateDay(date)
+	if !date.Before(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not ended yet", ErrInvalidArgument, date.Format("2006-01-02"))
+	}
+
+	accruals, err := s.db.AccrualBalances(ctx, date)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: date}
+	for _, a := range accruals {
+		a.Days = a.DayCount.Days(date)
+		if a.Balance > 0 {
+			a.AmountMicros = a.DayCount.Accrue(a.Balance, a.AnnualRateBP, date)
+		}
+
+		added, err := s.db.AddAccrual(ctx, a)
+		switch {
+		case err != nil:
+			s.l.Error(fmt.Errorf("accrue %s for %s: %w", a.AccountID, date.Format("2006-01-02"), err),
+				"usecase - interest - accrue")
+			run.Failed++
+		case added:
+			run.Processed++
+			run.Total += a.AmountMicros
+		default:
+			run.Skipped++
+		}
+	}
+
+	s.l.Info("usecase - interest - accrued %s: %d accounts, %d skipped, %d failed",
+		date.Format("2006-01-02"), run.Processed, run.Skipped, run.Failed)
+	return run, nil
+}
+
+// Post capitalizes the interest accrued in the month of the period. The
+// month must have ended. The whole units are transferred from the
+// expense account of the product, the rest of the micros is carried to
+// the next month. The accounts posted by the previous runs are skipped.
+func (s *interestService) Post(ctx context.Context, period time.Time) (entity.BatchRun, error) {
+	period = truncateDay(period).AddDate(0, 0, 1-period.UTC().Day())
+	if period.AddDate(0, 1, 0).After(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not ended yet", ErrInvalidArgument, period.Format("2006-01"))
+	}
+
+	totals, err := s.db.PostingTotals(ctx, period)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: period}
+	for _, v := range totals {
+		accrued := v.AccruedMicros + v.CarryMicros
+		p := entity.InterestPosting{
+			AccountID:     v.AccountID,
+			Period:        period,
+			AccruedMicros: accrued,
+			Amount:        accrued / entity.MicrosPerUnit,
+			CarryMicros:   accrued % entity.MicrosPerUnit,
+		}
+
+		_, res, err := s.db.Post(ctx, p, entity.Transfer{
+			FromAccountID: v.ExpenseAccountID,
+			ToAccountID:   v.AccountID,
+			Amount:        p.Amount,
+			Description:   "Interest for " + period.Format("2006-01"),
+			Reference:     "INT-" + period.Format("200601"),
+		})
+		switch {
+		case errors.Is(err, ErrDuplicate):
+			run.Skipped++
+		case err != nil:
+			s.l.Error(fmt.Errorf("post %s for %s: %w", v.AccountID, period.Format("2006-01"), err),
+				"usecase - interest - post")
+			run.Failed++
+		default:
+			run.Processed++
+			run.Total += p.Amount
+			if p.Amount > 0 {
+				publishTransfer(s.events, res)
+			}
+		}
+	}
+
+	s.l.Info("usecase - interest - posted %s: %d accounts, %d skipped, %d failed",
+		period.Format("2006-01"), run.Processed, run.Skipped, run.Failed)
+	return run, nil
+}
+
+// Accruals returns the accruals of the account for the dates [from, to).
+func (s *interestService) Accruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]entity.InterestAccrual, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: accrual period is empty", ErrInvalidArgument)
+	}
+	return s.db.Accruals(ctx, accountID, truncateDay(from), truncateDay(to))
+}
+
+func (s *interestService) Postings(ctx context.Context, accountID uuid.UUID) ([]entity.InterestPosting, error) {
+	return s.db.Postings(ctx, accountID)
+}
+
+// truncateDay returns the start of the UTC day of the time.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
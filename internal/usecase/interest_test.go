@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubInterestRepo struct {
+	InterestRepo
+	balances []entity.InterestAccrual
+	accruals map[uuid.UUID]entity.InterestAccrual
+	totals   []PostingTotal
+	postings map[uuid.UUID]entity.InterestPosting
+}
+
+func (r *stubInterestRepo) AccrualBalances(_ context.Context, date time.Time) ([]entity.InterestAccrual, error) {
+	result := make([]entity.InterestAccrual, 0, len(r.balances))
+	for _, a := range r.balances {
+		a.Date = date
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+func (r *stubInterestRepo) AddAccrual(_ context.Context, a entity.InterestAccrual) (bool, error) {
+	if _, ok := r.accruals[a.AccountID]; ok {
+		return false, nil
+	}
+	r.accruals[a.AccountID] = a
+	return true, nil
+}
+
+func (r *stubInterestRepo) PostingTotals(context.Context, time.Time) ([]PostingTotal, error) {
+	return r.totals, nil
+}
+
+func (r *stubInterestRepo) Post(_ context.Context, p entity.InterestPosting,
+	t entity.Transfer) (entity.InterestPosting, entity.TransferRes, error) {
+	if _, ok := r.postings[p.AccountID]; ok {
+		return entity.InterestPosting{}, entity.TransferRes{}, ErrDuplicate
+	}
+	r.postings[p.AccountID] = p
+	return p, entity.TransferRes{Transfer: t}, nil
+}
+
+type stubPublisher struct {
+	events []entity.AccountEvent
+}
+
+func (p *stubPublisher) Publish(events ...entity.AccountEvent) {
+	p.events = append(p.events, events...)
+}
+
+func TestInterestAccrue(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	rich, poor := uuid.New(), uuid.New()
+	repo := &stubInterestRepo{
+		balances: []entity.InterestAccrual{
+			{AccountID: rich, Balance: 100000, AnnualRateBP: 500, DayCount: entity.DayCount30360},
+			{AccountID: poor, AnnualRateBP: 500, DayCount: entity.DayCountACT365},
+		},
+		accruals: map[uuid.UUID]entity.InterestAccrual{},
+	}
+	s := NewInterestService(repo, nil, &stubPublisher{}, &logger)
+
+	_, err := s.Accrue(context.Background(), time.Now())
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	date := time.Date(2023, time.February, 28, 12, 0, 0, 0, time.UTC)
+	run, err := s.Accrue(context.Background(), date)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Processed)
+	assert.Equal(t, time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC), run.Date)
+	// the end of February accrues 3 days under 30/360
+	assert.Equal(t, int64(3), repo.accruals[rich].Days)
+	assert.Equal(t, int64(41666666), repo.accruals[rich].AmountMicros)
+	assert.Equal(t, int64(0), repo.accruals[poor].AmountMicros)
+	assert.Equal(t, int64(41666666), run.Total)
+
+	// the repeated run skips the accrued accounts
+	run, err = s.Accrue(context.Background(), date)
+	require.NoError(t, err)
+	assert.Equal(t, 0, run.Processed)
+	assert.Equal(t, 2, run.Skipped)
+}
+
+func TestInterestPost(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	expense, saver, tiny := uuid.New(), uuid.New(), uuid.New()
+	repo := &stubInterestRepo{
+		totals: []PostingTotal{
+			{AccountID: saver, ExpenseAccountID: expense, AccruedMicros: 412_345_678, CarryMicros: 700_000},
+			{AccountID: tiny, ExpenseAccountID: expense, AccruedMicros: 300_000},
+		},
+		postings: map[uuid.UUID]entity.InterestPosting{},
+	}
+	events := &stubPublisher{}
+	s := NewInterestService(repo, nil, events, &logger)
+
+	_, err := s.Post(context.Background(), time.Now())
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	run, err := s.Post(context.Background(), time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Processed)
+	assert.Equal(t, int64(413), run.Total)
+
+	p := repo.postings[saver]
+	assert.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), p.Period)
+	assert.Equal(t, int64(413), p.Amount)
+	assert.Equal(t, int64(45_678), p.CarryMicros)
+	// less than a unit is carried without a transfer
+	assert.Equal(t, int64(0), repo.postings[tiny].Amount)
+	assert.Equal(t, int64(300_000), repo.postings[tiny].CarryMicros)
+	assert.Len(t, events.events, 4)
+
+	run, err = s.Post(context.Background(), time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Skipped)
+}
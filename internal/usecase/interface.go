@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"alukart32.com/bank/entity"
 	"github.com/google/uuid"
@@ -11,11 +12,23 @@ type (
 	AccountService interface {
 		Create(ctx context.Context, a entity.Account) (uuid.UUID, error)
 		Get(ctx context.Context, id uuid.UUID) (entity.Account, error)
-		UpdateOwner(ctx context.Context, id uuid.UUID, owner string) (entity.Account, error)
-		AddBalance(ctx context.Context, id uuid.UUID, amount int64) (entity.Account, error)
+		GetByNumber(ctx context.Context, number string) (entity.Account, error)
+		// UpdateOwner changes the owner of the account by its primary holder.
+		// An empty subject is an admin changing the owner on behalf of the
+		// bank.
+		UpdateOwner(ctx context.Context, subject string, id uuid.UUID, owner string) (entity.Account, error)
 		Delete(ctx context.Context, id uuid.UUID) error
 		ListEntries(ctx context.Context, id uuid.UUID) ([]entity.Entry, error)
 		ListTransfers(ctx context.Context, id uuid.UUID) ([]entity.Transfer, error)
+		// Authorize returns ErrAccessDenied unless the role of the subject
+		// allows the action on the account.
+		Authorize(ctx context.Context, subject string, a entity.Account, p entity.Permission) error
+		Holders(ctx context.Context, id uuid.UUID) ([]entity.AccountHolder, error)
+		InviteHolder(ctx context.Context, inviter string, h entity.AccountHolder) (entity.AccountHolder, error)
+		AcceptHolder(ctx context.Context, holder string, id uuid.UUID) (entity.AccountHolder, error)
+		// RemoveHolder removes the holder by a holder allowed to manage the
+		// holders or by the holder itself.
+		RemoveHolder(ctx context.Context, subject string, id uuid.UUID, holder string) error
 	}
 
 	EntryService interface {
@@ -27,32 +40,479 @@ type (
 	}
 
 	TransferService interface {
-		Transfer(ctx context.Context, t entity.Transfer) (entity.TransferRes, error)
+		// Transfer executes the transfer initiated by the user.
+		Transfer(ctx context.Context, initiator string, t entity.Transfer) (entity.TransferRes, error)
 		Get(ctx context.Context, id int64) (entity.Transfer, error)
 		List(ctx context.Context, params ListTransferParams) ([]entity.Transfer, error)
-		Rollback(ctx context.Context, id int64) error
+		ListByReference(ctx context.Context, owner, reference string, status entity.TransferStatus) ([]entity.Transfer, error)
+		Transitions(ctx context.Context, id int64) ([]entity.TransferTransition, error)
+		// Rollback reverses the completed transfer.
+		Rollback(ctx context.Context, id int64) (entity.TransferRes, error)
+	}
+
+	StreamService interface {
+		EventPublisher
+		Subscribe(ctx context.Context, owner string, accountID uuid.UUID, lastEventID int64) (<-chan entity.AccountEvent, error)
+	}
+
+	StatementService interface {
+		Get(ctx context.Context, owner string, accountID uuid.UUID, from, to time.Time) (entity.Statement, error)
+	}
+
+	PaymentService interface {
+		Import(ctx context.Context, owner string, f entity.PaymentFile) (entity.PaymentStatusReport, error)
+	}
+
+	PayeeService interface {
+		Create(ctx context.Context, owner string, p entity.Payee) (entity.Payee, error)
+		Get(ctx context.Context, owner string, id int64) (entity.Payee, error)
+		List(ctx context.Context, owner string) ([]entity.Payee, error)
+		Rename(ctx context.Context, owner string, id int64, nickname string) (entity.Payee, error)
+		Delete(ctx context.Context, owner string, id int64) error
+		// Transfer sends the transfer to the payee account.
+		Transfer(ctx context.Context, owner string, id int64, t entity.Transfer) (entity.TransferRes, error)
+	}
+
+	LimitService interface {
+		Get(ctx context.Context, accountID uuid.UUID) (entity.AccountLimits, error)
+		Set(ctx context.Context, admin string, l entity.AccountLimits) (entity.AccountLimits, error)
+		Reset(ctx context.Context, accountID uuid.UUID) error
+	}
+
+	// ReviewService is the queue of the transfers held by the risk rules
+	// for operators.
+	ReviewService interface {
+		List(ctx context.Context, status entity.ReviewStatus) ([]entity.TransferReview, error)
+		Get(ctx context.Context, id int64) (entity.TransferReview, error)
+		// Approve executes the held transfer.
+		Approve(ctx context.Context, operator string, id int64, comment string) (entity.TransferReview, error)
+		Reject(ctx context.Context, operator string, id int64, comment string) (entity.TransferReview, error)
+	}
+
+	// ApprovalService is the maker-checker workflow of the transfers
+	// above the threshold of the account policy.
+	ApprovalService interface {
+		GetPolicy(ctx context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, error)
+		SetPolicy(ctx context.Context, admin string, p entity.ApprovalPolicy) (entity.ApprovalPolicy, error)
+		DeletePolicy(ctx context.Context, accountID uuid.UUID) error
+		List(ctx context.Context, approver string) ([]entity.TransferApproval, error)
+		Get(ctx context.Context, user string, id int64) (entity.TransferApproval, error)
+		// Approve executes the transfer, the approver must differ from
+		// the initiator.
+		Approve(ctx context.Context, approver string, id int64, comment string) (entity.TransferApproval, error)
+		Reject(ctx context.Context, approver string, id int64, comment string) (entity.TransferApproval, error)
+	}
+
+	// ScreeningService is the compliance review of the sanctions
+	// screening alerts.
+	ScreeningService interface {
+		List(ctx context.Context, status entity.AlertStatus) ([]entity.ScreeningAlert, error)
+		Get(ctx context.Context, id int64) (entity.ScreeningAlert, error)
+		Clear(ctx context.Context, officer string, id int64, comment string) (entity.ScreeningAlert, error)
+		Confirm(ctx context.Context, officer string, id int64, comment string) (entity.ScreeningAlert, error)
+	}
+
+	// AuditService queries and checks the audit log.
+	AuditService interface {
+		// List returns the records with the newest first.
+		List(ctx context.Context, params ListAuditParams) ([]entity.AuditRecord, error)
+		// Verify checks the hash chain of the whole log.
+		Verify(ctx context.Context) (entity.AuditVerification, error)
+	}
+
+	// InterestService manages the savings products and runs the interest
+	// batches.
+	InterestService interface {
+		CreateProduct(ctx context.Context, p entity.InterestProduct) (entity.InterestProduct, error)
+		GetProduct(ctx context.Context, id int64) (entity.InterestProduct, error)
+		ListProducts(ctx context.Context) ([]entity.InterestProduct, error)
+		SetAccountProduct(ctx context.Context, accountID uuid.UUID, productID int64) (entity.InterestProduct, error)
+		GetAccountProduct(ctx context.Context, accountID uuid.UUID) (entity.InterestProduct, error)
+		RemoveAccountProduct(ctx context.Context, accountID uuid.UUID) error
+		// Accrue accrues the daily interest of the date, Post capitalizes
+		// the interest of the month of the period. Both are safe to
+		// repeat for the same date.
+		Accrue(ctx context.Context, date time.Time) (entity.BatchRun, error)
+		Post(ctx context.Context, period time.Time) (entity.BatchRun, error)
+		Accruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]entity.InterestAccrual, error)
+		Postings(ctx context.Context, accountID uuid.UUID) ([]entity.InterestPosting, error)
+	}
+
+	// FeeService manages the fee schedules and charges the monthly
+	// maintenance fees.
+	FeeService interface {
+		CreateSchedule(ctx context.Context, s entity.FeeSchedule) (entity.FeeSchedule, error)
+		GetSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error)
+		ListSchedules(ctx context.Context) ([]entity.FeeSchedule, error)
+		DeactivateSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error)
+		// ChargeMaintenance is safe to repeat for the same month.
+		ChargeMaintenance(ctx context.Context, period time.Time) (entity.BatchRun, error)
+		Charges(ctx context.Context, accountID uuid.UUID) ([]entity.FeeCharge, error)
+	}
+
+	// LedgerService manages the chart of accounts of the general ledger.
+	LedgerService interface {
+		CreateAccount(ctx context.Context, a entity.GLAccount) (entity.GLAccount, error)
+		GetAccount(ctx context.Context, accountID uuid.UUID) (entity.GLAccount, error)
+		ListAccounts(ctx context.Context) ([]entity.GLAccount, error)
+		// TrialBalance returns the trial balance of each currency.
+		TrialBalance(ctx context.Context) ([]entity.TrialBalance, error)
+	}
+
+	// CashService executes the cash deposits and withdrawals on behalf of
+	// the operator, the returned operations are the receipts.
+	CashService interface {
+		Deposit(ctx context.Context, operator string, op entity.CashOperation) (entity.CashOperation, error)
+		Withdraw(ctx context.Context, operator string, op entity.CashOperation) (entity.CashOperation, error)
+		Receipt(ctx context.Context, reference string) (entity.CashOperation, error)
+		List(ctx context.Context, accountID uuid.UUID) ([]entity.CashOperation, error)
+	}
+
+	// BalanceService returns the past balances of the accounts from their
+	// end-of-day snapshots and entries.
+	BalanceService interface {
+		AsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (entity.AccountBalance, error)
+		Snapshot(ctx context.Context, date time.Time) (entity.BatchRun, error)
+		Rebuild(ctx context.Context) (entity.BatchRun, error)
+	}
+
+	// EODService closes the business dates, see entity.EODSteps. Run
+	// closes the business date once it has ended, RunDue closes all the
+	// due ones.
+	EODService interface {
+		BusinessDate(ctx context.Context) (entity.BusinessDate, error)
+		Run(ctx context.Context, operator string) (entity.EODRun, error)
+		RunDue(ctx context.Context, operator string) ([]entity.EODRun, error)
+		Get(ctx context.Context, date time.Time) (entity.EODRun, error)
+		List(ctx context.Context) ([]entity.EODRun, error)
+		Statements(ctx context.Context, accountID uuid.UUID) ([]entity.DailyStatement, error)
+	}
+
+	// ClearingService sends the transfers to the accounts at the other
+	// banks through the clearing house. SendBatches delivers the pending
+	// transfers in the batch files, ProcessResponses settles or returns
+	// them by the responses of the clearing house.
+	ClearingService interface {
+		// Transfer returns the transfer pending for the next batch or held
+		// for the approval or the review.
+		Transfer(ctx context.Context, owner string, t entity.ExternalTransfer) (entity.ExternalTransfer, error)
+		Get(ctx context.Context, id int64) (entity.ExternalTransfer, error)
+		List(ctx context.Context, accountID uuid.UUID) ([]entity.ExternalTransfer, error)
+		ListHeld(ctx context.Context, hold entity.ExternalHold) ([]entity.ExternalTransfer, error)
+		// Approve and Decline decide the transfers held for the approval
+		// on behalf of an approver of the account.
+		Approve(ctx context.Context, approver string, id int64, comment string) (entity.ExternalTransfer, error)
+		Decline(ctx context.Context, approver string, id int64, comment string) (entity.ExternalTransfer, error)
+		// Release and Reject decide the transfers held for the review on
+		// behalf of the operator.
+		Release(ctx context.Context, operator string, id int64, comment string) (entity.ExternalTransfer, error)
+		Reject(ctx context.Context, operator string, id int64, comment string) (entity.ExternalTransfer, error)
+		SendBatches(ctx context.Context) ([]entity.ClearingBatch, error)
+		ProcessResponses(ctx context.Context) (entity.ClearingReport, error)
+		ListBatches(ctx context.Context) ([]entity.ClearingBatch, error)
+		// GetBatch returns the batch with its transfers to export its file.
+		GetBatch(ctx context.Context, fileName string) (entity.ClearingBatch, error)
+	}
+
+	// ClearingGateway exchanges the files with the clearing house.
+	ClearingGateway interface {
+		Send(ctx context.Context, b entity.ClearingBatch) error
+		// Receive returns the responses which are not done yet.
+		Receive(ctx context.Context) ([]entity.ClearingResponse, error)
+		// Done archives the processed response.
+		Done(ctx context.Context, r entity.ClearingResponse) error
+	}
+
+	// LoanService lends to the customers. Disburse credits the loan to the
+	// account and schedules its installments, Repay collects the due ones
+	// from the account.
+	LoanService interface {
+		Disburse(ctx context.Context, operator string, l entity.Loan) (entity.Loan, error)
+		Get(ctx context.Context, id int64) (entity.Loan, error)
+		List(ctx context.Context, accountID uuid.UUID) ([]entity.Loan, error)
+		Repay(ctx context.Context, date time.Time) (entity.BatchRun, error)
+	}
+
+	DepositService interface {
+		Open(ctx context.Context, operator string, d entity.TermDeposit) (entity.TermDeposit, error)
+		Get(ctx context.Context, id int64) (entity.TermDeposit, error)
+		List(ctx context.Context, accountID uuid.UUID) ([]entity.TermDeposit, error)
+		// Withdraw closes the deposit before its maturity.
+		Withdraw(ctx context.Context, id int64) (entity.TermDeposit, error)
+		Mature(ctx context.Context, date time.Time) (entity.BatchRun, error)
+	}
+
+	// Watchlist matches the names against the sanctions lists.
+	Watchlist interface {
+		Match(name string, min float64) []entity.ScreeningMatch
+	}
+
+	EventPublisher interface {
+		Publish(events ...entity.AccountEvent)
 	}
 
 	AccountRepo interface {
 		Create(ctx context.Context, a entity.Account) (entity.Account, error)
 		Get(ctx context.Context, id uuid.UUID) (entity.Account, error)
+		GetByNumber(ctx context.Context, number string) (entity.Account, error)
+		NextNumber(ctx context.Context) (int64, error)
 		UpdateOwner(ctx context.Context, id uuid.UUID, owner string) (entity.Account, error)
-		UpdateBalance(ctx context.Context, id uuid.UUID, amount int64) (entity.Account, error)
 		Delete(ctx context.Context, id uuid.UUID) error
 	}
+	HolderRepo interface {
+		// Create invites the holder, the holders of the account fail with
+		// ErrDuplicate.
+		Create(ctx context.Context, h entity.AccountHolder) (entity.AccountHolder, error)
+		Get(ctx context.Context, accountID uuid.UUID, holder string) (entity.AccountHolder, error)
+		List(ctx context.Context, accountID uuid.UUID) ([]entity.AccountHolder, error)
+		Accept(ctx context.Context, accountID uuid.UUID, holder string) (entity.AccountHolder, error)
+		Delete(ctx context.Context, accountID uuid.UUID, holder string) error
+	}
 	EntryRepo interface {
 		Create(ctx context.Context, e entity.Entry) (entity.Entry, error)
 		Get(ctx context.Context, id int64) (entity.Entry, error)
 		UpdateAmount(ctx context.Context, e entity.Entry) error
 		List(ctx context.Context, accountId uuid.UUID) ([]entity.Entry, error)
+		// ListAfter returns the entries of the account with ids after
+		// afterID and the ones created within the window before it. An
+		// unknown afterID returns the window before now.
+		ListAfter(ctx context.Context, accountId uuid.UUID, afterID int64, window time.Duration) ([]entity.Entry, error)
 		Delete(ctx context.Context, id int64) error
 	}
 
 	TransferRepo interface {
-		Create(ctx context.Context, transfer entity.Transfer) (entity.TransferRes, error)
+		// Create executes the transfer and charges its fees in one tx.
+		Create(ctx context.Context, transfer entity.Transfer, fees []entity.FeeCharge) (entity.TransferRes, error)
 		Get(ctx context.Context, id int64) (entity.Transfer, error)
 		List(ctx context.Context, params ListTransferParams) ([]entity.Transfer, error)
-		Rollback(ctx context.Context, id int64) error
+		ListByReference(ctx context.Context, owner, reference string, status entity.TransferStatus) ([]entity.Transfer, error)
+		// Fail saves the transfer refused on the execution as failed
+		// with the reason.
+		Fail(ctx context.Context, t entity.Transfer, reason string) (entity.Transfer, error)
+		Transitions(ctx context.Context, id int64) ([]entity.TransferTransition, error)
+		Rollback(ctx context.Context, id int64) (entity.TransferRes, error)
+	}
+
+	// StatementRepo returns the statement of the account with the
+	// opening balance and lines of the period filled.
+	StatementRepo interface {
+		Get(ctx context.Context, accountID uuid.UUID, from, to time.Time) (entity.Statement, error)
+	}
+
+	PaymentImportRepo interface {
+		// Register records the imported payment file and fails with
+		// ErrDuplicate if the owner has already imported its message.
+		Register(ctx context.Context, owner, msgID string) error
+	}
+
+	PayeeRepo interface {
+		Create(ctx context.Context, p entity.Payee) (entity.Payee, error)
+		Get(ctx context.Context, owner string, id int64) (entity.Payee, error)
+		List(ctx context.Context, owner string) ([]entity.Payee, error)
+		UpdateNickname(ctx context.Context, owner string, id int64, nickname string) (entity.Payee, error)
+		Delete(ctx context.Context, owner string, id int64) error
+	}
+
+	LimitRepo interface {
+		Get(ctx context.Context, accountID uuid.UUID) (entity.AccountLimits, error)
+		Set(ctx context.Context, l entity.AccountLimits) (entity.AccountLimits, error)
+		Delete(ctx context.Context, accountID uuid.UUID) error
+	}
+
+	RiskRepo interface {
+		// History returns the activity of the from account, the recent
+		// transfers are the ones made since the time.
+		History(ctx context.Context, from, to uuid.UUID, since time.Time) (entity.RiskHistory, error)
+	}
+
+	TransferReviewRepo interface {
+		Create(ctx context.Context, r entity.TransferReview) (entity.TransferReview, error)
+		Get(ctx context.Context, id int64) (entity.TransferReview, error)
+		List(ctx context.Context, status entity.ReviewStatus) ([]entity.TransferReview, error)
+		// Approve closes the pending review and executes its transfer
+		// in one tx. It fails with ErrReviewClosed if the review is not
+		// pending.
+		Approve(ctx context.Context, id int64, operator, comment string,
+			fees []entity.FeeCharge) (entity.TransferReview, entity.TransferRes, error)
+		Reject(ctx context.Context, id int64, operator, comment string) (entity.TransferReview, error)
+	}
+
+	ApprovalRepo interface {
+		GetPolicy(ctx context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, error)
+		SetPolicy(ctx context.Context, p entity.ApprovalPolicy) (entity.ApprovalPolicy, error)
+		DeletePolicy(ctx context.Context, accountID uuid.UUID) error
+		Create(ctx context.Context, a entity.TransferApproval) (entity.TransferApproval, error)
+		Get(ctx context.Context, id int64) (entity.TransferApproval, error)
+		List(ctx context.Context, approver string) ([]entity.TransferApproval, error)
+		// Approve decides the pending approval and executes its transfer
+		// in one tx. It fails with ErrApprovalExpired or ErrApprovalClosed
+		// if the approval is not pending.
+		Approve(ctx context.Context, id int64, approver, comment string,
+			fees []entity.FeeCharge) (entity.TransferApproval, entity.TransferRes, error)
+		// Hold decides the pending approval and saves its transfer to
+		// the review in one tx.
+		Hold(ctx context.Context, id int64, approver, comment string,
+			review entity.TransferReview) (entity.TransferApproval, entity.TransferReview, error)
+		Reject(ctx context.Context, id int64, approver, comment string) (entity.TransferApproval, error)
+	}
+
+	ScreeningRepo interface {
+		// Create returns the pending alert of the account and name
+		// instead of a new one if there is any.
+		Create(ctx context.Context, a entity.ScreeningAlert) (entity.ScreeningAlert, error)
+		Get(ctx context.Context, id int64) (entity.ScreeningAlert, error)
+		List(ctx context.Context, status entity.AlertStatus) ([]entity.ScreeningAlert, error)
+		// Close fails with ErrAlertClosed if the alert is not pending.
+		Close(ctx context.Context, id int64, status entity.AlertStatus, operator, comment string) (entity.ScreeningAlert, error)
+		// LastClosed fails with ErrNotFound if the name of the account
+		// has no reviewed alerts.
+		LastClosed(ctx context.Context, accountID uuid.UUID, name string) (entity.ScreeningAlert, error)
+	}
+
+	// Transactor runs fn in a tx, the repos called with the context of
+	// fn join it.
+	Transactor interface {
+		InTx(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+
+	AuditRepo interface {
+		// Append sets the hashes and time of the record and adds it to
+		// the end of the chain.
+		Append(ctx context.Context, r entity.AuditRecord) (entity.AuditRecord, error)
+		List(ctx context.Context, params ListAuditParams) ([]entity.AuditRecord, error)
+		// Chain returns up to limit records after the id in the chain order.
+		Chain(ctx context.Context, afterID int64, limit int32) ([]entity.AuditRecord, error)
+	}
+
+	InterestRepo interface {
+		CreateProduct(ctx context.Context, p entity.InterestProduct) (entity.InterestProduct, error)
+		GetProduct(ctx context.Context, id int64) (entity.InterestProduct, error)
+		ListProducts(ctx context.Context) ([]entity.InterestProduct, error)
+		GetAccountProduct(ctx context.Context, accountID uuid.UUID) (entity.InterestProduct, error)
+		SetAccountProduct(ctx context.Context, accountID uuid.UUID, productID int64) error
+		DeleteAccountProduct(ctx context.Context, accountID uuid.UUID) error
+		AccrualBalances(ctx context.Context, date time.Time) ([]entity.InterestAccrual, error)
+		// AddAccrual reports false if the account has already accrued for
+		// the date or has been posted for its month.
+		AddAccrual(ctx context.Context, a entity.InterestAccrual) (bool, error)
+		Accruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]entity.InterestAccrual, error)
+		PostingTotals(ctx context.Context, period time.Time) ([]PostingTotal, error)
+		// Post saves the posting and executes its transfer in one tx. It
+		// fails with ErrDuplicate if the month is already posted.
+		Post(ctx context.Context, p entity.InterestPosting, t entity.Transfer) (entity.InterestPosting, entity.TransferRes, error)
+		Postings(ctx context.Context, accountID uuid.UUID) ([]entity.InterestPosting, error)
+	}
+
+	FeeRepo interface {
+		CreateSchedule(ctx context.Context, s entity.FeeSchedule) (entity.FeeSchedule, error)
+		GetSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error)
+		ListSchedules(ctx context.Context) ([]entity.FeeSchedule, error)
+		DeactivateSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error)
+		TransferSchedules(ctx context.Context, from, to uuid.UUID) ([]entity.FeeSchedule, error)
+		ExternalSchedules(ctx context.Context, accountID uuid.UUID) ([]entity.FeeSchedule, error)
+		MaintenanceFees(ctx context.Context, period time.Time) ([]MaintenanceFee, error)
+		// ChargeMaintenance fails with ErrDuplicate if the account has
+		// already been charged for the month.
+		ChargeMaintenance(ctx context.Context, c entity.FeeCharge) (entity.FeeCharge, error)
+		Charges(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.FeeCharge, error)
+	}
+
+	LedgerRepo interface {
+		Create(ctx context.Context, a entity.GLAccount) (entity.GLAccount, error)
+		Get(ctx context.Context, accountID uuid.UUID) (entity.GLAccount, error)
+		List(ctx context.Context) ([]entity.GLAccount, error)
+		Balances(ctx context.Context) ([]entity.GLAccount, []CustomerBalance, error)
+	}
+
+	CashRepo interface {
+		NextReference(ctx context.Context) (int64, error)
+		// Create posts the operation and returns it with the entry of the
+		// account. The operation above the limit fails with a *LimitError.
+		Create(ctx context.Context, op entity.CashOperation, limit entity.CashLimit) (entity.CashOperation, entity.Entry, error)
+		Get(ctx context.Context, reference string) (entity.CashOperation, error)
+		List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.CashOperation, error)
+	}
+
+	BalanceRepo interface {
+		AsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (entity.AccountBalance, error)
+		Snapshot(ctx context.Context, asOf time.Time) (int64, error)
+		Rebuild(ctx context.Context, until time.Time) (int64, error)
+	}
+
+	EODRepo interface {
+		// Lock keeps the other runs out until the returned unlock is
+		// called. It fails with ErrEODRunning if the lock is held.
+		Lock(ctx context.Context) (unlock func(), err error)
+		BusinessDate(ctx context.Context) (entity.BusinessDate, error)
+		Freeze(ctx context.Context, date time.Time) error
+		Advance(ctx context.Context, date time.Time) error
+		Start(ctx context.Context, date time.Time, operator string) (entity.EODRun, error)
+		Finish(ctx context.Context, runID int64, status entity.EODStatus, msg string) error
+		StartStep(ctx context.Context, runID int64, step entity.EODStep) error
+		FinishStep(ctx context.Context, runID int64, step entity.EODStep, status entity.EODStatus,
+			result entity.BatchRun, msg string) error
+		Get(ctx context.Context, date time.Time) (entity.EODRun, error)
+		List(ctx context.Context, limit int32) ([]entity.EODRun, error)
+		CreateStatements(ctx context.Context, date time.Time) (int64, error)
+		Statements(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.DailyStatement, error)
+	}
+
+	ClearingRepo interface {
+		// Create debits the account and returns the transfer with the
+		// account and its entry. The pending transfer is charged the fees.
+		Create(ctx context.Context, t entity.ExternalTransfer, fees []entity.FeeCharge) (entity.ExternalTransfer,
+			entity.Account, entity.Entry, error)
+		Get(ctx context.Context, id int64) (entity.ExternalTransfer, error)
+		List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.ExternalTransfer, error)
+		ListHeld(ctx context.Context, hold entity.ExternalHold) ([]entity.ExternalTransfer, error)
+		// Release, Hold and Reject fail with ErrInvalidTransition if the
+		// transfer isn't waiting for the hold.
+		Release(ctx context.Context, id int64, hold entity.ExternalHold, reviewer, comment string,
+			fees []entity.FeeCharge) (entity.ExternalTransfer, entity.Account, error)
+		Hold(ctx context.Context, id int64, approver, comment string, a entity.RiskAssessment) (entity.ExternalTransfer, error)
+		Reject(ctx context.Context, id int64, hold entity.ExternalHold, reviewer, comment string) (entity.ExternalTransfer,
+			entity.Account, entity.Entry, error)
+		CreateBatches(ctx context.Context, now time.Time) ([]entity.ClearingBatch, error)
+		UpdateBatch(ctx context.Context, id int64, status entity.ClearingBatchStatus) (entity.ClearingBatch, error)
+		// GetBatch returns the batch with its transfers.
+		GetBatch(ctx context.Context, fileName string) (entity.ClearingBatch, error)
+		ListBatches(ctx context.Context, limit int32) ([]entity.ClearingBatch, error)
+		// Settle and Return fail with ErrInvalidTransition if the transfer
+		// isn't waiting for the response to the batch.
+		Settle(ctx context.Context, batch entity.ClearingBatch, id int64) (entity.ExternalTransfer, error)
+		Return(ctx context.Context, batch entity.ClearingBatch, id int64, reason string) (entity.ExternalTransfer,
+			entity.Account, entity.Entry, error)
+	}
+
+	LoanRepo interface {
+		// Create disburses the loan and returns it with its installments,
+		// the account and its entry.
+		Create(ctx context.Context, l entity.Loan) (entity.Loan, entity.Account, entity.Entry, error)
+		// Get returns the loan with its installments.
+		Get(ctx context.Context, id int64) (entity.Loan, error)
+		List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.Loan, error)
+		DueInstallments(ctx context.Context, date time.Time) ([]DueInstallment, error)
+		// Repay fails with ErrInvalidTransition if the installment has been
+		// paid and with ErrInsufficientFunds if the account can't pay it.
+		Repay(ctx context.Context, d DueInstallment, date time.Time) (entity.Loan, entity.Account, []entity.Entry, error)
+		MarkLate(ctx context.Context, loanID int64, number int32) error
+	}
+
+	DepositRepo interface {
+		// Create takes the principal from the account and returns the
+		// deposit, the account and its entry.
+		Create(ctx context.Context, d entity.TermDeposit) (entity.TermDeposit, entity.Account, entity.Entry, error)
+		Get(ctx context.Context, id int64) (entity.TermDeposit, error)
+		List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.TermDeposit, error)
+		// Matured returns the active deposits maturing on or before the date.
+		Matured(ctx context.Context, date time.Time) ([]entity.TermDeposit, error)
+		// Close pays the principal and the interest to the payout account,
+		// it fails with ErrInvalidTransition if the deposit is closed.
+		Close(ctx context.Context, id int64, status entity.DepositStatus, interest int64) (entity.TermDeposit,
+			entity.Account, []entity.Entry, error)
+		// RollOver fails with ErrInvalidTransition if the term maturing on
+		// the date has been rolled over or the deposit is closed.
+		RollOver(ctx context.Context, id int64, maturity, next time.Time, interest int64) (entity.TermDeposit, error)
 	}
 
 	PaggingParams struct {
@@ -66,8 +526,52 @@ type (
 		FromAccountId uuid.UUID
 		ToAccountId   uuid.UUID
 		Order         ListTransferOrder
+		// Status filters the transfers, the empty one matches all.
+		Status entity.TransferStatus
 		PaggingParams
 	}
+
+	// ListAuditParams filters the audit records, the empty fields match
+	// all.
+	ListAuditParams struct {
+		TargetType string
+		TargetID   string
+		Actor      string
+		PaggingParams
+	}
+
+	// PostingTotal is the interest of the account accrued in the month
+	// and carried from the previous one.
+	PostingTotal struct {
+		AccountID        uuid.UUID
+		ExpenseAccountID uuid.UUID
+		AccruedMicros    int64
+		CarryMicros      int64
+	}
+
+	// CustomerBalance is the total balance of the customer accounts in
+	// the currency.
+	CustomerBalance struct {
+		Currency entity.Currency
+		Accounts int64
+		Balance  int64
+	}
+
+	// MaintenanceFee is the maintenance schedule of the account product
+	// with the account balance.
+	MaintenanceFee struct {
+		Schedule  entity.FeeSchedule
+		AccountID uuid.UUID
+		Balance   int64
+	}
+
+	// DueInstallment is the unpaid installment with the terms of its
+	// loan.
+	DueInstallment struct {
+		Installment   entity.Installment
+		AccountID     uuid.UUID
+		PenaltyRateBP int32
+	}
 )
 
 const (
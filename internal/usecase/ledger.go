@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const (
+	maxGLCodeLength = 10
+	maxGLNameLength = 70
+)
+
+type ledgerService struct {
+	db LedgerRepo
+	l  zerologx.Logger
+}
+
+func NewLedgerService(r LedgerRepo, l zerologx.Logger) LedgerService {
+	return &ledgerService{
+		db: r,
+		l:  l,
+	}
+}
+
+// CreateAccount opens the GL account under the code unique in its
+// currency.
+func (s *ledgerService) CreateAccount(ctx context.Context, a entity.GLAccount) (entity.GLAccount, error) {
+	if !isGLCode(a.Code) {
+		return entity.GLAccount{}, fmt.Errorf("%w: code must have 1 to %d digits", ErrInvalidArgument, maxGLCodeLength)
+	}
+	if a.Code == entity.GLCodeCustomerDeposits {
+		return entity.GLAccount{}, fmt.Errorf("%w: code %s is reserved for the customer accounts",
+			ErrInvalidArgument, a.Code)
+	}
+	if a.Name == "" || len(a.Name) > maxGLNameLength || !isPrintable(a.Name) {
+		return entity.GLAccount{}, fmt.Errorf("%w: name must have 1 to %d printable characters",
+			ErrInvalidArgument, maxGLNameLength)
+	}
+	if !a.Type.Valid() {
+		return entity.GLAccount{}, fmt.Errorf("%w: unknown account type %q", ErrInvalidArgument, a.Type)
+	}
+	if a.Currency != entity.CurrencyRUB && a.Currency != entity.CurrencyUSD {
+		return entity.GLAccount{}, fmt.Errorf("%w: unsupported currency %q", ErrInvalidArgument, a.Currency)
+	}
+	return s.db.Create(ctx, a)
+}
+
+func (s *ledgerService) GetAccount(ctx context.Context, accountID uuid.UUID) (entity.GLAccount, error) {
+	return s.db.Get(ctx, accountID)
+}
+
+func (s *ledgerService) ListAccounts(ctx context.Context) ([]entity.GLAccount, error) {
+	return s.db.List(ctx)
+}
+
+// TrialBalance lists the GL accounts and the customer accounts as one
+// liability line by code. Every balance change is a pair of entries of
+// the same amount, so the debits equal the credits unless the balances
+// were changed outside of the postings.
+func (s *ledgerService) TrialBalance(ctx context.Context) ([]entity.TrialBalance, error) {
+	accounts, customers, err := s.db.Balances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range customers {
+		accounts = append(accounts, entity.GLAccount{
+			Code:     entity.GLCodeCustomerDeposits,
+			Name:     fmt.Sprintf("Customer deposits (%d accounts)", c.Accounts),
+			Type:     entity.GLLiability,
+			Currency: c.Currency,
+			Balance:  c.Balance,
+		})
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if accounts[i].Currency != accounts[j].Currency {
+			return accounts[i].Currency < accounts[j].Currency
+		}
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	var result []entity.TrialBalance
+	for _, a := range accounts {
+		if len(result) == 0 || result[len(result)-1].Currency != a.Currency {
+			result = append(result, entity.TrialBalance{Currency: a.Currency, Balanced: true})
+		}
+		result[len(result)-1].Add(a.Code, a.Name, a.Type, a.Balance)
+	}
+
+	for _, b := range result {
+		if !b.Balanced {
+			s.l.Warn("usecase - ledger - trial balance of %s is off: debit %d, credit %d",
+				b.Currency, b.TotalDebit, b.TotalCredit)
+		}
+	}
+	return result, nil
+}
+
+func isGLCode(code string) bool {
+	if code == "" || len(code) > maxGLCodeLength {
+		return false
+	}
+	for _, r := range code {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLedgerRepo struct {
+	LedgerRepo
+	accounts  []entity.GLAccount
+	customers []CustomerBalance
+}
+
+func (r *stubLedgerRepo) Balances(context.Context) ([]entity.GLAccount, []CustomerBalance, error) {
+	return r.accounts, r.customers, nil
+}
+
+func TestLedgerTrialBalance(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubLedgerRepo{
+		accounts: []entity.GLAccount{
+			{Code: entity.GLCodeCash, Name: "Cash", Type: entity.GLAsset, Currency: entity.CurrencyRUB, Balance: -1000},
+			{Code: entity.GLCodeFeeIncome, Name: "Fee income", Type: entity.GLIncome, Currency: entity.CurrencyRUB,
+				Balance: 30},
+			{Code: entity.GLCodeCash, Name: "Cash", Type: entity.GLAsset, Currency: entity.CurrencyUSD, Balance: -500},
+		},
+		customers: []CustomerBalance{
+			{Currency: entity.CurrencyRUB, Accounts: 2, Balance: 970},
+			{Currency: entity.CurrencyUSD, Accounts: 1, Balance: 400},
+		},
+	}
+	s := NewLedgerService(repo, &logger)
+
+	balances, err := s.TrialBalance(context.Background())
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+
+	rub := balances[0]
+	assert.Equal(t, entity.CurrencyRUB, rub.Currency)
+	assert.True(t, rub.Balanced)
+	assert.Equal(t, int64(1000), rub.TotalDebit)
+	// the customer accounts are listed by their code
+	require.Len(t, rub.Lines, 3)
+	assert.Equal(t, entity.GLCodeCustomerDeposits, rub.Lines[1].Code)
+	assert.Equal(t, int64(970), rub.Lines[1].Credit)
+
+	usd := balances[1]
+	assert.False(t, usd.Balanced)
+	assert.Equal(t, int64(500), usd.TotalDebit)
+	assert.Equal(t, int64(400), usd.TotalCredit)
+}
+
+func TestLedgerCreateAccount(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	s := NewLedgerService(&stubLedgerRepo{}, &logger)
+	valid := entity.GLAccount{Code: "1100", Name: "Correspondent", Type: entity.GLAsset,
+		Currency: entity.CurrencyUSD}
+
+	tests := map[string]func(a *entity.GLAccount){
+		"no code":          func(a *entity.GLAccount) { a.Code = "" },
+		"letters in code":  func(a *entity.GLAccount) { a.Code = "11A0" },
+		"reserved code":    func(a *entity.GLAccount) { a.Code = entity.GLCodeCustomerDeposits },
+		"no name":          func(a *entity.GLAccount) { a.Name = "" },
+		"unknown type":     func(a *entity.GLAccount) { a.Type = "revenue" },
+		"unknown currency": func(a *entity.GLAccount) { a.Currency = "EUR" },
+	}
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := valid
+			change(&a)
+			_, err := s.CreateAccount(context.Background(), a)
+			require.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
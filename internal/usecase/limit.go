@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+// Names of the transfer limits.
+const (
+	LimitMaxSingle   = "max_single"
+	LimitDaily       = "daily"
+	LimitMonthly     = "monthly"
+	LimitHourlyCount = "hourly_count"
+)
+
+var ErrLimitExceeded = errors.New("transfer limit exceeded")
+
+// LimitError is the ErrLimitExceeded of a particular limit with the
+// allowance that remains within it.
+type LimitError struct {
+	Limit     string
+	Max       int64
+	Remaining int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %s limit is %d, remaining allowance is %d", ErrLimitExceeded, e.Limit, e.Max, e.Remaining)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// CheckLimits returns a *LimitError if the transfer of the amount on top
+// of the usage exceeds the limits.
+func CheckLimits(l entity.TransferLimits, u entity.TransferUsage, amount int64) error {
+	check := func(name string, max, used, add int64) error {
+		if max > 0 && used+add > max {
+			remaining := max - used
+			if remaining < 0 {
+				remaining = 0
+			}
+			return &LimitError{Limit: name, Max: max, Remaining: remaining}
+		}
+		return nil
+	}
+
+	if err := check(LimitMaxSingle, l.MaxSingle, 0, amount); err != nil {
+		return err
+	}
+	if err := check(LimitHourlyCount, l.HourlyCount, u.HourlyCount, 1); err != nil {
+		return err
+	}
+	if err := check(LimitDaily, l.Daily, u.Daily, amount); err != nil {
+		return err
+	}
+	return check(LimitMonthly, l.Monthly, u.Monthly, amount)
+}
+
+// LimitWindows returns the starts of the limit windows of the time: the
+// UTC day, month and the previous hour.
+func LimitWindows(now time.Time) (day, month, hour time.Time) {
+	now = now.UTC()
+	day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	hour = now.Add(-time.Hour)
+	return day, month, hour
+}
+
+type limitService struct {
+	db LimitRepo
+	l  zerologx.Logger
+}
+
+func NewLimitService(r LimitRepo, l zerologx.Logger) LimitService {
+	return &limitService{
+		db: r,
+		l:  l,
+	}
+}
+
+func (s *limitService) Get(ctx context.Context, accountID uuid.UUID) (entity.AccountLimits, error) {
+	return s.db.Get(ctx, accountID)
+}
+
+// Set overrides the limits of the account on behalf of the admin.
+func (s *limitService) Set(ctx context.Context, admin string, l entity.AccountLimits) (entity.AccountLimits, error) {
+	if l.Tier == "" {
+		return entity.AccountLimits{}, fmt.Errorf("%w: tier is required", ErrInvalidArgument)
+	}
+	for _, v := range []*int64{l.MaxSingle, l.Daily, l.Monthly, l.HourlyCount} {
+		if v != nil && *v < 0 {
+			return entity.AccountLimits{}, fmt.Errorf("%w: limits must not be negative", ErrInvalidArgument)
+		}
+	}
+
+	l.UpdatedBy = admin
+	return s.db.Set(ctx, l)
+}
+
+// Reset removes the overrides, so the account gets the standard tier.
+func (s *limitService) Reset(ctx context.Context, accountID uuid.UUID) error {
+	return s.db.Delete(ctx, accountID)
+}
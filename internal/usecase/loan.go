@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxLoans is the limit of the listed loans of an account.
+	maxLoans          = 100
+	maxLoanTermMonths = 360
+)
+
+type loanService struct {
+	db     LoanRepo
+	events EventPublisher
+	l      zerologx.Logger
+}
+
+func NewLoanService(r LoanRepo, p EventPublisher, l zerologx.Logger) LoanService {
+	return &loanService{
+		db:     r,
+		events: p,
+		l:      l,
+	}
+}
+
+// Disburse credits the principal to the account today and schedules the
+// monthly installments from the next month.
+func (s *loanService) Disburse(ctx context.Context, operator string, l entity.Loan) (entity.Loan, error) {
+	if err := checkLoan(l); err != nil {
+		return entity.Loan{}, err
+	}
+
+	l.DisbursedOn = truncateDay(time.Now())
+	l.CreatedBy = operator
+	l.Installments = entity.AmortizationSchedule(l.Principal, l.AnnualRateBP, l.TermMonths, l.Method, l.DisbursedOn)
+	result, account, entry, err := s.db.Create(ctx, l)
+	if err != nil {
+		return entity.Loan{}, err
+	}
+
+	s.events.Publish(entity.NewEntryEvent(entry), entity.NewBalanceEvent(account))
+	s.l.Info("usecase - loan - disbursed loan %d of %d to %s", result.ID, result.Principal, result.AccountID)
+	return result, nil
+}
+
+// Get returns the loan with its schedule. The overdue installments carry
+// their penalty interest as of today.
+func (s *loanService) Get(ctx context.Context, id int64) (entity.Loan, error) {
+	l, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.Loan{}, err
+	}
+
+	today := truncateDay(time.Now())
+	for n, i := range l.Installments {
+		if i.Status != entity.InstallmentPaid {
+			l.Installments[n].Penalty = entity.PenaltyInterest(i.Principal+i.Interest, l.PenaltyRateBP, i.DueDate, today)
+		}
+	}
+	return l, nil
+}
+
+func (s *loanService) List(ctx context.Context, accountID uuid.UUID) ([]entity.Loan, error) {
+	return s.db.List(ctx, accountID, maxLoans)
+}
+
+// Repay collects the installments due on or before the date from the
+// accounts of their loans, the late ones with the penalty interest until
+// the date. The installments the account can't pay are marked late and
+// skipped, the later ones of the loan wait for them. The installments
+// paid by the previous runs are skipped, so the run can be repeated. Only
+// the installments failed by an error are counted as failed.
+func (s *loanService) Repay(ctx context.Context, date time.Time) (entity.BatchRun, error) {
+	date = truncateDay(date)
+	if date.After(truncateDay(time.Now())) {
+		return entity.BatchRun{}, fmt.Errorf("%w: %s has not started yet", ErrInvalidArgument, date.Format("2006-01-02"))
+	}
+
+	due, err := s.db.DueInstallments(ctx, date)
+	if err != nil {
+		return entity.BatchRun{}, err
+	}
+
+	run := entity.BatchRun{Date: date}
+	unpaid := map[int64]bool{}
+	for _, d := range due {
+		i := &d.Installment
+		if unpaid[i.LoanID] {
+			s.markLate(ctx, *i)
+			run.Skipped++
+			continue
+		}
+
+		i.Penalty = entity.PenaltyInterest(i.Principal+i.Interest, d.PenaltyRateBP, i.DueDate, date)
+		loan, account, entries, err := s.db.Repay(ctx, d, date)
+		switch {
+		case errors.Is(err, ErrInvalidTransition):
+			run.Skipped++
+		case errors.Is(err, ErrInsufficientFunds):
+			unpaid[i.LoanID] = true
+			s.markLate(ctx, *i)
+			run.Skipped++
+		case err != nil:
+			s.l.Error(fmt.Errorf("repay loan %d installment %d: %w", i.LoanID, i.Number, err),
+				"usecase - loan - repay")
+			unpaid[i.LoanID] = true
+			s.markLate(ctx, *i)
+			run.Failed++
+		default:
+			run.Processed++
+			run.Total += i.Amount()
+			events := make([]entity.AccountEvent, 0, len(entries)+1)
+			for _, e := range entries {
+				events = append(events, entity.NewEntryEvent(e))
+			}
+			s.events.Publish(append(events, entity.NewBalanceEvent(account))...)
+			if loan.Status == entity.LoanRepaid {
+				s.l.Info("usecase - loan - loan %d is repaid", loan.ID)
+			}
+		}
+	}
+
+	s.l.Info("usecase - loan - repaid installments due %s: %d paid, %d skipped, %d failed",
+		date.Format("2006-01-02"), run.Processed, run.Skipped, run.Failed)
+	return run, nil
+}
+
+// markLate marks the installment unpaid after its due date late.
+func (s *loanService) markLate(ctx context.Context, i entity.Installment) {
+	if i.Status != entity.InstallmentScheduled {
+		return
+	}
+	if err := s.db.MarkLate(ctx, i.LoanID, i.Number); err != nil {
+		s.l.Error(fmt.Errorf("mark loan %d installment %d late: %w", i.LoanID, i.Number, err),
+			"usecase - loan - repay")
+	}
+}
+
+func checkLoan(l entity.Loan) error {
+	if l.Principal <= 0 {
+		return fmt.Errorf("%w: principal must be positive", ErrInvalidArgument)
+	}
+	if l.AnnualRateBP < 0 || l.AnnualRateBP > 10000 || l.PenaltyRateBP < 0 || l.PenaltyRateBP > 10000 {
+		return fmt.Errorf("%w: rates must be from 0 to 10000 bp", ErrInvalidArgument)
+	}
+	if l.TermMonths < 1 || l.TermMonths > maxLoanTermMonths {
+		return fmt.Errorf("%w: term must be from 1 to %d months", ErrInvalidArgument, maxLoanTermMonths)
+	}
+	if !l.Method.Valid() {
+		return fmt.Errorf("%w: unknown amortization method %q", ErrInvalidArgument, l.Method)
+	}
+	return nil
+}
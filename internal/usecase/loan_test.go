@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLoanRepo struct {
+	LoanRepo
+	loans map[int64]*entity.Loan
+	// balances are the balances of the accounts of the loans.
+	balances map[uuid.UUID]int64
+	late     []int32
+}
+
+func (r *stubLoanRepo) Create(_ context.Context, l entity.Loan) (entity.Loan, entity.Account, entity.Entry, error) {
+	l.ID = int64(len(r.loans) + 1)
+	l.Status, l.Outstanding = entity.LoanActive, l.Principal
+	for n := range l.Installments {
+		l.Installments[n].LoanID = l.ID
+	}
+	r.loans[l.ID] = &l
+	r.balances[l.AccountID] += l.Principal
+	return l, entity.Account{ID: l.AccountID, Balance: r.balances[l.AccountID]},
+		entity.Entry{AccountID: l.AccountID, Amount: l.Principal}, nil
+}
+
+func (r *stubLoanRepo) Get(_ context.Context, id int64) (entity.Loan, error) {
+	l, ok := r.loans[id]
+	if !ok {
+		return entity.Loan{}, ErrNotFound
+	}
+	result := *l
+	result.Installments = append([]entity.Installment(nil), l.Installments...)
+	return result, nil
+}
+
+func (r *stubLoanRepo) DueInstallments(_ context.Context, date time.Time) ([]DueInstallment, error) {
+	var result []DueInstallment
+	for id := int64(1); id <= int64(len(r.loans)); id++ {
+		l := r.loans[id]
+		for _, i := range l.Installments {
+			if i.Status != entity.InstallmentPaid && !i.DueDate.After(date) {
+				result = append(result, DueInstallment{
+					Installment:   i,
+					AccountID:     l.AccountID,
+					PenaltyRateBP: l.PenaltyRateBP,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *stubLoanRepo) Repay(_ context.Context, d DueInstallment, date time.Time) (entity.Loan, entity.Account,
+	[]entity.Entry, error) {
+	l := r.loans[d.Installment.LoanID]
+	i := &l.Installments[d.Installment.Number-1]
+	if i.Status == entity.InstallmentPaid {
+		return entity.Loan{}, entity.Account{}, nil, ErrInvalidTransition
+	}
+	amount := d.Installment.Amount()
+	if r.balances[l.AccountID] < amount {
+		return entity.Loan{}, entity.Account{}, nil, ErrInsufficientFunds
+	}
+
+	r.balances[l.AccountID] -= amount
+	i.Status, i.Penalty, i.PaidOn = entity.InstallmentPaid, d.Installment.Penalty, &date
+	l.Outstanding -= i.Principal
+	if l.Outstanding == 0 {
+		l.Status = entity.LoanRepaid
+	}
+	return *l, entity.Account{ID: l.AccountID, Balance: r.balances[l.AccountID]},
+		[]entity.Entry{{AccountID: l.AccountID, Amount: -amount}}, nil
+}
+
+func (r *stubLoanRepo) MarkLate(_ context.Context, loanID int64, number int32) error {
+	r.loans[loanID].Installments[number-1].Status = entity.InstallmentLate
+	r.late = append(r.late, number)
+	return nil
+}
+
+func TestLoanDisburse(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	repo := &stubLoanRepo{loans: map[int64]*entity.Loan{}, balances: map[uuid.UUID]int64{}}
+	events := &stubPublisher{}
+	s := NewLoanService(repo, events, &logger)
+
+	valid := entity.Loan{
+		AccountID:     uuid.New(),
+		Principal:     1000000,
+		AnnualRateBP:  1200,
+		PenaltyRateBP: 2000,
+		TermMonths:    12,
+		Method:        entity.AmortizationAnnuity,
+	}
+	loan, err := s.Disburse(context.Background(), "admin", valid)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", loan.CreatedBy)
+	assert.Equal(t, truncateDay(time.Now()), loan.DisbursedOn)
+	require.Len(t, loan.Installments, 12)
+	assert.Equal(t, int64(88849), loan.Installments[0].Amount())
+	assert.Len(t, events.events, 2)
+
+	tests := []struct {
+		name   string
+		modify func(l *entity.Loan)
+	}{
+		{"zero principal", func(l *entity.Loan) { l.Principal = 0 }},
+		{"negative rate", func(l *entity.Loan) { l.AnnualRateBP = -1 }},
+		{"penalty rate above 100%", func(l *entity.Loan) { l.PenaltyRateBP = 10001 }},
+		{"no term", func(l *entity.Loan) { l.TermMonths = 0 }},
+		{"long term", func(l *entity.Loan) { l.TermMonths = maxLoanTermMonths + 1 }},
+		{"unknown method", func(l *entity.Loan) { l.Method = "balloon" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valid
+			tt.modify(&v)
+			_, err := s.Disburse(context.Background(), "admin", v)
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+	assert.Len(t, repo.loans, 1)
+}
+
+func TestLoanRepay(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := uuid.New()
+	disbursed := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	repo := &stubLoanRepo{
+		loans: map[int64]*entity.Loan{1: {
+			ID:            1,
+			AccountID:     account,
+			Principal:     3000,
+			PenaltyRateBP: 3650,
+			TermMonths:    3,
+			Method:        entity.AmortizationLinear,
+			Status:        entity.LoanActive,
+			Outstanding:   3000,
+			Installments:  entity.AmortizationSchedule(3000, 0, 3, entity.AmortizationLinear, disbursed),
+		}},
+		balances: map[uuid.UUID]int64{account: 500},
+	}
+	for n := range repo.loans[1].Installments {
+		repo.loans[1].Installments[n].LoanID = 1
+	}
+	events := &stubPublisher{}
+	s := NewLoanService(repo, events, &logger)
+
+	_, err := s.Repay(context.Background(), time.Now().AddDate(0, 0, 2))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// the account can't pay the first installment on its due date
+	run, err := s.Repay(context.Background(), disbursed.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchRun{Date: disbursed.AddDate(0, 1, 0), Skipped: 1}, run)
+	assert.Equal(t, []int32{1}, repo.late)
+
+	// the second installment waits for the late one
+	run, err = s.Repay(context.Background(), disbursed.AddDate(0, 2, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Skipped)
+	assert.Equal(t, []int32{1, 2}, repo.late)
+
+	// the late installments are paid with 10% a year of penalty interest
+	repo.balances[account] = 5000
+	date := disbursed.AddDate(0, 2, 10)
+	run, err = s.Repay(context.Background(), date)
+	require.NoError(t, err)
+	assert.Equal(t, 2, run.Processed)
+	// 1000 is 38 days late and 1000 is 10 days late
+	assert.Equal(t, int64(2000+38+10), run.Total)
+	assert.Len(t, events.events, 4)
+
+	loan, err := s.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), loan.Outstanding)
+	assert.Equal(t, int64(38), loan.Installments[0].Penalty)
+	assert.Equal(t, &date, loan.Installments[1].PaidOn)
+
+	// the repeated run skips the paid installments
+	run, err = s.Repay(context.Background(), date)
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchRun{Date: date}, run)
+
+	run, err = s.Repay(context.Background(), disbursed.AddDate(0, 3, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 1, run.Processed)
+	assert.Equal(t, entity.LoanRepaid, repo.loans[1].Status)
+}
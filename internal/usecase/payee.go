@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+// maxNicknameLength is the limit of the payee nickname.
+const maxNicknameLength = 64
+
+type payeeService struct {
+	db        PayeeRepo
+	accounts  AccountService
+	transfers TransferService
+	// coolingOff is the period after the payee creation during which
+	// it can't receive amounts above coolingOffLimit. A zero value
+	// disables the check.
+	coolingOff      time.Duration
+	coolingOffLimit int64
+	l               zerologx.Logger
+}
+
+func NewPayeeService(r PayeeRepo, as AccountService, ts TransferService,
+	coolingOff time.Duration, coolingOffLimit int64, l zerologx.Logger) PayeeService {
+	return &payeeService{
+		db:              r,
+		accounts:        as,
+		transfers:       ts,
+		coolingOff:      coolingOff,
+		coolingOffLimit: coolingOffLimit,
+		l:               l,
+	}
+}
+
+// Create saves the recipient account given either by id or by number.
+func (s *payeeService) Create(ctx context.Context, owner string, p entity.Payee) (entity.Payee, error) {
+	nickname, err := checkNickname(p.Nickname)
+	if err != nil {
+		return entity.Payee{}, err
+	}
+
+	var account entity.Account
+	switch {
+	case p.AccountID != uuid.Nil && p.AccountNumber != "":
+		return entity.Payee{}, fmt.Errorf("%w: both account id and number are given", ErrInvalidArgument)
+	case p.AccountNumber != "":
+		account, err = s.accounts.GetByNumber(ctx, p.AccountNumber)
+	case p.AccountID != uuid.Nil:
+		account, err = s.accounts.Get(ctx, p.AccountID)
+	default:
+		return entity.Payee{}, fmt.Errorf("%w: account id or number is required", ErrInvalidArgument)
+	}
+	if err != nil {
+		return entity.Payee{}, err
+	}
+
+	return s.db.Create(ctx, entity.Payee{
+		Owner:     owner,
+		Nickname:  nickname,
+		AccountID: account.ID,
+	})
+}
+
+func (s *payeeService) Get(ctx context.Context, owner string, id int64) (entity.Payee, error) {
+	return s.db.Get(ctx, owner, id)
+}
+
+func (s *payeeService) List(ctx context.Context, owner string) ([]entity.Payee, error) {
+	return s.db.List(ctx, owner)
+}
+
+func (s *payeeService) Rename(ctx context.Context, owner string, id int64, nickname string) (entity.Payee, error) {
+	nickname, err := checkNickname(nickname)
+	if err != nil {
+		return entity.Payee{}, err
+	}
+	return s.db.UpdateNickname(ctx, owner, id, nickname)
+}
+
+func (s *payeeService) Delete(ctx context.Context, owner string, id int64) error {
+	return s.db.Delete(ctx, owner, id)
+}
+
+// Transfer sends the transfer from the account held by the owner to the
+// payee.
+func (s *payeeService) Transfer(ctx context.Context, owner string, id int64, t entity.Transfer) (entity.TransferRes, error) {
+	payee, err := s.db.Get(ctx, owner, id)
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	if s.coolingOff > 0 && t.Amount > s.coolingOffLimit {
+		if until := payee.CreatedAt.Add(s.coolingOff); time.Now().Before(until) {
+			return entity.TransferRes{}, fmt.Errorf("%w: amounts above %d are allowed after %s",
+				ErrCoolingOff, s.coolingOffLimit, until.UTC().Format(time.RFC3339))
+		}
+	}
+
+	from, err := s.accounts.Get(ctx, t.FromAccountID)
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	if err = s.accounts.Authorize(ctx, owner, from, entity.PermissionTransfer); err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	t.ToAccountID = payee.AccountID
+	return s.transfers.Transfer(ctx, owner, t)
+}
+
+func checkNickname(nickname string) (string, error) {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" || len([]rune(nickname)) > maxNicknameLength {
+		return "", fmt.Errorf("%w: nickname must have 1 to %d characters", ErrInvalidArgument, maxNicknameLength)
+	}
+	return nickname, nil
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/accnum"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+// ISO 20022 external status reason codes.
+const (
+	reasonIncorrectAccount  = "AC01"
+	reasonZeroAmount        = "AM01"
+	reasonInsufficientFunds = "AM04"
+	reasonInvalidControlSum = "AM10"
+	reasonInvalidCurrency   = "AM11"
+	reasonLimitExceeded     = "AM14"
+	reasonInvalidNbOfTxs    = "AM18"
+	reasonDuplicateMessage  = "DU01"
+	reasonFraud             = "FR01"
+	reasonNarrative         = "NARR"
+	reasonRegulatory        = "RR04"
+)
+
+// maxAdditionalInfoLength is the limit of the status reason narrative.
+const maxAdditionalInfoLength = 105
+
+type paymentService struct {
+	accounts  AccountRepo
+	imports   PaymentImportRepo
+	transfers TransferService
+	authz     *Authorizer
+	l         zerologx.Logger
+}
+
+func NewPaymentService(ar AccountRepo, ir PaymentImportRepo, ts TransferService, authz *Authorizer,
+	l zerologx.Logger) PaymentService {
+	return &paymentService{
+		accounts:  ar,
+		imports:   ir,
+		transfers: ts,
+		authz:     authz,
+		l:         l,
+	}
+}
+
+// Import executes the credit transfers of the payment file on behalf of
+// the holder of the debtor accounts. Every instruction is executed on its
+// own, so the report may accept a part of the file.
+func (s *paymentService) Import(ctx context.Context, owner string, f entity.PaymentFile) (entity.PaymentStatusReport, error) {
+	report := entity.PaymentStatusReport{
+		MsgID:        strings.ReplaceAll(uuid.NewString(), "-", ""),
+		CreatedAt:    time.Now().UTC(),
+		OrgnlMsgID:   f.MsgID,
+		OrgnlNbOfTxs: f.NbOfTxs,
+		OrgnlCtrlSum: f.CtrlSum,
+	}
+
+	var (
+		count int
+		sum   int64
+	)
+	for _, b := range f.Batches {
+		for _, instr := range b.Instructions {
+			count++
+			sum += instr.Amount
+		}
+	}
+	if reason := checkControls(f.NbOfTxs, f.CtrlSum, count, sum); reason != nil {
+		report.Status, report.Reason = entity.PaymentRejected, reason
+		return report, nil
+	}
+
+	err := s.imports.Register(ctx, owner, f.MsgID)
+	if errors.Is(err, ErrDuplicate) {
+		report.Status = entity.PaymentRejected
+		report.Reason = &entity.PaymentStatusReason{Code: reasonDuplicateMessage, Info: "duplicate message id"}
+		return report, nil
+	}
+	if err != nil {
+		return entity.PaymentStatusReport{}, err
+	}
+
+	var accepted, total int
+	for _, b := range f.Batches {
+		status := s.importBatch(ctx, owner, b)
+		for _, tx := range status.Transactions {
+			if tx.Status == entity.PaymentAccepted {
+				accepted++
+			}
+		}
+		total += len(status.Transactions)
+		report.Batches = append(report.Batches, status)
+	}
+	report.Status = aggregateStatus(accepted, total)
+
+	return report, nil
+}
+
+func (s *paymentService) importBatch(ctx context.Context, owner string, b entity.PaymentBatch) entity.PaymentBatchStatus {
+	status := entity.PaymentBatchStatus{OrgnlBatchID: b.ID}
+
+	var sum int64
+	for _, instr := range b.Instructions {
+		sum += instr.Amount
+	}
+
+	// a batch level failure rejects all of its instructions
+	reason := checkControls(b.NbOfTxs, b.CtrlSum, len(b.Instructions), sum)
+
+	var debtor entity.Account
+	if reason == nil {
+		var err error
+		debtor, err = s.account(ctx, b.DebtorAccount)
+		if err == nil && s.authz.Authorize(ctx, owner, debtor, entity.PermissionTransfer) != nil {
+			err = ErrNotFound
+		}
+		if err != nil {
+			reason = paymentReason(err, "debtor account")
+		}
+	}
+
+	var accepted int
+	for _, instr := range b.Instructions {
+		tx := entity.PaymentTxStatus{
+			OrgnlInstrID:    instr.InstrID,
+			OrgnlEndToEndID: instr.EndToEndID,
+			Status:          entity.PaymentRejected,
+			Reason:          reason,
+		}
+		if reason == nil {
+			tx.TransferID, tx.Status, tx.Reason = s.execute(ctx, owner, debtor, instr)
+		}
+		if tx.Status != entity.PaymentRejected {
+			accepted++
+		}
+		status.Transactions = append(status.Transactions, tx)
+	}
+	status.Status = aggregateStatus(accepted, len(b.Instructions))
+
+	return status
+}
+
+// execute transfers the instructed amount from the debtor account on
+// behalf of the initiator and returns the transfer id or the reason of
+// the rejection. The transfers held for review or waiting for approval
+// are pending.
+func (s *paymentService) execute(ctx context.Context, initiator string, debtor entity.Account,
+	instr entity.PaymentInstruction) (int64, entity.PaymentStatus, *entity.PaymentStatusReason) {
+	if instr.Amount <= 0 {
+		return 0, entity.PaymentRejected, &entity.PaymentStatusReason{Code: reasonZeroAmount, Info: "amount must be positive"}
+	}
+	if instr.Currency != debtor.Currency {
+		return 0, entity.PaymentRejected, &entity.PaymentStatusReason{
+			Code: reasonInvalidCurrency, Info: "currency differs from the debtor account",
+		}
+	}
+
+	creditor, err := s.account(ctx, instr.CreditorAccount)
+	if err != nil {
+		return 0, entity.PaymentRejected, paymentReason(err, "creditor account")
+	}
+	if creditor.Currency != instr.Currency {
+		return 0, entity.PaymentRejected, &entity.PaymentStatusReason{
+			Code: reasonInvalidCurrency, Info: "currency differs from the creditor account",
+		}
+	}
+
+	reference := instr.EndToEndID
+	if reference == "NOTPROVIDED" {
+		reference = ""
+	}
+
+	res, err := s.transfers.Transfer(ctx, initiator, entity.Transfer{
+		FromAccountID: debtor.ID,
+		ToAccountID:   creditor.ID,
+		Amount:        instr.Amount,
+		Description:   instr.Description,
+		Reference:     reference,
+	})
+	if errors.Is(err, ErrTransferHeld) || errors.Is(err, ErrApprovalRequired) {
+		return 0, entity.PaymentPending, &entity.PaymentStatusReason{Code: reasonNarrative, Info: additionalInfo(err)}
+	}
+	if err != nil {
+		s.l.Error(err, "usecase - payment - execute")
+		return 0, entity.PaymentRejected, paymentReason(err, "transfer")
+	}
+
+	return res.Transfer.ID, entity.PaymentAccepted, nil
+}
+
+// account resolves the account identification of the payment file,
+// either an account id or an account number.
+func (s *paymentService) account(ctx context.Context, identification string) (entity.Account, error) {
+	if id, err := uuid.Parse(identification); err == nil {
+		return s.accounts.Get(ctx, id)
+	}
+
+	number := accnum.Normalize(identification)
+	if accnum.Validate(number) != nil {
+		return entity.Account{}, ErrNotFound
+	}
+	return s.accounts.GetByNumber(ctx, number)
+}
+
+func checkControls(nbOfTxs int, ctrlSum *int64, count int, sum int64) *entity.PaymentStatusReason {
+	if nbOfTxs != count {
+		return &entity.PaymentStatusReason{
+			Code: reasonInvalidNbOfTxs,
+			Info: fmt.Sprintf("declared %d transactions, found %d", nbOfTxs, count),
+		}
+	}
+	if ctrlSum != nil && *ctrlSum != sum {
+		return &entity.PaymentStatusReason{Code: reasonInvalidControlSum, Info: "control sum mismatch"}
+	}
+	return nil
+}
+
+func paymentReason(err error, subject string) *entity.PaymentStatusReason {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return &entity.PaymentStatusReason{Code: reasonIncorrectAccount, Info: subject + " not found"}
+	case errors.Is(err, ErrInsufficientFunds):
+		return &entity.PaymentStatusReason{Code: reasonInsufficientFunds, Info: err.Error()}
+	case errors.Is(err, ErrLimitExceeded):
+		return &entity.PaymentStatusReason{Code: reasonLimitExceeded, Info: additionalInfo(err)}
+	case errors.Is(err, ErrTransferBlocked):
+		return &entity.PaymentStatusReason{Code: reasonFraud, Info: additionalInfo(err)}
+	case errors.Is(err, ErrScreeningBlocked):
+		return &entity.PaymentStatusReason{Code: reasonRegulatory, Info: "blocked by sanctions screening"}
+	case errors.Is(err, ErrInvalidArgument):
+		return &entity.PaymentStatusReason{Code: reasonNarrative, Info: additionalInfo(err)}
+	default:
+		return &entity.PaymentStatusReason{Code: reasonNarrative, Info: subject + " failed"}
+	}
+}
+
+// additionalInfo returns the error message cut to the limit of the
+// status reason narrative in characters.
+func additionalInfo(err error) string {
+	info := []rune(err.Error())
+	if len(info) > maxAdditionalInfoLength {
+		info = info[:maxAdditionalInfoLength]
+	}
+	return string(info)
+}
+
+func aggregateStatus(accepted, total int) entity.PaymentStatus {
+	switch {
+	case total > 0 && accepted == total:
+		return entity.PaymentAccepted
+	case accepted == 0:
+		return entity.PaymentRejected
+	default:
+		return entity.PaymentPartiallyAccepted
+	}
+}
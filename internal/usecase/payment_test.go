@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdditionalInfo(t *testing.T) {
+	assert.Equal(t, "short", additionalInfo(errors.New("short")))
+
+	// the cut falls on the multi-byte characters
+	info := additionalInfo(errors.New(strings.Repeat("ж", maxAdditionalInfoLength+1)))
+	assert.True(t, utf8.ValidString(info))
+	assert.Equal(t, maxAdditionalInfoLength, utf8.RuneCountInString(info))
+}
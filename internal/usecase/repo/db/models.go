@@ -5,10 +5,13 @@
 package db
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"alukart32.com/bank/entity"
 	"github.com/google/uuid"
 )
 
@@ -55,10 +58,123 @@ func (ns NullCurrency) Value() (driver.Value, error) {
 }
 
 type Account struct {
-	ID        uuid.UUID `json:"id"`
-	Owner     string    `json:"owner"`
+	ID        uuid.UUID      `json:"id"`
+	Owner     string         `json:"owner"`
+	Balance   int64          `json:"balance"`
+	Currency  Currency       `json:"currency"`
+	CreatedAt time.Time      `json:"created_at"`
+	Number    sql.NullString `json:"number"`
+	Kind      string         `json:"kind"`
+}
+
+type AccountHolder struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Holder    string    `json:"holder"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	// the holder who invited this one, empty for the primary holder
+	InvitedBy  string       `json:"invited_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+	AcceptedAt sql.NullTime `json:"accepted_at"`
+}
+
+type AccountProduct struct {
+	AccountID uuid.UUID `json:"account_id"`
+	ProductID int64     `json:"product_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AccountLimit struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Tier      string    `json:"tier"`
+	// overrides of the tier limits, null means the tier limit
+	MaxSingle   sql.NullInt64 `json:"max_single"`
+	Daily       sql.NullInt64 `json:"daily"`
+	Monthly     sql.NullInt64 `json:"monthly"`
+	HourlyCount sql.NullInt64 `json:"hourly_count"`
+	UpdatedBy   string        `json:"updated_by"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type ApprovalPolicy struct {
+	AccountID uuid.UUID `json:"account_id"`
+	// the lowest amount of the transfers that need an approval
+	Threshold int64 `json:"threshold"`
+	// the users allowed to approve the transfers of others
+	Approvers []string  `json:"approvers"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type AuditLog struct {
+	ID         int64  `json:"id"`
+	Actor      string `json:"actor"`
+	RequestID  string `json:"request_id"`
+	ClientIp   string `json:"client_ip"`
+	Action     string `json:"action"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	// json keeps the snapshots as they are hashed, jsonb reformats them
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	AccountID uuid.UUID `json:"account_id"`
+	// the end of the UTC day, the balance holds the entries made before it
+	AsOf      time.Time `json:"as_of"`
 	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BusinessDate struct {
+	Singleton bool      `json:"singleton"`
+	Date      time.Time `json:"date"`
+	Frozen    bool      `json:"frozen"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CashOperation struct {
+	ID int64 `json:"id"`
+	// the unique reference printed on the receipt
+	Reference string    `json:"reference"`
+	Direction string    `json:"direction"`
+	Channel   string    `json:"channel"`
+	TellerID  string    `json:"teller_id"`
+	AccountID uuid.UUID `json:"account_id"`
+	Amount    int64     `json:"amount"`
 	Currency  Currency  `json:"currency"`
+	// the account balance after the operation
+	Balance   int64     `json:"balance"`
+	EntryID   int64     `json:"entry_id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ClearingBatch struct {
+	ID       int64    `json:"id"`
+	FileName string   `json:"file_name"`
+	Currency Currency `json:"currency"`
+	Count    int64    `json:"count"`
+	Total    int64    `json:"total"`
+	// created until the file is delivered, processed with its response
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DailyStatement struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	StatementDate  time.Time `json:"statement_date"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	// the totals of the debit and credit entries of the date
+	Debits    int64     `json:"debits"`
+	Credits   int64     `json:"credits"`
+	Entries   int64     `json:"entries"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -66,17 +182,303 @@ type Entry struct {
 	ID        int64     `json:"id"`
 	AccountID uuid.UUID `json:"account_id"`
 	// can be negative or positive
-	Amount    int64     `json:"amount"`
+	Amount      int64           `json:"amount"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    entity.Metadata `json:"metadata"`
+}
+
+type EodRun struct {
+	ID           int64        `json:"id"`
+	BusinessDate time.Time    `json:"business_date"`
+	Status       string       `json:"status"`
+	Error        string       `json:"error"`
+	StartedBy    string       `json:"started_by"`
+	StartedAt    time.Time    `json:"started_at"`
+	FinishedAt   sql.NullTime `json:"finished_at"`
+}
+
+type EodStep struct {
+	RunID  int64  `json:"run_id"`
+	Step   string `json:"step"`
+	Status string `json:"status"`
+	// the batch run of the step
+	Result     json.RawMessage `json:"result"`
+	Error      string          `json:"error"`
+	StartedAt  time.Time       `json:"started_at"`
+	FinishedAt sql.NullTime    `json:"finished_at"`
+}
+
+type ExternalTransfer struct {
+	ID                 int64         `json:"id"`
+	AccountID          uuid.UUID     `json:"account_id"`
+	Amount             int64         `json:"amount"`
+	Currency           Currency      `json:"currency"`
+	BeneficiaryName    string        `json:"beneficiary_name"`
+	BeneficiaryAccount string        `json:"beneficiary_account"`
+	BeneficiaryBank    string        `json:"beneficiary_bank"`
+	Description        string        `json:"description"`
+	Reference          string        `json:"reference"`
+	Status             string        `json:"status"`
+	BatchID            sql.NullInt64 `json:"batch_id"`
+	ReturnReason       string        `json:"return_reason"`
+	// the debit of the customer account
+	EntryID   int64     `json:"entry_id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// approval waits for an approver of the account, review for an operator
+	Hold  string `json:"hold"`
+	Score int32  `json:"score"`
+	// the triggered risk rules
+	Signals    entity.RiskSignals `json:"signals"`
+	ReviewedBy string             `json:"reviewed_by"`
+	Comment    string             `json:"comment"`
+}
+
+type FeeCharge struct {
+	ID         int64     `json:"id"`
+	ScheduleID int64     `json:"schedule_id"`
+	AccountID  uuid.UUID `json:"account_id"`
+	Amount     int64     `json:"amount"`
+	// the charged transfer or the first day of the charged maintenance month
+	TransferID         sql.NullInt64 `json:"transfer_id"`
+	Period             sql.NullTime  `json:"period"`
+	DebitEntryID       int64         `json:"debit_entry_id"`
+	CreditEntryID      int64         `json:"credit_entry_id"`
+	CreatedAt          time.Time     `json:"created_at"`
+	ExternalTransferID sql.NullInt64 `json:"external_transfer_id"`
+}
+
+type FeeSchedule struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	AppliesTo string `json:"applies_to"`
+	// null applies to the accounts without a schedule of their product
+	ProductID sql.NullInt64 `json:"product_id"`
+	Currency  Currency      `json:"currency"`
+	Fixed     int64         `json:"fixed"`
+	RateBp    int32         `json:"rate_bp"`
+	// the bounds of the percentage and tiered fees, a zero max is not enforced
+	MinAmount int64           `json:"min_amount"`
+	MaxAmount int64           `json:"max_amount"`
+	Tiers     entity.FeeTiers `json:"tiers"`
+	// the bank account the fee is paid to
+	RevenueAccountID uuid.UUID `json:"revenue_account_id"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type GlAccount struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Currency  Currency  `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type InterestAccrual struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	ProductID   int64     `json:"product_id"`
+	// the end-of-day balance and the terms it accrued by
+	Balance      int64     `json:"balance"`
+	AnnualRateBp int32     `json:"annual_rate_bp"`
+	DayCount     string    `json:"day_count"`
+	Days         int64     `json:"days"`
+	AmountMicros int64     `json:"amount_micros"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64     `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	// the first day of the posted month
+	Period        time.Time `json:"period"`
+	AccruedMicros int64     `json:"accrued_micros"`
+	Amount        int64     `json:"amount"`
+	CarryMicros   int64     `json:"carry_micros"`
+	// null if nothing is posted
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type InterestProduct struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Currency Currency `json:"currency"`
+	// the annual rate in basis points
+	AnnualRateBp int32  `json:"annual_rate_bp"`
+	DayCount     string `json:"day_count"`
+	// the bank account the interest is paid from
+	ExpenseAccountID uuid.UUID `json:"expense_account_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type LimitTier struct {
+	Name string `json:"name"`
+	// a zero limit is not enforced
+	MaxSingle   int64 `json:"max_single"`
+	Daily       int64 `json:"daily"`
+	Monthly     int64 `json:"monthly"`
+	HourlyCount int64 `json:"hourly_count"`
+}
+
+type Loan struct {
+	ID int64 `json:"id"`
+	// the account the loan is disbursed to and repaid from
+	AccountID uuid.UUID `json:"account_id"`
+	Principal int64     `json:"principal"`
+	Currency  Currency  `json:"currency"`
+	// the annual rates in basis points
+	AnnualRateBp  int32  `json:"annual_rate_bp"`
+	PenaltyRateBp int32  `json:"penalty_rate_bp"`
+	TermMonths    int32  `json:"term_months"`
+	Method        string `json:"method"`
+	Status        string `json:"status"`
+	// the principal not repaid yet
+	Outstanding int64        `json:"outstanding"`
+	DisbursedOn time.Time    `json:"disbursed_on"`
+	CreatedBy   string       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+	ClosedAt    sql.NullTime `json:"closed_at"`
+}
+
+type LoanInstallment struct {
+	LoanID    int64     `json:"loan_id"`
+	Number    int32     `json:"number"`
+	DueDate   time.Time `json:"due_date"`
+	Principal int64     `json:"principal"`
+	Interest  int64     `json:"interest"`
+	// the penalty interest paid with the late installment
+	Penalty int64        `json:"penalty"`
+	Status  string       `json:"status"`
+	PaidOn  sql.NullTime `json:"paid_on"`
+}
+
+type Payee struct {
+	ID        int64     `json:"id"`
+	Owner     string    `json:"owner"`
+	Nickname  string    `json:"nickname"`
+	AccountID uuid.UUID `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PaymentImport struct {
+	Owner     string    `json:"owner"`
+	MsgID     string    `json:"msg_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScreeningAlert struct {
+	ID int64 `json:"id"`
+	// the screened owner name
+	Name    string `json:"name"`
+	Trigger string `json:"trigger"`
+	// the opened or updated account, or the transfer recipient
+	AccountID  uuid.UUID               `json:"account_id"`
+	Score      float64                 `json:"score"`
+	Matches    entity.ScreeningMatches `json:"matches"`
+	Decision   string                  `json:"decision"`
+	Status     string                  `json:"status"`
+	ReviewedBy string                  `json:"reviewed_by"`
+	Comment    string                  `json:"comment"`
+	ReviewedAt sql.NullTime            `json:"reviewed_at"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+type TermDeposit struct {
+	ID int64 `json:"id"`
+	// the account the principal is taken from
+	AccountID uuid.UUID `json:"account_id"`
+	// the account the deposit is paid out to
+	PayoutAccountID uuid.UUID `json:"payout_account_id"`
+	Principal       int64     `json:"principal"`
+	Currency        Currency  `json:"currency"`
+	// the annual rate in basis points
+	AnnualRateBp int32 `json:"annual_rate_bp"`
+	TermMonths   int32 `json:"term_months"`
+	// the dates of the current term
+	StartDate       time.Time `json:"start_date"`
+	MaturityDate    time.Time `json:"maturity_date"`
+	MaturityAction  string    `json:"maturity_action"`
+	EarlyWithdrawal string    `json:"early_withdrawal"`
+	// taken off the annual rate of the early withdrawal
+	EarlyPenaltyBp int32  `json:"early_penalty_bp"`
+	Status         string `json:"status"`
+	Rollovers      int32  `json:"rollovers"`
+	// the interest paid out or added to the principal
+	InterestPaid int64        `json:"interest_paid"`
+	CreatedBy    string       `json:"created_by"`
+	CreatedAt    time.Time    `json:"created_at"`
+	ClosedAt     sql.NullTime `json:"closed_at"`
+}
+
+type TransferApproval struct {
+	ID            int64           `json:"id"`
+	FromAccountID uuid.UUID       `json:"from_account_id"`
+	ToAccountID   uuid.UUID       `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      entity.Metadata `json:"metadata"`
+	Status        string          `json:"status"`
+	InitiatedBy   string          `json:"initiated_by"`
+	DecidedBy     string          `json:"decided_by"`
+	Comment       string          `json:"comment"`
+	// the transfer executed on approval
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	DecidedAt  sql.NullTime  `json:"decided_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type TransferReview struct {
+	ID            int64           `json:"id"`
+	FromAccountID uuid.UUID       `json:"from_account_id"`
+	ToAccountID   uuid.UUID       `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Description   string          `json:"description"`
+	Reference     string          `json:"reference"`
+	Metadata      entity.Metadata `json:"metadata"`
+	Score         int32           `json:"score"`
+	// the triggered risk rules
+	Signals entity.RiskSignals `json:"signals"`
+	Status  string             `json:"status"`
+	// the transfer executed on approval
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ReviewedBy string        `json:"reviewed_by"`
+	Comment    string        `json:"comment"`
+	ReviewedAt sql.NullTime  `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type TransferTransition struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// empty for the initial status
+	FromStatus entity.TransferStatus `json:"from_status"`
+	ToStatus   entity.TransferStatus `json:"to_status"`
+	Reason     string                `json:"reason"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
 type Transfer struct {
 	ID            int64     `json:"id"`
 	FromAccountID uuid.UUID `json:"from_account_id"`
 	ToAccountID   uuid.UUID `json:"to_account_id"`
 	// must be positive
-	Amount      int64     `json:"amount"`
-	CreatedAt   time.Time `json:"created_at"`
-	FromEntryID int64     `json:"from_entry_id"`
-	ToEntryID   int64     `json:"to_entry_id"`
+	Amount        int64                 `json:"amount"`
+	CreatedAt     time.Time             `json:"created_at"`
+	FromEntryID   sql.NullInt64         `json:"from_entry_id"`
+	ToEntryID     sql.NullInt64         `json:"to_entry_id"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	UpdatedAt     time.Time             `json:"updated_at"`
 }
@@ -0,0 +1,71 @@
+-- Approval
+-- name: GetApprovalPolicy :one
+SELECT * FROM approval_policies
+WHERE account_id = $1;
+
+-- name: UpsertApprovalPolicy :one
+INSERT INTO approval_policies (
+  account_id,
+  threshold,
+  approvers,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (account_id) DO UPDATE
+SET threshold = EXCLUDED.threshold,
+  approvers = EXCLUDED.approvers,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteApprovalPolicy :execrows
+DELETE FROM approval_policies
+WHERE account_id = $1;
+
+-- name: CreateTransferApproval :one
+INSERT INTO transfer_approvals (
+  from_account_id,
+  to_account_id,
+  amount,
+  description,
+  reference,
+  metadata,
+  initiated_by,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetTransferApproval :one
+SELECT * FROM transfer_approvals
+WHERE id = $1;
+
+-- name: ListTransferApprovals :many
+SELECT TA.* FROM transfer_approvals AS TA
+JOIN approval_policies AS P ON P.account_id = TA.from_account_id
+WHERE TA.status = 'pending_approval'
+  AND sqlc.arg(approver)::varchar = ANY(P.approvers)
+  AND TA.initiated_by <> sqlc.arg(approver)
+ORDER BY TA.id;
+
+-- name: DecideTransferApproval :one
+UPDATE transfer_approvals
+SET status = $2,
+  decided_by = $3,
+  comment = $4,
+  decided_at = now()
+WHERE id = $1 AND status = 'pending_approval' AND expires_at > now()
+RETURNING *;
+
+-- name: ExpireTransferApprovals :many
+-- the pending transfers of the expired approvals
+UPDATE transfer_approvals
+SET status = 'expired'
+WHERE status = 'pending_approval' AND expires_at <= now()
+RETURNING transfer_id;
+
+-- name: SetTransferApprovalTransfer :one
+UPDATE transfer_approvals
+SET transfer_id = $2
+WHERE id = $1
+RETURNING *;
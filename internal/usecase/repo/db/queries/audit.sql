@@ -0,0 +1,41 @@
+-- Audit
+-- name: LockAuditLog :exec
+-- serializes the appends, so each record follows the last one
+SELECT pg_advisory_xact_lock(hashtext('audit_log'));
+
+-- name: GetLastAuditRecord :one
+SELECT * FROM audit_log
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditRecord :one
+INSERT INTO audit_log (
+  actor,
+  request_id,
+  client_ip,
+  action,
+  target_type,
+  target_id,
+  before,
+  after,
+  prev_hash,
+  hash,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: ListAuditRecords :many
+SELECT * FROM audit_log
+WHERE (sqlc.arg(target_type)::varchar = '' OR target_type = sqlc.arg(target_type))
+  AND (sqlc.arg(target_id)::varchar = '' OR target_id = sqlc.arg(target_id))
+  AND (sqlc.arg(actor)::varchar = '' OR actor = sqlc.arg(actor))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListAuditChain :many
+SELECT * FROM audit_log
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
@@ -0,0 +1,29 @@
+-- Balance
+-- name: CreateBalanceSnapshots :execrows
+-- the balance as of the time is the current one less the entries made since
+INSERT INTO balance_snapshots (account_id, as_of, balance)
+SELECT A.id, sqlc.arg(as_of), (A.balance - COALESCE((
+    SELECT SUM(E.amount) FROM entries AS E
+    WHERE E.account_id = A.id AND E.created_at >= sqlc.arg(as_of)
+  ), 0))::bigint
+FROM accounts AS A
+WHERE A.created_at < sqlc.arg(as_of)
+ON CONFLICT (account_id, as_of) DO NOTHING;
+
+-- name: GetBalanceSnapshot :one
+-- the latest snapshot of the account at or before the time
+SELECT * FROM balance_snapshots
+WHERE account_id = $1 AND as_of <= sqlc.arg(before)
+ORDER BY as_of DESC
+LIMIT 1;
+
+-- name: DeleteBalanceSnapshots :execrows
+DELETE FROM balance_snapshots;
+
+-- name: GetFirstAccountTime :one
+SELECT COALESCE(MIN(created_at), now())::timestamptz FROM accounts;
+
+-- name: SumEntriesBetween :one
+-- the total of the account entries made in [from, to)
+SELECT COALESCE(SUM(amount), 0)::bigint FROM entries
+WHERE account_id = $1 AND created_at >= sqlc.arg(from_time) AND created_at < sqlc.arg(to_time);
@@ -0,0 +1,34 @@
+-- Cash
+-- name: NextCashReference :one
+SELECT nextval('cash_reference_seq')::bigint;
+
+-- name: CreateCashOperation :one
+INSERT INTO cash_operations (
+  reference,
+  direction,
+  channel,
+  teller_id,
+  account_id,
+  amount,
+  currency,
+  balance,
+  entry_id,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetCashOperation :one
+SELECT * FROM cash_operations
+WHERE reference = $1;
+
+-- name: ListCashOperations :many
+SELECT * FROM cash_operations
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: SumCashOperations :one
+-- the total of the account operations through the channel since the time
+SELECT coalesce(sum(amount), 0)::bigint FROM cash_operations
+WHERE account_id = $1 AND channel = $2 AND direction = $3 AND created_at >= sqlc.arg(since);
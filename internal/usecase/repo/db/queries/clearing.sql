@@ -0,0 +1,110 @@
+-- Clearing
+-- name: CreateExternalTransfer :one
+INSERT INTO external_transfers (
+  account_id,
+  amount,
+  currency,
+  beneficiary_name,
+  beneficiary_account,
+  beneficiary_bank,
+  description,
+  reference,
+  entry_id,
+  created_by,
+  status,
+  hold,
+  score,
+  signals
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetExternalTransfer :one
+SELECT * FROM external_transfers
+WHERE id = $1;
+
+-- name: ListExternalTransfers :many
+SELECT * FROM external_transfers
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: ListHeldExternalTransfers :many
+SELECT * FROM external_transfers
+WHERE status = 'held' AND hold = $1
+ORDER BY id;
+
+-- name: DecideExternalTransfer :one
+-- only the transfers waiting for the hold are decided
+UPDATE external_transfers
+SET status = $2, reviewed_by = $4, comment = $5, updated_at = now()
+WHERE id = $1 AND status = 'held' AND hold = $3
+RETURNING *;
+
+-- name: HoldExternalTransfer :one
+-- the approved transfer is held for review by the risk rules
+UPDATE external_transfers
+SET hold = 'review', score = $2, signals = $3, reviewed_by = $4, comment = $5, updated_at = now()
+WHERE id = $1 AND status = 'held' AND hold = 'approval'
+RETURNING *;
+
+-- name: ListPendingExternalTransfers :many
+-- the locks keep the transfers out of the other batches
+SELECT * FROM external_transfers
+WHERE status = 'pending'
+ORDER BY id
+FOR UPDATE SKIP LOCKED;
+
+-- name: ListBatchExternalTransfers :many
+SELECT * FROM external_transfers
+WHERE batch_id = $1
+ORDER BY id;
+
+-- name: AddExternalTransferToBatch :exec
+UPDATE external_transfers
+SET status = 'sent', batch_id = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: SettleExternalTransfer :one
+-- only the sent transfers of the batch are settled
+UPDATE external_transfers
+SET status = 'settled', updated_at = now()
+WHERE id = $1 AND batch_id = $2 AND status = 'sent'
+RETURNING *;
+
+-- name: ReturnExternalTransfer :one
+-- only the sent transfers of the batch are returned
+UPDATE external_transfers
+SET status = 'returned', return_reason = $3, updated_at = now()
+WHERE id = $1 AND batch_id = $2 AND status = 'sent'
+RETURNING *;
+
+-- name: CreateClearingBatch :one
+INSERT INTO clearing_batches (
+  file_name,
+  currency,
+  count,
+  total
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetClearingBatchByFileName :one
+SELECT * FROM clearing_batches
+WHERE file_name = $1;
+
+-- name: ListClearingBatches :many
+SELECT * FROM clearing_batches
+ORDER BY id DESC
+LIMIT $1;
+
+-- name: ListCreatedClearingBatches :many
+SELECT * FROM clearing_batches
+WHERE status = 'created'
+ORDER BY id;
+
+-- name: UpdateClearingBatchStatus :one
+UPDATE clearing_batches
+SET status = $2, updated_at = now()
+WHERE id = $1
+RETURNING *;
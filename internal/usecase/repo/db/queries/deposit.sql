@@ -0,0 +1,52 @@
+-- Term deposits
+-- name: CreateTermDeposit :one
+INSERT INTO term_deposits (
+  account_id,
+  payout_account_id,
+  principal,
+  currency,
+  annual_rate_bp,
+  term_months,
+  start_date,
+  maturity_date,
+  maturity_action,
+  early_withdrawal,
+  early_penalty_bp,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetTermDeposit :one
+SELECT * FROM term_deposits
+WHERE id = $1;
+
+-- name: ListTermDeposits :many
+SELECT * FROM term_deposits
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: ListMaturedTermDeposits :many
+SELECT * FROM term_deposits
+WHERE status = 'active' AND maturity_date <= $1
+ORDER BY id;
+
+-- name: CloseTermDeposit :one
+-- only the active deposits are closed
+UPDATE term_deposits
+SET status = $2, interest_paid = interest_paid + sqlc.arg(interest), closed_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: RollOverTermDeposit :one
+-- only the active deposit of the term is rolled over, the interest is
+-- added to the principal
+UPDATE term_deposits
+SET principal = principal + sqlc.arg(interest),
+    interest_paid = interest_paid + sqlc.arg(interest),
+    start_date = maturity_date,
+    maturity_date = sqlc.arg(next_maturity_date),
+    rollovers = rollovers + 1
+WHERE id = $1 AND status = 'active' AND maturity_date = sqlc.arg(maturity_date)
+RETURNING *;
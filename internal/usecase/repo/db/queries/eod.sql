@@ -0,0 +1,98 @@
+-- EOD
+-- name: TryEODLock :one
+-- the session lock keeps the other runners out until it is released
+SELECT pg_try_advisory_lock(hashtext('eod'))::boolean AS locked;
+
+-- name: ReleaseEODLock :one
+SELECT pg_advisory_unlock(hashtext('eod'))::boolean AS released;
+
+-- name: GetBusinessDate :one
+SELECT * FROM business_date;
+
+-- name: FreezeBusinessDate :execrows
+UPDATE business_date
+SET frozen = true, updated_at = now()
+WHERE date = $1;
+
+-- name: AdvanceBusinessDate :execrows
+-- the date is advanced only once
+UPDATE business_date
+SET date = date + 1, frozen = false, updated_at = now()
+WHERE date = $1;
+
+-- name: CreateEODRun :one
+-- the failed or interrupted run of the date is resumed
+INSERT INTO eod_runs (
+  business_date,
+  started_by
+) VALUES (
+  $1, $2
+) ON CONFLICT (business_date) DO UPDATE
+SET status = 'running', error = '', started_by = EXCLUDED.started_by, started_at = now(), finished_at = NULL
+RETURNING *;
+
+-- name: FinishEODRun :one
+UPDATE eod_runs
+SET status = $2, error = $3, finished_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetEODRun :one
+SELECT * FROM eod_runs
+WHERE business_date = $1;
+
+-- name: ListEODRuns :many
+SELECT * FROM eod_runs
+ORDER BY business_date DESC
+LIMIT $1;
+
+-- name: StartEODStep :exec
+INSERT INTO eod_steps (
+  run_id,
+  step
+) VALUES (
+  $1, $2
+) ON CONFLICT (run_id, step) DO UPDATE
+SET status = 'running', result = '{}', error = '', started_at = now(), finished_at = NULL;
+
+-- name: FinishEODStep :one
+UPDATE eod_steps
+SET status = $3, result = $4, error = $5, finished_at = now()
+WHERE run_id = $1 AND step = $2
+RETURNING *;
+
+-- name: ListEODSteps :many
+SELECT * FROM eod_steps
+WHERE run_id = $1
+ORDER BY started_at;
+
+-- name: CreateDailyStatements :execrows
+-- the statements of the accounts with the entries of the date, the
+-- closing balances are the end-of-day snapshots
+INSERT INTO daily_statements (
+  account_id,
+  statement_date,
+  opening_balance,
+  closing_balance,
+  debits,
+  credits,
+  entries
+)
+SELECT S.account_id, sqlc.arg(statement_date)::date,
+  (S.balance - SUM(E.amount))::bigint,
+  S.balance,
+  COALESCE(SUM(-E.amount) FILTER (WHERE E.amount < 0), 0)::bigint,
+  COALESCE(SUM(E.amount) FILTER (WHERE E.amount > 0), 0)::bigint,
+  COUNT(E.id)
+FROM balance_snapshots AS S
+JOIN entries AS E ON E.account_id = S.account_id
+  AND E.created_at >= sqlc.arg(day_start) AND E.created_at < S.as_of
+WHERE S.as_of = sqlc.arg(day_end)
+GROUP BY S.account_id, S.balance
+ON CONFLICT (account_id, statement_date) DO NOTHING;
+
+-- name: ListDailyStatements :many
+SELECT * FROM daily_statements
+WHERE account_id = $1
+ORDER BY statement_date DESC
+LIMIT $2;
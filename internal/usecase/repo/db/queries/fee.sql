@@ -0,0 +1,93 @@
+-- Fee
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+  name,
+  kind,
+  applies_to,
+  product_id,
+  currency,
+  fixed,
+  rate_bp,
+  min_amount,
+  max_amount,
+  tiers,
+  revenue_account_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE id = $1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY id;
+
+-- name: DeactivateFeeSchedule :one
+UPDATE fee_schedules
+SET active = false
+WHERE id = $1
+RETURNING *;
+
+-- name: ListTransferFeeSchedules :many
+-- the active schedules of the transfer type in the currency of the from
+-- account, of its product or of all accounts
+SELECT S.* FROM fee_schedules AS S
+JOIN accounts AS F ON F.id = sqlc.arg(from_account_id)
+JOIN accounts AS T ON T.id = sqlc.arg(to_account_id)
+LEFT JOIN account_products AS P ON P.account_id = F.id
+WHERE S.active AND S.currency = F.currency
+  AND S.applies_to = CASE WHEN F.owner = T.owner THEN 'transfer_own' ELSE 'transfer_p2p' END
+  AND (S.product_id IS NULL OR S.product_id = P.product_id)
+ORDER BY S.id;
+
+-- name: ListExternalFeeSchedules :many
+-- the active schedules of the transfers to the other banks in the
+-- currency of the account, of its product or of all accounts
+SELECT S.* FROM fee_schedules AS S
+JOIN accounts AS A ON A.id = sqlc.arg(account_id)
+LEFT JOIN account_products AS P ON P.account_id = A.id
+WHERE S.active AND S.currency = A.currency AND S.applies_to = 'external'
+  AND (S.product_id IS NULL OR S.product_id = P.product_id)
+ORDER BY S.id;
+
+-- name: ListMaintenanceFees :many
+-- the active maintenance schedules with the accounts of their products
+-- not charged for the period yet
+SELECT S.id, S.name, S.kind, S.fixed, S.rate_bp, S.min_amount, S.max_amount, S.tiers,
+  S.revenue_account_id, A.id AS account_id, A.balance
+FROM fee_schedules AS S
+JOIN account_products AS P ON P.product_id = S.product_id
+JOIN accounts AS A ON A.id = P.account_id AND A.currency = S.currency
+WHERE S.active AND S.applies_to = 'maintenance' AND P.created_at < sqlc.arg(period_end)
+  AND NOT EXISTS (
+    SELECT 1 FROM fee_charges AS C
+    WHERE C.schedule_id = S.id AND C.account_id = A.id AND C.period = sqlc.arg(period)
+  )
+ORDER BY S.id, A.id;
+
+-- name: CreateFeeCharge :one
+INSERT INTO fee_charges (
+  schedule_id,
+  account_id,
+  amount,
+  transfer_id,
+  period,
+  debit_entry_id,
+  credit_entry_id,
+  external_transfer_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListFeeCharges :many
+SELECT C.id, C.schedule_id, S.name, C.account_id, S.revenue_account_id, C.amount,
+  C.transfer_id, C.external_transfer_id, C.period, C.created_at, E.id AS entry_id, E.amount AS entry_amount,
+  E.description, E.reference, E.metadata
+FROM fee_charges AS C
+JOIN fee_schedules AS S ON S.id = C.schedule_id
+JOIN entries AS E ON E.id = C.debit_entry_id
+WHERE C.account_id = $1
+ORDER BY C.id DESC
+LIMIT $2;
@@ -0,0 +1,37 @@
+-- Account holders
+-- name: CreateAccountHolder :one
+INSERT INTO account_holders (
+  account_id,
+  holder,
+  role,
+  status,
+  invited_by,
+  accepted_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAccountHolder :one
+SELECT * FROM account_holders
+WHERE account_id = $1 AND holder = $2;
+
+-- name: ListAccountHolders :many
+SELECT * FROM account_holders
+WHERE account_id = $1
+ORDER BY created_at, holder;
+
+-- name: AcceptAccountHolder :one
+UPDATE account_holders
+SET status = 'active', accepted_at = now()
+WHERE account_id = $1 AND holder = $2 AND status = 'invited'
+RETURNING *;
+
+-- name: DeleteAccountHolder :execrows
+-- the primary holder changes with the account owner only
+DELETE FROM account_holders
+WHERE account_id = $1 AND holder = $2 AND role <> 'primary';
+
+-- name: UpdatePrimaryAccountHolder :execrows
+UPDATE account_holders
+SET holder = $2, status = 'active', accepted_at = now()
+WHERE account_id = $1 AND role = 'primary';
@@ -0,0 +1,113 @@
+-- Interest
+-- name: CreateInterestProduct :one
+INSERT INTO interest_products (
+  name,
+  currency,
+  annual_rate_bp,
+  day_count,
+  expense_account_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetInterestProduct :one
+SELECT * FROM interest_products
+WHERE id = $1;
+
+-- name: ListInterestProducts :many
+SELECT * FROM interest_products
+ORDER BY id;
+
+-- name: GetAccountProduct :one
+SELECT R.* FROM interest_products AS R
+JOIN account_products AS P ON P.product_id = R.id
+WHERE P.account_id = $1;
+
+-- name: UpsertAccountProduct :exec
+INSERT INTO account_products (
+  account_id,
+  product_id
+) VALUES (
+  $1, $2
+) ON CONFLICT (account_id) DO UPDATE
+SET product_id = EXCLUDED.product_id;
+
+-- name: DeleteAccountProduct :execrows
+DELETE FROM account_products
+WHERE account_id = $1;
+
+-- name: ListAccrualBalances :many
+-- the end-of-day balance is the current one less the entries made since
+SELECT P.account_id, R.id AS product_id, R.annual_rate_bp, R.day_count,
+  (A.balance - COALESCE((
+    SELECT SUM(E.amount) FROM entries AS E
+    WHERE E.account_id = A.id AND E.created_at >= sqlc.arg(day_end)
+  ), 0))::bigint AS balance
+FROM account_products AS P
+JOIN accounts AS A ON A.id = P.account_id
+JOIN interest_products AS R ON R.id = P.product_id
+WHERE P.created_at < sqlc.arg(day_end)
+ORDER BY P.account_id;
+
+-- name: CreateInterestAccrual :execrows
+-- the accruals of the posted months are not added
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  product_id,
+  balance,
+  annual_rate_bp,
+  day_count,
+  days,
+  amount_micros
+) SELECT $1, $2, $3, $4, $5, $6, $7, $8
+WHERE NOT EXISTS (
+  SELECT 1 FROM interest_postings
+  WHERE account_id = $1 AND period = date_trunc('month', $2::date)::date
+)
+ON CONFLICT (account_id, accrual_date) DO NOTHING;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND accrual_date >= $2 AND accrual_date < $3
+ORDER BY accrual_date;
+
+-- name: ListPostingTotals :many
+-- the accrued micros of the accounts not posted for the period yet, with
+-- the carry of the previous posting and the expense account of the last
+-- accrual
+SELECT I.account_id,
+  (array_agg(R.expense_account_id ORDER BY I.accrual_date DESC))[1]::uuid AS expense_account_id,
+  SUM(I.amount_micros)::bigint AS accrued_micros,
+  COALESCE((
+    SELECT L.carry_micros FROM interest_postings AS L
+    WHERE L.account_id = I.account_id AND L.period < sqlc.arg(period)
+    ORDER BY L.period DESC
+    LIMIT 1
+  ), 0)::bigint AS carry_micros
+FROM interest_accruals AS I
+JOIN interest_products AS R ON R.id = I.product_id
+WHERE I.accrual_date >= sqlc.arg(period) AND I.accrual_date < sqlc.arg(period_end)
+  AND NOT EXISTS (
+    SELECT 1 FROM interest_postings AS T
+    WHERE T.account_id = I.account_id AND T.period = sqlc.arg(period)
+  )
+GROUP BY I.account_id
+ORDER BY I.account_id;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  period,
+  accrued_micros,
+  amount,
+  carry_micros,
+  transfer_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListInterestPostings :many
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY period DESC;
@@ -0,0 +1,44 @@
+-- Ledger
+-- name: CreateLedgerAccount :one
+INSERT INTO accounts (
+  id,
+  owner,
+  currency,
+  kind
+) VALUES (
+  $1, '', $2, 'ledger'
+) RETURNING *;
+
+-- name: CreateGLAccount :one
+INSERT INTO gl_accounts (
+  account_id,
+  code,
+  name,
+  type,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetGLAccount :one
+SELECT G.*, A.balance FROM gl_accounts AS G
+JOIN accounts AS A ON A.id = G.account_id
+WHERE G.account_id = $1;
+
+-- name: GetGLAccountByCode :one
+SELECT G.*, A.balance FROM gl_accounts AS G
+JOIN accounts AS A ON A.id = G.account_id
+WHERE G.code = $1 AND G.currency = $2;
+
+-- name: ListGLAccounts :many
+SELECT G.*, A.balance FROM gl_accounts AS G
+JOIN accounts AS A ON A.id = G.account_id
+ORDER BY G.currency, G.code;
+
+-- name: SumCustomerBalances :many
+-- the total balance of the customer accounts in each currency
+SELECT currency, count(*) AS accounts, sum(balance)::bigint AS balance
+FROM accounts
+WHERE kind = 'customer'
+GROUP BY currency
+ORDER BY currency;
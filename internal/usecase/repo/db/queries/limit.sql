@@ -0,0 +1,58 @@
+-- Limit
+-- name: GetAccountLimits :one
+SELECT * FROM account_limits
+WHERE account_id = $1;
+
+-- name: GetTransferLimits :one
+SELECT COALESCE(L.tier, T.name)::varchar AS tier,
+  COALESCE(L.max_single, T.max_single)::bigint AS max_single,
+  COALESCE(L.daily, T.daily)::bigint AS daily,
+  COALESCE(L.monthly, T.monthly)::bigint AS monthly,
+  COALESCE(L.hourly_count, T.hourly_count)::bigint AS hourly_count
+FROM limit_tiers AS T
+LEFT JOIN account_limits AS L ON L.account_id = sqlc.arg(account_id)
+WHERE T.name = COALESCE(L.tier, 'standard');
+
+-- name: GetTransferUsage :one
+-- the executed transfers and the external transfers of the account,
+-- except the refunded rejected ones
+SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(day_start)), 0)::bigint AS daily,
+  COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly,
+  COUNT(*) FILTER (WHERE created_at >= sqlc.arg(hour_start))::bigint AS hourly_count
+FROM (
+  SELECT amount, created_at FROM transfers
+  WHERE from_account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(since)
+    AND id <> sqlc.arg(exclude_id)
+    AND status NOT IN ('pending', 'failed', 'cancelled')
+  UNION ALL
+  SELECT amount, created_at FROM external_transfers
+  WHERE account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(since)
+    AND status <> 'rejected'
+) AS U;
+
+-- name: UpsertAccountLimits :one
+INSERT INTO account_limits (
+  account_id,
+  tier,
+  max_single,
+  daily,
+  monthly,
+  hourly_count,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (account_id) DO UPDATE
+SET tier = EXCLUDED.tier,
+  max_single = EXCLUDED.max_single,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  hourly_count = EXCLUDED.hourly_count,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteAccountLimits :execrows
+DELETE FROM account_limits
+WHERE account_id = $1;
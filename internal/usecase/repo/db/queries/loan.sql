@@ -0,0 +1,71 @@
+-- Loans
+-- name: CreateLoan :one
+INSERT INTO loans (
+  account_id,
+  principal,
+  currency,
+  annual_rate_bp,
+  penalty_rate_bp,
+  term_months,
+  method,
+  outstanding,
+  disbursed_on,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $2, $8, $9
+) RETURNING *;
+
+-- name: GetLoan :one
+SELECT * FROM loans
+WHERE id = $1;
+
+-- name: ListLoans :many
+SELECT * FROM loans
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: RepayLoanPrincipal :one
+-- the loan is repaid with its last principal
+UPDATE loans
+SET outstanding = outstanding - $2,
+    status = CASE WHEN outstanding = $2 THEN 'repaid' ELSE status END,
+    closed_at = CASE WHEN outstanding = $2 THEN now() ELSE closed_at END
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateLoanInstallment :exec
+INSERT INTO loan_installments (
+  loan_id,
+  number,
+  due_date,
+  principal,
+  interest
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: ListLoanInstallments :many
+SELECT * FROM loan_installments
+WHERE loan_id = $1
+ORDER BY number;
+
+-- name: ListDueLoanInstallments :many
+-- the unpaid installments due on or before the date with the terms of
+-- their loans
+SELECT I.*, L.account_id, L.penalty_rate_bp FROM loan_installments AS I
+JOIN loans AS L ON L.id = I.loan_id
+WHERE I.status <> 'paid' AND I.due_date <= $1
+ORDER BY I.loan_id, I.number;
+
+-- name: PayLoanInstallment :one
+-- only the unpaid installments are paid
+UPDATE loan_installments
+SET status = 'paid', penalty = $3, paid_on = $4
+WHERE loan_id = $1 AND number = $2 AND status <> 'paid'
+RETURNING *;
+
+-- name: MarkLoanInstallmentLate :exec
+UPDATE loan_installments
+SET status = 'late'
+WHERE loan_id = $1 AND number = $2 AND status = 'scheduled';
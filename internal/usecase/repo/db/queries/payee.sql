@@ -0,0 +1,30 @@
+-- Payee
+-- name: CreatePayee :one
+INSERT INTO payees (
+  owner,
+  nickname,
+  account_id
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetPayee :one
+SELECT P.id, P.owner, P.nickname, P.account_id, P.created_at, A.number FROM payees as P
+JOIN accounts as A ON A.id = P.account_id
+WHERE P.id = $1 AND P.owner = $2;
+
+-- name: ListPayees :many
+SELECT P.id, P.owner, P.nickname, P.account_id, P.created_at, A.number FROM payees as P
+JOIN accounts as A ON A.id = P.account_id
+WHERE P.owner = $1
+ORDER BY P.nickname;
+
+-- name: UpdatePayeeNickname :one
+UPDATE payees
+SET nickname = $3
+WHERE id = $1 AND owner = $2
+RETURNING *;
+
+-- name: DeletePayee :execrows
+DELETE FROM payees
+WHERE id = $1 AND owner = $2;
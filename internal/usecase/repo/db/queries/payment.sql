@@ -0,0 +1,8 @@
+-- Payment import
+-- name: CreatePaymentImport :exec
+INSERT INTO payment_imports (
+  owner,
+  msg_id
+) VALUES (
+  $1, $2
+);
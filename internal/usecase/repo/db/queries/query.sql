@@ -4,15 +4,23 @@ INSERT INTO accounts (
     id,
     owner,
     balance,
-    currency
+    currency,
+    number
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts
 WHERE id = $1;
 
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts
+WHERE number = $1;
+
+-- name: NextAccountNumber :one
+SELECT nextval('account_number_seq')::bigint;
+
 -- name: UpdateAccountOwner :one
 UPDATE accounts
 SET owner = $2
@@ -26,7 +34,7 @@ WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: ListAccounts :many
-SELECT A.id, A.owner, A.balance, A.currency, A.created_at FROM accounts as A
+SELECT A.id, A.owner, A.balance, A.currency, A.created_at, A.number, A.kind FROM accounts as A
 JOIN (
     SELECT id FROM accounts
     LIMIT $1
@@ -43,9 +51,12 @@ WHERE id = $1;
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -53,7 +64,8 @@ SELECT * FROM entries
 WHERE id = $1;
 
 -- name: ListEntriesByAccount :many
-SELECT E.id, E.account_id, E.amount, E.created_at FROM entries as E
+SELECT E.id, E.account_id, E.amount, E.created_at,
+E.description, E.reference, E.metadata FROM entries as E
 JOIN (
     SELECT id FROM entries as je
     WHERE je.account_id = $1
@@ -62,6 +74,11 @@ JOIN (
   ) as P
   ON P.id = E.id;
 
+-- name: ListEntriesByAccountAfter :many
+SELECT * FROM entries
+WHERE account_id = $1 AND (id > sqlc.arg(after_id) OR created_at >= sqlc.arg(since))
+ORDER BY id;
+
 -- name: UpdateEntry :exec
 UPDATE entries
 SET amount = $2
@@ -79,9 +96,13 @@ INSERT INTO transfers (
   to_account_id,
   from_entry_id,
   to_entry_id,
-  amount
+  amount,
+  description,
+  reference,
+  metadata,
+  status
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetTransfer :one
@@ -90,37 +111,95 @@ WHERE id = $1;
 
 -- name: ListTransfersByFromAccount :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
-    WHERE jt.from_account_id = $1
-    LIMIT $2
-    OFFSET $3
+    WHERE jt.from_account_id = sqlc.arg(from_account_id)
+      AND (sqlc.arg(status)::varchar = '' OR jt.status = sqlc.arg(status))
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset')
   ) as P
   ON P.id = T.id;
 
 -- name: ListTransfersByToAccount :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
-    WHERE jt.to_account_id = $1
-    LIMIT $2
-    OFFSET $3
+    WHERE jt.to_account_id = sqlc.arg(to_account_id)
+      AND (sqlc.arg(status)::varchar = '' OR jt.status = sqlc.arg(status))
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset')
   ) as P
   ON P.id = T.id;
 
 -- name: ListTransfersByAccounts :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
-    WHERE jt.to_account_id = $1 AND jt.from_account_id = $2
-    LIMIT $3
-    OFFSET $4
+    WHERE jt.to_account_id = sqlc.arg(to_account_id) AND jt.from_account_id = sqlc.arg(from_account_id)
+      AND (sqlc.arg(status)::varchar = '' OR jt.status = sqlc.arg(status))
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset')
   ) as P
   ON P.id = T.id;
 
+-- name: ListTransfersByReference :many
+SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
+WHERE T.reference = sqlc.arg(reference)
+  AND EXISTS (
+    SELECT 1 FROM account_holders AS H
+    WHERE H.account_id IN (T.from_account_id, T.to_account_id)
+      AND H.holder = sqlc.arg(holder) AND H.status = 'active'
+  )
+  AND (sqlc.arg(status)::varchar = '' OR T.status = sqlc.arg(status))
+ORDER BY T.id;
+
+-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = sqlc.arg(status),
+  failure_reason = sqlc.arg(failure_reason),
+  updated_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: SetTransferEntries :one
+UPDATE transfers
+SET from_entry_id = $2,
+  to_entry_id = $3,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateTransferTransition :one
+INSERT INTO transfer_transitions (
+  transfer_id,
+  from_status,
+  to_status,
+  reason
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListTransferTransitions :many
+SELECT * FROM transfer_transitions
+WHERE transfer_id = $1
+ORDER BY id;
+
 -- name: DeleteTransfer :exec
 DELETE FROM transfers
 WHERE id = $1;
\ No newline at end of file
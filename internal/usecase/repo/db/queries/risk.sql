@@ -0,0 +1,47 @@
+-- Risk
+-- name: GetRiskHistory :one
+SELECT COUNT(*) FILTER (WHERE to_account_id = sqlc.arg(to_account_id))::bigint AS payee_transfers,
+  COUNT(*)::bigint AS transfer_count,
+  COALESCE(AVG(amount), 0)::bigint AS average_amount,
+  COUNT(*) FILTER (WHERE created_at >= sqlc.arg(since))::bigint AS recent_count
+FROM transfers
+WHERE from_account_id = sqlc.arg(from_account_id)
+  AND status NOT IN ('pending', 'failed', 'cancelled');
+
+-- name: CreateTransferReview :one
+INSERT INTO transfer_reviews (
+  from_account_id,
+  to_account_id,
+  amount,
+  description,
+  reference,
+  metadata,
+  score,
+  signals
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetTransferReview :one
+SELECT * FROM transfer_reviews
+WHERE id = $1;
+
+-- name: ListTransferReviews :many
+SELECT * FROM transfer_reviews
+WHERE status = $1
+ORDER BY id;
+
+-- name: CloseTransferReview :one
+UPDATE transfer_reviews
+SET status = $2,
+  reviewed_by = $3,
+  comment = $4,
+  reviewed_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: SetTransferReviewTransfer :one
+UPDATE transfer_reviews
+SET transfer_id = $2
+WHERE id = $1
+RETURNING *;
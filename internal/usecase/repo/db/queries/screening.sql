@@ -0,0 +1,45 @@
+-- Screening
+-- name: CreateScreeningAlert :one
+INSERT INTO screening_alerts (
+  name,
+  trigger,
+  account_id,
+  score,
+  matches,
+  decision
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetScreeningAlert :one
+SELECT * FROM screening_alerts
+WHERE id = $1;
+
+-- name: ListScreeningAlerts :many
+SELECT * FROM screening_alerts
+WHERE status = $1
+ORDER BY id;
+
+-- name: CloseScreeningAlert :one
+UPDATE screening_alerts
+SET status = $2,
+  reviewed_by = $3,
+  comment = $4,
+  reviewed_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: LockScreeningName :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(account_id)::text || sqlc.arg(name)::text));
+
+-- name: GetPendingScreeningAlert :one
+SELECT * FROM screening_alerts
+WHERE account_id = $1 AND name = $2 AND status = 'pending'
+ORDER BY id
+LIMIT 1;
+
+-- name: GetLastClosedScreeningAlert :one
+SELECT * FROM screening_alerts
+WHERE account_id = $1 AND name = $2 AND status <> 'pending'
+ORDER BY reviewed_at DESC, id DESC
+LIMIT 1;
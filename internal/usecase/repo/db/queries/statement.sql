@@ -0,0 +1,12 @@
+-- Statement
+-- name: SumEntriesSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total FROM entries
+WHERE account_id = $1 AND created_at >= $2;
+
+-- name: ListStatementEntries :many
+SELECT E.id, E.account_id, E.amount, E.created_at, E.description, E.reference,
+T.id AS transfer_id, T.from_account_id, T.to_account_id FROM entries AS E
+LEFT JOIN transfers AS T
+  ON T.from_entry_id = E.id OR T.to_entry_id = E.id
+WHERE E.account_id = $1 AND E.created_at >= $2 AND E.created_at < $3
+ORDER BY E.created_at, E.id;
@@ -7,8 +7,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
+	"alukart32.com/bank/entity"
 	"github.com/google/uuid"
 )
 
@@ -16,7 +18,7 @@ const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, number, kind
 `
 
 type AddAccountBalanceParams struct {
@@ -33,6 +35,8 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -42,17 +46,19 @@ INSERT INTO accounts (
     id,
     owner,
     balance,
-    currency
+    currency,
+    number
 ) VALUES (
-  $1, $2, $3, $4
-) RETURNING id, owner, balance, currency, created_at
+  $1, $2, $3, $4, $5
+) RETURNING id, owner, balance, currency, created_at, number, kind
 `
 
 type CreateAccountParams struct {
-	ID       uuid.UUID `json:"id"`
-	Owner    string    `json:"owner"`
-	Balance  int64     `json:"balance"`
-	Currency Currency  `json:"currency"`
+	ID       uuid.UUID      `json:"id"`
+	Owner    string         `json:"owner"`
+	Balance  int64          `json:"balance"`
+	Currency Currency       `json:"currency"`
+	Number   sql.NullString `json:"number"`
 }
 
 // Account
@@ -62,6 +68,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		arg.Owner,
 		arg.Balance,
 		arg.Currency,
+		arg.Number,
 	)
 	var i Account
 	err := row.Scan(
@@ -70,6 +77,8 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -77,26 +86,41 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  description,
+  reference,
+  metadata
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5
+) RETURNING id, account_id, amount, created_at, description, reference, metadata
 `
 
 type CreateEntryParams struct {
-	AccountID uuid.UUID `json:"account_id"`
-	Amount    int64     `json:"amount"`
+	AccountID   uuid.UUID       `json:"account_id"`
+	Amount      int64           `json:"amount"`
+	Description string          `json:"description"`
+	Reference   string          `json:"reference"`
+	Metadata    entity.Metadata `json:"metadata"`
 }
 
 // Entry
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
@@ -107,18 +131,26 @@ INSERT INTO transfers (
   to_account_id,
   from_entry_id,
   to_entry_id,
-  amount
+  amount,
+  description,
+  reference,
+  metadata,
+  status
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id, description, reference, metadata, status, failure_reason, updated_at
 `
 
 type CreateTransferParams struct {
-	FromAccountID uuid.UUID `json:"from_account_id"`
-	ToAccountID   uuid.UUID `json:"to_account_id"`
-	FromEntryID   int64     `json:"from_entry_id"`
-	ToEntryID     int64     `json:"to_entry_id"`
-	Amount        int64     `json:"amount"`
+	FromAccountID uuid.UUID             `json:"from_account_id"`
+	ToAccountID   uuid.UUID             `json:"to_account_id"`
+	FromEntryID   sql.NullInt64         `json:"from_entry_id"`
+	ToEntryID     sql.NullInt64         `json:"to_entry_id"`
+	Amount        int64                 `json:"amount"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
 }
 
 // Transfer
@@ -129,6 +161,10 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		arg.FromEntryID,
 		arg.ToEntryID,
 		arg.Amount,
+		arg.Description,
+		arg.Reference,
+		arg.Metadata,
+		arg.Status,
 	)
 	var i Transfer
 	err := row.Scan(
@@ -139,6 +175,49 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.CreatedAt,
 		&i.FromEntryID,
 		&i.ToEntryID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+		&i.FailureReason,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createTransferTransition = `-- name: CreateTransferTransition :one
+INSERT INTO transfer_transitions (
+  transfer_id,
+  from_status,
+  to_status,
+  reason
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, transfer_id, from_status, to_status, reason, created_at
+`
+
+type CreateTransferTransitionParams struct {
+	TransferID int64                 `json:"transfer_id"`
+	FromStatus entity.TransferStatus `json:"from_status"`
+	ToStatus   entity.TransferStatus `json:"to_status"`
+	Reason     string                `json:"reason"`
+}
+
+func (q *Queries) CreateTransferTransition(ctx context.Context, arg CreateTransferTransitionParams) (TransferTransition, error) {
+	row := q.db.QueryRowContext(ctx, createTransferTransition,
+		arg.TransferID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+	)
+	var i TransferTransition
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Reason,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -174,7 +253,7 @@ func (q *Queries) DeleteTransfer(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, number, kind FROM accounts
 WHERE id = $1
 `
 
@@ -187,12 +266,34 @@ func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, number, kind FROM accounts
+WHERE number = $1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, number sql.NullString) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, number)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Number,
+		&i.Kind,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, description, reference, metadata FROM entries
 WHERE id = $1
 `
 
@@ -204,12 +305,15 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id, description, reference, metadata, status, failure_reason, updated_at FROM transfers
 WHERE id = $1
 `
 
@@ -224,12 +328,18 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.CreatedAt,
 		&i.FromEntryID,
 		&i.ToEntryID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+		&i.FailureReason,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT A.id, A.owner, A.balance, A.currency, A.created_at FROM accounts as A
+SELECT A.id, A.owner, A.balance, A.currency, A.created_at, A.number, A.kind FROM accounts as A
 JOIN (
     SELECT id FROM accounts
     LIMIT $1
@@ -258,6 +368,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Number,
+			&i.Kind,
 		); err != nil {
 			return nil, err
 		}
@@ -273,7 +385,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 }
 
 const listEntriesByAccount = `-- name: ListEntriesByAccount :many
-SELECT E.id, E.account_id, E.amount, E.created_at FROM entries as E
+SELECT E.id, E.account_id, E.amount, E.created_at,
+E.description, E.reference, E.metadata FROM entries as E
 JOIN (
     SELECT id FROM entries as je
     WHERE je.account_id = $1
@@ -303,6 +416,88 @@ func (q *Queries) ListEntriesByAccount(ctx context.Context, arg ListEntriesByAcc
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesByAccountAfter = `-- name: ListEntriesByAccountAfter :many
+SELECT id, account_id, amount, created_at, description, reference, metadata FROM entries
+WHERE account_id = $1 AND (id > $2 OR created_at >= $3)
+ORDER BY id
+`
+
+type ListEntriesByAccountAfterParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	AfterID   int64     `json:"after_id"`
+	Since     time.Time `json:"since"`
+}
+
+func (q *Queries) ListEntriesByAccountAfter(ctx context.Context, arg ListEntriesByAccountAfterParams) ([]Entry, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesByAccountAfter, arg.AccountID, arg.AfterID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entry
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferTransitions = `-- name: ListTransferTransitions :many
+SELECT id, transfer_id, from_status, to_status, reason, created_at FROM transfer_transitions
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListTransferTransitions(ctx context.Context, transferID int64) ([]TransferTransition, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferTransitions, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TransferTransition
+	for rows.Next() {
+		var i TransferTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -319,12 +514,16 @@ func (q *Queries) ListEntriesByAccount(ctx context.Context, arg ListEntriesByAcc
 
 const listTransfersByAccounts = `-- name: ListTransfersByAccounts :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
     WHERE jt.to_account_id = $1 AND jt.from_account_id = $2
-    LIMIT $3
-    OFFSET $4
+      AND ($3::varchar = '' OR jt.status = $3)
+    LIMIT $4
+    OFFSET $5
   ) as P
   ON P.id = T.id
 `
@@ -332,24 +531,32 @@ JOIN (
 type ListTransfersByAccountsParams struct {
 	ToAccountID   uuid.UUID `json:"to_account_id"`
 	FromAccountID uuid.UUID `json:"from_account_id"`
+	Status        string    `json:"status"`
 	Limit         int32     `json:"limit"`
 	Offset        int32     `json:"offset"`
 }
 
 type ListTransfersByAccountsRow struct {
-	ID            int64     `json:"id"`
-	FromAccountID uuid.UUID `json:"from_account_id"`
-	ToAccountID   uuid.UUID `json:"to_account_id"`
-	Amount        int64     `json:"amount"`
-	FromEntryID   int64     `json:"from_entry_id"`
-	ToEntryID     int64     `json:"to_entry_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            int64                 `json:"id"`
+	FromAccountID uuid.UUID             `json:"from_account_id"`
+	ToAccountID   uuid.UUID             `json:"to_account_id"`
+	Amount        int64                 `json:"amount"`
+	FromEntryID   int64                 `json:"from_entry_id"`
+	ToEntryID     int64                 `json:"to_entry_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	UpdatedAt     time.Time             `json:"updated_at"`
 }
 
 func (q *Queries) ListTransfersByAccounts(ctx context.Context, arg ListTransfersByAccountsParams) ([]ListTransfersByAccountsRow, error) {
 	rows, err := q.db.QueryContext(ctx, listTransfersByAccounts,
 		arg.ToAccountID,
 		arg.FromAccountID,
+		arg.Status,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -368,6 +575,12 @@ func (q *Queries) ListTransfersByAccounts(ctx context.Context, arg ListTransfers
 			&i.FromEntryID,
 			&i.ToEntryID,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.FailureReason,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -384,34 +597,50 @@ func (q *Queries) ListTransfersByAccounts(ctx context.Context, arg ListTransfers
 
 const listTransfersByFromAccount = `-- name: ListTransfersByFromAccount :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
     WHERE jt.from_account_id = $1
-    LIMIT $2
-    OFFSET $3
+      AND ($2::varchar = '' OR jt.status = $2)
+    LIMIT $3
+    OFFSET $4
   ) as P
   ON P.id = T.id
 `
 
 type ListTransfersByFromAccountParams struct {
 	FromAccountID uuid.UUID `json:"from_account_id"`
+	Status        string    `json:"status"`
 	Limit         int32     `json:"limit"`
 	Offset        int32     `json:"offset"`
 }
 
 type ListTransfersByFromAccountRow struct {
-	ID            int64     `json:"id"`
-	FromAccountID uuid.UUID `json:"from_account_id"`
-	ToAccountID   uuid.UUID `json:"to_account_id"`
-	Amount        int64     `json:"amount"`
-	FromEntryID   int64     `json:"from_entry_id"`
-	ToEntryID     int64     `json:"to_entry_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            int64                 `json:"id"`
+	FromAccountID uuid.UUID             `json:"from_account_id"`
+	ToAccountID   uuid.UUID             `json:"to_account_id"`
+	Amount        int64                 `json:"amount"`
+	FromEntryID   int64                 `json:"from_entry_id"`
+	ToEntryID     int64                 `json:"to_entry_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	UpdatedAt     time.Time             `json:"updated_at"`
 }
 
 func (q *Queries) ListTransfersByFromAccount(ctx context.Context, arg ListTransfersByFromAccountParams) ([]ListTransfersByFromAccountRow, error) {
-	rows, err := q.db.QueryContext(ctx, listTransfersByFromAccount, arg.FromAccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTransfersByFromAccount,
+		arg.FromAccountID,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -427,6 +656,87 @@ func (q *Queries) ListTransfersByFromAccount(ctx context.Context, arg ListTransf
 			&i.FromEntryID,
 			&i.ToEntryID,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.FailureReason,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByReference = `-- name: ListTransfersByReference :many
+SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
+WHERE T.reference = $1
+  AND EXISTS (
+    SELECT 1 FROM account_holders AS H
+    WHERE H.account_id IN (T.from_account_id, T.to_account_id)
+      AND H.holder = $2 AND H.status = 'active'
+  )
+  AND ($3::varchar = '' OR T.status = $3)
+ORDER BY T.id
+`
+
+type ListTransfersByReferenceParams struct {
+	Reference string `json:"reference"`
+	Holder    string `json:"holder"`
+	Status    string `json:"status"`
+}
+
+type ListTransfersByReferenceRow struct {
+	ID            int64                 `json:"id"`
+	FromAccountID uuid.UUID             `json:"from_account_id"`
+	ToAccountID   uuid.UUID             `json:"to_account_id"`
+	Amount        int64                 `json:"amount"`
+	FromEntryID   int64                 `json:"from_entry_id"`
+	ToEntryID     int64                 `json:"to_entry_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+func (q *Queries) ListTransfersByReference(ctx context.Context, arg ListTransfersByReferenceParams) ([]ListTransfersByReferenceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersByReference, arg.Reference, arg.Holder, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransfersByReferenceRow
+	for rows.Next() {
+		var i ListTransfersByReferenceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.FromEntryID,
+			&i.ToEntryID,
+			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.FailureReason,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -443,34 +753,50 @@ func (q *Queries) ListTransfersByFromAccount(ctx context.Context, arg ListTransf
 
 const listTransfersByToAccount = `-- name: ListTransfersByToAccount :many
 SELECT T.id, T.from_account_id, T.to_account_id, T.amount,
-T.from_entry_id, T.to_entry_id, T.created_at FROM transfers AS T
+COALESCE(T.from_entry_id, 0)::bigint AS from_entry_id,
+COALESCE(T.to_entry_id, 0)::bigint AS to_entry_id, T.created_at,
+T.description, T.reference, T.metadata,
+T.status, T.failure_reason, T.updated_at FROM transfers AS T
 JOIN (
     SELECT id FROM transfers as jt
     WHERE jt.to_account_id = $1
-    LIMIT $2
-    OFFSET $3
+      AND ($2::varchar = '' OR jt.status = $2)
+    LIMIT $3
+    OFFSET $4
   ) as P
   ON P.id = T.id
 `
 
 type ListTransfersByToAccountParams struct {
 	ToAccountID uuid.UUID `json:"to_account_id"`
+	Status      string    `json:"status"`
 	Limit       int32     `json:"limit"`
 	Offset      int32     `json:"offset"`
 }
 
 type ListTransfersByToAccountRow struct {
-	ID            int64     `json:"id"`
-	FromAccountID uuid.UUID `json:"from_account_id"`
-	ToAccountID   uuid.UUID `json:"to_account_id"`
-	Amount        int64     `json:"amount"`
-	FromEntryID   int64     `json:"from_entry_id"`
-	ToEntryID     int64     `json:"to_entry_id"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            int64                 `json:"id"`
+	FromAccountID uuid.UUID             `json:"from_account_id"`
+	ToAccountID   uuid.UUID             `json:"to_account_id"`
+	Amount        int64                 `json:"amount"`
+	FromEntryID   int64                 `json:"from_entry_id"`
+	ToEntryID     int64                 `json:"to_entry_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Description   string                `json:"description"`
+	Reference     string                `json:"reference"`
+	Metadata      entity.Metadata       `json:"metadata"`
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	UpdatedAt     time.Time             `json:"updated_at"`
 }
 
 func (q *Queries) ListTransfersByToAccount(ctx context.Context, arg ListTransfersByToAccountParams) ([]ListTransfersByToAccountRow, error) {
-	rows, err := q.db.QueryContext(ctx, listTransfersByToAccount, arg.ToAccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listTransfersByToAccount,
+		arg.ToAccountID,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -486,6 +812,12 @@ func (q *Queries) ListTransfersByToAccount(ctx context.Context, arg ListTransfer
 			&i.FromEntryID,
 			&i.ToEntryID,
 			&i.CreatedAt,
+			&i.Description,
+			&i.Reference,
+			&i.Metadata,
+			&i.Status,
+			&i.FailureReason,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -500,11 +832,58 @@ func (q *Queries) ListTransfersByToAccount(ctx context.Context, arg ListTransfer
 	return items, nil
 }
 
+const nextAccountNumber = `-- name: NextAccountNumber :one
+SELECT nextval('account_number_seq')::bigint
+`
+
+func (q *Queries) NextAccountNumber(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextAccountNumber)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const setTransferEntries = `-- name: SetTransferEntries :one
+UPDATE transfers
+SET from_entry_id = $2,
+  to_entry_id = $3,
+  updated_at = now()
+WHERE id = $1
+RETURNING id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id, description, reference, metadata, status, failure_reason, updated_at
+`
+
+type SetTransferEntriesParams struct {
+	ID          int64         `json:"id"`
+	FromEntryID sql.NullInt64 `json:"from_entry_id"`
+	ToEntryID   sql.NullInt64 `json:"to_entry_id"`
+}
+
+func (q *Queries) SetTransferEntries(ctx context.Context, arg SetTransferEntriesParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, setTransferEntries, arg.ID, arg.FromEntryID, arg.ToEntryID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.FromEntryID,
+		&i.ToEntryID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+		&i.FailureReason,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateAccountOwner = `-- name: UpdateAccountOwner :one
 UPDATE accounts
 SET owner = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, number, kind
 `
 
 type UpdateAccountOwnerParams struct {
@@ -521,6 +900,8 @@ func (q *Queries) UpdateAccountOwner(ctx context.Context, arg UpdateAccountOwner
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Number,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -540,3 +921,45 @@ func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) error
 	_, err := q.db.ExecContext(ctx, updateEntry, arg.ID, arg.Amount)
 	return err
 }
+
+const updateTransferStatus = `-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = $1,
+  failure_reason = $2,
+  updated_at = now()
+WHERE id = $3 AND status = $4
+RETURNING id, from_account_id, to_account_id, amount, created_at, from_entry_id, to_entry_id, description, reference, metadata, status, failure_reason, updated_at
+`
+
+type UpdateTransferStatusParams struct {
+	Status        entity.TransferStatus `json:"status"`
+	FailureReason string                `json:"failure_reason"`
+	ID            int64                 `json:"id"`
+	FromStatus    entity.TransferStatus `json:"from_status"`
+}
+
+func (q *Queries) UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferStatus,
+		arg.Status,
+		arg.FailureReason,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.FromEntryID,
+		&i.ToEntryID,
+		&i.Description,
+		&i.Reference,
+		&i.Metadata,
+		&i.Status,
+		&i.FailureReason,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -3,12 +3,14 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"os"
 	"testing"
 	"time"
 
 	"alukart32.com/bank/config"
+	"alukart32.com/bank/entity"
 	"alukart32.com/bank/pkg/postgres"
 	"alukart32.com/bank/pkg/random"
 	"github.com/google/uuid"
@@ -72,6 +74,38 @@ func TestGetAccount(t *testing.T) {
 	}
 }
 
+func TestGetAccountByNumber(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	seq, err := qtx.NextAccountNumber(context.Background())
+	require.NoError(t, err)
+
+	account1, err := qtx.CreateAccount(context.Background(), CreateAccountParams{
+		ID:       uuid.New(),
+		Owner:    string(random.String(20)),
+		Currency: CurrencyRUB,
+		Number:   sql.NullString{String: fmt.Sprintf("RU00TEST%012d", seq), Valid: true},
+	})
+	require.NoError(t, err)
+
+	account2, err := qtx.GetAccountByNumber(context.Background(), account1.Number)
+	require.NoError(t, err)
+	assert.Equal(t, account1.ID, account2.ID)
+	assert.Equal(t, account1.Number, account2.Number)
+
+	_, err = qtx.GetAccountByNumber(context.Background(), sql.NullString{String: "RU00TEST", Valid: true})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestUpdateAccount(t *testing.T) {
 	tx, err := testDB.Begin()
 	if err != nil {
@@ -332,9 +366,10 @@ func TestCreateTransfer(t *testing.T) {
 	r, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
-		FromEntryID:   fromEntry.ID,
-		ToEntryID:     toEntry.ID,
+		FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
 		Amount:        amount,
+		Status:        entity.TransferCompleted,
 	})
 	require.NoError(t, err)
 	assert.Equal(t, fromAccount.ID, r.FromAccountID)
@@ -346,6 +381,106 @@ func TestCreateTransfer(t *testing.T) {
 	}
 }
 
+func TestListTransfersByReference(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	fromAccount := createRandomAccount(t, qtx)
+	toAccount := createRandomAccount(t, qtx)
+	reference := string(random.String(20))
+	metadata := entity.Metadata{"order": "42"}
+
+	fromEntry, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: fromAccount.ID,
+		Amount:    -100,
+		Reference: reference,
+		Metadata:  metadata,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, metadata, fromEntry.Metadata)
+	toEntry, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: toAccount.ID,
+		Amount:    100,
+		Reference: reference,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, toEntry.Metadata)
+
+	r, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
+		Amount:        100,
+		Description:   "invoice",
+		Reference:     reference,
+		Metadata:      metadata,
+		Status:        entity.TransferCompleted,
+	})
+	require.NoError(t, err)
+
+	_, err = qtx.CreateAccountHolder(context.Background(), CreateAccountHolderParams{
+		AccountID:  toAccount.ID,
+		Holder:     toAccount.Owner,
+		Role:       string(entity.HolderPrimary),
+		Status:     string(entity.HolderActive),
+		AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	require.NoError(t, err)
+	transfers, err := qtx.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: reference,
+		Holder:    toAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, r.ID, transfers[0].ID)
+	assert.Equal(t, "invoice", transfers[0].Description)
+	assert.Equal(t, metadata, transfers[0].Metadata)
+
+	// transfers between accounts of other holders are not found
+	transfers, err = qtx.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: reference,
+		Holder:    "other",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	// the invited holder sees the transfers once the invitation is accepted
+	_, err = qtx.CreateAccountHolder(context.Background(), CreateAccountHolderParams{
+		AccountID: fromAccount.ID,
+		Holder:    "other",
+		Role:      string(entity.HolderViewer),
+		Status:    string(entity.HolderInvited),
+		InvitedBy: fromAccount.Owner,
+	})
+	require.NoError(t, err)
+	transfers, err = qtx.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: reference,
+		Holder:    "other",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	_, err = qtx.AcceptAccountHolder(context.Background(), AcceptAccountHolderParams{
+		AccountID: fromAccount.ID,
+		Holder:    "other",
+	})
+	require.NoError(t, err)
+	transfers, err = qtx.ListTransfersByReference(context.Background(), ListTransfersByReferenceParams{
+		Reference: reference,
+		Holder:    "other",
+	})
+	require.NoError(t, err)
+	assert.Len(t, transfers, 1)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestGetTransfer(t *testing.T) {
 	tx, err := testDB.Begin()
 	if err != nil {
@@ -371,9 +506,10 @@ func TestGetTransfer(t *testing.T) {
 	r, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
-		FromEntryID:   fromEntry.ID,
-		ToEntryID:     toEntry.ID,
+		FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
 		Amount:        amount,
+		Status:        entity.TransferCompleted,
 	})
 	require.NoError(t, err)
 
@@ -420,9 +556,10 @@ func TestListTransfersByFromAccount(t *testing.T) {
 		transfer, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
 			FromAccountID: fromAccount.ID,
 			ToAccountID:   v.ID,
-			FromEntryID:   fromEntry.ID,
-			ToEntryID:     toEntry.ID,
+			FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+			ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
 			Amount:        amount,
+			Status:        entity.TransferCompleted,
 		})
 		require.NoError(t, err)
 
@@ -472,9 +609,10 @@ func TestListTransfersByToAccount(t *testing.T) {
 		transfer, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
 			FromAccountID: v.ID,
 			ToAccountID:   toAccount.ID,
-			FromEntryID:   fromEntry.ID,
-			ToEntryID:     toEntry.ID,
+			FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+			ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
 			Amount:        amount,
+			Status:        entity.TransferCompleted,
 		})
 		require.NoError(t, err)
 
@@ -517,9 +655,10 @@ func TestDeleteTransfer(t *testing.T) {
 	r, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
-		FromEntryID:   fromEntry.ID,
-		ToEntryID:     toEntry.ID,
+		FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
 		Amount:        amount,
+		Status:        entity.TransferCompleted,
 	})
 	require.NoError(t, err)
 
@@ -531,21 +670,1410 @@ func TestDeleteTransfer(t *testing.T) {
 	}
 }
 
-// TODO: replace with golden files
-func createRandomAccount(t *testing.T, queries *Queries) Account {
-	arg := CreateAccountParams{
-		ID:       uuid.New(),
-		Owner:    string(random.String(20)),
-		Balance:  random.Int64(1, 900000),
-		Currency: Currency(random.GetString([]string{string(CurrencyRUB), string(CurrencyUSD)}...)),
+func TestPayees(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer tx.Rollback()
+	qtx := New(tx)
 
-	acc, err := queries.CreateAccount(context.Background(), arg)
+	owner := string(random.String(20))
+	account := createRandomAccount(t, qtx)
+
+	payee, err := qtx.CreatePayee(context.Background(), CreatePayeeParams{
+		Owner:     owner,
+		Nickname:  "landlord",
+		AccountID: account.ID,
+	})
+	require.NoError(t, err)
+
+	renamed, err := qtx.UpdatePayeeNickname(context.Background(), UpdatePayeeNicknameParams{
+		ID:       payee.ID,
+		Owner:    owner,
+		Nickname: "rent",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rent", renamed.Nickname)
+
+	// payees of other owners are not visible
+	_, err = qtx.GetPayee(context.Background(), GetPayeeParams{ID: payee.ID, Owner: "other"})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	payees, err := qtx.ListPayees(context.Background(), owner)
+	require.NoError(t, err)
+	require.Len(t, payees, 1)
+	assert.Equal(t, account.ID, payees[0].AccountID)
+
+	n, err := qtx.DeletePayee(context.Background(), DeletePayeeParams{ID: payee.ID, Owner: owner})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransferLimits(t *testing.T) {
+	tx, err := testDB.Begin()
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
+	defer tx.Rollback()
+	qtx := New(tx)
 
+	account := createRandomAccount(t, qtx)
+
+	// accounts without overrides get the standard tier
+	limits, err := qtx.GetTransferLimits(context.Background(), account.ID)
 	require.NoError(t, err)
+	assert.Equal(t, "standard", limits.Tier)
+	assert.Equal(t, int64(10000000), limits.MaxSingle)
 
-	return acc
+	_, err = qtx.UpsertAccountLimits(context.Background(), UpsertAccountLimitsParams{
+		AccountID: account.ID,
+		Tier:      "premium",
+		Daily:     sql.NullInt64{Int64: 5000, Valid: true},
+		UpdatedBy: "admin",
+	})
+	require.NoError(t, err)
+
+	limits, err = qtx.GetTransferLimits(context.Background(), account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "premium", limits.Tier)
+	assert.Equal(t, int64(100000000), limits.MaxSingle)
+	assert.Equal(t, int64(5000), limits.Daily)
+
+	n, err := qtx.DeleteAccountLimits(context.Background(), account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransferReviews(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	from := createRandomAccount(t, qtx)
+	to := createRandomAccount(t, qtx)
+
+	review, err := qtx.CreateTransferReview(context.Background(), CreateTransferReviewParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        100,
+		Score:         60,
+		Signals:       entity.RiskSignals{{Rule: "unusual_hour", Score: 60}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", review.Status)
+	assert.Equal(t, entity.RiskSignals{{Rule: "unusual_hour", Score: 60}}, review.Signals)
+
+	closed, err := qtx.CloseTransferReview(context.Background(), CloseTransferReviewParams{
+		ID:         review.ID,
+		Status:     "rejected",
+		ReviewedBy: "operator",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", closed.Status)
+	assert.True(t, closed.ReviewedAt.Valid)
+
+	// closed reviews can't be closed again
+	_, err = qtx.CloseTransferReview(context.Background(), CloseTransferReviewParams{
+		ID:     review.ID,
+		Status: "approved",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	h, err := qtx.GetRiskHistory(context.Background(), GetRiskHistoryParams{
+		ToAccountID:   to.ID,
+		Since:         time.Now().Add(-time.Hour),
+		FromAccountID: from.ID,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, h.TransferCount)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransferApprovals(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	from := createRandomAccount(t, qtx)
+	to := createRandomAccount(t, qtx)
+
+	policy, err := qtx.UpsertApprovalPolicy(context.Background(), UpsertApprovalPolicyParams{
+		AccountID: from.ID,
+		Threshold: 1000,
+		Approvers: []string{"maker", "checker"},
+		UpdatedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"maker", "checker"}, policy.Approvers)
+
+	approval, err := qtx.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        5000,
+		InitiatedBy:   "maker",
+		ExpiresAt:     time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending_approval", approval.Status)
+
+	// the initiator can't see its own approvals
+	approvals, err := qtx.ListTransferApprovals(context.Background(), "maker")
+	require.NoError(t, err)
+	assert.Empty(t, approvals)
+
+	approvals, err = qtx.ListTransferApprovals(context.Background(), "checker")
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, approval.ID, approvals[0].ID)
+
+	decided, err := qtx.DecideTransferApproval(context.Background(), DecideTransferApprovalParams{
+		ID:        approval.ID,
+		Status:    "rejected",
+		DecidedBy: "checker",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "checker", decided.DecidedBy)
+	assert.True(t, decided.DecidedAt.Valid)
+
+	// expired approvals can't be decided
+	expired, err := qtx.CreateTransferApproval(context.Background(), CreateTransferApprovalParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        5000,
+		InitiatedBy:   "maker",
+		ExpiresAt:     time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	_, err = qtx.DecideTransferApproval(context.Background(), DecideTransferApprovalParams{
+		ID:        expired.ID,
+		Status:    "approved",
+		DecidedBy: "checker",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	transfers, err := qtx.ExpireTransferApprovals(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, transfers)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransferStatus(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	from := createRandomAccount(t, qtx)
+	to := createRandomAccount(t, qtx)
+	fromEntry, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: from.ID,
+		Amount:    -100,
+	})
+	require.NoError(t, err)
+	toEntry, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: to.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+
+	transfer, err := qtx.CreateTransfer(context.Background(), CreateTransferParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		FromEntryID:   sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:     sql.NullInt64{Int64: toEntry.ID, Valid: true},
+		Amount:        100,
+		Status:        entity.TransferPending,
+	})
+	require.NoError(t, err)
+
+	failed, err := qtx.UpdateTransferStatus(context.Background(), UpdateTransferStatusParams{
+		Status:        entity.TransferFailed,
+		FailureReason: entity.FailureInsufficientFunds,
+		ID:            transfer.ID,
+		FromStatus:    entity.TransferPending,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferFailed, failed.Status)
+	assert.Equal(t, entity.FailureInsufficientFunds, failed.FailureReason)
+
+	// the status has been changed since the transfer was read
+	_, err = qtx.UpdateTransferStatus(context.Background(), UpdateTransferStatusParams{
+		Status:     entity.TransferProcessing,
+		ID:         transfer.ID,
+		FromStatus: entity.TransferPending,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = qtx.CreateTransferTransition(context.Background(), CreateTransferTransitionParams{
+		TransferID: transfer.ID,
+		ToStatus:   entity.TransferPending,
+	})
+	require.NoError(t, err)
+	_, err = qtx.CreateTransferTransition(context.Background(), CreateTransferTransitionParams{
+		TransferID: transfer.ID,
+		FromStatus: entity.TransferPending,
+		ToStatus:   entity.TransferFailed,
+		Reason:     entity.FailureInsufficientFunds,
+	})
+	require.NoError(t, err)
+
+	transitions, err := qtx.ListTransferTransitions(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, entity.TransferPending, transitions[0].ToStatus)
+	assert.Equal(t, entity.TransferFailed, transitions[1].ToStatus)
+
+	transfers, err := qtx.ListTransfersByFromAccount(context.Background(), ListTransfersByFromAccountParams{
+		FromAccountID: from.ID,
+		Status:        string(entity.TransferCompleted),
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScreeningAlerts(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	alert, err := qtx.CreateScreeningAlert(context.Background(), CreateScreeningAlertParams{
+		Name:      account.Owner,
+		Trigger:   string(entity.ScreenTransfer),
+		AccountID: account.ID,
+		Score:     0.9,
+		Matches:   entity.ScreeningMatches{{EntryID: "101", Name: "IVANOV, Sergei", Score: 0.9}},
+		Decision:  string(entity.ScreeningFlag),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", alert.Status)
+	require.Len(t, alert.Matches, 1)
+
+	pending, err := qtx.GetPendingScreeningAlert(context.Background(), GetPendingScreeningAlertParams{
+		AccountID: account.ID,
+		Name:      account.Owner,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, alert.ID, pending.ID)
+
+	_, err = qtx.GetLastClosedScreeningAlert(context.Background(), GetLastClosedScreeningAlertParams{
+		AccountID: account.ID,
+		Name:      account.Owner,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	closed, err := qtx.CloseScreeningAlert(context.Background(), CloseScreeningAlertParams{
+		ID:         alert.ID,
+		Status:     string(entity.AlertCleared),
+		ReviewedBy: "officer",
+	})
+	require.NoError(t, err)
+	assert.True(t, closed.ReviewedAt.Valid)
+
+	// closed alerts can't be closed again
+	_, err = qtx.CloseScreeningAlert(context.Background(), CloseScreeningAlertParams{
+		ID:         alert.ID,
+		Status:     string(entity.AlertConfirmed),
+		ReviewedBy: "officer",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	last, err := qtx.GetLastClosedScreeningAlert(context.Background(), GetLastClosedScreeningAlertParams{
+		AccountID: account.ID,
+		Name:      account.Owner,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.AlertCleared), last.Status)
+
+	_, err = qtx.GetPendingScreeningAlert(context.Background(), GetPendingScreeningAlertParams{
+		AccountID: account.ID,
+		Name:      account.Owner,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TODO: replace with golden files
+func TestAuditLog(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	require.NoError(t, qtx.LockAuditLog(context.Background()))
+
+	prevHash := entity.AuditGenesisHash
+	if last, err := qtx.GetLastAuditRecord(context.Background()); err == nil {
+		prevHash = last.Hash
+	} else {
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	}
+
+	r := entity.AuditRecord{
+		AuditActor: entity.AuditActor{Subject: "admin", RequestID: "req-1", ClientIP: "10.0.0.1"},
+		Action:     entity.AuditAccountUpdateOwner,
+		TargetType: entity.AuditTargetAccount,
+		TargetID:   account.ID.String(),
+		Before:     []byte(`{"owner": "old"}`),
+		After:      []byte(`{"owner": "new"}`),
+		PrevHash:   prevHash,
+		CreatedAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+	r.Hash = r.ComputeHash()
+
+	record, err := qtx.CreateAuditRecord(context.Background(), CreateAuditRecordParams{
+		Actor:      r.Subject,
+		RequestID:  r.RequestID,
+		ClientIp:   r.ClientIP,
+		Action:     r.Action,
+		TargetType: r.TargetType,
+		TargetID:   r.TargetID,
+		Before:     r.Before,
+		After:      r.After,
+		PrevHash:   r.PrevHash,
+		Hash:       r.Hash,
+		CreatedAt:  r.CreatedAt,
+	})
+	require.NoError(t, err)
+	// the stored snapshots and time must hash the same
+	assert.Equal(t, string(r.Before), string(record.Before))
+	assert.True(t, r.CreatedAt.Equal(record.CreatedAt))
+
+	records, err := qtx.ListAuditRecords(context.Background(), ListAuditRecordsParams{
+		TargetType: entity.AuditTargetAccount,
+		TargetID:   account.ID.String(),
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, record.Hash, records[0].Hash)
+
+	chain, err := qtx.ListAuditChain(context.Background(), ListAuditChainParams{ID: record.ID - 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+	assert.Equal(t, record.ID, chain[0].ID)
+
+	// the log is append only
+	_, err = tx.Exec("UPDATE audit_log SET actor = 'intruder' WHERE id = $1", record.ID)
+	require.Error(t, err)
+}
+
+func TestInterest(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	expense := createRandomAccount(t, qtx)
+	saver := createRandomAccount(t, qtx)
+	product, err := qtx.CreateInterestProduct(context.Background(), CreateInterestProductParams{
+		Name:             "savings " + string(random.String(10)),
+		Currency:         saver.Currency,
+		AnnualRateBp:     500,
+		DayCount:         string(entity.DayCountACT365),
+		ExpenseAccountID: expense.ID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, qtx.UpsertAccountProduct(context.Background(), UpsertAccountProductParams{
+		AccountID: saver.ID,
+		ProductID: product.ID,
+	}))
+
+	// the end-of-day balance excludes the entries made after the day
+	dayEnd := time.Now().Add(time.Hour)
+	balances, err := qtx.ListAccrualBalances(context.Background(), dayEnd)
+	require.NoError(t, err)
+	var found bool
+	for _, v := range balances {
+		if v.AccountID == saver.ID {
+			found = true
+			assert.Equal(t, saver.Balance, v.Balance)
+			assert.Equal(t, product.ID, v.ProductID)
+		}
+	}
+	require.True(t, found)
+
+	period := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	accrual := CreateInterestAccrualParams{
+		AccountID:    saver.ID,
+		AccrualDate:  period,
+		ProductID:    product.ID,
+		Balance:      saver.Balance,
+		AnnualRateBp: 500,
+		DayCount:     string(entity.DayCountACT365),
+		Days:         1,
+		AmountMicros: 1_500_000,
+	}
+	n, err := qtx.CreateInterestAccrual(context.Background(), accrual)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	// the repeated accrual is skipped
+	n, err = qtx.CreateInterestAccrual(context.Background(), accrual)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	totals, err := qtx.ListPostingTotals(context.Background(), ListPostingTotalsParams{
+		Period:    period,
+		PeriodEnd: period.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	assert.Equal(t, expense.ID, totals[0].ExpenseAccountID)
+	assert.Equal(t, int64(1_500_000), totals[0].AccruedMicros)
+
+	_, err = qtx.CreateInterestPosting(context.Background(), CreateInterestPostingParams{
+		AccountID:     saver.ID,
+		Period:        period,
+		AccruedMicros: 1_500_000,
+		Amount:        1,
+		CarryMicros:   500_000,
+	})
+	require.NoError(t, err)
+
+	// the posted month takes no more accruals
+	accrual.AccrualDate = period.AddDate(0, 0, 1)
+	n, err = qtx.CreateInterestAccrual(context.Background(), accrual)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	totals, err = qtx.ListPostingTotals(context.Background(), ListPostingTotalsParams{
+		Period:    period,
+		PeriodEnd: period.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, totals)
+}
+
+func TestFees(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	revenue := createRandomAccount(t, qtx)
+	payer := createRandomAccount(t, qtx)
+	payee := createRandomAccount(t, qtx)
+	product, err := qtx.CreateInterestProduct(context.Background(), CreateInterestProductParams{
+		Name:             "current " + string(random.String(10)),
+		Currency:         payer.Currency,
+		DayCount:         string(entity.DayCountACT365),
+		ExpenseAccountID: revenue.ID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, qtx.UpsertAccountProduct(context.Background(), UpsertAccountProductParams{
+		AccountID: payer.ID,
+		ProductID: product.ID,
+	}))
+
+	p2p, err := qtx.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:             "p2p",
+		Kind:             string(entity.FeeTiered),
+		AppliesTo:        string(entity.FeeTransferP2P),
+		Currency:         payer.Currency,
+		Tiers:            entity.FeeTiers{{UpTo: 1000, Fixed: 10}, {RateBP: 100}},
+		RevenueAccountID: revenue.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeeTiers{{UpTo: 1000, Fixed: 10}, {RateBP: 100}}, p2p.Tiers)
+
+	maintenance, err := qtx.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:             "maintenance",
+		Kind:             string(entity.FeeFixed),
+		AppliesTo:        string(entity.FeeMaintenance),
+		ProductID:        sql.NullInt64{Int64: product.ID, Valid: true},
+		Currency:         payer.Currency,
+		Fixed:            100,
+		RevenueAccountID: revenue.ID,
+	})
+	require.NoError(t, err)
+
+	// the p2p schedule applies to the other owners only
+	schedules, err := qtx.ListTransferFeeSchedules(context.Background(), ListTransferFeeSchedulesParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   payee.ID,
+	})
+	require.NoError(t, err)
+	var ids []int64
+	for _, v := range schedules {
+		ids = append(ids, v.ID)
+	}
+	assert.Contains(t, ids, p2p.ID)
+	assert.NotContains(t, ids, maintenance.ID)
+
+	period := time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC)
+	params := ListMaintenanceFeesParams{
+		PeriodEnd: period.AddDate(0, 1, 0),
+		Period:    period,
+	}
+	fees, err := qtx.ListMaintenanceFees(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	assert.Equal(t, payer.ID, fees[0].AccountID)
+	assert.Equal(t, payer.Balance, fees[0].Balance)
+
+	debit, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID:   payer.ID,
+		Amount:      -100,
+		Description: "Fee: maintenance",
+	})
+	require.NoError(t, err)
+	credit, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: revenue.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+	_, err = qtx.CreateFeeCharge(context.Background(), CreateFeeChargeParams{
+		ScheduleID:    maintenance.ID,
+		AccountID:     payer.ID,
+		Amount:        100,
+		Period:        sql.NullTime{Time: period, Valid: true},
+		DebitEntryID:  debit.ID,
+		CreditEntryID: credit.ID,
+	})
+	require.NoError(t, err)
+
+	// the charged account is skipped
+	fees, err = qtx.ListMaintenanceFees(context.Background(), params)
+	require.NoError(t, err)
+	assert.Empty(t, fees)
+
+	charges, err := qtx.ListFeeCharges(context.Background(), ListFeeChargesParams{
+		AccountID: payer.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, charges, 1)
+	assert.Equal(t, "maintenance", charges[0].Name)
+	assert.Equal(t, debit.ID, charges[0].EntryID)
+
+	_, err = qtx.DeactivateFeeSchedule(context.Background(), p2p.ID)
+	require.NoError(t, err)
+	schedules, err = qtx.ListTransferFeeSchedules(context.Background(), ListTransferFeeSchedulesParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   payee.ID,
+	})
+	require.NoError(t, err)
+	for _, v := range schedules {
+		assert.NotEqual(t, p2p.ID, v.ID)
+	}
+}
+
+func TestLedger(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	// the default chart of accounts is opened in each currency
+	cash, err := qtx.GetGLAccountByCode(context.Background(), GetGLAccountByCodeParams{
+		Code:     entity.GLCodeCash,
+		Currency: CurrencyUSD,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.GLAsset), cash.Type)
+
+	// the ledger accounts go negative
+	account, err := qtx.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     cash.AccountID,
+		Amount: -cash.Balance - 100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-100), account.Balance)
+	assert.Equal(t, "ledger", account.Kind)
+
+	ledger, err := qtx.CreateLedgerAccount(context.Background(), CreateLedgerAccountParams{
+		ID:       uuid.New(),
+		Currency: CurrencyRUB,
+	})
+	require.NoError(t, err)
+	gl, err := qtx.CreateGLAccount(context.Background(), CreateGLAccountParams{
+		AccountID: ledger.ID,
+		Code:      "1100",
+		Name:      "Correspondent",
+		Type:      string(entity.GLAsset),
+		Currency:  ledger.Currency,
+	})
+	require.NoError(t, err)
+
+	got, err := qtx.GetGLAccount(context.Background(), gl.AccountID)
+	require.NoError(t, err)
+	assert.Equal(t, "1100", got.Code)
+	assert.Equal(t, int64(0), got.Balance)
+
+	customer := createRandomAccount(t, qtx)
+	assert.Equal(t, "customer", customer.Kind)
+	totals, err := qtx.SumCustomerBalances(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for _, v := range totals {
+		if v.Currency == customer.Currency {
+			found = true
+			assert.GreaterOrEqual(t, v.Balance, customer.Balance)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestCashOperations(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	// the channels are posted against their GL accounts
+	_, err = qtx.GetGLAccountByCode(context.Background(), GetGLAccountByCodeParams{
+		Code:     entity.GLCodeATMCash,
+		Currency: CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	account := createRandomAccount(t, qtx)
+	seq, err := qtx.NextCashReference(context.Background())
+	require.NoError(t, err)
+	reference := entity.CashReference(entity.CashDeposit, time.Now(), seq)
+
+	var entries []Entry
+	for i := 0; i < 2; i++ {
+		e, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID:   account.ID,
+			Amount:      500,
+			Description: "Cash deposit",
+			Reference:   reference,
+			Metadata:    entity.Metadata{"channel": string(entity.CashATM)},
+		})
+		require.NoError(t, err)
+		entries = append(entries, e)
+	}
+
+	arg := CreateCashOperationParams{
+		Reference: reference,
+		Direction: string(entity.CashDeposit),
+		Channel:   string(entity.CashATM),
+		AccountID: account.ID,
+		Amount:    500,
+		Currency:  account.Currency,
+		Balance:   account.Balance + 500,
+		EntryID:   entries[0].ID,
+		CreatedBy: "atm-1",
+	}
+	op, err := qtx.CreateCashOperation(context.Background(), arg)
+	require.NoError(t, err)
+	assert.Equal(t, reference, op.Reference)
+
+	got, err := qtx.GetCashOperation(context.Background(), reference)
+	require.NoError(t, err)
+	assert.Equal(t, op.ID, got.ID)
+
+	used, err := qtx.SumCashOperations(context.Background(), SumCashOperationsParams{
+		AccountID: account.ID,
+		Channel:   string(entity.CashATM),
+		Direction: string(entity.CashDeposit),
+		Since:     time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), used)
+
+	list, err := qtx.ListCashOperations(context.Background(), ListCashOperationsParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// the receipt reference is unique
+	arg.EntryID = entries[1].ID
+	_, err = qtx.CreateCashOperation(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestBalanceSnapshots(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	_, err = qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    100,
+	})
+	require.NoError(t, err)
+
+	// the entries are made within the transaction time
+	asOf := time.Now().Add(time.Hour)
+	n, err := qtx.CreateBalanceSnapshots(context.Background(), asOf)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, int64(1))
+
+	// the snapshotted accounts are skipped
+	n, err = qtx.CreateBalanceSnapshots(context.Background(), asOf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	snapshot, err := qtx.GetBalanceSnapshot(context.Background(), GetBalanceSnapshotParams{
+		AccountID: account.ID,
+		Before:    asOf.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, account.Balance, snapshot.Balance)
+
+	_, err = qtx.GetBalanceSnapshot(context.Background(), GetBalanceSnapshotParams{
+		AccountID: account.ID,
+		Before:    asOf.Add(-time.Minute),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	sum, err := qtx.SumEntriesBetween(context.Background(), SumEntriesBetweenParams{
+		AccountID: account.ID,
+		FromTime:  time.Now().Add(-time.Hour),
+		ToTime:    asOf,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), sum)
+}
+
+func TestEOD(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	locked, err := qtx.TryEODLock(context.Background())
+	require.NoError(t, err)
+	require.True(t, locked)
+	released, err := qtx.ReleaseEODLock(context.Background())
+	require.NoError(t, err)
+	require.True(t, released)
+
+	bd, err := qtx.GetBusinessDate(context.Background())
+	require.NoError(t, err)
+	n, err := qtx.FreezeBusinessDate(context.Background(), bd.Date)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	run, err := qtx.CreateEODRun(context.Background(), CreateEODRunParams{
+		BusinessDate: bd.Date,
+		StartedBy:    "eod",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "running", run.Status)
+
+	err = qtx.StartEODStep(context.Background(), StartEODStepParams{RunID: run.ID, Step: "freeze"})
+	require.NoError(t, err)
+	step, err := qtx.FinishEODStep(context.Background(), FinishEODStepParams{
+		RunID:  run.ID,
+		Step:   "freeze",
+		Status: "failed",
+		Result: []byte(`{"processed":1}`),
+		Error:  "failed",
+	})
+	require.NoError(t, err)
+	assert.True(t, step.FinishedAt.Valid)
+
+	// the failed run and step are resumed
+	run, err = qtx.FinishEODRun(context.Background(), FinishEODRunParams{ID: run.ID, Status: "failed", Error: "freeze"})
+	require.NoError(t, err)
+	resumed, err := qtx.CreateEODRun(context.Background(), CreateEODRunParams{
+		BusinessDate: bd.Date,
+		StartedBy:    "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, run.ID, resumed.ID)
+	assert.Equal(t, "running", resumed.Status)
+	assert.False(t, resumed.FinishedAt.Valid)
+
+	err = qtx.StartEODStep(context.Background(), StartEODStepParams{RunID: run.ID, Step: "freeze"})
+	require.NoError(t, err)
+	steps, err := qtx.ListEODSteps(context.Background(), run.ID)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	assert.Equal(t, "running", steps[0].Status)
+
+	// the date is advanced only once
+	n, err = qtx.AdvanceBusinessDate(context.Background(), bd.Date)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = qtx.AdvanceBusinessDate(context.Background(), bd.Date)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	next, err := qtx.GetBusinessDate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bd.Date.AddDate(0, 0, 1), next.Date)
+	assert.False(t, next.Frozen)
+}
+
+func TestDailyStatements(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	for _, amount := range []int64{300, -100} {
+		_, err = qtx.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+		})
+		require.NoError(t, err)
+	}
+
+	dayStart := time.Now().UTC().Truncate(24 * time.Hour)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	_, err = qtx.CreateBalanceSnapshots(context.Background(), dayEnd)
+	require.NoError(t, err)
+
+	n, err := qtx.CreateDailyStatements(context.Background(), CreateDailyStatementsParams{
+		StatementDate: dayStart,
+		DayStart:      dayStart,
+		DayEnd:        dayEnd,
+	})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, int64(1))
+
+	statements, err := qtx.ListDailyStatements(context.Background(), ListDailyStatementsParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+	assert.Equal(t, account.Balance, statements[0].ClosingBalance)
+	assert.Equal(t, account.Balance-200, statements[0].OpeningBalance)
+	assert.Equal(t, int64(100), statements[0].Debits)
+	assert.Equal(t, int64(300), statements[0].Credits)
+	assert.Equal(t, int64(2), statements[0].Entries)
+}
+
+func TestExternalTransfers(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	// the clearing house account is opened in every currency
+	_, err = qtx.GetGLAccountByCode(context.Background(), GetGLAccountByCodeParams{
+		Code:     entity.GLCodeClearing,
+		Currency: CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	account := createRandomAccount(t, qtx)
+	var transfers []ExternalTransfer
+	for i := 0; i < 2; i++ {
+		e, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID:   account.ID,
+			Amount:      -100,
+			Description: "External transfer",
+		})
+		require.NoError(t, err)
+
+		v, err := qtx.CreateExternalTransfer(context.Background(), CreateExternalTransferParams{
+			AccountID:          account.ID,
+			Amount:             100,
+			Currency:           account.Currency,
+			BeneficiaryName:    "Max Mustermann",
+			BeneficiaryAccount: "DE89370400440532013000",
+			BeneficiaryBank:    "COBADEFF",
+			EntryID:            e.ID,
+			CreatedBy:          account.Owner,
+			Status:             string(entity.ExternalPending),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, string(entity.ExternalPending), v.Status)
+		assert.False(t, v.BatchID.Valid)
+		transfers = append(transfers, v)
+	}
+
+	pending, err := qtx.ListPendingExternalTransfers(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, len(pending), 2)
+
+	batch, err := qtx.CreateClearingBatch(context.Background(), CreateClearingBatchParams{
+		FileName: entity.ClearingBatchName(time.Now(), entity.Currency(account.Currency), transfers[0].ID),
+		Currency: account.Currency,
+		Count:    2,
+		Total:    200,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BatchCreated), batch.Status)
+	batchID := sql.NullInt64{Int64: batch.ID, Valid: true}
+
+	for _, v := range transfers {
+		err = qtx.AddExternalTransferToBatch(context.Background(), AddExternalTransferToBatchParams{
+			ID:      v.ID,
+			BatchID: batchID,
+		})
+		require.NoError(t, err)
+	}
+	inBatch, err := qtx.ListBatchExternalTransfers(context.Background(), batchID)
+	require.NoError(t, err)
+	require.Len(t, inBatch, 2)
+	assert.Equal(t, string(entity.ExternalSent), inBatch[0].Status)
+
+	created, err := qtx.ListCreatedClearingBatches(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, created)
+	batch, err = qtx.UpdateClearingBatchStatus(context.Background(), UpdateClearingBatchStatusParams{
+		ID:     batch.ID,
+		Status: string(entity.BatchSent),
+	})
+	require.NoError(t, err)
+	got, err := qtx.GetClearingBatchByFileName(context.Background(), batch.FileName)
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BatchSent), got.Status)
+
+	settled, err := qtx.SettleExternalTransfer(context.Background(), SettleExternalTransferParams{
+		ID:      transfers[0].ID,
+		BatchID: batchID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.ExternalSettled), settled.Status)
+
+	returned, err := qtx.ReturnExternalTransfer(context.Background(), ReturnExternalTransferParams{
+		ID:           transfers[1].ID,
+		BatchID:      batchID,
+		ReturnReason: entity.ReturnClosedAccount,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReturnClosedAccount, returned.ReturnReason)
+
+	// the done transfers are not settled or returned again
+	_, err = qtx.ReturnExternalTransfer(context.Background(), ReturnExternalTransferParams{
+		ID:           transfers[0].ID,
+		BatchID:      batchID,
+		ReturnReason: entity.ReturnClosedAccount,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	list, err := qtx.ListExternalTransfers(context.Background(), ListExternalTransfersParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, transfers[1].ID, list[0].ID)
+
+	v, err := qtx.GetExternalTransfer(context.Background(), transfers[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, batchID, v.BatchID)
+
+	batches, err := qtx.ListClearingBatches(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, batch.ID, batches[0].ID)
+}
+
+func TestHeldExternalTransfers(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	e, err := qtx.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID:   account.ID,
+		Amount:      -100,
+		Description: "External transfer",
+	})
+	require.NoError(t, err)
+
+	v, err := qtx.CreateExternalTransfer(context.Background(), CreateExternalTransferParams{
+		AccountID:          account.ID,
+		Amount:             100,
+		Currency:           account.Currency,
+		BeneficiaryName:    "Max Mustermann",
+		BeneficiaryAccount: "DE89370400440532013000",
+		BeneficiaryBank:    "COBADEFF",
+		EntryID:            e.ID,
+		CreatedBy:          account.Owner,
+		Status:             string(entity.ExternalHeld),
+		Hold:               string(entity.HoldApproval),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, v.Signals)
+
+	held, err := qtx.ListHeldExternalTransfers(context.Background(), string(entity.HoldApproval))
+	require.NoError(t, err)
+	require.NotEmpty(t, held)
+	assert.Equal(t, v.ID, held[len(held)-1].ID)
+
+	// the transfers held for the other hold are not decided
+	_, err = qtx.DecideExternalTransfer(context.Background(), DecideExternalTransferParams{
+		ID:     v.ID,
+		Status: string(entity.ExternalPending),
+		Hold:   string(entity.HoldReview),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	signals := entity.RiskSignals{{Rule: "new_payee_large_amount", Score: 60, Reason: "first transfer"}}
+	v, err = qtx.HoldExternalTransfer(context.Background(), HoldExternalTransferParams{
+		ID:         v.ID,
+		Score:      60,
+		Signals:    signals,
+		ReviewedBy: "approver",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.HoldReview), v.Hold)
+	assert.Equal(t, signals, v.Signals)
+
+	v, err = qtx.DecideExternalTransfer(context.Background(), DecideExternalTransferParams{
+		ID:         v.ID,
+		Status:     string(entity.ExternalRejected),
+		Hold:       string(entity.HoldReview),
+		ReviewedBy: "operator",
+		Comment:    "fraud",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.ExternalRejected), v.Status)
+	assert.Equal(t, "operator", v.ReviewedBy)
+
+	// the rejected transfer is refunded and leaves the usage
+	now := time.Now()
+	usage, err := qtx.GetTransferUsage(context.Background(), GetTransferUsageParams{
+		DayStart:   now.Add(-time.Hour),
+		MonthStart: now.Add(-time.Hour),
+		HourStart:  now.Add(-time.Hour),
+		AccountID:  account.ID,
+		Since:      now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, usage.Daily)
+}
+
+func TestLoans(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	// the loans and their interest income are booked in every currency
+	for _, code := range []string{entity.GLCodeLoans, entity.GLCodeInterestIncome} {
+		_, err = qtx.GetGLAccountByCode(context.Background(), GetGLAccountByCodeParams{
+			Code:     code,
+			Currency: CurrencyUSD,
+		})
+		require.NoError(t, err)
+	}
+
+	account := createRandomAccount(t, qtx)
+	disbursed := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	loan, err := qtx.CreateLoan(context.Background(), CreateLoanParams{
+		AccountID:     account.ID,
+		Principal:     1000,
+		Currency:      account.Currency,
+		AnnualRateBp:  1200,
+		PenaltyRateBp: 2000,
+		TermMonths:    2,
+		Method:        string(entity.AmortizationLinear),
+		DisbursedOn:   disbursed,
+		CreatedBy:     "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.LoanActive), loan.Status)
+	assert.Equal(t, int64(1000), loan.Outstanding)
+
+	for _, i := range entity.AmortizationSchedule(1000, 1200, 2, entity.AmortizationLinear, disbursed) {
+		err = qtx.CreateLoanInstallment(context.Background(), CreateLoanInstallmentParams{
+			LoanID:    loan.ID,
+			Number:    i.Number,
+			DueDate:   i.DueDate,
+			Principal: i.Principal,
+			Interest:  i.Interest,
+		})
+		require.NoError(t, err)
+	}
+	installments, err := qtx.ListLoanInstallments(context.Background(), loan.ID)
+	require.NoError(t, err)
+	require.Len(t, installments, 2)
+	assert.Equal(t, string(entity.InstallmentScheduled), installments[0].Status)
+
+	// the first installment is due on the last day of February
+	due, err := qtx.ListDueLoanInstallments(context.Background(), disbursed.AddDate(0, 0, 28))
+	require.NoError(t, err)
+	var found []ListDueLoanInstallmentsRow
+	for _, v := range due {
+		if v.LoanID == loan.ID {
+			found = append(found, v)
+		}
+	}
+	require.Len(t, found, 1)
+	assert.Equal(t, int32(1), found[0].Number)
+	assert.Equal(t, account.ID, found[0].AccountID)
+	assert.Equal(t, int32(2000), found[0].PenaltyRateBp)
+
+	err = qtx.MarkLoanInstallmentLate(context.Background(), MarkLoanInstallmentLateParams{LoanID: loan.ID, Number: 1})
+	require.NoError(t, err)
+	paidOn := sql.NullTime{Time: disbursed.AddDate(0, 1, 5), Valid: true}
+	paid, err := qtx.PayLoanInstallment(context.Background(), PayLoanInstallmentParams{
+		LoanID:  loan.ID,
+		Number:  1,
+		Penalty: 3,
+		PaidOn:  paidOn,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.InstallmentPaid), paid.Status)
+	assert.Equal(t, int64(3), paid.Penalty)
+
+	// the paid installment is not paid again or marked late
+	_, err = qtx.PayLoanInstallment(context.Background(), PayLoanInstallmentParams{LoanID: loan.ID, Number: 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	err = qtx.MarkLoanInstallmentLate(context.Background(), MarkLoanInstallmentLateParams{LoanID: loan.ID, Number: 1})
+	require.NoError(t, err)
+
+	loan, err = qtx.RepayLoanPrincipal(context.Background(), RepayLoanPrincipalParams{ID: loan.ID, Amount: 500})
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), loan.Outstanding)
+	assert.Equal(t, string(entity.LoanActive), loan.Status)
+	loan, err = qtx.RepayLoanPrincipal(context.Background(), RepayLoanPrincipalParams{ID: loan.ID, Amount: 500})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.LoanRepaid), loan.Status)
+	assert.True(t, loan.ClosedAt.Valid)
+
+	got, err := qtx.GetLoan(context.Background(), loan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.Outstanding)
+
+	loans, err := qtx.ListLoans(context.Background(), ListLoansParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	assert.Equal(t, loan.ID, loans[0].ID)
+}
+
+func createRandomAccount(t *testing.T, queries *Queries) Account {
+	arg := CreateAccountParams{
+		ID:       uuid.New(),
+		Owner:    string(random.String(20)),
+		Balance:  random.Int64(1, 900000),
+		Currency: Currency(random.GetString([]string{string(CurrencyRUB), string(CurrencyUSD)}...)),
+	}
+
+	acc, err := queries.CreateAccount(context.Background(), arg)
+	if err != nil {
+		t.Error(err)
+	}
+
+	require.NoError(t, err)
+
+	return acc
+}
+
+func TestTermDeposits(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	_, err = qtx.GetGLAccountByCode(context.Background(), GetGLAccountByCodeParams{
+		Code:     entity.GLCodeTermDeposits,
+		Currency: CurrencyUSD,
+	})
+	require.NoError(t, err)
+
+	account := createRandomAccount(t, qtx)
+	start := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	maturity := entity.DepositMaturity(start, 1)
+	deposit, err := qtx.CreateTermDeposit(context.Background(), CreateTermDepositParams{
+		AccountID:       account.ID,
+		PayoutAccountID: account.ID,
+		Principal:       1000,
+		Currency:        account.Currency,
+		AnnualRateBp:    1200,
+		TermMonths:      1,
+		StartDate:       start,
+		MaturityDate:    maturity,
+		MaturityAction:  string(entity.MaturityRollover),
+		EarlyWithdrawal: string(entity.EarlyWithdrawalBlocked),
+		CreatedBy:       "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.DepositActive), deposit.Status)
+
+	// the deposit matures on the last day of February
+	matured, err := qtx.ListMaturedTermDeposits(context.Background(), maturity.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	for _, v := range matured {
+		assert.NotEqual(t, deposit.ID, v.ID)
+	}
+	matured, err = qtx.ListMaturedTermDeposits(context.Background(), maturity)
+	require.NoError(t, err)
+	var found bool
+	for _, v := range matured {
+		found = found || v.ID == deposit.ID
+	}
+	assert.True(t, found)
+
+	next := entity.DepositMaturity(maturity, 1)
+	rolled, err := qtx.RollOverTermDeposit(context.Background(), RollOverTermDepositParams{
+		ID:               deposit.ID,
+		Interest:         9,
+		NextMaturityDate: next,
+		MaturityDate:     maturity,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1009), rolled.Principal)
+	assert.Equal(t, int64(9), rolled.InterestPaid)
+	assert.Equal(t, int32(1), rolled.Rollovers)
+	assert.True(t, maturity.Equal(rolled.StartDate))
+
+	// the term is rolled over once
+	_, err = qtx.RollOverTermDeposit(context.Background(), RollOverTermDepositParams{
+		ID:               deposit.ID,
+		Interest:         9,
+		NextMaturityDate: next,
+		MaturityDate:     maturity,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	closed, err := qtx.CloseTermDeposit(context.Background(), CloseTermDepositParams{
+		ID:       deposit.ID,
+		Status:   string(entity.DepositWithdrawn),
+		Interest: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.DepositWithdrawn), closed.Status)
+	assert.Equal(t, int64(11), closed.InterestPaid)
+	assert.True(t, closed.ClosedAt.Valid)
+
+	_, err = qtx.CloseTermDeposit(context.Background(), CloseTermDepositParams{
+		ID:     deposit.ID,
+		Status: string(entity.DepositMatured),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	deposits, err := qtx.ListTermDeposits(context.Background(), ListTermDepositsParams{
+		AccountID: account.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	assert.Equal(t, deposit.ID, deposits[0].ID)
+}
+
+func TestAccountHolders(t *testing.T) {
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	account := createRandomAccount(t, qtx)
+	for _, h := range []CreateAccountHolderParams{
+		{AccountID: account.ID, Holder: account.Owner, Role: string(entity.HolderPrimary), Status: string(entity.HolderActive)},
+		{AccountID: account.ID, Holder: "joint", Role: string(entity.HolderJoint), Status: string(entity.HolderInvited),
+			InvitedBy: account.Owner},
+	} {
+		_, err = qtx.CreateAccountHolder(context.Background(), h)
+		require.NoError(t, err)
+	}
+
+	joint, err := qtx.AcceptAccountHolder(context.Background(), AcceptAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    "joint",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.HolderActive), joint.Status)
+	assert.True(t, joint.AcceptedAt.Valid)
+	_, err = qtx.AcceptAccountHolder(context.Background(), AcceptAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    "joint",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	holders, err := qtx.ListAccountHolders(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, holders, 2)
+
+	// the joint holder becomes the primary one with the account owner
+	n, err := qtx.DeleteAccountHolder(context.Background(), DeleteAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    account.Owner,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = qtx.DeleteAccountHolder(context.Background(), DeleteAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    "joint",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = qtx.UpdatePrimaryAccountHolder(context.Background(), UpdatePrimaryAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    "joint",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	primary, err := qtx.GetAccountHolder(context.Background(), GetAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    "joint",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.HolderPrimary), primary.Role)
+
+	// there is one primary holder of the account
+	_, err = qtx.CreateAccountHolder(context.Background(), CreateAccountHolderParams{
+		AccountID: account.ID,
+		Holder:    account.Owner,
+		Role:      string(entity.HolderPrimary),
+		Status:    string(entity.HolderActive),
+	})
+	require.Error(t, err)
 }
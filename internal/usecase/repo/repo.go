@@ -3,21 +3,62 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/lib/pq"
 )
 
+const uniqueViolation = "23505"
+
 type SQLRepo struct {
 	db *sql.DB
 }
 
+// txKey is the context key of the tx begun by InTx.
+type txKey struct{}
+
+// NewTransactor returns the repo running the changes of several repos in
+// one tx.
+func NewTransactor(db *sql.DB) *SQLRepo {
+	return &SQLRepo{
+		db: db,
+	}
+}
+
+// InTx runs fn in a tx, the repos called with the context of fn join it
+// instead of beginning their own. The nested calls join the outer tx.
+func (r *SQLRepo) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rollback err: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
 func (r *SQLRepo) execTx(ctx context.Context, opts *sql.TxOptions, fn func(q *db.Queries) error) error {
-	errCh := make(chan error)
+	// the tx of InTx is committed or rolled back by InTx
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(db.New(tx))
+	}
+
+	errCh := make(chan error, 1)
 	go func() {
 		tx, err := r.db.BeginTx(ctx, opts)
 		if err != nil {
 			errCh <- err
+			return
 		}
 
 		// new queries for tx
@@ -25,11 +66,25 @@ func (r *SQLRepo) execTx(ctx context.Context, opts *sql.TxOptions, fn func(q *db
 		err = fn(qtx)
 		if err != nil {
 			if rbErr := tx.Rollback(); rbErr != nil {
-				errCh <- fmt.Errorf("tx err: %v, rollback err: %v", err, rbErr)
+				errCh <- fmt.Errorf("tx err: %w, rollback err: %v", err, rbErr)
+				return
 			}
 			errCh <- err
+			return
 		}
 		errCh <- tx.Commit()
 	}()
 	return <-errCh
 }
+
+// isConstraint reports whether err is a violation of the named constraint.
+func isConstraint(err error, name string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Constraint == name
+}
+
+// isUniqueViolation reports whether err is a violation of a unique constraint.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
@@ -3,8 +3,11 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
 	"alukart32.com/bank/internal/usecase/repo/db"
 	"github.com/google/uuid"
 )
@@ -21,6 +24,8 @@ func NewAccountSQLRepo(db *sql.DB) *AccountSQLRepo {
 	}
 }
 
+// Create opens the account with its owner as the primary holder, the
+// opening balance is deposited from the cash GL account.
 func (r *AccountSQLRepo) Create(ctx context.Context, account entity.Account) (entity.Account, error) {
 	var result entity.Account
 
@@ -28,21 +33,34 @@ func (r *AccountSQLRepo) Create(ctx context.Context, account entity.Account) (en
 		a, err := q.CreateAccount(ctx, db.CreateAccountParams{
 			ID:       account.ID,
 			Owner:    account.Owner,
-			Balance:  account.Balance,
 			Currency: db.Currency(account.Currency),
+			Number: sql.NullString{
+				String: account.Number,
+				Valid:  account.Number != "",
+			},
 		})
+		if isUniqueViolation(err) {
+			return usecase.ErrDuplicate
+		}
 		if err != nil {
 			return err
 		}
+		if _, err = q.CreateAccountHolder(ctx, db.CreateAccountHolderParams{
+			AccountID:  a.ID,
+			Holder:     a.Owner,
+			Role:       string(entity.HolderPrimary),
+			Status:     string(entity.HolderActive),
+			AcceptedAt: sql.NullTime{Time: a.CreatedAt, Valid: true},
+		}); err != nil {
+			return err
+		}
 
-		result = entity.Account{
-			ID:        a.ID,
-			Owner:     a.Owner,
-			Balance:   a.Balance,
-			Currency:  entity.Currency(a.Currency),
-			CreatedAt: a.CreatedAt,
+		result = toAccount(a)
+		if account.Balance > 0 {
+			result, _, err = postCash(ctx, q, entity.GLCodeCash, a.ID, a.Currency, account.Balance,
+				entity.Posting{Description: "Opening deposit"})
 		}
-		return nil
+		return err
 	})
 
 	return result, err
@@ -53,6 +71,9 @@ func (r *AccountSQLRepo) Get(ctx context.Context, id uuid.UUID) (entity.Account,
 
 	err := r.execTx(ctx, nil, func(q *db.Queries) error {
 		a, err := q.GetAccount(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
 		if err != nil {
 			return err
 		}
@@ -63,6 +84,7 @@ func (r *AccountSQLRepo) Get(ctx context.Context, id uuid.UUID) (entity.Account,
 			Balance:   a.Balance,
 			Currency:  entity.Currency(a.Currency),
 			CreatedAt: a.CreatedAt,
+			Number:    a.Number.String,
 		}
 		return nil
 	})
@@ -70,14 +92,14 @@ func (r *AccountSQLRepo) Get(ctx context.Context, id uuid.UUID) (entity.Account,
 	return result, err
 }
 
-func (r *AccountSQLRepo) UpdateOwner(ctx context.Context, id uuid.UUID, owner string) (entity.Account, error) {
+func (r *AccountSQLRepo) GetByNumber(ctx context.Context, number string) (entity.Account, error) {
 	var result entity.Account
 
 	err := r.execTx(ctx, nil, func(q *db.Queries) error {
-		a, err := q.UpdateAccountOwner(ctx, db.UpdateAccountOwnerParams{
-			ID:    id,
-			Owner: owner,
-		})
+		a, err := q.GetAccountByNumber(ctx, sql.NullString{String: number, Valid: true})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
 		if err != nil {
 			return err
 		}
@@ -88,6 +110,7 @@ func (r *AccountSQLRepo) UpdateOwner(ctx context.Context, id uuid.UUID, owner st
 			Balance:   a.Balance,
 			Currency:  entity.Currency(a.Currency),
 			CreatedAt: a.CreatedAt,
+			Number:    a.Number.String,
 		}
 		return nil
 	})
@@ -95,24 +118,66 @@ func (r *AccountSQLRepo) UpdateOwner(ctx context.Context, id uuid.UUID, owner st
 	return result, err
 }
 
-func (r *AccountSQLRepo) UpdateBalance(ctx context.Context, id uuid.UUID, amount int64) (entity.Account, error) {
-	var result entity.Account
+// NextNumber returns the next value of the account number sequence.
+func (r *AccountSQLRepo) NextNumber(ctx context.Context) (int64, error) {
+	var seq int64
 
 	err := r.execTx(ctx, nil, func(q *db.Queries) error {
-		a, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
-			ID:     id,
-			Amount: amount,
+		var err error
+		seq, err = q.NextAccountNumber(ctx)
+		return err
+	})
+
+	return seq, err
+}
+
+// UpdateOwner makes the owner the primary holder of the account, the
+// other role of the owner is dropped.
+func (r *AccountSQLRepo) UpdateOwner(ctx context.Context, id uuid.UUID, owner string) (entity.Account, error) {
+	var result entity.Account
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := q.UpdateAccountOwner(ctx, db.UpdateAccountOwnerParams{
+			ID:    id,
+			Owner: owner,
 		})
 		if err != nil {
 			return err
 		}
 
+		if _, err = q.DeleteAccountHolder(ctx, db.DeleteAccountHolderParams{
+			AccountID: id,
+			Holder:    owner,
+		}); err != nil {
+			return err
+		}
+		n, err := q.UpdatePrimaryAccountHolder(ctx, db.UpdatePrimaryAccountHolderParams{
+			AccountID: id,
+			Holder:    owner,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			_, err = q.CreateAccountHolder(ctx, db.CreateAccountHolderParams{
+				AccountID:  id,
+				Holder:     owner,
+				Role:       string(entity.HolderPrimary),
+				Status:     string(entity.HolderActive),
+				AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		result = entity.Account{
 			ID:        a.ID,
 			Owner:     a.Owner,
 			Balance:   a.Balance,
 			Currency:  entity.Currency(a.Currency),
 			CreatedAt: a.CreatedAt,
+			Number:    a.Number.String,
 		}
 		return nil
 	})
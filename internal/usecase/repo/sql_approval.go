@@ -0,0 +1,299 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type ApprovalSQLRepo struct {
+	SQLRepo
+	// transfers executes the approved transfers.
+	transfers *TransferSQLRepo
+}
+
+func NewApprovalSQLRepo(db *sql.DB, transfers *TransferSQLRepo) *ApprovalSQLRepo {
+	return &ApprovalSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+		transfers: transfers,
+	}
+}
+
+func (r *ApprovalSQLRepo) GetPolicy(ctx context.Context, accountID uuid.UUID) (entity.ApprovalPolicy, error) {
+	var result entity.ApprovalPolicy
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		p, err := q.GetApprovalPolicy(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = entity.ApprovalPolicy(p)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ApprovalSQLRepo) SetPolicy(ctx context.Context, policy entity.ApprovalPolicy) (entity.ApprovalPolicy, error) {
+	var result entity.ApprovalPolicy
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		p, err := q.UpsertApprovalPolicy(ctx, db.UpsertApprovalPolicyParams{
+			AccountID: policy.AccountID,
+			Threshold: policy.Threshold,
+			Approvers: policy.Approvers,
+			UpdatedBy: policy.UpdatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		result = entity.ApprovalPolicy(p)
+		return nil
+	})
+	if isConstraint(err, "approval_policies_account_fk") {
+		return entity.ApprovalPolicy{}, usecase.ErrNotFound
+	}
+
+	return result, err
+}
+
+func (r *ApprovalSQLRepo) DeletePolicy(ctx context.Context, accountID uuid.UUID) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		n, err := q.DeleteApprovalPolicy(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return usecase.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *ApprovalSQLRepo) Create(ctx context.Context, approval entity.TransferApproval) (entity.TransferApproval, error) {
+	var result entity.TransferApproval
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		t := approval.Transfer
+		pending, err := insertTransfer(ctx, q, t, entity.TransferPending)
+		if err != nil {
+			return err
+		}
+
+		a, err := q.CreateTransferApproval(ctx, db.CreateTransferApprovalParams{
+			FromAccountID: t.FromAccountID,
+			ToAccountID:   t.ToAccountID,
+			Amount:        t.Amount,
+			Description:   t.Description,
+			Reference:     t.Reference,
+			Metadata:      t.Metadata,
+			InitiatedBy:   approval.InitiatedBy,
+			ExpiresAt:     approval.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		a, err = q.SetTransferApprovalTransfer(ctx, db.SetTransferApprovalTransferParams{
+			ID:         a.ID,
+			TransferID: sql.NullInt64{Int64: pending.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		result = toTransferApproval(a)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ApprovalSQLRepo) Get(ctx context.Context, id int64) (entity.TransferApproval, error) {
+	var result entity.TransferApproval
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		a, err := q.GetTransferApproval(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toTransferApproval(a)
+		return nil
+	})
+
+	return result, err
+}
+
+// List returns the pending approvals of the accounts the approver is
+// allowed to approve, except the ones initiated by the approver. The
+// expired approvals are closed first.
+func (r *ApprovalSQLRepo) List(ctx context.Context, approver string) ([]entity.TransferApproval, error) {
+	var result []entity.TransferApproval
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		expired, err := q.ExpireTransferApprovals(ctx)
+		if err != nil {
+			return err
+		}
+		for _, id := range expired {
+			if err = cancelTransfer(ctx, q, id, entity.FailureExpired); err != nil {
+				return err
+			}
+		}
+
+		approvals, err := q.ListTransferApprovals(ctx, approver)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.TransferApproval, 0, len(approvals))
+		for _, a := range approvals {
+			result = append(result, toTransferApproval(a))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ApprovalSQLRepo) Approve(ctx context.Context, id int64, approver, comment string,
+	fees []entity.FeeCharge) (entity.TransferApproval, entity.TransferRes, error) {
+	var (
+		approval entity.TransferApproval
+		res      entity.TransferRes
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := decideApproval(ctx, q, id, entity.ApprovalApproved, approver, comment)
+		if err != nil {
+			return err
+		}
+
+		approval = toTransferApproval(a)
+		res, err = r.transfers.create(ctx, q, approval.Transfer, fees)
+		if err != nil {
+			return err
+		}
+
+		a, err = q.SetTransferApprovalTransfer(ctx, db.SetTransferApprovalTransferParams{
+			ID:         id,
+			TransferID: sql.NullInt64{Int64: res.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		approval = toTransferApproval(a)
+		approval.Transfer = res.Transfer
+		return nil
+	})
+
+	return approval, res, err
+}
+
+// Hold approves the pending approval without executing its transfer, the
+// transfer waits in the review instead.
+func (r *ApprovalSQLRepo) Hold(ctx context.Context, id int64, approver, comment string,
+	review entity.TransferReview) (entity.TransferApproval, entity.TransferReview, error) {
+	var (
+		approval entity.TransferApproval
+		held     entity.TransferReview
+	)
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		a, err := decideApproval(ctx, q, id, entity.ApprovalApproved, approver, comment)
+		if err != nil {
+			return err
+		}
+		approval = toTransferApproval(a)
+
+		held, err = createTransferReview(ctx, q, review)
+		return err
+	})
+
+	return approval, held, err
+}
+
+func (r *ApprovalSQLRepo) Reject(ctx context.Context, id int64, approver, comment string) (entity.TransferApproval, error) {
+	var result entity.TransferApproval
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		a, err := decideApproval(ctx, q, id, entity.ApprovalRejected, approver, comment)
+		if err != nil {
+			return err
+		}
+		result = toTransferApproval(a)
+		return cancelTransfer(ctx, q, a.TransferID, entity.FailureRejected)
+	})
+
+	return result, err
+}
+
+// decideApproval moves the pending approval to the status. The update
+// locks the approval, so it is decided only once.
+func decideApproval(ctx context.Context, q *db.Queries, id int64, status entity.ApprovalStatus,
+	approver, comment string) (db.TransferApproval, error) {
+	a, err := q.DecideTransferApproval(ctx, db.DecideTransferApprovalParams{
+		ID:        id,
+		Status:    string(status),
+		DecidedBy: approver,
+		Comment:   comment,
+	})
+	if !errors.Is(err, sql.ErrNoRows) {
+		return a, err
+	}
+
+	a, err = q.GetTransferApproval(ctx, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return db.TransferApproval{}, usecase.ErrNotFound
+	case err != nil:
+		return db.TransferApproval{}, err
+	case a.Status == string(entity.ApprovalPending) && !a.ExpiresAt.After(time.Now()),
+		a.Status == string(entity.ApprovalExpired):
+		return db.TransferApproval{}, usecase.ErrApprovalExpired
+	default:
+		return db.TransferApproval{}, usecase.ErrApprovalClosed
+	}
+}
+
+func toTransferApproval(a db.TransferApproval) entity.TransferApproval {
+	result := entity.TransferApproval{
+		ID: a.ID,
+		Transfer: entity.Transfer{
+			ID:            a.TransferID.Int64,
+			FromAccountID: a.FromAccountID,
+			ToAccountID:   a.ToAccountID,
+			Amount:        a.Amount,
+			Description:   a.Description,
+			Reference:     a.Reference,
+			Metadata:      a.Metadata,
+		},
+		Status:      entity.ApprovalStatus(a.Status),
+		InitiatedBy: a.InitiatedBy,
+		DecidedBy:   a.DecidedBy,
+		Comment:     a.Comment,
+		ExpiresAt:   a.ExpiresAt,
+		CreatedAt:   a.CreatedAt,
+	}
+	// the pending approvals expire on read until they are closed
+	if result.Status == entity.ApprovalPending && !a.ExpiresAt.After(time.Now()) {
+		result.Status = entity.ApprovalExpired
+	}
+	if a.DecidedAt.Valid {
+		result.DecidedAt = &a.DecidedAt.Time
+	}
+	return result
+}
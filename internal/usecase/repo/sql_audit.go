@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+)
+
+type AuditSQLRepo struct {
+	SQLRepo
+}
+
+func NewAuditSQLRepo(db *sql.DB) *AuditSQLRepo {
+	return &AuditSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Append chains the record to the last one. The advisory lock serializes
+// the appends, so two records never share the previous hash.
+func (r *AuditSQLRepo) Append(ctx context.Context, a entity.AuditRecord) (entity.AuditRecord, error) {
+	var result entity.AuditRecord
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		if err := q.LockAuditLog(ctx); err != nil {
+			return err
+		}
+
+		a.PrevHash = entity.AuditGenesisHash
+		last, err := q.GetLastAuditRecord(ctx)
+		if err == nil {
+			a.PrevHash = last.Hash
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		// postgres keeps microseconds, the hash must match the stored time
+		a.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		a.Hash = a.ComputeHash()
+
+		v, err := q.CreateAuditRecord(ctx, db.CreateAuditRecordParams{
+			Actor:      a.Subject,
+			RequestID:  a.RequestID,
+			ClientIp:   a.ClientIP,
+			Action:     a.Action,
+			TargetType: a.TargetType,
+			TargetID:   a.TargetID,
+			Before:     a.Before,
+			After:      a.After,
+			PrevHash:   a.PrevHash,
+			Hash:       a.Hash,
+			CreatedAt:  a.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		result = toAuditRecord(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *AuditSQLRepo) List(ctx context.Context, params usecase.ListAuditParams) ([]entity.AuditRecord, error) {
+	var result []entity.AuditRecord
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		records, err := q.ListAuditRecords(ctx, db.ListAuditRecordsParams{
+			TargetType: params.TargetType,
+			TargetID:   params.TargetID,
+			Actor:      params.Actor,
+			Limit:      params.Limit,
+			Offset:     params.Offset,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.AuditRecord, 0, len(records))
+		for _, v := range records {
+			result = append(result, toAuditRecord(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Chain returns the records after the id in the chain order.
+func (r *AuditSQLRepo) Chain(ctx context.Context, afterID int64, limit int32) ([]entity.AuditRecord, error) {
+	var result []entity.AuditRecord
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		records, err := q.ListAuditChain(ctx, db.ListAuditChainParams{
+			ID:    afterID,
+			Limit: limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.AuditRecord, 0, len(records))
+		for _, v := range records {
+			result = append(result, toAuditRecord(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func toAuditRecord(v db.AuditLog) entity.AuditRecord {
+	return entity.AuditRecord{
+		ID: v.ID,
+		AuditActor: entity.AuditActor{
+			Subject:   v.Actor,
+			RequestID: v.RequestID,
+			ClientIP:  v.ClientIp,
+		},
+		Action:     v.Action,
+		TargetType: v.TargetType,
+		TargetID:   v.TargetID,
+		Before:     v.Before,
+		After:      v.After,
+		PrevHash:   v.PrevHash,
+		Hash:       v.Hash,
+		CreatedAt:  v.CreatedAt,
+	}
+}
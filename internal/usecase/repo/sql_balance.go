@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type BalanceSQLRepo struct {
+	SQLRepo
+}
+
+func NewBalanceSQLRepo(db *sql.DB) *BalanceSQLRepo {
+	return &BalanceSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// AsOf returns the balance of the account as of the time. It adds the
+// entries made since the latest snapshot before the time, without a
+// snapshot it takes the current balance less the entries made since the
+// time.
+func (r *BalanceSQLRepo) AsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (entity.AccountBalance, error) {
+	var result entity.AccountBalance
+
+	// the balance and entries must be read from the same snapshot
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := r.execTx(ctx, opts, func(q *db.Queries) error {
+		a, err := q.GetAccount(ctx, accountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = entity.AccountBalance{
+			AccountID: a.ID,
+			Currency:  entity.Currency(a.Currency),
+			AsOf:      asOf,
+		}
+
+		snapshot, err := q.GetBalanceSnapshot(ctx, db.GetBalanceSnapshotParams{
+			AccountID: accountID,
+			Before:    asOf,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			since, err := q.SumEntriesSince(ctx, db.SumEntriesSinceParams{
+				AccountID: accountID,
+				CreatedAt: asOf,
+			})
+			if err != nil {
+				return err
+			}
+			result.Balance = a.Balance - since
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		after, err := q.SumEntriesBetween(ctx, db.SumEntriesBetweenParams{
+			AccountID: accountID,
+			FromTime:  snapshot.AsOf,
+			ToTime:    asOf,
+		})
+		if err != nil {
+			return err
+		}
+		result.Balance = snapshot.Balance + after
+		return nil
+	})
+
+	return result, err
+}
+
+// Snapshot saves the balances of the accounts as of the time and returns
+// the number of the saved ones. The accounts snapshotted before are
+// skipped.
+func (r *BalanceSQLRepo) Snapshot(ctx context.Context, asOf time.Time) (int64, error) {
+	var result int64
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		var err error
+		result, err = q.CreateBalanceSnapshots(ctx, asOf)
+		return err
+	})
+
+	return result, err
+}
+
+// Rebuild replaces the snapshots with the ones of each day end from the
+// creation of the first account until the time.
+func (r *BalanceSQLRepo) Rebuild(ctx context.Context, until time.Time) (int64, error) {
+	var result int64
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		if _, err := q.DeleteBalanceSnapshots(ctx); err != nil {
+			return err
+		}
+
+		first, err := q.GetFirstAccountTime(ctx)
+		if err != nil {
+			return err
+		}
+		// the first snapshot is the end of the day the first account was
+		// created
+		asOf := first.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+		for ; !asOf.After(until); asOf = asOf.AddDate(0, 0, 1) {
+			n, err := q.CreateBalanceSnapshots(ctx, asOf)
+			if err != nil {
+				return err
+			}
+			result += n
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type CashSQLRepo struct {
+	SQLRepo
+}
+
+func NewCashSQLRepo(db *sql.DB) *CashSQLRepo {
+	return &CashSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// NextReference returns the next value of the receipt reference sequence.
+func (r *CashSQLRepo) NextReference(ctx context.Context) (int64, error) {
+	var seq int64
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		var err error
+		seq, err = q.NextCashReference(ctx)
+		return err
+	})
+
+	return seq, err
+}
+
+// Create posts the operation against the GL account of its channel and
+// saves the receipt. The balance update locks the account row, so the
+// daily limit is checked against the operations of the account one by
+// one.
+func (r *CashSQLRepo) Create(ctx context.Context, op entity.CashOperation,
+	limit entity.CashLimit) (entity.CashOperation, entity.Entry, error) {
+	var (
+		result entity.CashOperation
+		entry  entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := q.GetAccount(ctx, op.AccountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if a.Kind != "customer" {
+			return fmt.Errorf("%w: not a customer account", usecase.ErrInvalidArgument)
+		}
+
+		amount, description := op.Amount, "Cash deposit"
+		if op.Direction == entity.CashWithdrawal {
+			amount, description = -op.Amount, "Cash withdrawal"
+		}
+		metadata := entity.Metadata{"channel": string(op.Channel)}
+		if op.TellerID != "" {
+			metadata["teller_id"] = op.TellerID
+		}
+		account, e, err := postCash(ctx, q, op.Channel.GLCode(), a.ID, a.Currency, amount, entity.Posting{
+			Description: description,
+			Reference:   op.Reference,
+			Metadata:    metadata,
+		})
+		if err != nil {
+			return err
+		}
+
+		day, _, _ := usecase.LimitWindows(time.Now())
+		used, err := q.SumCashOperations(ctx, db.SumCashOperationsParams{
+			AccountID: a.ID,
+			Channel:   string(op.Channel),
+			Direction: string(op.Direction),
+			Since:     day,
+		})
+		if err != nil {
+			return err
+		}
+		if err = usecase.CheckCashLimit(limit, used, op.Amount); err != nil {
+			return err
+		}
+
+		v, err := q.CreateCashOperation(ctx, db.CreateCashOperationParams{
+			Reference: op.Reference,
+			Direction: string(op.Direction),
+			Channel:   string(op.Channel),
+			TellerID:  op.TellerID,
+			AccountID: a.ID,
+			Amount:    op.Amount,
+			Currency:  a.Currency,
+			Balance:   account.Balance,
+			EntryID:   e.ID,
+			CreatedBy: op.CreatedBy,
+		})
+		if isUniqueViolation(err) {
+			return usecase.ErrDuplicate
+		}
+		if err != nil {
+			return err
+		}
+		result, entry = toCashOperation(v), e
+		return nil
+	})
+
+	return result, entry, err
+}
+
+func (r *CashSQLRepo) Get(ctx context.Context, reference string) (entity.CashOperation, error) {
+	var result entity.CashOperation
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetCashOperation(ctx, reference)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toCashOperation(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *CashSQLRepo) List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.CashOperation, error) {
+	var result []entity.CashOperation
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		operations, err := q.ListCashOperations(ctx, db.ListCashOperationsParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.CashOperation, 0, len(operations))
+		for _, v := range operations {
+			result = append(result, toCashOperation(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func toCashOperation(v db.CashOperation) entity.CashOperation {
+	return entity.CashOperation{
+		ID:        v.ID,
+		Reference: v.Reference,
+		Direction: entity.CashDirection(v.Direction),
+		Channel:   entity.CashChannel(v.Channel),
+		TellerID:  v.TellerID,
+		AccountID: v.AccountID,
+		Amount:    v.Amount,
+		Currency:  entity.Currency(v.Currency),
+		Balance:   v.Balance,
+		EntryID:   v.EntryID,
+		CreatedBy: v.CreatedBy,
+		CreatedAt: v.CreatedAt,
+	}
+}
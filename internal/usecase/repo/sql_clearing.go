@@ -0,0 +1,534 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type ClearingSQLRepo struct {
+	SQLRepo
+}
+
+func NewClearingSQLRepo(db *sql.DB) *ClearingSQLRepo {
+	return &ClearingSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create debits the customer account into the suspense account and saves
+// the transfer pending or held by its status. The limits of the account
+// are checked under the lock of its balance, the fees are charged from
+// the pending transfer only.
+func (r *ClearingSQLRepo) Create(ctx context.Context, t entity.ExternalTransfer,
+	fees []entity.FeeCharge) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+		entry   entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := q.GetAccount(ctx, t.AccountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if a.Kind != "customer" {
+			return fmt.Errorf("%w: not a customer account", usecase.ErrInvalidArgument)
+		}
+
+		account, entry, err = postCash(ctx, q, entity.GLCodeSuspense, a.ID, a.Currency, -t.Amount, entity.Posting{
+			Description: "External transfer",
+			Reference:   t.Reference,
+			Metadata: entity.Metadata{
+				"beneficiary_account": t.BeneficiaryAccount,
+				"beneficiary_bank":    t.BeneficiaryBank,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		err = checkTransferLimits(ctx, q, entity.Transfer{FromAccountID: a.ID, Amount: t.Amount})
+		if err != nil {
+			return err
+		}
+
+		v, err := q.CreateExternalTransfer(ctx, db.CreateExternalTransferParams{
+			AccountID:          a.ID,
+			Amount:             t.Amount,
+			Currency:           a.Currency,
+			BeneficiaryName:    t.BeneficiaryName,
+			BeneficiaryAccount: t.BeneficiaryAccount,
+			BeneficiaryBank:    t.BeneficiaryBank,
+			Description:        t.Description,
+			Reference:          t.Reference,
+			EntryID:            entry.ID,
+			CreatedBy:          t.CreatedBy,
+			Status:             string(t.Status),
+			Hold:               string(t.Hold),
+			Score:              int32(t.Score),
+			Signals:            t.Signals,
+		})
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+
+		if result.Status != entity.ExternalPending {
+			return nil
+		}
+		for _, fee := range fees {
+			fee.AccountID, fee.ExternalTransferID = v.AccountID, v.ID
+			charged, a, err := chargeFee(ctx, q, fee)
+			if err != nil {
+				return err
+			}
+			result.Fees = append(result.Fees, charged)
+			account = toAccount(a)
+		}
+		return nil
+	})
+
+	return result, account, entry, err
+}
+
+func (r *ClearingSQLRepo) Get(ctx context.Context, id int64) (entity.ExternalTransfer, error) {
+	var result entity.ExternalTransfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetExternalTransfer(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ClearingSQLRepo) List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.ExternalTransfer, error) {
+	var result []entity.ExternalTransfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		transfers, err := q.ListExternalTransfers(ctx, db.ListExternalTransfersParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfers(transfers)
+		return nil
+	})
+
+	return result, err
+}
+
+// ListHeld returns the transfers waiting for the hold.
+func (r *ClearingSQLRepo) ListHeld(ctx context.Context, hold entity.ExternalHold) ([]entity.ExternalTransfer, error) {
+	var result []entity.ExternalTransfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		transfers, err := q.ListHeldExternalTransfers(ctx, string(hold))
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfers(transfers)
+		return nil
+	})
+
+	return result, err
+}
+
+// Release submits the transfer waiting for the hold to the next batch
+// and charges its fees. The account is returned with the balance after
+// the fees, it is empty without them.
+func (r *ClearingSQLRepo) Release(ctx context.Context, id int64, hold entity.ExternalHold, reviewer, comment string,
+	fees []entity.FeeCharge) (entity.ExternalTransfer, entity.Account, error) {
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := decideExternalTransfer(ctx, q, id, hold, entity.ExternalPending, reviewer, comment)
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+
+		for _, fee := range fees {
+			fee.AccountID, fee.ExternalTransferID = v.AccountID, v.ID
+			charged, a, err := chargeFee(ctx, q, fee)
+			if err != nil {
+				return err
+			}
+			result.Fees = append(result.Fees, charged)
+			account = toAccount(a)
+		}
+		return nil
+	})
+
+	return result, account, err
+}
+
+// Hold moves the approved transfer to review with its risk assessment.
+func (r *ClearingSQLRepo) Hold(ctx context.Context, id int64, approver, comment string,
+	a entity.RiskAssessment) (entity.ExternalTransfer, error) {
+	var result entity.ExternalTransfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.HoldExternalTransfer(ctx, db.HoldExternalTransferParams{
+			ID:         id,
+			Score:      int32(a.Score),
+			Signals:    a.Signals,
+			ReviewedBy: approver,
+			Comment:    comment,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// Reject refunds the transfer waiting for the hold from the suspense
+// account.
+func (r *ClearingSQLRepo) Reject(ctx context.Context, id int64, hold entity.ExternalHold,
+	reviewer, comment string) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+		entry   entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := decideExternalTransfer(ctx, q, id, hold, entity.ExternalRejected, reviewer, comment)
+		if err != nil {
+			return err
+		}
+
+		account, entry, err = postCash(ctx, q, entity.GLCodeSuspense, v.AccountID, v.Currency, v.Amount, entity.Posting{
+			Description: "External transfer rejection",
+			Reference:   v.Reference,
+			Metadata:    entity.Metadata{"external_transfer_id": fmt.Sprint(v.ID)},
+		})
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+		return nil
+	})
+
+	return result, account, entry, err
+}
+
+// decideExternalTransfer sets the status of the transfer waiting for the
+// hold. The other transfers return usecase.ErrInvalidTransition.
+func decideExternalTransfer(ctx context.Context, q *db.Queries, id int64, hold entity.ExternalHold,
+	status entity.ExternalTransferStatus, reviewer, comment string) (db.ExternalTransfer, error) {
+	v, err := q.DecideExternalTransfer(ctx, db.DecideExternalTransferParams{
+		ID:         id,
+		Status:     string(status),
+		Hold:       string(hold),
+		ReviewedBy: reviewer,
+		Comment:    comment,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, usecase.ErrInvalidTransition
+	}
+	return v, err
+}
+
+// CreateBatches returns the batches to send: the ones not delivered
+// before and a new batch of each currency with the pending transfers.
+// The transfers of the new batches are marked sent.
+func (r *ClearingSQLRepo) CreateBatches(ctx context.Context, now time.Time) ([]entity.ClearingBatch, error) {
+	var result []entity.ClearingBatch
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		created, err := q.ListCreatedClearingBatches(ctx)
+		if err != nil {
+			return err
+		}
+		for _, v := range created {
+			transfers, err := q.ListBatchExternalTransfers(ctx, sql.NullInt64{Int64: v.ID, Valid: true})
+			if err != nil {
+				return err
+			}
+			batch := toClearingBatch(v)
+			batch.Transfers = toExternalTransfers(transfers)
+			result = append(result, batch)
+		}
+
+		pending, err := q.ListPendingExternalTransfers(ctx)
+		if err != nil {
+			return err
+		}
+		var currencies []db.Currency
+		groups := make(map[db.Currency][]db.ExternalTransfer)
+		for _, v := range pending {
+			if _, ok := groups[v.Currency]; !ok {
+				currencies = append(currencies, v.Currency)
+			}
+			groups[v.Currency] = append(groups[v.Currency], v)
+		}
+
+		for _, currency := range currencies {
+			transfers := groups[currency]
+			var total int64
+			for _, v := range transfers {
+				total += v.Amount
+			}
+			v, err := q.CreateClearingBatch(ctx, db.CreateClearingBatchParams{
+				FileName: entity.ClearingBatchName(now, entity.Currency(currency), transfers[0].ID),
+				Currency: currency,
+				Count:    int64(len(transfers)),
+				Total:    total,
+			})
+			if err != nil {
+				return err
+			}
+
+			batch := toClearingBatch(v)
+			for _, t := range transfers {
+				err = q.AddExternalTransferToBatch(ctx, db.AddExternalTransferToBatchParams{
+					ID:      t.ID,
+					BatchID: sql.NullInt64{Int64: v.ID, Valid: true},
+				})
+				if err != nil {
+					return err
+				}
+				t.Status = string(entity.ExternalSent)
+				t.BatchID = sql.NullInt64{Int64: v.ID, Valid: true}
+				batch.Transfers = append(batch.Transfers, toExternalTransfer(t))
+			}
+			result = append(result, batch)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// UpdateBatch sets the status of the batch.
+func (r *ClearingSQLRepo) UpdateBatch(ctx context.Context, id int64, status entity.ClearingBatchStatus) (entity.ClearingBatch, error) {
+	var result entity.ClearingBatch
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.UpdateClearingBatchStatus(ctx, db.UpdateClearingBatchStatusParams{
+			ID:     id,
+			Status: string(status),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toClearingBatch(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// GetBatch returns the batch with its transfers.
+func (r *ClearingSQLRepo) GetBatch(ctx context.Context, fileName string) (entity.ClearingBatch, error) {
+	var result entity.ClearingBatch
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetClearingBatchByFileName(ctx, fileName)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		transfers, err := q.ListBatchExternalTransfers(ctx, sql.NullInt64{Int64: v.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		result = toClearingBatch(v)
+		result.Transfers = toExternalTransfers(transfers)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ClearingSQLRepo) ListBatches(ctx context.Context, limit int32) ([]entity.ClearingBatch, error) {
+	var result []entity.ClearingBatch
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		batches, err := q.ListClearingBatches(ctx, limit)
+		if err != nil {
+			return err
+		}
+		result = make([]entity.ClearingBatch, 0, len(batches))
+		for _, v := range batches {
+			result = append(result, toClearingBatch(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Settle moves the amount of the sent transfer of the batch from the
+// suspense account to the clearing house account. The transfers which
+// aren't waiting for the response return usecase.ErrInvalidTransition.
+func (r *ClearingSQLRepo) Settle(ctx context.Context, batch entity.ClearingBatch, id int64) (entity.ExternalTransfer, error) {
+	var result entity.ExternalTransfer
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := q.SettleExternalTransfer(ctx, db.SettleExternalTransferParams{
+			ID:      id,
+			BatchID: sql.NullInt64{Int64: batch.ID, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		suspense, err := glAccountByCode(ctx, q, entity.GLCodeSuspense, v.Currency)
+		if err != nil {
+			return err
+		}
+		clearing, err := glAccountByCode(ctx, q, entity.GLCodeClearing, v.Currency)
+		if err != nil {
+			return err
+		}
+		_, err = post(ctx, q, entity.Posting{
+			DebitAccountID:  suspense.AccountID,
+			CreditAccountID: clearing.AccountID,
+			Amount:          v.Amount,
+			Description:     "External transfer settlement",
+			Reference:       batch.FileName,
+			Metadata:        entity.Metadata{"external_transfer_id": fmt.Sprint(v.ID)},
+		})
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// Return credits the amount of the sent transfer of the batch back to
+// the customer account. The transfers which aren't waiting for the
+// response return usecase.ErrInvalidTransition.
+func (r *ClearingSQLRepo) Return(ctx context.Context, batch entity.ClearingBatch, id int64,
+	reason string) (entity.ExternalTransfer, entity.Account, entity.Entry, error) {
+	var (
+		result  entity.ExternalTransfer
+		account entity.Account
+		entry   entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := q.ReturnExternalTransfer(ctx, db.ReturnExternalTransferParams{
+			ID:           id,
+			BatchID:      sql.NullInt64{Int64: batch.ID, Valid: true},
+			ReturnReason: reason,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		account, entry, err = postCash(ctx, q, entity.GLCodeSuspense, v.AccountID, v.Currency, v.Amount, entity.Posting{
+			Description: "External transfer return",
+			Reference:   batch.FileName,
+			Metadata: entity.Metadata{
+				"external_transfer_id": fmt.Sprint(v.ID),
+				"reason":               reason,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		result = toExternalTransfer(v)
+		return nil
+	})
+
+	return result, account, entry, err
+}
+
+func toExternalTransfer(v db.ExternalTransfer) entity.ExternalTransfer {
+	t := entity.ExternalTransfer{
+		ID:                 v.ID,
+		AccountID:          v.AccountID,
+		Amount:             v.Amount,
+		Currency:           entity.Currency(v.Currency),
+		BeneficiaryName:    v.BeneficiaryName,
+		BeneficiaryAccount: v.BeneficiaryAccount,
+		BeneficiaryBank:    v.BeneficiaryBank,
+		Description:        v.Description,
+		Reference:          v.Reference,
+		Status:             entity.ExternalTransferStatus(v.Status),
+		ReturnReason:       v.ReturnReason,
+		EntryID:            v.EntryID,
+		Hold:               entity.ExternalHold(v.Hold),
+		Score:              int(v.Score),
+		Signals:            v.Signals,
+		ReviewedBy:         v.ReviewedBy,
+		Comment:            v.Comment,
+		CreatedBy:          v.CreatedBy,
+		CreatedAt:          v.CreatedAt,
+		UpdatedAt:          v.UpdatedAt,
+	}
+	if v.BatchID.Valid {
+		t.BatchID = &v.BatchID.Int64
+	}
+	return t
+}
+
+func toExternalTransfers(v []db.ExternalTransfer) []entity.ExternalTransfer {
+	result := make([]entity.ExternalTransfer, 0, len(v))
+	for _, t := range v {
+		result = append(result, toExternalTransfer(t))
+	}
+	return result
+}
+
+func toClearingBatch(v db.ClearingBatch) entity.ClearingBatch {
+	return entity.ClearingBatch{
+		ID:        v.ID,
+		FileName:  v.FileName,
+		Currency:  entity.Currency(v.Currency),
+		Count:     v.Count,
+		Total:     v.Total,
+		Status:    entity.ClearingBatchStatus(v.Status),
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearingCreateAudited(t *testing.T) {
+	ctx := usecase.WithActor(context.Background(), entity.AuditActor{Subject: "owner_clearing_audit"})
+	repoClearing := NewClearingSQLRepo(testDB)
+	repoAudit := NewAuditSQLRepo(testDB)
+	auditor := usecase.NewAuditor(repoAudit, NewTransactor(testDB))
+
+	account, err := NewAccountSQLRepo(testDB).Create(ctx, entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_clearing_audit",
+		Balance:  100_000,
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	var transfer entity.ExternalTransfer
+	err = auditor.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transfer, _, _, err = repoClearing.Create(ctx, entity.ExternalTransfer{
+			AccountID:          account.ID,
+			Amount:             1_000,
+			BeneficiaryName:    "John Smith",
+			BeneficiaryAccount: "DE89370400440532013000",
+			BeneficiaryBank:    "COBADEFFXXX",
+			Status:             entity.ExternalPending,
+			CreatedBy:          account.Owner,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		return auditor.Record(ctx, entity.AuditExternalCreate, entity.AuditTargetExternalTransfer,
+			strconv.FormatInt(transfer.ID, 10), nil, transfer)
+	})
+	require.NoError(t, err)
+
+	records, err := repoAudit.List(ctx, usecase.ListAuditParams{
+		TargetType:    entity.AuditTargetExternalTransfer,
+		TargetID:      strconv.FormatInt(transfer.ID, 10),
+		PaggingParams: usecase.PaggingParams{Limit: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, entity.AuditExternalCreate, records[0].Action)
+	assert.Equal(t, "owner_clearing_audit", records[0].Subject)
+
+	stored, err := repoClearing.Get(ctx, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ExternalPending, stored.Status)
+}
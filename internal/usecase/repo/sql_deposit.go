@@ -0,0 +1,294 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type DepositSQLRepo struct {
+	SQLRepo
+}
+
+func NewDepositSQLRepo(db *sql.DB) *DepositSQLRepo {
+	return &DepositSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create takes the principal of the deposit from the customer account to
+// the term deposits GL account. The payout account must be a customer
+// account in the same currency.
+func (r *DepositSQLRepo) Create(ctx context.Context, d entity.TermDeposit) (entity.TermDeposit, entity.Account,
+	entity.Entry, error) {
+	var (
+		result  entity.TermDeposit
+		account entity.Account
+		entry   entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := customerAccount(ctx, q, d.AccountID)
+		if err != nil {
+			return err
+		}
+		payout := a
+		if d.PayoutAccountID != a.ID {
+			if payout, err = customerAccount(ctx, q, d.PayoutAccountID); err != nil {
+				return err
+			}
+		}
+		if payout.Currency != a.Currency {
+			return fmt.Errorf("%w: payout account currency %s differs from %s",
+				usecase.ErrInvalidArgument, payout.Currency, a.Currency)
+		}
+
+		v, err := q.CreateTermDeposit(ctx, db.CreateTermDepositParams{
+			AccountID:       a.ID,
+			PayoutAccountID: payout.ID,
+			Principal:       d.Principal,
+			Currency:        a.Currency,
+			AnnualRateBp:    d.AnnualRateBP,
+			TermMonths:      d.TermMonths,
+			StartDate:       d.StartDate,
+			MaturityDate:    d.MaturityDate,
+			MaturityAction:  string(d.MaturityAction),
+			EarlyWithdrawal: string(d.EarlyWithdrawal),
+			EarlyPenaltyBp:  d.EarlyPenaltyBP,
+			CreatedBy:       d.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		account, entry, err = postCash(ctx, q, entity.GLCodeTermDeposits, a.ID, a.Currency, -d.Principal,
+			depositPosting(v.ID, "Term deposit opening"))
+		if err != nil {
+			return err
+		}
+
+		result = toTermDeposit(v)
+		return nil
+	})
+
+	return result, account, entry, err
+}
+
+func (r *DepositSQLRepo) Get(ctx context.Context, id int64) (entity.TermDeposit, error) {
+	var result entity.TermDeposit
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetTermDeposit(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toTermDeposit(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// List returns up to limit deposits of the account, the latest first.
+func (r *DepositSQLRepo) List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.TermDeposit, error) {
+	var result []entity.TermDeposit
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		deposits, err := q.ListTermDeposits(ctx, db.ListTermDepositsParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.TermDeposit, 0, len(deposits))
+		for _, v := range deposits {
+			result = append(result, toTermDeposit(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Matured returns the active deposits maturing on or before the date by
+// id.
+func (r *DepositSQLRepo) Matured(ctx context.Context, date time.Time) ([]entity.TermDeposit, error) {
+	var result []entity.TermDeposit
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		deposits, err := q.ListMaturedTermDeposits(ctx, date)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.TermDeposit, 0, len(deposits))
+		for _, v := range deposits {
+			result = append(result, toTermDeposit(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Close closes the active deposit with the status and pays the principal
+// from the term deposits GL account and the interest from the interest
+// expense to the payout account. The closed deposits return
+// usecase.ErrInvalidTransition.
+func (r *DepositSQLRepo) Close(ctx context.Context, id int64, status entity.DepositStatus,
+	interest int64) (entity.TermDeposit, entity.Account, []entity.Entry, error) {
+	var (
+		result  entity.TermDeposit
+		account entity.Account
+		entries []entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := q.CloseTermDeposit(ctx, db.CloseTermDepositParams{
+			ID:       id,
+			Status:   string(status),
+			Interest: interest,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, p := range []struct {
+			code        string
+			amount      int64
+			description string
+		}{
+			{entity.GLCodeTermDeposits, v.Principal, "Term deposit payout: principal"},
+			{entity.GLCodeInterestExpense, interest, "Term deposit payout: interest"},
+		} {
+			if p.amount == 0 {
+				continue
+			}
+			a, e, err := postCash(ctx, q, p.code, v.PayoutAccountID, v.Currency, p.amount,
+				depositPosting(v.ID, p.description))
+			if err != nil {
+				return err
+			}
+			account, entries = a, append(entries, e)
+		}
+
+		result = toTermDeposit(v)
+		return nil
+	})
+
+	return result, account, entries, err
+}
+
+// RollOver adds the interest of the term maturing on the date to the
+// principal and starts the next term maturing on the next date. The
+// interest is moved from the interest expense to the term deposits GL
+// account. The deposits closed or rolled over already return
+// usecase.ErrInvalidTransition.
+func (r *DepositSQLRepo) RollOver(ctx context.Context, id int64, maturity, next time.Time,
+	interest int64) (entity.TermDeposit, error) {
+	var result entity.TermDeposit
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := q.RollOverTermDeposit(ctx, db.RollOverTermDepositParams{
+			ID:               id,
+			Interest:         interest,
+			NextMaturityDate: next,
+			MaturityDate:     maturity,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		if interest > 0 {
+			expense, err := glAccountByCode(ctx, q, entity.GLCodeInterestExpense, v.Currency)
+			if err != nil {
+				return err
+			}
+			deposits, err := glAccountByCode(ctx, q, entity.GLCodeTermDeposits, v.Currency)
+			if err != nil {
+				return err
+			}
+			p := depositPosting(v.ID, "Term deposit rollover: interest")
+			p.DebitAccountID, p.CreditAccountID, p.Amount = expense.AccountID, deposits.AccountID, interest
+			if _, err = post(ctx, q, p); err != nil {
+				return err
+			}
+		}
+
+		result = toTermDeposit(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// customerAccount returns the customer account of the id.
+func customerAccount(ctx context.Context, q *db.Queries, id uuid.UUID) (db.Account, error) {
+	a, err := q.GetAccount(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return a, usecase.ErrNotFound
+	}
+	if err != nil {
+		return a, err
+	}
+	if a.Kind != "customer" {
+		return a, fmt.Errorf("%w: not a customer account", usecase.ErrInvalidArgument)
+	}
+	return a, nil
+}
+
+// depositPosting is the posting of the deposit entries.
+func depositPosting(id int64, description string) entity.Posting {
+	return entity.Posting{
+		Description: description,
+		Reference:   "DEP-" + strconv.FormatInt(id, 10),
+		Metadata:    entity.Metadata{"deposit_id": strconv.FormatInt(id, 10)},
+	}
+}
+
+func toTermDeposit(v db.TermDeposit) entity.TermDeposit {
+	d := entity.TermDeposit{
+		ID:              v.ID,
+		AccountID:       v.AccountID,
+		PayoutAccountID: v.PayoutAccountID,
+		Principal:       v.Principal,
+		Currency:        entity.Currency(v.Currency),
+		AnnualRateBP:    v.AnnualRateBp,
+		TermMonths:      v.TermMonths,
+		StartDate:       v.StartDate,
+		MaturityDate:    v.MaturityDate,
+		MaturityAction:  entity.MaturityAction(v.MaturityAction),
+		EarlyWithdrawal: entity.EarlyWithdrawal(v.EarlyWithdrawal),
+		EarlyPenaltyBP:  v.EarlyPenaltyBp,
+		Status:          entity.DepositStatus(v.Status),
+		Rollovers:       v.Rollovers,
+		InterestPaid:    v.InterestPaid,
+		CreatedBy:       v.CreatedBy,
+		CreatedAt:       v.CreatedAt,
+	}
+	if v.ClosedAt.Valid {
+		d.ClosedAt = &v.ClosedAt.Time
+	}
+	return d
+}
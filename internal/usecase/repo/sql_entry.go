@@ -3,6 +3,9 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"math"
+	"time"
 
 	"alukart32.com/bank/entity"
 	"alukart32.com/bank/internal/usecase/repo/db"
@@ -26,8 +29,11 @@ func (r *EntrySQLRepo) Create(ctx context.Context, e entity.Entry) (entity.Entry
 
 	err := r.execTx(ctx, nil, func(q *db.Queries) error {
 		e, err := q.CreateEntry(ctx, db.CreateEntryParams{
-			AccountID: e.AccountID,
-			Amount:    e.Amount,
+			AccountID:   e.AccountID,
+			Amount:      e.Amount,
+			Description: e.Description,
+			Reference:   e.Reference,
+			Metadata:    e.Metadata,
 		})
 		if err != nil {
 			return err
@@ -86,6 +92,47 @@ func (r *EntrySQLRepo) List(ctx context.Context, accountId uuid.UUID) ([]entity.
 	return result, err
 }
 
+// ListAfter returns the entries of the account with ids after afterID
+// and the ones created within the window before the entry afterID. The
+// ids are taken before the commit, so an entry with a smaller id may be
+// committed after the entry afterID by a concurrent tx. An unknown
+// afterID or the one of another account re-reads the window before now
+// only.
+func (r *EntrySQLRepo) ListAfter(ctx context.Context, accountId uuid.UUID, afterID int64,
+	window time.Duration) ([]entity.Entry, error) {
+	var result []entity.Entry
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		since := time.Now()
+		after, err := q.GetEntry(ctx, afterID)
+		switch {
+		case err == nil && after.AccountID == accountId:
+			since = after.CreatedAt
+		case err == nil, errors.Is(err, sql.ErrNoRows):
+			// no id is after the unknown one, only the window is read
+			afterID = math.MaxInt64
+		default:
+			return err
+		}
+
+		entries, err := q.ListEntriesByAccountAfter(ctx, db.ListEntriesByAccountAfterParams{
+			AccountID: accountId,
+			AfterID:   afterID,
+			Since:     since.Add(-window),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range entries {
+			result = append(result, entity.Entry(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
 func (r *EntrySQLRepo) Delete(ctx context.Context, id int64) error {
 	return r.execTx(ctx, nil, func(q *db.Queries) error {
 		return q.DeleteEntry(ctx, id)
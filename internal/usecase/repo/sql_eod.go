@@ -0,0 +1,275 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type EODSQLRepo struct {
+	SQLRepo
+}
+
+func NewEODSQLRepo(db *sql.DB) *EODSQLRepo {
+	return &EODSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Lock takes the session lock of the EOD runs on a connection of its own,
+// so the runners of the other processes are kept out as well. It fails
+// with usecase.ErrEODRunning if the lock is held.
+func (r *EODSQLRepo) Lock(ctx context.Context) (func(), error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := db.New(conn)
+	locked, err := q.TryEODLock(ctx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !locked {
+		conn.Close()
+		return nil, usecase.ErrEODRunning
+	}
+
+	return func() {
+		// the lock is released with the session anyway
+		q.ReleaseEODLock(context.Background())
+		conn.Close()
+	}, nil
+}
+
+func (r *EODSQLRepo) BusinessDate(ctx context.Context) (entity.BusinessDate, error) {
+	var result entity.BusinessDate
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetBusinessDate(ctx)
+		if err != nil {
+			return err
+		}
+		result = entity.BusinessDate{
+			Date:      v.Date,
+			Frozen:    v.Frozen,
+			UpdatedAt: v.UpdatedAt,
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Freeze freezes the business date if it is still open.
+func (r *EODSQLRepo) Freeze(ctx context.Context, date time.Time) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.FreezeBusinessDate(ctx, date)
+		return err
+	})
+}
+
+// Advance opens the next date if the date is still the business date.
+func (r *EODSQLRepo) Advance(ctx context.Context, date time.Time) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.AdvanceBusinessDate(ctx, date)
+		return err
+	})
+}
+
+// Start starts the run of the date or resumes the previous one with its
+// steps.
+func (r *EODSQLRepo) Start(ctx context.Context, date time.Time, operator string) (entity.EODRun, error) {
+	var result entity.EODRun
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.CreateEODRun(ctx, db.CreateEODRunParams{
+			BusinessDate: date,
+			StartedBy:    operator,
+		})
+		if err != nil {
+			return err
+		}
+		result, err = eodRun(ctx, q, v)
+		return err
+	})
+
+	return result, err
+}
+
+func (r *EODSQLRepo) Finish(ctx context.Context, runID int64, status entity.EODStatus, msg string) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.FinishEODRun(ctx, db.FinishEODRunParams{
+			ID:     runID,
+			Status: string(status),
+			Error:  msg,
+		})
+		return err
+	})
+}
+
+func (r *EODSQLRepo) StartStep(ctx context.Context, runID int64, step entity.EODStep) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		return q.StartEODStep(ctx, db.StartEODStepParams{
+			RunID: runID,
+			Step:  string(step),
+		})
+	})
+}
+
+func (r *EODSQLRepo) FinishStep(ctx context.Context, runID int64, step entity.EODStep, status entity.EODStatus,
+	result entity.BatchRun, msg string) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.FinishEODStep(ctx, db.FinishEODStepParams{
+			RunID:  runID,
+			Step:   string(step),
+			Status: string(status),
+			Result: data,
+			Error:  msg,
+		})
+		return err
+	})
+}
+
+// Get returns the run of the date with its steps.
+func (r *EODSQLRepo) Get(ctx context.Context, date time.Time) (entity.EODRun, error) {
+	var result entity.EODRun
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetEODRun(ctx, date)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result, err = eodRun(ctx, q, v)
+		return err
+	})
+
+	return result, err
+}
+
+// List returns the latest runs without their steps.
+func (r *EODSQLRepo) List(ctx context.Context, limit int32) ([]entity.EODRun, error) {
+	var result []entity.EODRun
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		runs, err := q.ListEODRuns(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.EODRun, 0, len(runs))
+		for _, v := range runs {
+			result = append(result, toEODRun(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CreateStatements saves the daily statements of the date from its
+// end-of-day snapshots and returns the number of the saved ones.
+func (r *EODSQLRepo) CreateStatements(ctx context.Context, date time.Time) (int64, error) {
+	var result int64
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		var err error
+		result, err = q.CreateDailyStatements(ctx, db.CreateDailyStatementsParams{
+			StatementDate: date,
+			DayStart:      date,
+			DayEnd:        date.AddDate(0, 0, 1),
+		})
+		return err
+	})
+
+	return result, err
+}
+
+func (r *EODSQLRepo) Statements(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.DailyStatement, error) {
+	var result []entity.DailyStatement
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		statements, err := q.ListDailyStatements(ctx, db.ListDailyStatementsParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.DailyStatement, 0, len(statements))
+		for _, v := range statements {
+			result = append(result, entity.DailyStatement{
+				AccountID:      v.AccountID,
+				Date:           v.StatementDate,
+				OpeningBalance: v.OpeningBalance,
+				ClosingBalance: v.ClosingBalance,
+				Debits:         v.Debits,
+				Credits:        v.Credits,
+				Entries:        v.Entries,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// eodRun returns the run with its steps.
+func eodRun(ctx context.Context, q *db.Queries, v db.EodRun) (entity.EODRun, error) {
+	steps, err := q.ListEODSteps(ctx, v.ID)
+	if err != nil {
+		return entity.EODRun{}, err
+	}
+
+	result := toEODRun(v)
+	for _, s := range steps {
+		step := entity.EODStepRun{
+			Step:      entity.EODStep(s.Step),
+			Status:    entity.EODStatus(s.Status),
+			Error:     s.Error,
+			StartedAt: s.StartedAt,
+		}
+		if err = json.Unmarshal(s.Result, &step.Result); err != nil {
+			return entity.EODRun{}, err
+		}
+		if s.FinishedAt.Valid {
+			step.FinishedAt = &s.FinishedAt.Time
+		}
+		result.Steps = append(result.Steps, step)
+	}
+	return result, nil
+}
+
+func toEODRun(v db.EodRun) entity.EODRun {
+	result := entity.EODRun{
+		ID:           v.ID,
+		BusinessDate: v.BusinessDate,
+		Status:       entity.EODStatus(v.Status),
+		Error:        v.Error,
+		StartedBy:    v.StartedBy,
+		StartedAt:    v.StartedAt,
+	}
+	if v.FinishedAt.Valid {
+		result.FinishedAt = &v.FinishedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,355 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type FeeSQLRepo struct {
+	SQLRepo
+}
+
+func NewFeeSQLRepo(db *sql.DB) *FeeSQLRepo {
+	return &FeeSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *FeeSQLRepo) CreateSchedule(ctx context.Context, s entity.FeeSchedule) (entity.FeeSchedule, error) {
+	var result entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.CreateFeeSchedule(ctx, db.CreateFeeScheduleParams{
+			Name:             s.Name,
+			Kind:             string(s.Kind),
+			AppliesTo:        string(s.AppliesTo),
+			ProductID:        sql.NullInt64{Int64: s.ProductID, Valid: s.ProductID != 0},
+			Currency:         db.Currency(s.Currency),
+			Fixed:            s.Fixed,
+			RateBp:           s.RateBP,
+			MinAmount:        s.Min,
+			MaxAmount:        s.Max,
+			Tiers:            s.Tiers,
+			RevenueAccountID: s.RevenueAccountID,
+		})
+		if isConstraint(err, "fee_schedules_product_fk") {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toFeeSchedule(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *FeeSQLRepo) GetSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error) {
+	var result entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetFeeSchedule(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toFeeSchedule(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *FeeSQLRepo) ListSchedules(ctx context.Context) ([]entity.FeeSchedule, error) {
+	var result []entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		schedules, err := q.ListFeeSchedules(ctx)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.FeeSchedule, 0, len(schedules))
+		for _, v := range schedules {
+			result = append(result, toFeeSchedule(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// DeactivateSchedule stops charging the fee, the charges are kept.
+func (r *FeeSQLRepo) DeactivateSchedule(ctx context.Context, id int64) (entity.FeeSchedule, error) {
+	var result entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.DeactivateFeeSchedule(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toFeeSchedule(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// TransferSchedules returns the active schedules of the transfer between
+// the accounts, of the product of the from account or of all accounts.
+func (r *FeeSQLRepo) TransferSchedules(ctx context.Context, from, to uuid.UUID) ([]entity.FeeSchedule, error) {
+	var result []entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		schedules, err := q.ListTransferFeeSchedules(ctx, db.ListTransferFeeSchedulesParams{
+			FromAccountID: from,
+			ToAccountID:   to,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.FeeSchedule, 0, len(schedules))
+		for _, v := range schedules {
+			result = append(result, toFeeSchedule(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ExternalSchedules returns the schedules of the transfers from the
+// account to the other banks.
+func (r *FeeSQLRepo) ExternalSchedules(ctx context.Context, accountID uuid.UUID) ([]entity.FeeSchedule, error) {
+	var result []entity.FeeSchedule
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		schedules, err := q.ListExternalFeeSchedules(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.FeeSchedule, 0, len(schedules))
+		for _, v := range schedules {
+			result = append(result, toFeeSchedule(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// MaintenanceFees returns the maintenance schedules with the accounts of
+// their products and balances, which are not charged for the month yet.
+func (r *FeeSQLRepo) MaintenanceFees(ctx context.Context, period time.Time) ([]usecase.MaintenanceFee, error) {
+	var result []usecase.MaintenanceFee
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		fees, err := q.ListMaintenanceFees(ctx, db.ListMaintenanceFeesParams{
+			PeriodEnd: period.AddDate(0, 1, 0),
+			Period:    period,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]usecase.MaintenanceFee, 0, len(fees))
+		for _, v := range fees {
+			result = append(result, usecase.MaintenanceFee{
+				Schedule: entity.FeeSchedule{
+					ID:               v.ID,
+					Name:             v.Name,
+					Kind:             entity.FeeKind(v.Kind),
+					AppliesTo:        entity.FeeMaintenance,
+					Fixed:            v.Fixed,
+					RateBP:           v.RateBp,
+					Min:              v.MinAmount,
+					Max:              v.MaxAmount,
+					Tiers:            v.Tiers,
+					RevenueAccountID: v.RevenueAccountID,
+					Active:           true,
+				},
+				AccountID: v.AccountID,
+				Balance:   v.Balance,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ChargeMaintenance posts the maintenance fee of the month. It fails
+// with ErrDuplicate if the account has already been charged for the
+// month.
+func (r *FeeSQLRepo) ChargeMaintenance(ctx context.Context, c entity.FeeCharge) (entity.FeeCharge, error) {
+	var result entity.FeeCharge
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, _, err := chargeFee(ctx, q, c)
+		if isConstraint(err, "fee_charges_period") {
+			return usecase.ErrDuplicate
+		}
+		if err != nil {
+			return err
+		}
+		result = v
+		return nil
+	})
+
+	return result, err
+}
+
+// Charges returns up to limit charges of the account, the latest first.
+func (r *FeeSQLRepo) Charges(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.FeeCharge, error) {
+	var result []entity.FeeCharge
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		charges, err := q.ListFeeCharges(ctx, db.ListFeeChargesParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.FeeCharge, 0, len(charges))
+		for _, v := range charges {
+			c := entity.FeeCharge{
+				ID:                 v.ID,
+				ScheduleID:         v.ScheduleID,
+				Name:               v.Name,
+				AccountID:          v.AccountID,
+				RevenueAccountID:   v.RevenueAccountID,
+				Amount:             v.Amount,
+				TransferID:         v.TransferID.Int64,
+				ExternalTransferID: v.ExternalTransferID.Int64,
+				Entry: entity.Entry{
+					ID:          v.EntryID,
+					AccountID:   v.AccountID,
+					Amount:      v.EntryAmount,
+					CreatedAt:   v.CreatedAt,
+					Description: v.Description,
+					Reference:   v.Reference,
+					Metadata:    v.Metadata,
+				},
+				CreatedAt: v.CreatedAt,
+			}
+			if v.Period.Valid {
+				c.Period = &v.Period.Time
+			}
+			result = append(result, c)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// chargeFee posts the fee within the tx of the queries: the debit entry
+// of the account and the credit entry of the revenue account. It returns
+// the charged account.
+func chargeFee(ctx context.Context, q *db.Queries, c entity.FeeCharge) (entity.FeeCharge, db.Account, error) {
+	description := "Fee: " + c.Name
+	metadata := entity.Metadata{"fee_schedule_id": strconv.FormatInt(c.ScheduleID, 10)}
+	if c.TransferID != 0 {
+		metadata["transfer_id"] = strconv.FormatInt(c.TransferID, 10)
+	}
+	if c.ExternalTransferID != 0 {
+		metadata["external_transfer_id"] = strconv.FormatInt(c.ExternalTransferID, 10)
+	}
+
+	debit, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   c.AccountID,
+		Amount:      -c.Amount,
+		Description: description,
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return entity.FeeCharge{}, db.Account{}, err
+	}
+	credit, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   c.RevenueAccountID,
+		Amount:      c.Amount,
+		Description: description,
+		Metadata:    metadata,
+	})
+	if err != nil {
+		return entity.FeeCharge{}, db.Account{}, err
+	}
+
+	account, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     c.AccountID,
+		Amount: -c.Amount,
+	})
+	if isConstraint(err, "positive_balance") {
+		return entity.FeeCharge{}, db.Account{}, usecase.ErrInsufficientFunds
+	}
+	if err != nil {
+		return entity.FeeCharge{}, db.Account{}, err
+	}
+	if _, err = q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     c.RevenueAccountID,
+		Amount: c.Amount,
+	}); err != nil {
+		return entity.FeeCharge{}, db.Account{}, err
+	}
+
+	params := db.CreateFeeChargeParams{
+		ScheduleID:         c.ScheduleID,
+		AccountID:          c.AccountID,
+		Amount:             c.Amount,
+		TransferID:         sql.NullInt64{Int64: c.TransferID, Valid: c.TransferID != 0},
+		DebitEntryID:       debit.ID,
+		CreditEntryID:      credit.ID,
+		ExternalTransferID: sql.NullInt64{Int64: c.ExternalTransferID, Valid: c.ExternalTransferID != 0},
+	}
+	if c.Period != nil {
+		params.Period = sql.NullTime{Time: *c.Period, Valid: true}
+	}
+	v, err := q.CreateFeeCharge(ctx, params)
+	if err != nil {
+		return entity.FeeCharge{}, db.Account{}, err
+	}
+
+	c.ID = v.ID
+	c.Entry = entity.Entry(debit)
+	c.CreatedAt = v.CreatedAt
+	return c, account, nil
+}
+
+func toFeeSchedule(v db.FeeSchedule) entity.FeeSchedule {
+	return entity.FeeSchedule{
+		ID:               v.ID,
+		Name:             v.Name,
+		Kind:             entity.FeeKind(v.Kind),
+		AppliesTo:        entity.FeeAppliesTo(v.AppliesTo),
+		ProductID:        v.ProductID.Int64,
+		Currency:         entity.Currency(v.Currency),
+		Fixed:            v.Fixed,
+		RateBP:           v.RateBp,
+		Min:              v.MinAmount,
+		Max:              v.MaxAmount,
+		Tiers:            v.Tiers,
+		RevenueAccountID: v.RevenueAccountID,
+		Active:           v.Active,
+		CreatedAt:        v.CreatedAt,
+	}
+}
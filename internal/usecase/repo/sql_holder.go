@@ -0,0 +1,148 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type HolderSQLRepo struct {
+	SQLRepo
+}
+
+func NewHolderSQLRepo(db *sql.DB) *HolderSQLRepo {
+	return &HolderSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create invites the holder to the account. The holders of the account
+// return usecase.ErrDuplicate.
+func (r *HolderSQLRepo) Create(ctx context.Context, h entity.AccountHolder) (entity.AccountHolder, error) {
+	var result entity.AccountHolder
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.CreateAccountHolder(ctx, db.CreateAccountHolderParams{
+			AccountID: h.AccountID,
+			Holder:    h.Holder,
+			Role:      string(h.Role),
+			Status:    string(entity.HolderInvited),
+			InvitedBy: h.InvitedBy,
+		})
+		if isUniqueViolation(err) {
+			return usecase.ErrDuplicate
+		}
+		if isConstraint(err, "account_holders_account_fk") {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toAccountHolder(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *HolderSQLRepo) Get(ctx context.Context, accountID uuid.UUID, holder string) (entity.AccountHolder, error) {
+	var result entity.AccountHolder
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetAccountHolder(ctx, db.GetAccountHolderParams{
+			AccountID: accountID,
+			Holder:    holder,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toAccountHolder(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *HolderSQLRepo) List(ctx context.Context, accountID uuid.UUID) ([]entity.AccountHolder, error) {
+	var result []entity.AccountHolder
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		holders, err := q.ListAccountHolders(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.AccountHolder, 0, len(holders))
+		for _, v := range holders {
+			result = append(result, toAccountHolder(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Accept activates the invited holder, the holders without an
+// invitation return usecase.ErrNotFound.
+func (r *HolderSQLRepo) Accept(ctx context.Context, accountID uuid.UUID, holder string) (entity.AccountHolder, error) {
+	var result entity.AccountHolder
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.AcceptAccountHolder(ctx, db.AcceptAccountHolderParams{
+			AccountID: accountID,
+			Holder:    holder,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toAccountHolder(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// Delete removes the holder but the primary one from the account.
+func (r *HolderSQLRepo) Delete(ctx context.Context, accountID uuid.UUID, holder string) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		n, err := q.DeleteAccountHolder(ctx, db.DeleteAccountHolderParams{
+			AccountID: accountID,
+			Holder:    holder,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return usecase.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func toAccountHolder(v db.AccountHolder) entity.AccountHolder {
+	h := entity.AccountHolder{
+		AccountID: v.AccountID,
+		Holder:    v.Holder,
+		Role:      entity.HolderRole(v.Role),
+		Status:    entity.HolderStatus(v.Status),
+		InvitedBy: v.InvitedBy,
+		CreatedAt: v.CreatedAt,
+	}
+	if v.AcceptedAt.Valid {
+		h.AcceptedAt = &v.AcceptedAt.Time
+	}
+	return h
+}
@@ -0,0 +1,327 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type InterestSQLRepo struct {
+	SQLRepo
+	// transfers posts the interest from the expense accounts.
+	transfers *TransferSQLRepo
+}
+
+func NewInterestSQLRepo(db *sql.DB, transfers *TransferSQLRepo) *InterestSQLRepo {
+	return &InterestSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+		transfers: transfers,
+	}
+}
+
+func (r *InterestSQLRepo) CreateProduct(ctx context.Context, p entity.InterestProduct) (entity.InterestProduct, error) {
+	var result entity.InterestProduct
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.CreateInterestProduct(ctx, db.CreateInterestProductParams{
+			Name:             p.Name,
+			Currency:         db.Currency(p.Currency),
+			AnnualRateBp:     p.AnnualRateBP,
+			DayCount:         string(p.DayCount),
+			ExpenseAccountID: p.ExpenseAccountID,
+		})
+		if isUniqueViolation(err) {
+			return usecase.ErrDuplicate
+		}
+		if err != nil {
+			return err
+		}
+		result = toInterestProduct(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *InterestSQLRepo) GetProduct(ctx context.Context, id int64) (entity.InterestProduct, error) {
+	var result entity.InterestProduct
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetInterestProduct(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toInterestProduct(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *InterestSQLRepo) ListProducts(ctx context.Context) ([]entity.InterestProduct, error) {
+	var result []entity.InterestProduct
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		products, err := q.ListInterestProducts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.InterestProduct, 0, len(products))
+		for _, v := range products {
+			result = append(result, toInterestProduct(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// GetAccountProduct returns the product of the account or ErrNotFound
+// if the account has none.
+func (r *InterestSQLRepo) GetAccountProduct(ctx context.Context, accountID uuid.UUID) (entity.InterestProduct, error) {
+	var result entity.InterestProduct
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetAccountProduct(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toInterestProduct(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *InterestSQLRepo) SetAccountProduct(ctx context.Context, accountID uuid.UUID, productID int64) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		return q.UpsertAccountProduct(ctx, db.UpsertAccountProductParams{
+			AccountID: accountID,
+			ProductID: productID,
+		})
+	})
+}
+
+func (r *InterestSQLRepo) DeleteAccountProduct(ctx context.Context, accountID uuid.UUID) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		n, err := q.DeleteAccountProduct(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return usecase.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// AccrualBalances returns the accounts with a product and their balances
+// at the end of the day. The amounts are left to accrue.
+func (r *InterestSQLRepo) AccrualBalances(ctx context.Context, date time.Time) ([]entity.InterestAccrual, error) {
+	var result []entity.InterestAccrual
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		balances, err := q.ListAccrualBalances(ctx, date.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.InterestAccrual, 0, len(balances))
+		for _, v := range balances {
+			result = append(result, entity.InterestAccrual{
+				AccountID:    v.AccountID,
+				Date:         date,
+				ProductID:    v.ProductID,
+				Balance:      v.Balance,
+				AnnualRateBP: v.AnnualRateBp,
+				DayCount:     entity.DayCount(v.DayCount),
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// AddAccrual saves the accrual unless the account has already accrued
+// for the date or has been posted for its month. It reports whether
+// the accrual is saved.
+func (r *InterestSQLRepo) AddAccrual(ctx context.Context, a entity.InterestAccrual) (bool, error) {
+	var added bool
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		n, err := q.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+			AccountID:    a.AccountID,
+			AccrualDate:  a.Date,
+			ProductID:    a.ProductID,
+			Balance:      a.Balance,
+			AnnualRateBp: a.AnnualRateBP,
+			DayCount:     string(a.DayCount),
+			Days:         a.Days,
+			AmountMicros: a.AmountMicros,
+		})
+		added = n > 0
+		return err
+	})
+
+	return added, err
+}
+
+// Accruals returns the accruals of the account for the dates [from, to).
+func (r *InterestSQLRepo) Accruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]entity.InterestAccrual, error) {
+	var result []entity.InterestAccrual
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		accruals, err := q.ListInterestAccruals(ctx, db.ListInterestAccrualsParams{
+			AccountID:     accountID,
+			AccrualDate:   from,
+			AccrualDate_2: to,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.InterestAccrual, 0, len(accruals))
+		for _, v := range accruals {
+			result = append(result, entity.InterestAccrual{
+				AccountID:    v.AccountID,
+				Date:         v.AccrualDate,
+				ProductID:    v.ProductID,
+				Balance:      v.Balance,
+				AnnualRateBP: v.AnnualRateBp,
+				DayCount:     entity.DayCount(v.DayCount),
+				Days:         v.Days,
+				AmountMicros: v.AmountMicros,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// PostingTotals returns the accrued interest of the accounts that are
+// not posted for the month yet.
+func (r *InterestSQLRepo) PostingTotals(ctx context.Context, period time.Time) ([]usecase.PostingTotal, error) {
+	var result []usecase.PostingTotal
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		totals, err := q.ListPostingTotals(ctx, db.ListPostingTotalsParams{
+			Period:    period,
+			PeriodEnd: period.AddDate(0, 1, 0),
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]usecase.PostingTotal, 0, len(totals))
+		for _, v := range totals {
+			result = append(result, usecase.PostingTotal(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Post saves the posting and executes its transfer in one tx. It fails
+// with ErrDuplicate if the account has already been posted for the
+// month. The transfer from the expense account isn't subject to the
+// customer limits.
+func (r *InterestSQLRepo) Post(ctx context.Context, p entity.InterestPosting,
+	t entity.Transfer) (entity.InterestPosting, entity.TransferRes, error) {
+	var (
+		posting entity.InterestPosting
+		res     entity.TransferRes
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		var transferID sql.NullInt64
+		if p.Amount > 0 {
+			var err error
+			if res, err = r.transfers.post(ctx, q, t); err != nil {
+				return err
+			}
+			transferID = sql.NullInt64{Int64: res.Transfer.ID, Valid: true}
+		}
+
+		v, err := q.CreateInterestPosting(ctx, db.CreateInterestPostingParams{
+			AccountID:     p.AccountID,
+			Period:        p.Period,
+			AccruedMicros: p.AccruedMicros,
+			Amount:        p.Amount,
+			CarryMicros:   p.CarryMicros,
+			TransferID:    transferID,
+		})
+		if isConstraint(err, "interest_postings_period") {
+			return usecase.ErrDuplicate
+		}
+		if err != nil {
+			return err
+		}
+		posting = toInterestPosting(v)
+		return nil
+	})
+
+	return posting, res, err
+}
+
+// Postings returns the postings of the account, the latest first.
+func (r *InterestSQLRepo) Postings(ctx context.Context, accountID uuid.UUID) ([]entity.InterestPosting, error) {
+	var result []entity.InterestPosting
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		postings, err := q.ListInterestPostings(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.InterestPosting, 0, len(postings))
+		for _, v := range postings {
+			result = append(result, toInterestPosting(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func toInterestProduct(v db.InterestProduct) entity.InterestProduct {
+	return entity.InterestProduct{
+		ID:               v.ID,
+		Name:             v.Name,
+		Currency:         entity.Currency(v.Currency),
+		AnnualRateBP:     v.AnnualRateBp,
+		DayCount:         entity.DayCount(v.DayCount),
+		ExpenseAccountID: v.ExpenseAccountID,
+		CreatedAt:        v.CreatedAt,
+	}
+}
+
+func toInterestPosting(v db.InterestPosting) entity.InterestPosting {
+	return entity.InterestPosting{
+		ID:            v.ID,
+		AccountID:     v.AccountID,
+		Period:        v.Period,
+		AccruedMicros: v.AccruedMicros,
+		Amount:        v.Amount,
+		CarryMicros:   v.CarryMicros,
+		TransferID:    v.TransferID.Int64,
+		CreatedAt:     v.CreatedAt,
+	}
+}
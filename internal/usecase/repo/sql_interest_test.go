@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestPostBeyondTransferLimits(t *testing.T) {
+	repoAccount := NewAccountSQLRepo(testDB)
+	repoInterest := NewInterestSQLRepo(testDB, NewTransferSQLRepo(testDB))
+
+	expense, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "bank_interest_expense",
+		Balance:  100_000_000,
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	// the standard tier allows 20 transfers an hour
+	period := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 25; i++ {
+		account, err := repoAccount.Create(context.Background(), entity.Account{
+			ID:       uuid.New(),
+			Owner:    "owner_test_1",
+			Currency: entity.CurrencyRUB,
+		})
+		require.NoError(t, err)
+
+		posting, res, err := repoInterest.Post(context.Background(), entity.InterestPosting{
+			AccountID:     account.ID,
+			Period:        period,
+			AccruedMicros: 1_500_000,
+			Amount:        1,
+			CarryMicros:   500_000,
+		}, entity.Transfer{
+			FromAccountID: expense.ID,
+			ToAccountID:   account.ID,
+			Amount:        1,
+			Description:   "Interest for 2020-01",
+			Reference:     "INT-202001",
+		})
+		require.NoError(t, err, "posting %d", i+1)
+		assert.Equal(t, res.Transfer.ID, posting.TransferID)
+		assert.Equal(t, int64(1), res.ToAccount.Balance)
+	}
+}
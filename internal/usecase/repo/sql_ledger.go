@@ -0,0 +1,256 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type LedgerSQLRepo struct {
+	SQLRepo
+}
+
+func NewLedgerSQLRepo(db *sql.DB) *LedgerSQLRepo {
+	return &LedgerSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create opens the ledger account of the GL account.
+func (r *LedgerSQLRepo) Create(ctx context.Context, a entity.GLAccount) (entity.GLAccount, error) {
+	var result entity.GLAccount
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		account, err := q.CreateLedgerAccount(ctx, db.CreateLedgerAccountParams{
+			ID:       uuid.New(),
+			Currency: db.Currency(a.Currency),
+		})
+		if err != nil {
+			return err
+		}
+
+		v, err := q.CreateGLAccount(ctx, db.CreateGLAccountParams{
+			AccountID: account.ID,
+			Code:      a.Code,
+			Name:      a.Name,
+			Type:      string(a.Type),
+			Currency:  account.Currency,
+		})
+		if isConstraint(err, "gl_accounts_code") {
+			return usecase.ErrDuplicate
+		}
+		if err != nil {
+			return err
+		}
+		result = toGLAccount(db.GetGLAccountRow{
+			AccountID: v.AccountID,
+			Code:      v.Code,
+			Name:      v.Name,
+			Type:      v.Type,
+			Currency:  v.Currency,
+			CreatedAt: v.CreatedAt,
+		})
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *LedgerSQLRepo) Get(ctx context.Context, accountID uuid.UUID) (entity.GLAccount, error) {
+	var result entity.GLAccount
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetGLAccount(ctx, accountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result = toGLAccount(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *LedgerSQLRepo) List(ctx context.Context) ([]entity.GLAccount, error) {
+	var result []entity.GLAccount
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		accounts, err := q.ListGLAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.GLAccount, 0, len(accounts))
+		for _, v := range accounts {
+			result = append(result, toGLAccount(db.GetGLAccountRow(v)))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Balances returns the GL accounts and the totals of the customer
+// accounts from one snapshot of the balances.
+func (r *LedgerSQLRepo) Balances(ctx context.Context) ([]entity.GLAccount, []usecase.CustomerBalance, error) {
+	var (
+		accounts  []entity.GLAccount
+		customers []usecase.CustomerBalance
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, func(q *db.Queries) error {
+		gl, err := q.ListGLAccounts(ctx)
+		if err != nil {
+			return err
+		}
+		totals, err := q.SumCustomerBalances(ctx)
+		if err != nil {
+			return err
+		}
+
+		accounts = make([]entity.GLAccount, 0, len(gl))
+		for _, v := range gl {
+			accounts = append(accounts, toGLAccount(db.GetGLAccountRow(v)))
+		}
+		customers = make([]usecase.CustomerBalance, 0, len(totals))
+		for _, v := range totals {
+			customers = append(customers, usecase.CustomerBalance{
+				Currency: entity.Currency(v.Currency),
+				Accounts: v.Accounts,
+				Balance:  v.Balance,
+			})
+		}
+		return nil
+	})
+
+	return accounts, customers, err
+}
+
+// post moves the amount from the debited account to the credited one
+// within the tx of the queries. The accounts must have the same currency.
+func post(ctx context.Context, q *db.Queries, p entity.Posting) (entity.PostingRes, error) {
+	var result entity.PostingRes
+
+	debitAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     p.DebitAccountID,
+		Amount: -p.Amount,
+	})
+	if isConstraint(err, "positive_balance") {
+		return entity.PostingRes{}, usecase.ErrInsufficientFunds
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PostingRes{}, usecase.ErrNotFound
+	}
+	if err != nil {
+		return entity.PostingRes{}, err
+	}
+	creditAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     p.CreditAccountID,
+		Amount: p.Amount,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PostingRes{}, usecase.ErrNotFound
+	}
+	if err != nil {
+		return entity.PostingRes{}, err
+	}
+	if debitAccount.Currency != creditAccount.Currency {
+		return entity.PostingRes{}, fmt.Errorf("%w: posting from %s to %s",
+			usecase.ErrInvalidArgument, debitAccount.Currency, creditAccount.Currency)
+	}
+
+	debit, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   p.DebitAccountID,
+		Amount:      -p.Amount,
+		Description: p.Description,
+		Reference:   p.Reference,
+		Metadata:    p.Metadata,
+	})
+	if err != nil {
+		return entity.PostingRes{}, err
+	}
+	credit, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   p.CreditAccountID,
+		Amount:      p.Amount,
+		Description: p.Description,
+		Reference:   p.Reference,
+		Metadata:    p.Metadata,
+	})
+	if err != nil {
+		return entity.PostingRes{}, err
+	}
+
+	result.DebitEntry = entity.Entry(debit)
+	result.DebitAccount = toAccount(debitAccount)
+	result.CreditEntry = entity.Entry(credit)
+	result.CreditAccount = toAccount(creditAccount)
+	return result, nil
+}
+
+// postCash deposits the positive amount to the account from the GL
+// account of the code in its currency and withdraws the negative one to
+// it. The details of the posting are taken from p. It returns the account
+// and its entry.
+func postCash(ctx context.Context, q *db.Queries, code string, accountID uuid.UUID, currency db.Currency,
+	amount int64, p entity.Posting) (entity.Account, entity.Entry, error) {
+	cash, err := glAccountByCode(ctx, q, code, currency)
+	if err != nil {
+		return entity.Account{}, entity.Entry{}, err
+	}
+
+	if amount > 0 {
+		p.DebitAccountID, p.CreditAccountID, p.Amount = cash.AccountID, accountID, amount
+		res, err := post(ctx, q, p)
+		return res.CreditAccount, res.CreditEntry, err
+	}
+	p.DebitAccountID, p.CreditAccountID, p.Amount = accountID, cash.AccountID, -amount
+	res, err := post(ctx, q, p)
+	return res.DebitAccount, res.DebitEntry, err
+}
+
+// glAccountByCode returns the GL account of the code in the currency.
+func glAccountByCode(ctx context.Context, q *db.Queries, code string,
+	currency db.Currency) (db.GetGLAccountByCodeRow, error) {
+	v, err := q.GetGLAccountByCode(ctx, db.GetGLAccountByCodeParams{
+		Code:     code,
+		Currency: currency,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, fmt.Errorf("%w: no GL account %s in %s", usecase.ErrNotFound, code, currency)
+	}
+	return v, err
+}
+
+func toAccount(a db.Account) entity.Account {
+	return entity.Account{
+		ID:        a.ID,
+		Owner:     a.Owner,
+		Balance:   a.Balance,
+		Currency:  entity.Currency(a.Currency),
+		CreatedAt: a.CreatedAt,
+		Number:    a.Number.String,
+	}
+}
+
+func toGLAccount(v db.GetGLAccountRow) entity.GLAccount {
+	return entity.GLAccount{
+		AccountID: v.AccountID,
+		Code:      v.Code,
+		Name:      v.Name,
+		Type:      entity.GLAccountType(v.Type),
+		Currency:  entity.Currency(v.Currency),
+		Balance:   v.Balance,
+		CreatedAt: v.CreatedAt,
+	}
+}
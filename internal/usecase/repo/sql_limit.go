@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type LimitSQLRepo struct {
+	SQLRepo
+}
+
+func NewLimitSQLRepo(db *sql.DB) *LimitSQLRepo {
+	return &LimitSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *LimitSQLRepo) Get(ctx context.Context, accountID uuid.UUID) (entity.AccountLimits, error) {
+	var result entity.AccountLimits
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		if _, err := q.GetAccount(ctx, accountID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+
+		l, err := q.GetAccountLimits(ctx, accountID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		result = toAccountLimits(accountID, l)
+
+		result.Effective, err = transferLimits(ctx, q, accountID)
+		return err
+	})
+
+	return result, err
+}
+
+func (r *LimitSQLRepo) Set(ctx context.Context, limits entity.AccountLimits) (entity.AccountLimits, error) {
+	var result entity.AccountLimits
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		l, err := q.UpsertAccountLimits(ctx, db.UpsertAccountLimitsParams{
+			AccountID:   limits.AccountID,
+			Tier:        limits.Tier,
+			MaxSingle:   nullInt64(limits.MaxSingle),
+			Daily:       nullInt64(limits.Daily),
+			Monthly:     nullInt64(limits.Monthly),
+			HourlyCount: nullInt64(limits.HourlyCount),
+			UpdatedBy:   limits.UpdatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		result = toAccountLimits(limits.AccountID, l)
+
+		result.Effective, err = transferLimits(ctx, q, limits.AccountID)
+		return err
+	})
+	switch {
+	case isConstraint(err, "account_limits_account_fk"):
+		return entity.AccountLimits{}, usecase.ErrNotFound
+	case isConstraint(err, "account_limits_tier_fk"):
+		return entity.AccountLimits{}, fmt.Errorf("%w: unknown tier %q", usecase.ErrInvalidArgument, limits.Tier)
+	}
+
+	return result, err
+}
+
+func (r *LimitSQLRepo) Delete(ctx context.Context, accountID uuid.UUID) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.DeleteAccountLimits(ctx, accountID)
+		return err
+	})
+}
+
+// checkTransferLimits returns a *usecase.LimitError if the transfer
+// exceeds the limits of its debited account. The usage counts the
+// transfers and the external transfers of the account except the
+// transfer itself, so the external transfer is checked before it is
+// saved. The account row must be locked by the caller's tx, so that
+// concurrent transfers from the account are evaluated one by one.
+func checkTransferLimits(ctx context.Context, q *db.Queries, t entity.Transfer) error {
+	limits, err := transferLimits(ctx, q, t.FromAccountID)
+	if err != nil {
+		return err
+	}
+
+	day, month, hour := usecase.LimitWindows(time.Now())
+	since := month
+	if hour.Before(since) {
+		since = hour
+	}
+	u, err := q.GetTransferUsage(ctx, db.GetTransferUsageParams{
+		DayStart:   day,
+		MonthStart: month,
+		HourStart:  hour,
+		AccountID:  t.FromAccountID,
+		Since:      since,
+		ExcludeID:  t.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return usecase.CheckLimits(limits, entity.TransferUsage(u), t.Amount)
+}
+
+func transferLimits(ctx context.Context, q *db.Queries, accountID uuid.UUID) (entity.TransferLimits, error) {
+	l, err := q.GetTransferLimits(ctx, accountID)
+	if err != nil {
+		return entity.TransferLimits{}, err
+	}
+	return entity.TransferLimits(l), nil
+}
+
+func toAccountLimits(accountID uuid.UUID, l db.AccountLimit) entity.AccountLimits {
+	result := entity.AccountLimits{
+		AccountID: accountID,
+		Tier:      l.Tier,
+		UpdatedBy: l.UpdatedBy,
+		UpdatedAt: l.UpdatedAt,
+	}
+	if result.Tier == "" {
+		result.Tier = "standard"
+	}
+	if l.MaxSingle.Valid {
+		result.MaxSingle = &l.MaxSingle.Int64
+	}
+	if l.Daily.Valid {
+		result.Daily = &l.Daily.Int64
+	}
+	if l.Monthly.Valid {
+		result.Monthly = &l.Monthly.Int64
+	}
+	if l.HourlyCount.Valid {
+		result.HourlyCount = &l.HourlyCount.Int64
+	}
+	return result
+}
+
+func nullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
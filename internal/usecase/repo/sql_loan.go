@@ -0,0 +1,306 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type LoanSQLRepo struct {
+	SQLRepo
+}
+
+func NewLoanSQLRepo(db *sql.DB) *LoanSQLRepo {
+	return &LoanSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create disburses the loan from the loans GL account to the customer
+// account and saves its installments.
+func (r *LoanSQLRepo) Create(ctx context.Context, l entity.Loan) (entity.Loan, entity.Account, entity.Entry, error) {
+	var (
+		result  entity.Loan
+		account entity.Account
+		entry   entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		a, err := q.GetAccount(ctx, l.AccountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if a.Kind != "customer" {
+			return fmt.Errorf("%w: not a customer account", usecase.ErrInvalidArgument)
+		}
+
+		v, err := q.CreateLoan(ctx, db.CreateLoanParams{
+			AccountID:     a.ID,
+			Principal:     l.Principal,
+			Currency:      a.Currency,
+			AnnualRateBp:  l.AnnualRateBP,
+			PenaltyRateBp: l.PenaltyRateBP,
+			TermMonths:    l.TermMonths,
+			Method:        string(l.Method),
+			DisbursedOn:   l.DisbursedOn,
+			CreatedBy:     l.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+		for _, i := range l.Installments {
+			if err = q.CreateLoanInstallment(ctx, db.CreateLoanInstallmentParams{
+				LoanID:    v.ID,
+				Number:    i.Number,
+				DueDate:   i.DueDate,
+				Principal: i.Principal,
+				Interest:  i.Interest,
+			}); err != nil {
+				return err
+			}
+		}
+
+		account, entry, err = postCash(ctx, q, entity.GLCodeLoans, a.ID, a.Currency, l.Principal, entity.Posting{
+			Description: "Loan disbursement",
+			Reference:   loanReference(v.ID),
+			Metadata:    entity.Metadata{"loan_id": strconv.FormatInt(v.ID, 10)},
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = getLoan(ctx, q, v)
+		return err
+	})
+
+	return result, account, entry, err
+}
+
+// Get returns the loan with its installments.
+func (r *LoanSQLRepo) Get(ctx context.Context, id int64) (entity.Loan, error) {
+	var result entity.Loan
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetLoan(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		result, err = getLoan(ctx, q, v)
+		return err
+	})
+
+	return result, err
+}
+
+// List returns up to limit loans of the account without their
+// installments, the latest first.
+func (r *LoanSQLRepo) List(ctx context.Context, accountID uuid.UUID, limit int32) ([]entity.Loan, error) {
+	var result []entity.Loan
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		loans, err := q.ListLoans(ctx, db.ListLoansParams{
+			AccountID: accountID,
+			Limit:     limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.Loan, 0, len(loans))
+		for _, v := range loans {
+			result = append(result, toLoan(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// DueInstallments returns the unpaid installments due on or before the
+// date by loan and number.
+func (r *LoanSQLRepo) DueInstallments(ctx context.Context, date time.Time) ([]usecase.DueInstallment, error) {
+	var result []usecase.DueInstallment
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		due, err := q.ListDueLoanInstallments(ctx, date)
+		if err != nil {
+			return err
+		}
+
+		result = make([]usecase.DueInstallment, 0, len(due))
+		for _, v := range due {
+			result = append(result, usecase.DueInstallment{
+				Installment: toInstallment(db.LoanInstallment{
+					LoanID:    v.LoanID,
+					Number:    v.Number,
+					DueDate:   v.DueDate,
+					Principal: v.Principal,
+					Interest:  v.Interest,
+					Penalty:   v.Penalty,
+					Status:    v.Status,
+					PaidOn:    v.PaidOn,
+				}),
+				AccountID:     v.AccountID,
+				PenaltyRateBP: v.PenaltyRateBp,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Repay collects the installment from the account of its loan: the
+// principal to the loans GL account and the interest with the penalty to
+// the interest income. The paid installments return
+// usecase.ErrInvalidTransition.
+func (r *LoanSQLRepo) Repay(ctx context.Context, d usecase.DueInstallment, date time.Time) (entity.Loan,
+	entity.Account, []entity.Entry, error) {
+	var (
+		result  entity.Loan
+		account entity.Account
+		entries []entity.Entry
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		i := d.Installment
+		v, err := q.PayLoanInstallment(ctx, db.PayLoanInstallmentParams{
+			LoanID:  i.LoanID,
+			Number:  i.Number,
+			Penalty: i.Penalty,
+			PaidOn:  sql.NullTime{Time: date, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrInvalidTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		loan, err := q.RepayLoanPrincipal(ctx, db.RepayLoanPrincipalParams{
+			ID:     v.LoanID,
+			Amount: v.Principal,
+		})
+		if err != nil {
+			return err
+		}
+
+		posting := entity.Posting{
+			Reference: loanReference(v.LoanID),
+			Metadata: entity.Metadata{
+				"loan_id":     strconv.FormatInt(v.LoanID, 10),
+				"installment": strconv.Itoa(int(v.Number)),
+			},
+		}
+		for _, p := range []struct {
+			code        string
+			amount      int64
+			description string
+		}{
+			{entity.GLCodeLoans, v.Principal, "Loan repayment: principal"},
+			{entity.GLCodeInterestIncome, v.Interest, "Loan repayment: interest"},
+			{entity.GLCodeInterestIncome, v.Penalty, "Loan repayment: penalty interest"},
+		} {
+			if p.amount == 0 {
+				continue
+			}
+			posting.Description = p.description
+			a, e, err := postCash(ctx, q, p.code, d.AccountID, loan.Currency, -p.amount, posting)
+			if err != nil {
+				return err
+			}
+			account, entries = a, append(entries, e)
+		}
+
+		result = toLoan(loan)
+		return nil
+	})
+
+	return result, account, entries, err
+}
+
+// MarkLate marks the scheduled installment late, the late and paid ones
+// are left as is.
+func (r *LoanSQLRepo) MarkLate(ctx context.Context, loanID int64, number int32) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		return q.MarkLoanInstallmentLate(ctx, db.MarkLoanInstallmentLateParams{
+			LoanID: loanID,
+			Number: number,
+		})
+	})
+}
+
+// getLoan returns the loan with its installments within the tx of the
+// queries.
+func getLoan(ctx context.Context, q *db.Queries, v db.Loan) (entity.Loan, error) {
+	installments, err := q.ListLoanInstallments(ctx, v.ID)
+	if err != nil {
+		return entity.Loan{}, err
+	}
+
+	l := toLoan(v)
+	l.Installments = make([]entity.Installment, 0, len(installments))
+	for _, i := range installments {
+		l.Installments = append(l.Installments, toInstallment(i))
+	}
+	return l, nil
+}
+
+// loanReference is the reference of the loan entries.
+func loanReference(id int64) string {
+	return "LOAN-" + strconv.FormatInt(id, 10)
+}
+
+func toLoan(v db.Loan) entity.Loan {
+	l := entity.Loan{
+		ID:            v.ID,
+		AccountID:     v.AccountID,
+		Principal:     v.Principal,
+		Currency:      entity.Currency(v.Currency),
+		AnnualRateBP:  v.AnnualRateBp,
+		PenaltyRateBP: v.PenaltyRateBp,
+		TermMonths:    v.TermMonths,
+		Method:        entity.AmortizationMethod(v.Method),
+		Status:        entity.LoanStatus(v.Status),
+		Outstanding:   v.Outstanding,
+		DisbursedOn:   v.DisbursedOn,
+		CreatedBy:     v.CreatedBy,
+		CreatedAt:     v.CreatedAt,
+	}
+	if v.ClosedAt.Valid {
+		l.ClosedAt = &v.ClosedAt.Time
+	}
+	return l
+}
+
+func toInstallment(v db.LoanInstallment) entity.Installment {
+	i := entity.Installment{
+		LoanID:    v.LoanID,
+		Number:    v.Number,
+		DueDate:   v.DueDate,
+		Principal: v.Principal,
+		Interest:  v.Interest,
+		Penalty:   v.Penalty,
+		Status:    entity.InstallmentStatus(v.Status),
+	}
+	if v.PaidOn.Valid {
+		i.PaidOn = &v.PaidOn.Time
+	}
+	return i
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+)
+
+type PayeeSQLRepo struct {
+	SQLRepo
+}
+
+func NewPayeeSQLRepo(db *sql.DB) *PayeeSQLRepo {
+	return &PayeeSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *PayeeSQLRepo) Create(ctx context.Context, payee entity.Payee) (entity.Payee, error) {
+	var result entity.Payee
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		p, err := q.CreatePayee(ctx, db.CreatePayeeParams{
+			Owner:     payee.Owner,
+			Nickname:  payee.Nickname,
+			AccountID: payee.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = getPayee(ctx, q, p.Owner, p.ID)
+		return err
+	})
+	if isUniqueViolation(err) {
+		return entity.Payee{}, usecase.ErrDuplicate
+	}
+
+	return result, err
+}
+
+func (r *PayeeSQLRepo) Get(ctx context.Context, owner string, id int64) (entity.Payee, error) {
+	var result entity.Payee
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		var err error
+		result, err = getPayee(ctx, q, owner, id)
+		return err
+	})
+
+	return result, err
+}
+
+func (r *PayeeSQLRepo) List(ctx context.Context, owner string) ([]entity.Payee, error) {
+	var result []entity.Payee
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		payees, err := q.ListPayees(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.Payee, 0, len(payees))
+		for _, p := range payees {
+			result = append(result, entity.Payee{
+				ID:            p.ID,
+				Owner:         p.Owner,
+				Nickname:      p.Nickname,
+				AccountID:     p.AccountID,
+				AccountNumber: p.Number.String,
+				CreatedAt:     p.CreatedAt,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *PayeeSQLRepo) UpdateNickname(ctx context.Context, owner string, id int64, nickname string) (entity.Payee, error) {
+	var result entity.Payee
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		_, err := q.UpdatePayeeNickname(ctx, db.UpdatePayeeNicknameParams{
+			ID:       id,
+			Owner:    owner,
+			Nickname: nickname,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err = getPayee(ctx, q, owner, id)
+		return err
+	})
+	if isUniqueViolation(err) {
+		return entity.Payee{}, usecase.ErrDuplicate
+	}
+
+	return result, err
+}
+
+func (r *PayeeSQLRepo) Delete(ctx context.Context, owner string, id int64) error {
+	return r.execTx(ctx, nil, func(q *db.Queries) error {
+		n, err := q.DeletePayee(ctx, db.DeletePayeeParams{
+			ID:    id,
+			Owner: owner,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return usecase.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// getPayee returns the payee with the number of its account.
+func getPayee(ctx context.Context, q *db.Queries, owner string, id int64) (entity.Payee, error) {
+	p, err := q.GetPayee(ctx, db.GetPayeeParams{
+		ID:    id,
+		Owner: owner,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Payee{}, usecase.ErrNotFound
+	}
+	if err != nil {
+		return entity.Payee{}, err
+	}
+
+	return entity.Payee{
+		ID:            p.ID,
+		Owner:         p.Owner,
+		Nickname:      p.Nickname,
+		AccountID:     p.AccountID,
+		AccountNumber: p.Number.String,
+		CreatedAt:     p.CreatedAt,
+	}, nil
+}
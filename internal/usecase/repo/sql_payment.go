@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+)
+
+type PaymentImportSQLRepo struct {
+	SQLRepo
+}
+
+func NewPaymentImportSQLRepo(db *sql.DB) *PaymentImportSQLRepo {
+	return &PaymentImportSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *PaymentImportSQLRepo) Register(ctx context.Context, owner, msgID string) error {
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		return q.CreatePaymentImport(ctx, db.CreatePaymentImportParams{
+			Owner: owner,
+			MsgID: msgID,
+		})
+	})
+	if isUniqueViolation(err) {
+		return usecase.ErrDuplicate
+	}
+	return err
+}
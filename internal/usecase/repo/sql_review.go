@@ -0,0 +1,208 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+)
+
+type TransferReviewSQLRepo struct {
+	SQLRepo
+	// transfers executes the approved transfers.
+	transfers *TransferSQLRepo
+}
+
+func NewTransferReviewSQLRepo(db *sql.DB, transfers *TransferSQLRepo) *TransferReviewSQLRepo {
+	return &TransferReviewSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+		transfers: transfers,
+	}
+}
+
+func (r *TransferReviewSQLRepo) Create(ctx context.Context, review entity.TransferReview) (entity.TransferReview, error) {
+	var result entity.TransferReview
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		var err error
+		result, err = createTransferReview(ctx, q, review)
+		return err
+	})
+
+	return result, err
+}
+
+// createTransferReview holds the transfer for the review. The transfer is
+// saved as pending unless it is already pending in the decided approval.
+func createTransferReview(ctx context.Context, q *db.Queries, review entity.TransferReview) (entity.TransferReview, error) {
+	t := review.Transfer
+	if t.ID == 0 {
+		pending, err := insertTransfer(ctx, q, t, entity.TransferPending)
+		if err != nil {
+			return entity.TransferReview{}, err
+		}
+		t.ID = pending.ID
+	}
+
+	v, err := q.CreateTransferReview(ctx, db.CreateTransferReviewParams{
+		FromAccountID: t.FromAccountID,
+		ToAccountID:   t.ToAccountID,
+		Amount:        t.Amount,
+		Description:   t.Description,
+		Reference:     t.Reference,
+		Metadata:      t.Metadata,
+		Score:         int32(review.Score),
+		Signals:       review.Signals,
+	})
+	if err != nil {
+		return entity.TransferReview{}, err
+	}
+
+	v, err = q.SetTransferReviewTransfer(ctx, db.SetTransferReviewTransferParams{
+		ID:         v.ID,
+		TransferID: sql.NullInt64{Int64: t.ID, Valid: true},
+	})
+	if err != nil {
+		return entity.TransferReview{}, err
+	}
+	return toTransferReview(v), nil
+}
+
+func (r *TransferReviewSQLRepo) Get(ctx context.Context, id int64) (entity.TransferReview, error) {
+	var result entity.TransferReview
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetTransferReview(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toTransferReview(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *TransferReviewSQLRepo) List(ctx context.Context, status entity.ReviewStatus) ([]entity.TransferReview, error) {
+	var result []entity.TransferReview
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		reviews, err := q.ListTransferReviews(ctx, string(status))
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.TransferReview, 0, len(reviews))
+		for _, v := range reviews {
+			result = append(result, toTransferReview(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *TransferReviewSQLRepo) Approve(ctx context.Context, id int64, operator, comment string,
+	fees []entity.FeeCharge) (entity.TransferReview, entity.TransferRes, error) {
+	var (
+		review entity.TransferReview
+		res    entity.TransferRes
+	)
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		v, err := closeReview(ctx, q, id, entity.ReviewApproved, operator, comment)
+		if err != nil {
+			return err
+		}
+
+		review = toTransferReview(v)
+		res, err = r.transfers.create(ctx, q, review.Transfer, fees)
+		if err != nil {
+			return err
+		}
+
+		v, err = q.SetTransferReviewTransfer(ctx, db.SetTransferReviewTransferParams{
+			ID:         id,
+			TransferID: sql.NullInt64{Int64: res.Transfer.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		review = toTransferReview(v)
+		review.Transfer = res.Transfer
+		return nil
+	})
+
+	return review, res, err
+}
+
+func (r *TransferReviewSQLRepo) Reject(ctx context.Context, id int64, operator, comment string) (entity.TransferReview, error) {
+	var result entity.TransferReview
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := closeReview(ctx, q, id, entity.ReviewRejected, operator, comment)
+		if err != nil {
+			return err
+		}
+		result = toTransferReview(v)
+		return cancelTransfer(ctx, q, v.TransferID, entity.FailureRejected)
+	})
+
+	return result, err
+}
+
+// closeReview moves the pending review to the status. The update locks
+// the review, so it is closed only once.
+func closeReview(ctx context.Context, q *db.Queries, id int64, status entity.ReviewStatus,
+	operator, comment string) (db.TransferReview, error) {
+	v, err := q.CloseTransferReview(ctx, db.CloseTransferReviewParams{
+		ID:         id,
+		Status:     string(status),
+		ReviewedBy: operator,
+		Comment:    comment,
+	})
+	if !errors.Is(err, sql.ErrNoRows) {
+		return v, err
+	}
+
+	if _, err = q.GetTransferReview(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.TransferReview{}, usecase.ErrNotFound
+		}
+		return db.TransferReview{}, err
+	}
+	return db.TransferReview{}, usecase.ErrReviewClosed
+}
+
+func toTransferReview(v db.TransferReview) entity.TransferReview {
+	result := entity.TransferReview{
+		ID: v.ID,
+		Transfer: entity.Transfer{
+			ID:            v.TransferID.Int64,
+			FromAccountID: v.FromAccountID,
+			ToAccountID:   v.ToAccountID,
+			Amount:        v.Amount,
+			Description:   v.Description,
+			Reference:     v.Reference,
+			Metadata:      v.Metadata,
+		},
+		Score:      int(v.Score),
+		Signals:    v.Signals,
+		Status:     entity.ReviewStatus(v.Status),
+		ReviewedBy: v.ReviewedBy,
+		Comment:    v.Comment,
+		CreatedAt:  v.CreatedAt,
+	}
+	if v.ReviewedAt.Valid {
+		result.ReviewedAt = &v.ReviewedAt.Time
+	}
+	return result
+}
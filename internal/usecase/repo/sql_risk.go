@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type RiskSQLRepo struct {
+	SQLRepo
+}
+
+func NewRiskSQLRepo(db *sql.DB) *RiskSQLRepo {
+	return &RiskSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *RiskSQLRepo) History(ctx context.Context, from, to uuid.UUID, since time.Time) (entity.RiskHistory, error) {
+	var result entity.RiskHistory
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		h, err := q.GetRiskHistory(ctx, db.GetRiskHistoryParams{
+			ToAccountID:   to,
+			Since:         since,
+			FromAccountID: from,
+		})
+		if err != nil {
+			return err
+		}
+		result = entity.RiskHistory(h)
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type ScreeningSQLRepo struct {
+	SQLRepo
+}
+
+func NewScreeningSQLRepo(db *sql.DB) *ScreeningSQLRepo {
+	return &ScreeningSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+// Create saves the alert unless the name of the account already has a
+// pending one, then the pending alert is returned. The name is locked
+// until the tx ends, so the concurrent screenings share the alert.
+func (r *ScreeningSQLRepo) Create(ctx context.Context, a entity.ScreeningAlert) (entity.ScreeningAlert, error) {
+	var result entity.ScreeningAlert
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		err := q.LockScreeningName(ctx, db.LockScreeningNameParams{
+			AccountID: a.AccountID.String(),
+			Name:      a.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		v, err := q.GetPendingScreeningAlert(ctx, db.GetPendingScreeningAlertParams{
+			AccountID: a.AccountID,
+			Name:      a.Name,
+		})
+		if err == nil {
+			result = toScreeningAlert(v)
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		v, err = q.CreateScreeningAlert(ctx, db.CreateScreeningAlertParams{
+			Name:      a.Name,
+			Trigger:   string(a.Trigger),
+			AccountID: a.AccountID,
+			Score:     a.Score,
+			Matches:   a.Matches,
+			Decision:  string(a.Decision),
+		})
+		if err != nil {
+			return err
+		}
+		result = toScreeningAlert(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ScreeningSQLRepo) Get(ctx context.Context, id int64) (entity.ScreeningAlert, error) {
+	var result entity.ScreeningAlert
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetScreeningAlert(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toScreeningAlert(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *ScreeningSQLRepo) List(ctx context.Context, status entity.AlertStatus) ([]entity.ScreeningAlert, error) {
+	var result []entity.ScreeningAlert
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		alerts, err := q.ListScreeningAlerts(ctx, string(status))
+		if err != nil {
+			return err
+		}
+
+		result = make([]entity.ScreeningAlert, 0, len(alerts))
+		for _, v := range alerts {
+			result = append(result, toScreeningAlert(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Close moves the pending alert to the status. The update locks the
+// alert, so it is closed only once.
+func (r *ScreeningSQLRepo) Close(ctx context.Context, id int64, status entity.AlertStatus,
+	operator, comment string) (entity.ScreeningAlert, error) {
+	var result entity.ScreeningAlert
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.CloseScreeningAlert(ctx, db.CloseScreeningAlertParams{
+			ID:         id,
+			Status:     string(status),
+			ReviewedBy: operator,
+			Comment:    comment,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, err = q.GetScreeningAlert(ctx, id); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return usecase.ErrNotFound
+				}
+				return err
+			}
+			return usecase.ErrAlertClosed
+		}
+		if err != nil {
+			return err
+		}
+		result = toScreeningAlert(v)
+		return nil
+	})
+
+	return result, err
+}
+
+// LastClosed returns the latest reviewed alert of the name of the
+// account, usecase.ErrNotFound if the name hasn't been reviewed.
+func (r *ScreeningSQLRepo) LastClosed(ctx context.Context, accountID uuid.UUID, name string) (entity.ScreeningAlert, error) {
+	var result entity.ScreeningAlert
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		v, err := q.GetLastClosedScreeningAlert(ctx, db.GetLastClosedScreeningAlertParams{
+			AccountID: accountID,
+			Name:      name,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return usecase.ErrNotFound
+			}
+			return err
+		}
+		result = toScreeningAlert(v)
+		return nil
+	})
+
+	return result, err
+}
+
+func toScreeningAlert(v db.ScreeningAlert) entity.ScreeningAlert {
+	result := entity.ScreeningAlert{
+		ID:         v.ID,
+		Name:       v.Name,
+		Trigger:    entity.ScreeningTrigger(v.Trigger),
+		AccountID:  v.AccountID,
+		Score:      v.Score,
+		Matches:    v.Matches,
+		Decision:   entity.ScreeningDecision(v.Decision),
+		Status:     entity.AlertStatus(v.Status),
+		ReviewedBy: v.ReviewedBy,
+		Comment:    v.Comment,
+		CreatedAt:  v.CreatedAt,
+	}
+	if v.ReviewedAt.Valid {
+		result.ReviewedAt = &v.ReviewedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/internal/usecase"
+	"alukart32.com/bank/internal/usecase/repo/db"
+	"github.com/google/uuid"
+)
+
+type StatementSQLRepo struct {
+	SQLRepo
+}
+
+func NewStatementSQLRepo(db *sql.DB) *StatementSQLRepo {
+	return &StatementSQLRepo{
+		SQLRepo: SQLRepo{
+			db: db,
+		},
+	}
+}
+
+func (r *StatementSQLRepo) Get(ctx context.Context, accountID uuid.UUID, from, to time.Time) (entity.Statement, error) {
+	var result entity.Statement
+
+	// the balance and entries must be read from the same snapshot
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := r.execTx(ctx, opts, func(q *db.Queries) error {
+		a, err := q.GetAccount(ctx, accountID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return usecase.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		since, err := q.SumEntriesSince(ctx, db.SumEntriesSinceParams{
+			AccountID: accountID,
+			CreatedAt: from,
+		})
+		if err != nil {
+			return err
+		}
+
+		entries, err := q.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+			AccountID:   accountID,
+			CreatedAt:   from,
+			CreatedAt_2: to,
+		})
+		if err != nil {
+			return err
+		}
+
+		result = entity.Statement{
+			AccountID:      a.ID,
+			Owner:          a.Owner,
+			Currency:       entity.Currency(a.Currency),
+			From:           from,
+			To:             to,
+			OpeningBalance: a.Balance - since,
+			Lines:          make([]entity.StatementLine, 0, len(entries)),
+		}
+		for _, v := range entries {
+			line := entity.StatementLine{
+				EntryID:     v.ID,
+				Reference:   v.Reference,
+				Description: v.Description,
+				Amount:      v.Amount,
+				CreatedAt:   v.CreatedAt,
+			}
+			if v.TransferID.Valid {
+				line.TransferID = v.TransferID.Int64
+				counterparty := v.FromAccountID.UUID
+				if counterparty == accountID {
+					counterparty = v.ToAccountID.UUID
+				}
+				line.Counterparty = &counterparty
+			}
+			result.Lines = append(result.Lines, line)
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"sync"
 
 	"alukart32.com/bank/entity"
@@ -24,103 +25,252 @@ func NewTransferSQLRepo(db *sql.DB) *TransferSQLRepo {
 	}
 }
 
-func (r *TransferSQLRepo) Create(ctx context.Context, transfer entity.Transfer) (entity.TransferRes, error) {
+// Create executes the transfer and charges its fees from the from
+// account in one tx.
+func (r *TransferSQLRepo) Create(ctx context.Context, transfer entity.Transfer,
+	fees []entity.FeeCharge) (entity.TransferRes, error) {
 	var result entity.TransferRes
 
 	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
-		fromEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
-			AccountID: transfer.FromAccountID,
-			Amount:    -transfer.Amount,
-		})
-		if err != nil {
-			return err
+		var err error
+		result, err = r.create(ctx, q, transfer, fees)
+		return err
+	})
+	return result, err
+}
+
+// create executes the customer transfer within the limits of the from
+// account and charges the fees within the tx of the queries.
+func (r *TransferSQLRepo) create(ctx context.Context, q *db.Queries, transfer entity.Transfer,
+	fees []entity.FeeCharge) (entity.TransferRes, error) {
+	return r.execute(ctx, q, transfer, fees, checkTransferLimits)
+}
+
+// post executes the transfer of the bank, such as the interest paid from
+// the expense account, within the tx of the queries. The velocity limits
+// of the customers don't apply to it.
+func (r *TransferSQLRepo) post(ctx context.Context, q *db.Queries, transfer entity.Transfer) (entity.TransferRes, error) {
+	return r.execute(ctx, q, transfer, nil, nil)
+}
+
+// execute executes the transfer and charges the fees. The new transfer is
+// saved as processing, the pending one of the approved review or approval
+// moves to processing. Both complete once the entries are posted. The
+// accounts must be in the same currency, a nil limits skips the check of
+// the limits.
+func (r *TransferSQLRepo) execute(ctx context.Context, q *db.Queries, transfer entity.Transfer,
+	fees []entity.FeeCharge, limits func(context.Context, *db.Queries, entity.Transfer) error) (entity.TransferRes, error) {
+	var result entity.TransferRes
+
+	if err := checkCurrencies(ctx, q, transfer); err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	t, err := startTransfer(ctx, q, transfer)
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	fromEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   transfer.FromAccountID,
+		Amount:      -transfer.Amount,
+		Description: transfer.Description,
+		Reference:   transfer.Reference,
+		Metadata:    transfer.Metadata,
+	})
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	result.FromEntry = entity.Entry(fromEntry)
+
+	toEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
+		AccountID:   transfer.ToAccountID,
+		Amount:      transfer.Amount,
+		Description: transfer.Description,
+		Reference:   transfer.Reference,
+		Metadata:    transfer.Metadata,
+	})
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	result.ToEntry = entity.Entry(toEntry)
+
+	t, err = q.SetTransferEntries(ctx, db.SetTransferEntriesParams{
+		ID:          t.ID,
+		FromEntryID: sql.NullInt64{Int64: fromEntry.ID, Valid: true},
+		ToEntryID:   sql.NullInt64{Int64: toEntry.ID, Valid: true},
+	})
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	result.Transfer = toTransfer(t)
+
+	// update accounts
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	fromAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     transfer.FromAccountID,
+		Amount: -transfer.Amount,
+	})
+	if isConstraint(err, "positive_balance") {
+		return entity.TransferRes{}, usecase.ErrInsufficientFunds
+	}
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	// the update holds the debited account lock until the tx end
+	if limits != nil {
+		if err = limits(ctx, q, result.Transfer); err != nil {
+			return entity.TransferRes{}, err
 		}
-		result.FromEntry = entity.Entry(fromEntry)
+	}
+	result.FromAccount = entity.Account{
+		ID:        fromAccount.ID,
+		Owner:     fromAccount.Owner,
+		Balance:   fromAccount.Balance,
+		Currency:  entity.Currency(fromAccount.Currency),
+		CreatedAt: fromAccount.CreatedAt,
+	}
 
-		toEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
-			AccountID: transfer.ToAccountID,
-			Amount:    transfer.Amount,
-		})
+	toAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+		ID:     transfer.ToAccountID,
+		Amount: transfer.Amount,
+	})
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	result.ToAccount = entity.Account{
+		ID:        toAccount.ID,
+		Owner:     toAccount.Owner,
+		Balance:   toAccount.Balance,
+		Currency:  entity.Currency(toAccount.Currency),
+		CreatedAt: toAccount.CreatedAt,
+	}
+
+	for _, fee := range fees {
+		fee.AccountID = transfer.FromAccountID
+		fee.TransferID = result.Transfer.ID
+		charged, account, err := chargeFee(ctx, q, fee)
 		if err != nil {
-			return err
+			return entity.TransferRes{}, err
 		}
-		result.ToEntry = entity.Entry(toEntry)
+		result.Fees = append(result.Fees, charged)
+		result.FromAccount.Balance = account.Balance
+	}
 
-		t, err := q.CreateTransfer(ctx, db.CreateTransferParams{
-			FromAccountID: transfer.FromAccountID,
-			ToAccountID:   transfer.ToAccountID,
-			FromEntryID:   fromEntry.ID,
-			ToEntryID:     toEntry.ID,
-			Amount:        transfer.Amount,
-		})
+	if t, err = transitTransfer(ctx, q, t, entity.TransferCompleted, ""); err != nil {
+		return entity.TransferRes{}, err
+	}
+	result.Transfer = toTransfer(t)
+	return result, nil
+}
+
+// startTransfer moves the pending transfer to processing or saves the
+// new one as processing.
+func startTransfer(ctx context.Context, q *db.Queries, transfer entity.Transfer) (db.Transfer, error) {
+	if transfer.ID == 0 {
+		return insertTransfer(ctx, q, transfer, entity.TransferProcessing)
+	}
+
+	t, err := getTransfer(ctx, q, transfer.ID)
+	if err != nil {
+		return db.Transfer{}, err
+	}
+	return transitTransfer(ctx, q, t, entity.TransferProcessing, "")
+}
+
+// insertTransfer saves the transfer without entries in the initial
+// status.
+func insertTransfer(ctx context.Context, q *db.Queries, transfer entity.Transfer,
+	status entity.TransferStatus) (db.Transfer, error) {
+	t, err := q.CreateTransfer(ctx, db.CreateTransferParams{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		Description:   transfer.Description,
+		Reference:     transfer.Reference,
+		Metadata:      transfer.Metadata,
+		Status:        status,
+	})
+	if err != nil {
+		return db.Transfer{}, err
+	}
+
+	_, err = q.CreateTransferTransition(ctx, db.CreateTransferTransitionParams{
+		TransferID: t.ID,
+		ToStatus:   status,
+	})
+	return t, err
+}
+
+// cancelTransfer cancels the pending transfer of the closed review or
+// approval with the reason. The ones held before the transfers were
+// saved as pending have none.
+func cancelTransfer(ctx context.Context, q *db.Queries, id sql.NullInt64, reason string) error {
+	if !id.Valid {
+		return nil
+	}
+
+	t, err := getTransfer(ctx, q, id.Int64)
+	if err != nil {
+		return err
+	}
+	_, err = transitTransfer(ctx, q, t, entity.TransferCancelled, reason)
+	return err
+}
+
+func (r *TransferSQLRepo) Get(ctx context.Context, id int64) (entity.Transfer, error) {
+	var result entity.Transfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		t, err := getTransfer(ctx, q, id)
 		if err != nil {
 			return err
 		}
-		result.Transfer = entity.Transfer{
-			ID:            t.ID,
-			FromAccountID: t.FromAccountID,
-			ToAccountID:   t.ToAccountID,
-			Amount:        t.Amount,
-			FromEntryID:   t.FromEntryID,
-			ToEntryID:     t.ToEntryID,
-			CreatedAt:     t.CreatedAt,
-		}
+		result = toTransfer(t)
+		return nil
+	})
+	return result, err
+}
 
-		// update accounts
-		r.mux.Lock()
-		fromAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
-			ID:     transfer.FromAccountID,
-			Amount: -transfer.Amount,
-		})
+// Fail saves the transfer refused on the execution as failed with the
+// reason. The failed transfer has no entries.
+func (r *TransferSQLRepo) Fail(ctx context.Context, transfer entity.Transfer, reason string) (entity.Transfer, error) {
+	var result entity.Transfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		t, err := insertTransfer(ctx, q, transfer, entity.TransferProcessing)
 		if err != nil {
 			return err
 		}
-		result.FromAccount = entity.Account{
-			ID:        fromAccount.ID,
-			Owner:     fromAccount.Owner,
-			Balance:   fromAccount.Balance,
-			Currency:  entity.Currency(fromAccount.Currency),
-			CreatedAt: fromAccount.CreatedAt,
-		}
 
-		toAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
-			ID:     transfer.ToAccountID,
-			Amount: transfer.Amount,
-		})
+		t, err = transitTransfer(ctx, q, t, entity.TransferFailed, reason)
 		if err != nil {
 			return err
 		}
-		result.ToAccount = entity.Account{
-			ID:        toAccount.ID,
-			Owner:     toAccount.Owner,
-			Balance:   toAccount.Balance,
-			Currency:  entity.Currency(toAccount.Currency),
-			CreatedAt: toAccount.CreatedAt,
-		}
-
-		r.mux.Unlock()
+		result = toTransfer(t)
 		return nil
 	})
 	return result, err
 }
 
-func (r *TransferSQLRepo) Get(ctx context.Context, id int64) (entity.Transfer, error) {
-	var result entity.Transfer
+// Transitions returns the status changes of the transfer, the oldest first.
+func (r *TransferSQLRepo) Transitions(ctx context.Context, id int64) ([]entity.TransferTransition, error) {
+	var result []entity.TransferTransition
 
 	err := r.execTx(ctx, nil, func(q *db.Queries) error {
-		t, err := q.GetTransfer(ctx, id)
+		if _, err := getTransfer(ctx, q, id); err != nil {
+			return err
+		}
+
+		transitions, err := q.ListTransferTransitions(ctx, id)
 		if err != nil {
 			return err
 		}
 
-		result = entity.Transfer{
-			ID:            t.ID,
-			FromAccountID: t.FromAccountID,
-			ToAccountID:   t.ToAccountID,
-			Amount:        t.Amount,
-			FromEntryID:   t.FromEntryID,
-			ToEntryID:     t.ToEntryID,
-			CreatedAt:     t.CreatedAt,
+		result = make([]entity.TransferTransition, 0, len(transitions))
+		for _, v := range transitions {
+			result = append(result, entity.TransferTransition(v))
 		}
 		return nil
 	})
@@ -137,6 +287,7 @@ func (r *TransferSQLRepo) List(ctx context.Context, params usecase.ListTransferP
 			var transfers []db.ListTransfersByFromAccountRow
 			transfers, err = q.ListTransfersByFromAccount(ctx, db.ListTransfersByFromAccountParams{
 				FromAccountID: params.FromAccountId,
+				Status:        string(params.Status),
 				Limit:         params.Limit,
 				Offset:        params.Offset,
 			})
@@ -151,7 +302,8 @@ func (r *TransferSQLRepo) List(ctx context.Context, params usecase.ListTransferP
 		case usecase.ListToAccount:
 			var transfers []db.ListTransfersByToAccountRow
 			transfers, err = q.ListTransfersByToAccount(ctx, db.ListTransfersByToAccountParams{
-				ToAccountID: params.FromAccountId,
+				ToAccountID: params.ToAccountId,
+				Status:      string(params.Status),
 				Limit:       params.Limit,
 				Offset:      params.Offset,
 			})
@@ -168,6 +320,7 @@ func (r *TransferSQLRepo) List(ctx context.Context, params usecase.ListTransferP
 			transfers, err = q.ListTransfersByAccounts(ctx, db.ListTransfersByAccountsParams{
 				ToAccountID:   params.ToAccountId,
 				FromAccountID: params.FromAccountId,
+				Status:        string(params.Status),
 				Limit:         params.Limit,
 				Offset:        params.Offset,
 			})
@@ -188,57 +341,194 @@ func (r *TransferSQLRepo) List(ctx context.Context, params usecase.ListTransferP
 	return result, err
 }
 
-func (r *TransferSQLRepo) Rollback(ctx context.Context, id int64) error {
-	return r.execTx(ctx, nil, func(q *db.Queries) error {
-		// get transfer, fromEntry, toEntry
-		transfer, err := q.GetTransfer(ctx, id)
+// ListByReference returns the transfers with the reference from or to
+// the accounts of the holder. An empty status matches all transfers.
+func (r *TransferSQLRepo) ListByReference(ctx context.Context, holder, reference string,
+	status entity.TransferStatus) ([]entity.Transfer, error) {
+	var result []entity.Transfer
+
+	err := r.execTx(ctx, nil, func(q *db.Queries) error {
+		transfers, err := q.ListTransfersByReference(ctx, db.ListTransfersByReferenceParams{
+			Reference: reference,
+			Holder:    holder,
+			Status:    string(status),
+		})
 		if err != nil {
 			return err
 		}
-		fromEntry, err := q.GetEntry(ctx, transfer.FromEntryID)
+
+		result = make([]entity.Transfer, 0, len(transfers))
+		for _, v := range transfers {
+			result = append(result, entity.Transfer(v))
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Rollback reverses the completed transfer with the entries opposite to
+// its ones. The transfer and its entries are kept, the transfer becomes
+// reversed.
+func (r *TransferSQLRepo) Rollback(ctx context.Context, id int64) (entity.TransferRes, error) {
+	var result entity.TransferRes
+
+	err := r.execTx(ctx, &sql.TxOptions{}, func(q *db.Queries) error {
+		t, err := getTransfer(ctx, q, id)
 		if err != nil {
 			return err
 		}
-		toEntry, err := q.GetEntry(ctx, transfer.ToEntryID)
-		if err != nil {
+		if t, err = transitTransfer(ctx, q, t, entity.TransferReversed, ""); err != nil {
 			return err
 		}
+		result.Transfer = toTransfer(t)
 
-		// update fromAccount, toAccount
-		r.mux.Lock()
-		_, err = q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
-			ID:     transfer.FromAccountID,
-			Amount: transfer.Amount,
+		description := fmt.Sprintf("reversal of transfer %d", t.ID)
+		fromEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
+			AccountID:   t.FromAccountID,
+			Amount:      t.Amount,
+			Description: description,
+			Reference:   t.Reference,
+			Metadata:    t.Metadata,
 		})
 		if err != nil {
 			return err
 		}
+		result.FromEntry = entity.Entry(fromEntry)
 
-		_, err = q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
-			ID:     transfer.ToAccountID,
-			Amount: -transfer.Amount,
+		toEntry, err := q.CreateEntry(ctx, db.CreateEntryParams{
+			AccountID:   t.ToAccountID,
+			Amount:      -t.Amount,
+			Description: description,
+			Reference:   t.Reference,
+			Metadata:    t.Metadata,
 		})
 		if err != nil {
 			return err
 		}
+		result.ToEntry = entity.Entry(toEntry)
 
-		// delete fromEntry, toEntry, transfer
-		err = q.DeleteTransfer(ctx, transfer.ID)
-		if err != nil {
-			return err
+		// update toAccount, fromAccount
+		r.mux.Lock()
+		defer r.mux.Unlock()
+		toAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+			ID:     t.ToAccountID,
+			Amount: -t.Amount,
+		})
+		if isConstraint(err, "positive_balance") {
+			return usecase.ErrInsufficientFunds
 		}
-
-		err = q.DeleteEntry(ctx, fromEntry.ID)
 		if err != nil {
 			return err
 		}
+		result.ToAccount = entity.Account{
+			ID:        toAccount.ID,
+			Owner:     toAccount.Owner,
+			Balance:   toAccount.Balance,
+			Currency:  entity.Currency(toAccount.Currency),
+			CreatedAt: toAccount.CreatedAt,
+		}
 
-		err = q.DeleteEntry(ctx, toEntry.ID)
+		fromAccount, err := q.AddAccountBalance(ctx, db.AddAccountBalanceParams{
+			ID:     t.FromAccountID,
+			Amount: t.Amount,
+		})
 		if err != nil {
 			return err
 		}
-
-		r.mux.Unlock()
+		result.FromAccount = entity.Account{
+			ID:        fromAccount.ID,
+			Owner:     fromAccount.Owner,
+			Balance:   fromAccount.Balance,
+			Currency:  entity.Currency(fromAccount.Currency),
+			CreatedAt: fromAccount.CreatedAt,
+		}
 		return nil
 	})
+	return result, err
+}
+
+// checkCurrencies returns usecase.ErrInvalidArgument if the accounts of
+// the transfer are in different currencies, the amount isn't converted.
+func checkCurrencies(ctx context.Context, q *db.Queries, t entity.Transfer) error {
+	from, err := q.GetAccount(ctx, t.FromAccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return usecase.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	to, err := q.GetAccount(ctx, t.ToAccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return usecase.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if from.Currency != to.Currency {
+		return fmt.Errorf("%w: %s account can't transfer to %s account", usecase.ErrInvalidArgument,
+			from.Currency, to.Currency)
+	}
+	return nil
+}
+
+func getTransfer(ctx context.Context, q *db.Queries, id int64) (db.Transfer, error) {
+	t, err := q.GetTransfer(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Transfer{}, usecase.ErrNotFound
+	}
+	return t, err
+}
+
+// transitTransfer moves the transfer to the status if the transition
+// table allows it. The update fails if a concurrent tx has changed the
+// status since the transfer was read.
+func transitTransfer(ctx context.Context, q *db.Queries, t db.Transfer, status entity.TransferStatus,
+	reason string) (db.Transfer, error) {
+	if !t.Status.CanTransition(status) {
+		return db.Transfer{}, fmt.Errorf("%w: %s transfer can't become %s", usecase.ErrInvalidTransition, t.Status, status)
+	}
+
+	failureReason := t.FailureReason
+	if status == entity.TransferFailed || status == entity.TransferCancelled {
+		failureReason = reason
+	}
+	updated, err := q.UpdateTransferStatus(ctx, db.UpdateTransferStatusParams{
+		Status:        status,
+		FailureReason: failureReason,
+		ID:            t.ID,
+		FromStatus:    t.Status,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Transfer{}, fmt.Errorf("%w: transfer status has been changed", usecase.ErrInvalidTransition)
+	}
+	if err != nil {
+		return db.Transfer{}, err
+	}
+
+	_, err = q.CreateTransferTransition(ctx, db.CreateTransferTransitionParams{
+		TransferID: t.ID,
+		FromStatus: t.Status,
+		ToStatus:   status,
+		Reason:     reason,
+	})
+	return updated, err
+}
+
+func toTransfer(t db.Transfer) entity.Transfer {
+	return entity.Transfer{
+		ID:            t.ID,
+		FromAccountID: t.FromAccountID,
+		ToAccountID:   t.ToAccountID,
+		Amount:        t.Amount,
+		FromEntryID:   t.FromEntryID.Int64,
+		ToEntryID:     t.ToEntryID.Int64,
+		CreatedAt:     t.CreatedAt,
+		Description:   t.Description,
+		Reference:     t.Reference,
+		Metadata:      t.Metadata,
+		Status:        t.Status,
+		FailureReason: t.FailureReason,
+		UpdatedAt:     t.UpdatedAt,
+	}
 }
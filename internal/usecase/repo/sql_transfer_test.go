@@ -70,7 +70,7 @@ func TestTransfer(t *testing.T) {
 				FromAccountID: fromAccount.ID,
 				ToAccountID:   toAccount.ID,
 				Amount:        amount,
-			})
+			}, nil)
 
 			errors <- err
 			results <- t
@@ -186,7 +186,7 @@ func TestTransferDeadlock(t *testing.T) {
 				FromAccountID: fromAccountID,
 				ToAccountID:   toAccountID,
 				Amount:        amount,
-			})
+			}, nil)
 			errors <- err
 		}()
 	}
@@ -237,7 +237,7 @@ func TestTransferGet(t *testing.T) {
 		FromAccountID: fromAccount.ID,
 		ToAccountID:   toAccount.ID,
 		Amount:        amount,
-	})
+	}, nil)
 	require.NoError(t, err)
 
 	transfer, err := repoTransfer.Get(context.Background(), createdTransfer.Transfer.ID)
@@ -247,6 +247,38 @@ func TestTransferGet(t *testing.T) {
 	assert.Equal(t, amount, transfer.Amount)
 }
 
+func TestTransferCurrencyMismatch(t *testing.T) {
+	repoTransfer := NewTransferSQLRepo(testDB)
+	repoAccount := NewAccountSQLRepo(testDB)
+
+	fromAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_1",
+		Balance:  random.Int64(10_000, 100_000_000),
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	toAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_2",
+		Balance:  random.Int64(10_000, 10_000_000),
+		Currency: entity.CurrencyUSD,
+	})
+	require.NoError(t, err)
+
+	_, err = repoTransfer.Create(context.Background(), entity.Transfer{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        100,
+	}, nil)
+	require.ErrorIs(t, err, usecase.ErrInvalidArgument)
+
+	updatedFromAccount, err := repoAccount.Get(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, updatedFromAccount.Balance)
+}
+
 func TestTransferListByFromAccount(t *testing.T) {
 	repoTransfer := NewTransferSQLRepo(testDB)
 	repoAccount := NewAccountSQLRepo(testDB)
@@ -280,7 +312,7 @@ func TestTransferListByFromAccount(t *testing.T) {
 				FromAccountID: fromAccount.ID,
 				ToAccountID:   toAccount.ID,
 				Amount:        random.Int64(1, 2000),
-			})
+			}, nil)
 
 			errors <- err
 		}()
@@ -340,7 +372,7 @@ func TestTransferListByToAccount(t *testing.T) {
 				FromAccountID: fromAccount.ID,
 				ToAccountID:   toAccount.ID,
 				Amount:        random.Int64(1, 2000),
-			})
+			}, nil)
 
 			errors <- err
 		}()
@@ -400,7 +432,7 @@ func TestTransferListByAccounts(t *testing.T) {
 				FromAccountID: fromAccount.ID,
 				ToAccountID:   toAccount.ID,
 				Amount:        random.Int64(1, 2000),
-			})
+			}, nil)
 
 			errors <- err
 		}()
@@ -464,7 +496,7 @@ func TestTransferRollback(t *testing.T) {
 				FromAccountID: fromAccount.ID,
 				ToAccountID:   toAccount.ID,
 				Amount:        amount,
-			})
+			}, nil)
 
 			errors <- err
 			results <- t
@@ -479,8 +511,18 @@ func TestTransferRollback(t *testing.T) {
 	close(results)
 
 	for v := range results {
-		err = repoTransfer.Rollback(context.Background(), v.Transfer.ID)
+		res, err := repoTransfer.Rollback(context.Background(), v.Transfer.ID)
 		require.NoError(t, err)
+		assert.Equal(t, entity.TransferReversed, res.Transfer.Status)
+
+		_, err = repoTransfer.Rollback(context.Background(), v.Transfer.ID)
+		require.ErrorIs(t, err, usecase.ErrInvalidTransition)
+
+		transitions, err := repoTransfer.Transitions(context.Background(), v.Transfer.ID)
+		require.NoError(t, err)
+		require.Len(t, transitions, 2)
+		assert.Equal(t, entity.TransferCompleted, transitions[1].FromStatus)
+		assert.Equal(t, entity.TransferReversed, transitions[1].ToStatus)
 	}
 	fromAccountUpdated, err := repoAccount.Get(context.Background(), fromAccount.ID)
 	require.NoError(t, err)
@@ -490,3 +532,152 @@ func TestTransferRollback(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, toAccount.Balance, toAccountUpdated.Balance)
 }
+
+func TestTransferListToAccount(t *testing.T) {
+	repoTransfer := NewTransferSQLRepo(testDB)
+	repoAccount := NewAccountSQLRepo(testDB)
+
+	var accounts []entity.Account
+	for _, owner := range []string{"owner_test_1", "owner_test_2", "owner_test_3"} {
+		a, err := repoAccount.Create(context.Background(), entity.Account{
+			ID:       uuid.New(),
+			Owner:    owner,
+			Balance:  10_000,
+			Currency: entity.CurrencyRUB,
+		})
+		require.NoError(t, err)
+		accounts = append(accounts, a)
+	}
+
+	// the first account sends to the second, the second to the third
+	for i := 0; i < 2; i++ {
+		_, err := repoTransfer.Create(context.Background(), entity.Transfer{
+			FromAccountID: accounts[i].ID,
+			ToAccountID:   accounts[i+1].ID,
+			Amount:        100,
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	transfers, err := repoTransfer.List(context.Background(), usecase.ListTransferParams{
+		FromAccountId: accounts[0].ID,
+		ToAccountId:   accounts[1].ID,
+		Order:         usecase.ListToAccount,
+		PaggingParams: usecase.PaggingParams{Limit: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, accounts[0].ID, transfers[0].FromAccountID)
+	assert.Equal(t, accounts[1].ID, transfers[0].ToAccountID)
+}
+
+func TestTransferStatuses(t *testing.T) {
+	repoTransfer := NewTransferSQLRepo(testDB)
+	repoAccount := NewAccountSQLRepo(testDB)
+
+	fromAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_1",
+		Balance:  10_000,
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+	toAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_2",
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	res, err := repoTransfer.Create(context.Background(), entity.Transfer{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        100,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferCompleted, res.Transfer.Status)
+	assert.Equal(t, res.FromEntry.ID, res.Transfer.FromEntryID)
+
+	transitions, err := repoTransfer.Transitions(context.Background(), res.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, entity.TransferProcessing, transitions[0].ToStatus)
+	assert.Equal(t, entity.TransferCompleted, transitions[1].ToStatus)
+
+	failed, err := repoTransfer.Fail(context.Background(), entity.Transfer{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        100_000,
+	}, entity.FailureInsufficientFunds)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferFailed, failed.Status)
+	assert.Equal(t, entity.FailureInsufficientFunds, failed.FailureReason)
+	assert.Zero(t, failed.FromEntryID)
+
+	transfers, err := repoTransfer.List(context.Background(), usecase.ListTransferParams{
+		FromAccountId: fromAccount.ID,
+		Order:         usecase.ListFromAccount,
+		Status:        entity.TransferFailed,
+		PaggingParams: usecase.PaggingParams{Limit: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, failed.ID, transfers[0].ID)
+}
+
+func TestTransferHeldForReview(t *testing.T) {
+	repoTransfer := NewTransferSQLRepo(testDB)
+	repoReview := NewTransferReviewSQLRepo(testDB, repoTransfer)
+	repoAccount := NewAccountSQLRepo(testDB)
+
+	fromAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_1",
+		Balance:  10_000,
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+	toAccount, err := repoAccount.Create(context.Background(), entity.Account{
+		ID:       uuid.New(),
+		Owner:    "owner_test_2",
+		Currency: entity.CurrencyRUB,
+	})
+	require.NoError(t, err)
+
+	hold := func() entity.TransferReview {
+		review, err := repoReview.Create(context.Background(), entity.TransferReview{
+			Transfer: entity.Transfer{
+				FromAccountID: fromAccount.ID,
+				ToAccountID:   toAccount.ID,
+				Amount:        100,
+			},
+			Score: 50,
+		})
+		require.NoError(t, err)
+
+		pending, err := repoTransfer.Get(context.Background(), review.Transfer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.TransferPending, pending.Status)
+		return review
+	}
+
+	rejected, err := repoReview.Reject(context.Background(), hold().ID, "operator", "")
+	require.NoError(t, err)
+	cancelled, err := repoTransfer.Get(context.Background(), rejected.Transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TransferCancelled, cancelled.Status)
+	assert.Equal(t, entity.FailureRejected, cancelled.FailureReason)
+
+	review := hold()
+	_, res, err := repoReview.Approve(context.Background(), review.ID, "operator", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, review.Transfer.ID, res.Transfer.ID)
+	assert.Equal(t, entity.TransferCompleted, res.Transfer.Status)
+
+	transitions, err := repoTransfer.Transitions(context.Background(), res.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, transitions, 3)
+	assert.Equal(t, entity.TransferPending, transitions[0].ToStatus)
+	assert.Equal(t, entity.TransferProcessing, transitions[1].ToStatus)
+	assert.Equal(t, entity.TransferCompleted, transitions[2].ToStatus)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+)
+
+// maxCommentLength is the limit of the operator comment of a review.
+const maxCommentLength = 280
+
+type reviewService struct {
+	db     TransferReviewRepo
+	events EventPublisher
+	fees   *FeeEngine
+	audit  *Auditor
+	l      zerologx.Logger
+}
+
+func NewReviewService(r TransferReviewRepo, p EventPublisher, fees *FeeEngine, audit *Auditor,
+	l zerologx.Logger) ReviewService {
+	return &reviewService{
+		db:     r,
+		events: p,
+		fees:   fees,
+		audit:  audit,
+		l:      l,
+	}
+}
+
+func (s *reviewService) List(ctx context.Context, status entity.ReviewStatus) ([]entity.TransferReview, error) {
+	if status == "" {
+		status = entity.ReviewPending
+	}
+	if err := checkReviewStatus(status); err != nil {
+		return nil, err
+	}
+	return s.db.List(ctx, status)
+}
+
+func (s *reviewService) Get(ctx context.Context, id int64) (entity.TransferReview, error) {
+	return s.db.Get(ctx, id)
+}
+
+// Approve executes the held transfer on behalf of the operator. The
+// limits, the balance and the fees are of the approval time.
+func (s *reviewService) Approve(ctx context.Context, operator string, id int64, comment string) (entity.TransferReview, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.TransferReview{}, err
+	}
+
+	review, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.TransferReview{}, err
+	}
+	fees, err := s.fees.Charges(ctx, review.Transfer)
+	if err != nil {
+		return entity.TransferReview{}, err
+	}
+
+	before := review
+	var res entity.TransferRes
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if review, res, err = s.db.Approve(ctx, id, operator, comment, fees); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditTransferRelease, entity.AuditTargetTransfer,
+			strconv.FormatInt(res.Transfer.ID, 10), before, res)
+	})
+	if err != nil {
+		return entity.TransferReview{}, err
+	}
+
+	publishTransfer(s.events, res)
+	return review, nil
+}
+
+func (s *reviewService) Reject(ctx context.Context, operator string, id int64, comment string) (entity.TransferReview, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.TransferReview{}, err
+	}
+	return s.db.Reject(ctx, id, operator, comment)
+}
+
+func checkReviewStatus(status entity.ReviewStatus) error {
+	switch status {
+	case entity.ReviewPending, entity.ReviewApproved, entity.ReviewRejected:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown review status %q", ErrInvalidArgument, status)
+	}
+}
+
+func checkComment(comment string) error {
+	if utf8.RuneCountInString(comment) > maxCommentLength || !isPrintable(comment) {
+		return fmt.Errorf("%w: comment must have at most %d printable characters", ErrInvalidArgument, maxCommentLength)
+	}
+	return nil
+}
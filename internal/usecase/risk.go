@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"alukart32.com/bank/entity"
+)
+
+var (
+	ErrTransferBlocked = errors.New("transfer blocked by risk rules")
+	ErrTransferHeld    = errors.New("transfer held for review")
+	ErrReviewClosed    = errors.New("review is already closed")
+)
+
+// ReviewError is the ErrTransferHeld with the review the transfer waits for.
+type ReviewError struct {
+	Review entity.TransferReview
+}
+
+func (e *ReviewError) Error() string {
+	return fmt.Sprintf("%v: review %d", ErrTransferHeld, e.Review.ID)
+}
+
+func (e *ReviewError) Unwrap() error {
+	return ErrTransferHeld
+}
+
+// RiskInput is the transfer to evaluate with the history of its debited
+// account.
+type RiskInput struct {
+	Transfer entity.Transfer
+	History  entity.RiskHistory
+	Now      time.Time
+}
+
+// RiskRule scores a risk factor of transfers. Evaluate reports whether
+// the rule is triggered by the input.
+type RiskRule interface {
+	Name() string
+	Evaluate(in RiskInput) (entity.RiskSignal, bool)
+}
+
+// NewPayeeRule is triggered by large amounts sent to a recipient the
+// account has never sent money to.
+type NewPayeeRule struct {
+	MinAmount int64
+	Score     int
+}
+
+func (r NewPayeeRule) Name() string { return "new_payee_large_amount" }
+
+func (r NewPayeeRule) Evaluate(in RiskInput) (entity.RiskSignal, bool) {
+	if in.History.PayeeTransfers > 0 || in.Transfer.Amount < r.MinAmount {
+		return entity.RiskSignal{}, false
+	}
+	return entity.RiskSignal{
+		Rule:   r.Name(),
+		Score:  r.Score,
+		Reason: fmt.Sprintf("first transfer to the recipient of at least %d", r.MinAmount),
+	}, true
+}
+
+// UnusualHourRule is triggered by transfers made in [From, To) hours of
+// the location, the range may wrap midnight. A nil location means UTC.
+type UnusualHourRule struct {
+	From, To int
+	Location *time.Location
+	Score    int
+}
+
+func (r UnusualHourRule) Name() string { return "unusual_hour" }
+
+func (r UnusualHourRule) Evaluate(in RiskInput) (entity.RiskSignal, bool) {
+	loc := r.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	hour := in.Now.In(loc).Hour()
+
+	var unusual bool
+	if r.From <= r.To {
+		unusual = hour >= r.From && hour < r.To
+	} else {
+		unusual = hour >= r.From || hour < r.To
+	}
+	if !unusual {
+		return entity.RiskSignal{}, false
+	}
+	return entity.RiskSignal{
+		Rule:   r.Name(),
+		Score:  r.Score,
+		Reason: fmt.Sprintf("made between %02d:00 and %02d:00", r.From, r.To),
+	}, true
+}
+
+// RapidTransfersRule is triggered when the account has already made
+// MaxCount transfers within the recent window of the engine.
+type RapidTransfersRule struct {
+	MaxCount int64
+	Score    int
+}
+
+func (r RapidTransfersRule) Name() string { return "rapid_transfers" }
+
+func (r RapidTransfersRule) Evaluate(in RiskInput) (entity.RiskSignal, bool) {
+	if in.History.RecentCount < r.MaxCount {
+		return entity.RiskSignal{}, false
+	}
+	return entity.RiskSignal{
+		Rule:   r.Name(),
+		Score:  r.Score,
+		Reason: fmt.Sprintf("%d transfers made recently", in.History.RecentCount),
+	}, true
+}
+
+// AverageAmountRule is triggered by amounts Factor times above the
+// average transfer of the account. Accounts with less than MinTransfers
+// transfers have no representative average and are skipped.
+type AverageAmountRule struct {
+	Factor       int64
+	MinTransfers int64
+	Score        int
+}
+
+func (r AverageAmountRule) Name() string { return "above_average" }
+
+func (r AverageAmountRule) Evaluate(in RiskInput) (entity.RiskSignal, bool) {
+	h := in.History
+	if h.TransferCount < r.MinTransfers || h.AverageAmount <= 0 || in.Transfer.Amount <= r.Factor*h.AverageAmount {
+		return entity.RiskSignal{}, false
+	}
+	return entity.RiskSignal{
+		Rule:   r.Name(),
+		Score:  r.Score,
+		Reason: fmt.Sprintf("more than %d times the average amount %d", r.Factor, h.AverageAmount),
+	}, true
+}
+
+// RiskEngine sums the scores of the triggered rules and decides whether
+// the transfer is allowed, held for review or blocked.
+type RiskEngine struct {
+	db    RiskRepo
+	rules []RiskRule
+	// window is the period of the recent transfers of the history.
+	window time.Duration
+	// reviewScore and blockScore are the lowest scores of the decisions,
+	// a zero value disables the decision.
+	reviewScore int
+	blockScore  int
+}
+
+func NewRiskEngine(r RiskRepo, window time.Duration, reviewScore, blockScore int, rules ...RiskRule) *RiskEngine {
+	return &RiskEngine{
+		db:          r,
+		rules:       rules,
+		window:      window,
+		reviewScore: reviewScore,
+		blockScore:  blockScore,
+	}
+}
+
+// Assess evaluates the rules against the transfer.
+func (e *RiskEngine) Assess(ctx context.Context, t entity.Transfer) (entity.RiskAssessment, error) {
+	now := time.Now()
+	h, err := e.db.History(ctx, t.FromAccountID, t.ToAccountID, now.Add(-e.window))
+	if err != nil {
+		return entity.RiskAssessment{}, err
+	}
+
+	return e.Evaluate(RiskInput{Transfer: t, History: h, Now: now}), nil
+}
+
+// Evaluate scores the input without loading the history.
+func (e *RiskEngine) Evaluate(in RiskInput) entity.RiskAssessment {
+	var a entity.RiskAssessment
+	for _, r := range e.rules {
+		if s, ok := r.Evaluate(in); ok {
+			a.Score += s.Score
+			a.Signals = append(a.Signals, s)
+		}
+	}
+
+	switch {
+	case e.blockScore > 0 && a.Score >= e.blockScore:
+		a.Decision = entity.RiskBlock
+	case e.reviewScore > 0 && a.Score >= e.reviewScore:
+		a.Decision = entity.RiskReview
+	default:
+		a.Decision = entity.RiskAllow
+	}
+	return a
+}
+
+// blockedError returns the ErrTransferBlocked with the triggered rules.
+func blockedError(a entity.RiskAssessment) error {
+	rules := make([]string, 0, len(a.Signals))
+	for _, s := range a.Signals {
+		rules = append(rules, s.Rule)
+	}
+	return fmt.Errorf("%w: %s", ErrTransferBlocked, strings.Join(rules, ", "))
+}
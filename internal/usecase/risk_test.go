@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskEngineEvaluate(t *testing.T) {
+	engine := NewRiskEngine(nil, 10*time.Minute, 50, 100,
+		NewPayeeRule{MinAmount: 1000, Score: 40},
+		UnusualHourRule{From: 23, To: 5, Score: 20},
+		RapidTransfersRule{MaxCount: 5, Score: 40},
+		AverageAmountRule{Factor: 10, MinTransfers: 5, Score: 30},
+	)
+	day := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2022, 10, 1, 2, 0, 0, 0, time.UTC)
+	usual := entity.RiskHistory{PayeeTransfers: 3, TransferCount: 10, AverageAmount: 500, RecentCount: 1}
+
+	tests := []struct {
+		name     string
+		in       RiskInput
+		decision entity.RiskDecision
+		rules    []string
+	}{
+		{
+			name:     "usual transfer",
+			in:       RiskInput{Transfer: entity.Transfer{Amount: 2000}, History: usual, Now: day},
+			decision: entity.RiskAllow,
+		},
+		{
+			name:     "small amount to new payee at night",
+			in:       RiskInput{Transfer: entity.Transfer{Amount: 100}, Now: night},
+			decision: entity.RiskAllow,
+			rules:    []string{"unusual_hour"},
+		},
+		{
+			name:     "large amount to new payee at night",
+			in:       RiskInput{Transfer: entity.Transfer{Amount: 1000}, Now: night},
+			decision: entity.RiskReview,
+			rules:    []string{"new_payee_large_amount", "unusual_hour"},
+		},
+		{
+			name: "rapid transfers far above average",
+			in: RiskInput{
+				Transfer: entity.Transfer{Amount: 6000},
+				History:  entity.RiskHistory{PayeeTransfers: 1, TransferCount: 10, AverageAmount: 500, RecentCount: 5},
+				Now:      day,
+			},
+			decision: entity.RiskReview,
+			rules:    []string{"rapid_transfers", "above_average"},
+		},
+		{
+			name: "everything at once",
+			in: RiskInput{
+				Transfer: entity.Transfer{Amount: 6000},
+				History:  entity.RiskHistory{TransferCount: 10, AverageAmount: 500, RecentCount: 5},
+				Now:      night,
+			},
+			decision: entity.RiskBlock,
+			rules:    []string{"new_payee_large_amount", "unusual_hour", "rapid_transfers", "above_average"},
+		},
+		{
+			name: "short history has no average",
+			in: RiskInput{
+				Transfer: entity.Transfer{Amount: 6000},
+				History:  entity.RiskHistory{PayeeTransfers: 1, TransferCount: 2, AverageAmount: 100},
+				Now:      day,
+			},
+			decision: entity.RiskAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := engine.Evaluate(tt.in)
+			assert.Equal(t, tt.decision, a.Decision)
+
+			var rules []string
+			for _, s := range a.Signals {
+				rules = append(rules, s.Rule)
+			}
+			assert.Equal(t, tt.rules, rules)
+		})
+	}
+}
+
+func TestUnusualHourRule(t *testing.T) {
+	r := UnusualHourRule{From: 1, To: 5, Score: 20}
+
+	_, ok := r.Evaluate(RiskInput{Now: time.Date(2022, 10, 1, 1, 0, 0, 0, time.UTC)})
+	assert.True(t, ok)
+	_, ok = r.Evaluate(RiskInput{Now: time.Date(2022, 10, 1, 5, 0, 0, 0, time.UTC)})
+	assert.False(t, ok)
+
+	// the hour is taken in the location of the rule
+	r.Location = time.FixedZone("UTC+3", 3*60*60)
+	_, ok = r.Evaluate(RiskInput{Now: time.Date(2022, 10, 1, 23, 0, 0, 0, time.UTC)})
+	assert.True(t, ok)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScreeningBlocked = errors.New("blocked by sanctions screening")
+	ErrAlertClosed      = errors.New("screening alert is already closed")
+)
+
+// Screener screens the owner names against the watchlist. The matches
+// at least by the flag score are saved as alerts for the compliance
+// review, the ones at least by the block score are blocked as well.
+type Screener struct {
+	db         ScreeningRepo
+	accounts   AccountRepo
+	list       Watchlist
+	flagScore  float64
+	blockScore float64
+}
+
+func NewScreener(r ScreeningRepo, ar AccountRepo, w Watchlist, flagScore, blockScore float64) *Screener {
+	return &Screener{
+		db:         r,
+		accounts:   ar,
+		list:       w,
+		flagScore:  flagScore,
+		blockScore: blockScore,
+	}
+}
+
+// Screen matches the name of the account against the watchlist. The
+// returned alert is zero if the name is clear or has been cleared by the
+// compliance officer before. The name still pending the review keeps its
+// alert. The blocked and confirmed names return ErrScreeningBlocked.
+func (s *Screener) Screen(ctx context.Context, trigger entity.ScreeningTrigger, accountID uuid.UUID,
+	name string) (entity.ScreeningAlert, error) {
+	matches := s.list.Match(name, s.flagScore)
+	if len(matches) == 0 {
+		return entity.ScreeningAlert{}, nil
+	}
+
+	reviewed, err := s.db.LastClosed(ctx, accountID, name)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return entity.ScreeningAlert{}, err
+	case reviewed.Status == entity.AlertCleared:
+		return entity.ScreeningAlert{}, nil
+	case reviewed.Status == entity.AlertConfirmed:
+		return reviewed, fmt.Errorf("%w: %q is a confirmed match, alert %d",
+			ErrScreeningBlocked, name, reviewed.ID)
+	}
+
+	decision := entity.ScreeningFlag
+	if matches[0].Score >= s.blockScore {
+		decision = entity.ScreeningBlock
+	}
+	alert, err := s.db.Create(ctx, entity.ScreeningAlert{
+		Name:      name,
+		Trigger:   trigger,
+		AccountID: accountID,
+		Score:     matches[0].Score,
+		Matches:   matches,
+		Decision:  decision,
+	})
+	if err != nil {
+		return entity.ScreeningAlert{}, err
+	}
+
+	if alert.Decision == entity.ScreeningBlock {
+		return alert, fmt.Errorf("%w: %q matches %q of %s, alert %d",
+			ErrScreeningBlocked, name, alert.Matches[0].Name, alert.Matches[0].Program, alert.ID)
+	}
+	return alert, nil
+}
+
+// ScreenTransfer screens the owner of the credited account if it isn't
+// the owner of the debited one.
+func (s *Screener) ScreenTransfer(ctx context.Context, t entity.Transfer) (entity.ScreeningAlert, error) {
+	from, err := s.accounts.Get(ctx, t.FromAccountID)
+	if err != nil {
+		return entity.ScreeningAlert{}, err
+	}
+	to, err := s.accounts.Get(ctx, t.ToAccountID)
+	if err != nil {
+		return entity.ScreeningAlert{}, err
+	}
+	if from.Owner == to.Owner {
+		return entity.ScreeningAlert{}, nil
+	}
+	return s.Screen(ctx, entity.ScreenTransfer, to.ID, to.Owner)
+}
+
+type screeningService struct {
+	db ScreeningRepo
+	l  zerologx.Logger
+}
+
+func NewScreeningService(r ScreeningRepo, l zerologx.Logger) ScreeningService {
+	return &screeningService{
+		db: r,
+		l:  l,
+	}
+}
+
+func (s *screeningService) List(ctx context.Context, status entity.AlertStatus) ([]entity.ScreeningAlert, error) {
+	if status == "" {
+		status = entity.AlertPending
+	}
+	switch status {
+	case entity.AlertPending, entity.AlertCleared, entity.AlertConfirmed:
+	default:
+		return nil, fmt.Errorf("%w: unknown alert status %q", ErrInvalidArgument, status)
+	}
+	return s.db.List(ctx, status)
+}
+
+func (s *screeningService) Get(ctx context.Context, id int64) (entity.ScreeningAlert, error) {
+	return s.db.Get(ctx, id)
+}
+
+// Clear closes the alert as a false positive, the name of the account
+// isn't screened anymore.
+func (s *screeningService) Clear(ctx context.Context, officer string, id int64, comment string) (entity.ScreeningAlert, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.ScreeningAlert{}, err
+	}
+	return s.db.Close(ctx, id, entity.AlertCleared, officer, comment)
+}
+
+// Confirm closes the alert as a true match.
+func (s *screeningService) Confirm(ctx context.Context, officer string, id int64, comment string) (entity.ScreeningAlert, error) {
+	if err := checkComment(comment); err != nil {
+		return entity.ScreeningAlert{}, err
+	}
+	return s.db.Close(ctx, id, entity.AlertConfirmed, officer, comment)
+}
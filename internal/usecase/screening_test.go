@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubWatchlist map[string]float64
+
+func (w stubWatchlist) Match(name string, min float64) []entity.ScreeningMatch {
+	if score, ok := w[name]; ok && score >= min {
+		return []entity.ScreeningMatch{{EntryID: "101", Name: name, Program: "SDGT", Score: score}}
+	}
+	return nil
+}
+
+type stubScreeningRepo struct {
+	ScreeningRepo
+	alerts   []entity.ScreeningAlert
+	reviewed map[string]entity.AlertStatus
+}
+
+func (r *stubScreeningRepo) Create(_ context.Context, a entity.ScreeningAlert) (entity.ScreeningAlert, error) {
+	for _, v := range r.alerts {
+		if v.AccountID == a.AccountID && v.Name == a.Name && v.Status == entity.AlertPending {
+			return v, nil
+		}
+	}
+	a.ID = int64(len(r.alerts) + 1)
+	a.Status = entity.AlertPending
+	r.alerts = append(r.alerts, a)
+	return a, nil
+}
+
+func (r *stubScreeningRepo) LastClosed(_ context.Context, _ uuid.UUID, name string) (entity.ScreeningAlert, error) {
+	status, ok := r.reviewed[name]
+	if !ok {
+		return entity.ScreeningAlert{}, ErrNotFound
+	}
+	return entity.ScreeningAlert{ID: 100, Name: name, Status: status}, nil
+}
+
+func TestScreenerScreen(t *testing.T) {
+	repo := &stubScreeningRepo{reviewed: map[string]entity.AlertStatus{
+		"cleared":   entity.AlertCleared,
+		"confirmed": entity.AlertConfirmed,
+	}}
+	screener := NewScreener(repo, nil, stubWatchlist{
+		"exact":     1,
+		"similar":   0.9,
+		"distant":   0.7,
+		"cleared":   1,
+		"confirmed": 0.9,
+	}, 0.85, 0.97)
+
+	tests := []struct {
+		name     string
+		decision entity.ScreeningDecision
+		blocked  bool
+	}{
+		{name: "nobody"},
+		{name: "distant"},
+		{name: "cleared"},
+		{name: "confirmed", blocked: true},
+		{name: "similar", decision: entity.ScreeningFlag},
+		{name: "exact", decision: entity.ScreeningBlock, blocked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, err := screener.Screen(context.Background(), entity.ScreenAccountOpening, uuid.New(), tt.name)
+			if tt.blocked {
+				require.ErrorIs(t, err, ErrScreeningBlocked)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.decision, alert.Decision)
+		})
+	}
+	assert.Len(t, repo.alerts, 2)
+}
+
+func TestScreenerScreenPending(t *testing.T) {
+	repo := &stubScreeningRepo{}
+	screener := NewScreener(repo, nil, stubWatchlist{"similar": 0.9}, 0.85, 0.97)
+	accountID := uuid.New()
+
+	first, err := screener.Screen(context.Background(), entity.ScreenAccountOpening, accountID, "similar")
+	require.NoError(t, err)
+	second, err := screener.Screen(context.Background(), entity.ScreenTransfer, accountID, "similar")
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+	assert.Len(t, repo.alerts, 1)
+}
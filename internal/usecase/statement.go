@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+type statementService struct {
+	db    StatementRepo
+	authz *Authorizer
+	l     zerologx.Logger
+}
+
+func NewStatementService(r StatementRepo, authz *Authorizer, l zerologx.Logger) StatementService {
+	return &statementService{
+		db:    r,
+		authz: authz,
+		l:     l,
+	}
+}
+
+// Get returns the statement of the account for the period [from, to) to
+// its holder.
+func (s *statementService) Get(ctx context.Context, holder string, accountID uuid.UUID, from, to time.Time) (entity.Statement, error) {
+	if !from.Before(to) {
+		return entity.Statement{}, fmt.Errorf("%w: statement period is empty", ErrInvalidArgument)
+	}
+
+	st, err := s.db.Get(ctx, accountID, from, to)
+	if err != nil {
+		return entity.Statement{}, err
+	}
+	err = s.authz.Authorize(ctx, holder, entity.Account{ID: accountID, Owner: st.Owner}, entity.PermissionView)
+	if err != nil {
+		return entity.Statement{}, err
+	}
+
+	balance := st.OpeningBalance
+	for i := range st.Lines {
+		line := &st.Lines[i]
+
+		balance += line.Amount
+		line.Balance = balance
+		if line.Amount < 0 {
+			st.TotalDebits += -line.Amount
+		} else {
+			st.TotalCredits += line.Amount
+		}
+		if line.Description == "" {
+			line.Description = describe(*line)
+		}
+	}
+	st.ClosingBalance = balance
+	st.GeneratedAt = time.Now().UTC()
+
+	return st, nil
+}
+
+// describe returns the narrative of the statement line.
+func describe(line entity.StatementLine) string {
+	switch {
+	case line.Counterparty == nil && line.Amount < 0:
+		return "Withdrawal"
+	case line.Counterparty == nil:
+		return "Deposit"
+	case line.Amount < 0:
+		return fmt.Sprintf("Transfer to %s", line.Counterparty)
+	default:
+		return fmt.Sprintf("Transfer from %s", line.Counterparty)
+	}
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/pubsub"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+)
+
+type streamService struct {
+	accounts AccountRepo
+	entries  EntryRepo
+	broker   *pubsub.Broker
+	authz    *Authorizer
+	// resumeWindow is the period before the last event whose entries
+	// are replayed again to a reconnecting subscriber.
+	resumeWindow time.Duration
+	l            zerologx.Logger
+}
+
+func NewStreamService(ar AccountRepo, er EntryRepo, b *pubsub.Broker, authz *Authorizer,
+	resumeWindow time.Duration, l zerologx.Logger) StreamService {
+	return &streamService{
+		accounts:     ar,
+		entries:      er,
+		broker:       b,
+		authz:        authz,
+		resumeWindow: resumeWindow,
+		l:            l,
+	}
+}
+
+// Publish sends the events to the subscribers of their accounts.
+func (s *streamService) Publish(events ...entity.AccountEvent) {
+	for _, e := range events {
+		s.broker.Publish(e.AccountID.String(), e)
+	}
+}
+
+// Subscribe streams the events of the account viewed by the holder. Entries
+// posted after lastEventID are replayed first, followed by the current
+// balance and then live events. The entries ids don't follow the commit
+// order, so the entries of the resume window before lastEventID are
+// replayed again and the subscriber skips the ids it already has. The
+// zero lastEventID is a new subscriber, nothing is replayed to it. The
+// channel is closed when ctx is done or the subscriber falls behind.
+func (s *streamService) Subscribe(ctx context.Context, holder string, accountID uuid.UUID, lastEventID int64) (<-chan entity.AccountEvent, error) {
+	account, err := s.accounts.Get(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.authz.Authorize(ctx, holder, account, entity.PermissionView); err != nil {
+		return nil, err
+	}
+
+	// subscribe before the replay so that no posting falls in between
+	live, cancel := s.broker.Subscribe(accountID.String())
+
+	var missed []entity.Entry
+	if lastEventID > 0 {
+		if missed, err = s.entries.ListAfter(ctx, accountID, lastEventID, s.resumeWindow); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if account, err = s.accounts.Get(ctx, accountID); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan entity.AccountEvent)
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		send := func(e entity.AccountEvent) bool {
+			select {
+			case out <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// replayed are the ids of the replayed entries, the live events
+		// of the entries committed before the replay are skipped
+		replayed := make(map[int64]bool, len(missed))
+		for _, e := range missed {
+			if !send(entity.NewEntryEvent(e)) {
+				return
+			}
+			replayed[e.ID] = true
+		}
+		if !send(entity.NewBalanceEvent(account)) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-live:
+				if !ok {
+					s.l.Warn("stream - subscriber of account %s fell behind", accountID)
+					return
+				}
+
+				e := msg.(entity.AccountEvent)
+				if e.Type == entity.EventEntry && replayed[e.ID] {
+					continue
+				}
+				if !send(e) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/pubsub"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubStreamEntries struct {
+	EntryRepo
+	entries []entity.Entry
+	after   []int64
+}
+
+func (r *stubStreamEntries) ListAfter(_ context.Context, _ uuid.UUID, afterID int64, _ time.Duration) ([]entity.Entry, error) {
+	r.after = append(r.after, afterID)
+	return r.entries, nil
+}
+
+func TestStreamSubscribe(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	account := entity.Account{ID: uuid.New(), Owner: "owner", Balance: 100, Currency: entity.CurrencyRUB}
+	accounts := &stubClearingAccounts{accounts: map[uuid.UUID]entity.Account{account.ID: account}}
+	entries := &stubStreamEntries{entries: []entity.Entry{{ID: 7, AccountID: account.ID, Amount: 100}}}
+	s := NewStreamService(accounts, entries, pubsub.New(8), nil, time.Minute, &logger)
+
+	_, err := s.Subscribe(context.Background(), "stranger", account.ID, 0)
+	require.ErrorIs(t, err, ErrAccessDenied)
+
+	// the new subscriber starts from the current balance
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := s.Subscribe(ctx, "owner", account.ID, 0)
+	require.NoError(t, err)
+	e := <-events
+	assert.Equal(t, entity.EventBalance, e.Type)
+	assert.Empty(t, entries.after)
+	cancel()
+
+	// the reconnecting one gets the missed entries first
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	events, err = s.Subscribe(ctx, "owner", account.ID, 5)
+	require.NoError(t, err)
+	e = <-events
+	assert.Equal(t, entity.EventEntry, e.Type)
+	assert.Equal(t, int64(7), e.ID)
+	e = <-events
+	assert.Equal(t, entity.EventBalance, e.Type)
+	assert.Equal(t, []int64{5}, entries.after)
+}
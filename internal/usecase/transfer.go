@@ -3,35 +3,373 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"alukart32.com/bank/entity"
 	"alukart32.com/bank/pkg/zerologx"
 )
 
+// Limits of the transfer details. The description and reference fit
+// the ISO 20022 unstructured remittance information and end to end id.
+const (
+	maxDescriptionLength   = 140
+	maxReferenceLength     = 35
+	maxMetadataEntries     = 10
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 256
+)
+
+// Page sizes of the transfer lists.
+const (
+	defaultTransferLimit = 50
+	maxTransferLimit     = 500
+)
+
+// ErrInvalidTransition is returned when the transfer status can't be
+// changed to the requested one.
+var ErrInvalidTransition = errors.New("invalid transfer status transition")
+
 type transferService struct {
-	db TransferRepo
-	l  zerologx.Logger
+	db     TransferRepo
+	events EventPublisher
+	// screener screens the recipients of the transfers to the other
+	// owners. A nil screener disables the screening.
+	screener *Screener
+	// risk evaluates transfers before the execution, the held ones are
+	// saved to reviews. A nil engine allows all transfers.
+	risk    *RiskEngine
+	reviews TransferReviewRepo
+	// approvals holds the transfers above the threshold of the account
+	// approval policy until approvalTTL expires.
+	approvals   ApprovalRepo
+	approvalTTL time.Duration
+	// fees calculates the fees charged with the transfer. A nil engine
+	// charges nothing.
+	fees *FeeEngine
+	// authz checks the initiator may transfer from the account. A nil
+	// authorizer skips the check.
+	authz *Authorizer
+	audit *Auditor
+	l     zerologx.Logger
 }
 
-func NewTransferService(r TransferRepo, l zerologx.Logger) TransferService {
+func NewTransferService(r TransferRepo, p EventPublisher, screener *Screener, risk *RiskEngine,
+	reviews TransferReviewRepo, approvals ApprovalRepo, approvalTTL time.Duration, fees *FeeEngine,
+	authz *Authorizer, audit *Auditor, l zerologx.Logger) TransferService {
 	return &transferService{
-		db: r,
-		l:  l,
+		db:          r,
+		events:      p,
+		screener:    screener,
+		risk:        risk,
+		reviews:     reviews,
+		approvals:   approvals,
+		approvalTTL: approvalTTL,
+		fees:        fees,
+		authz:       authz,
+		audit:       audit,
+		l:           l,
+	}
+}
+
+func (s *transferService) Transfer(ctx context.Context, initiator string, t entity.Transfer) (entity.TransferRes, error) {
+	if t.Amount <= 0 {
+		return entity.TransferRes{}, fmt.Errorf("%w: amount must be positive", ErrInvalidArgument)
+	}
+	if t.FromAccountID == t.ToAccountID {
+		return entity.TransferRes{}, fmt.Errorf("%w: transfer to the same account", ErrInvalidArgument)
+	}
+	if err := checkDetails(t); err != nil {
+		return entity.TransferRes{}, err
+	}
+	if err := s.authorize(ctx, initiator, t); err != nil {
+		return entity.TransferRes{}, err
+	}
+	if err := s.screen(ctx, t); err != nil {
+		return entity.TransferRes{}, err
+	}
+	if err := s.requireApproval(ctx, initiator, t); err != nil {
+		return entity.TransferRes{}, err
+	}
+	if err := s.assess(ctx, t); err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	fees, err := s.fees.Charges(ctx, t)
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	var res entity.TransferRes
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if res, err = s.db.Create(ctx, t, fees); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditTransferCreate, entity.AuditTargetTransfer,
+			strconv.FormatInt(res.Transfer.ID, 10), nil, res)
+	})
+	if err != nil {
+		s.fail(ctx, t, err)
+		return entity.TransferRes{}, err
+	}
+
+	publishTransfer(s.events, res)
+	return res, nil
+}
+
+// fail saves the transfer refused on the execution as failed with the
+// reason of the error. The other errors leave no transfer.
+func (s *transferService) fail(ctx context.Context, t entity.Transfer, cause error) {
+	var reason string
+	switch {
+	case errors.Is(cause, ErrInsufficientFunds):
+		reason = entity.FailureInsufficientFunds
+	case errors.Is(cause, ErrLimitExceeded):
+		reason = entity.FailureLimitExceeded
+	default:
+		return
+	}
+
+	err := s.audit.Do(ctx, func(ctx context.Context) error {
+		failed, err := s.db.Fail(ctx, t, reason)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditTransferFail, entity.AuditTargetTransfer,
+			strconv.FormatInt(failed.ID, 10), nil, failed)
+	})
+	if err != nil {
+		s.l.Error(fmt.Errorf("failed transfer from %s: %w", t.FromAccountID, err), "usecase - transfer - fail")
+	}
+}
+
+// authorize returns ErrAccessDenied unless the initiator holds the
+// account of the transfer in a role allowing the transfers.
+func (s *transferService) authorize(ctx context.Context, initiator string, t entity.Transfer) error {
+	if s.authz == nil {
+		return nil
+	}
+	return s.authz.Authorize(ctx, initiator, entity.Account{ID: t.FromAccountID}, entity.PermissionTransfer)
+}
+
+// screen returns ErrScreeningBlocked for the blocked recipient. The
+// transfer to the flagged one is held for review with the screening
+// signal.
+func (s *transferService) screen(ctx context.Context, t entity.Transfer) error {
+	if s.screener == nil {
+		return nil
+	}
+
+	alert, err := s.screener.ScreenTransfer(ctx, t)
+	if err != nil || alert.Decision != entity.ScreeningFlag || s.reviews == nil {
+		return err
+	}
+
+	match := alert.Matches[0]
+	review, err := s.reviews.Create(ctx, entity.TransferReview{
+		Transfer: t,
+		Score:    int(alert.Score * 100),
+		Signals: entity.RiskSignals{{
+			Rule:   "sanctions_screening",
+			Score:  int(alert.Score * 100),
+			Reason: fmt.Sprintf("recipient matches %q of %s, alert %d", match.Name, match.Program, alert.ID),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return &ReviewError{Review: review}
+}
+
+// requireApproval returns a *ApprovalError if the transfer needs the
+// approval of a second person.
+func (s *transferService) requireApproval(ctx context.Context, initiator string, t entity.Transfer) error {
+	if s.approvals == nil {
+		return nil
+	}
+
+	p, err := s.approvals.GetPolicy(ctx, t.FromAccountID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if t.Amount < p.Threshold {
+		return nil
 	}
+
+	approval, err := s.approvals.Create(ctx, entity.TransferApproval{
+		Transfer:    t,
+		InitiatedBy: initiator,
+		ExpiresAt:   time.Now().Add(s.approvalTTL),
+	})
+	if err != nil {
+		return err
+	}
+	return &ApprovalError{Approval: approval}
 }
 
-func (s *transferService) Transfer(ctx context.Context, t entity.Transfer) (entity.TransferRes, error) {
-	return entity.TransferRes{}, errors.New("not implemented yet")
+// assess returns ErrTransferBlocked for the blocked transfer and a
+// *ReviewError for the one held for review.
+func (s *transferService) assess(ctx context.Context, t entity.Transfer) error {
+	if s.risk == nil {
+		return nil
+	}
+
+	a, err := s.risk.Assess(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	switch a.Decision {
+	case entity.RiskBlock:
+		return blockedError(a)
+	case entity.RiskReview:
+		review, err := s.reviews.Create(ctx, entity.TransferReview{
+			Transfer: t,
+			Score:    a.Score,
+			Signals:  a.Signals,
+		})
+		if err != nil {
+			return err
+		}
+		return &ReviewError{Review: review}
+	}
+	return nil
 }
 
 func (s *transferService) Get(ctx context.Context, id int64) (entity.Transfer, error) {
-	return entity.Transfer{}, errors.New("not implemented yet")
+	return s.db.Get(ctx, id)
 }
 
+// List returns the page of the transfers with the status, the default
+// page if the limit is zero.
 func (s *transferService) List(ctx context.Context, params ListTransferParams) ([]entity.Transfer, error) {
-	return nil, errors.New("not implemented yet")
+	switch {
+	case params.Limit < 0 || params.Limit > maxTransferLimit:
+		return nil, fmt.Errorf("%w: limit must be from 1 to %d", ErrInvalidArgument, maxTransferLimit)
+	case params.Offset < 0:
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidArgument)
+	case params.Limit == 0:
+		params.Limit = defaultTransferLimit
+	}
+	if err := checkStatusFilter(params.Status); err != nil {
+		return nil, err
+	}
+	return s.db.List(ctx, params)
+}
+
+// ListByReference returns the transfers of the owner's accounts with
+// the reference and status.
+func (s *transferService) ListByReference(ctx context.Context, owner, reference string,
+	status entity.TransferStatus) ([]entity.Transfer, error) {
+	if reference == "" || len(reference) > maxReferenceLength || !isReference(reference) {
+		return nil, fmt.Errorf("%w: invalid reference", ErrInvalidArgument)
+	}
+	if err := checkStatusFilter(status); err != nil {
+		return nil, err
+	}
+	return s.db.ListByReference(ctx, owner, reference, status)
+}
+
+func (s *transferService) Transitions(ctx context.Context, id int64) ([]entity.TransferTransition, error) {
+	return s.db.Transitions(ctx, id)
+}
+
+// Rollback reverses the completed transfer with the compensating entries.
+// The fees charged with the transfer aren't refunded.
+func (s *transferService) Rollback(ctx context.Context, id int64) (entity.TransferRes, error) {
+	before, err := s.db.Get(ctx, id)
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+	var res entity.TransferRes
+	err = s.audit.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if res, err = s.db.Rollback(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, entity.AuditTransferRollback, entity.AuditTargetTransfer,
+			strconv.FormatInt(id, 10), before, res)
+	})
+	if err != nil {
+		return entity.TransferRes{}, err
+	}
+
+	publishTransfer(s.events, res)
+	return res, nil
+}
+
+func checkStatusFilter(status entity.TransferStatus) error {
+	if status != "" && !status.Valid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidArgument, status)
+	}
+	return nil
+}
+
+func publishTransfer(p EventPublisher, res entity.TransferRes) {
+	p.Publish(
+		entity.NewEntryEvent(res.FromEntry),
+		entity.NewBalanceEvent(res.FromAccount),
+		entity.NewEntryEvent(res.ToEntry),
+		entity.NewBalanceEvent(res.ToAccount),
+	)
+	for _, f := range res.Fees {
+		p.Publish(entity.NewEntryEvent(f.Entry))
+	}
+}
+
+// checkDetails validates the description, reference and metadata of the
+// transfer.
+func checkDetails(t entity.Transfer) error {
+	if utf8.RuneCountInString(t.Description) > maxDescriptionLength || !isPrintable(t.Description) {
+		return fmt.Errorf("%w: description must have at most %d printable characters",
+			ErrInvalidArgument, maxDescriptionLength)
+	}
+
+	if len(t.Reference) > maxReferenceLength || !isReference(t.Reference) {
+		return fmt.Errorf("%w: reference must have at most %d latin letters, digits or /-?:().,'+ "+
+			"and must not start or end with / or contain //", ErrInvalidArgument, maxReferenceLength)
+	}
+
+	if len(t.Metadata) > maxMetadataEntries {
+		return fmt.Errorf("%w: metadata must have at most %d entries", ErrInvalidArgument, maxMetadataEntries)
+	}
+	for k, v := range t.Metadata {
+		if k == "" || len(k) > maxMetadataKeyLength || strings.IndexFunc(k, isNotKeyRune) >= 0 {
+			return fmt.Errorf("%w: metadata key %q must have 1 to %d latin letters, digits or _.-",
+				ErrInvalidArgument, k, maxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(v) > maxMetadataValueLength || !isPrintable(v) {
+			return fmt.Errorf("%w: metadata value of %q must have at most %d printable characters",
+				ErrInvalidArgument, k, maxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+func isPrintable(s string) bool {
+	return utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsPrint(r) }) < 0
+}
+
+// isReference reports whether s is a valid reference in the SWIFT X
+// character set without spaces.
+func isReference(s string) bool {
+	const allowed = "/-?:().,'+"
+
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && !strings.ContainsRune(allowed, r) {
+			return false
+		}
+	}
+	return !strings.HasPrefix(s, "/") && !strings.HasSuffix(s, "/") && !strings.Contains(s, "//")
 }
 
-func (s *transferService) Rollback(ctx context.Context, id int64) error {
-	return errors.New("not implemented yet")
+func isNotKeyRune(r rune) bool {
+	return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' && r != '.' && r != '-'
 }
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTransferRepo struct {
+	TransferRepo
+	err    error
+	failed []entity.Transfer
+}
+
+func (r *stubTransferRepo) Create(_ context.Context, _ entity.Transfer, _ []entity.FeeCharge) (entity.TransferRes, error) {
+	return entity.TransferRes{}, r.err
+}
+
+func (r *stubTransferRepo) Fail(_ context.Context, t entity.Transfer, reason string) (entity.Transfer, error) {
+	t.ID = int64(len(r.failed) + 1)
+	t.Status = entity.TransferFailed
+	t.FailureReason = reason
+	r.failed = append(r.failed, t)
+	return t, nil
+}
+
+func TestTransferFailed(t *testing.T) {
+	logger := zerologx.New("error", io.Discard)
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{name: "insufficient funds", err: ErrInsufficientFunds, reason: entity.FailureInsufficientFunds},
+		{name: "limit exceeded", err: &LimitError{Limit: "daily", Max: 1000}, reason: entity.FailureLimitExceeded},
+		{name: "technical", err: errors.New("connection reset")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubTransferRepo{err: tt.err}
+			audits := &stubAuditRepo{}
+			service := NewTransferService(repo, &stubPublisher{}, nil, nil, nil, nil, 0, nil, nil,
+				NewAuditor(audits, stubTransactor{}), &logger)
+
+			_, err := service.Transfer(context.Background(), "owner", entity.Transfer{
+				FromAccountID: uuid.New(),
+				ToAccountID:   uuid.New(),
+				Amount:        100,
+			})
+			require.ErrorIs(t, err, tt.err)
+
+			if tt.reason == "" {
+				assert.Empty(t, repo.failed)
+				assert.Empty(t, audits.records)
+				return
+			}
+			require.Len(t, repo.failed, 1)
+			assert.Equal(t, tt.reason, repo.failed[0].FailureReason)
+			require.Len(t, audits.records, 1)
+			assert.Equal(t, entity.AuditTransferFail, audits.records[0].Action)
+		})
+	}
+}
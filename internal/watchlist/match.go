@@ -0,0 +1,201 @@
+package watchlist
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// cyrillic transliterates the Cyrillic letters by the ICAO 9303 table
+// used in the Russian passports.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "ie",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+	'і': "i", 'ї': "i", 'є': "ie", 'ґ': "g", 'ў': "u",
+}
+
+// latin folds the Latin letters with diacritics common in the lists.
+var latin = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a",
+	'ç': "c", 'ć': "c", 'č': "c", 'đ': "d", 'è': "e", 'é': "e", 'ê': "e",
+	'ë': "e", 'ē': "e", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'ł': "l", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'ø': "o", 'ō': "o", 'š': "s", 'ß': "ss", 'ù': "u", 'ú': "u", 'û': "u",
+	'ü': "u", 'ū': "u", 'ý': "y", 'ž': "z",
+}
+
+// Normalize splits the name into lower case Latin tokens sorted
+// alphabetically. Cyrillic is transliterated, diacritics are dropped and
+// all other characters separate the tokens, so "ПУТИН, Владимир" and
+// "Vladimir Putin" become the same tokens.
+func Normalize(name string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r)
+		case cyrillic[r] != "" || r == 'ь':
+			b.WriteString(cyrillic[r])
+		case latin[r] != "":
+			b.WriteString(latin[r])
+		case r == '\'' || r == '’' || unicode.Is(unicode.Mn, r):
+			// apostrophes and combining marks don't split the names
+		default:
+			b.WriteByte(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	sort.Strings(tokens)
+	return tokens
+}
+
+// Similarity compares the normalized names from 0 to 1. Each token of
+// the shorter name is matched to the most similar token of the longer
+// one, so the order of the names doesn't matter and a missing middle
+// name or patronymic lowers the score only a little.
+func Similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	used := make([]bool, len(b))
+	var sum, weight float64
+	for _, s := range a {
+		best, bestIdx := 0.0, -1
+		for i, t := range b {
+			if used[i] {
+				continue
+			}
+			if v := tokenSimilarity(s, t); v > best {
+				best, bestIdx = v, i
+			}
+		}
+		if bestIdx >= 0 {
+			used[bestIdx] = true
+		}
+		sum += best * float64(len(s))
+		weight += float64(len(s))
+	}
+	score := sum / weight
+
+	// a single name matches too many people
+	switch missing := len(b) - len(a); {
+	case missing == 0:
+	case len(a) == 1:
+		score *= 0.75
+	default:
+		score *= 1 - 0.05*float64(missing)
+	}
+	return score
+}
+
+// tokenSimilarity averages the Jaro-Winkler similarity, tolerant to the
+// spelling variants, and the edit distance ratio, that keeps a name from
+// matching the longer names it is the prefix of.
+func tokenSimilarity(s, t string) float64 {
+	if s == t {
+		return 1
+	}
+	a, b := []rune(s), []rune(t)
+	ratio := 1 - float64(levenshtein(a, b))/float64(max(len(a), len(b)))
+	return (jaroWinkler(s, t) + ratio) / 2
+}
+
+// levenshtein returns the number of the single rune edits between a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range a {
+		cur[0] = i + 1
+		for j := range b {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+			cur[j+1] = min(min(prev[j+1]+1, cur[j]+1), prev[j]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of the strings, it
+// favors the strings with the same prefix.
+func jaroWinkler(s, t string) float64 {
+	if s == t {
+		return 1
+	}
+	a, b := []rune(s), []rune(t)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+
+	var matches int
+	for i := range a {
+		from, to := max(0, i-window), min(len(b), i+window+1)
+		for j := from; j < to; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	var transpositions, j int
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions/2))/m) / 3
+
+	var prefix int
+	for prefix < min(4, min(len(a), len(b))) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,77 @@
+package watchlist
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"alukart32.com/bank/entity"
+)
+
+// Columns of the OFAC SDN.CSV file, it has no header.
+const (
+	sdnEntNum = iota
+	sdnName
+	sdnType
+	sdnProgram
+	sdnRemarks = 11
+)
+
+// sdnNull is the empty value of the SDN file.
+const sdnNull = "-0-"
+
+// sdnAlias finds the aliases in the remarks: a.k.a. 'NAME';
+var sdnAlias = regexp.MustCompile(`a\.k\.a\. '([^']+)'`)
+
+// ParseSDN reads the OFAC Specially Designated Nationals list in the
+// legacy SDN.CSV format. The aliases are taken from the remarks.
+func ParseSDN(r io.Reader) ([]entity.WatchlistEntry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var entries []entity.WatchlistEntry
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		// the file ends with the EOF control character
+		if len(record) == 1 && strings.Trim(record[0], "\x1a \r\n") == "" {
+			continue
+		}
+		if len(record) <= sdnProgram {
+			return nil, fmt.Errorf("%w: line %d has %d fields", ErrMalformed, line, len(record))
+		}
+
+		e := entity.WatchlistEntry{
+			ID:      sdnValue(record[sdnEntNum]),
+			Name:    sdnValue(record[sdnName]),
+			Program: sdnValue(record[sdnProgram]),
+		}
+		if e.ID == "" || e.Name == "" {
+			return nil, fmt.Errorf("%w: line %d has no id or name", ErrMalformed, line)
+		}
+		if len(record) > sdnRemarks {
+			for _, m := range sdnAlias.FindAllStringSubmatch(record[sdnRemarks], -1) {
+				e.Aliases = append(e.Aliases, m[1])
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func sdnValue(s string) string {
+	s = strings.TrimSpace(s)
+	if s == sdnNull {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,95 @@
+package watchlist
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"alukart32.com/bank/entity"
+)
+
+type (
+	unList struct {
+		XMLName     xml.Name       `xml:"CONSOLIDATED_LIST"`
+		Individuals []unIndividual `xml:"INDIVIDUALS>INDIVIDUAL"`
+		Entities    []unEntity     `xml:"ENTITIES>ENTITY"`
+	}
+
+	unIndividual struct {
+		unEntry
+		Aliases []unAlias `xml:"INDIVIDUAL_ALIAS"`
+	}
+
+	unEntity struct {
+		unEntry
+		Aliases []unAlias `xml:"ENTITY_ALIAS"`
+	}
+
+	unEntry struct {
+		DataID          string `xml:"DATAID"`
+		ReferenceNumber string `xml:"REFERENCE_NUMBER"`
+		ListType        string `xml:"UN_LIST_TYPE"`
+		FirstName       string `xml:"FIRST_NAME"`
+		SecondName      string `xml:"SECOND_NAME"`
+		ThirdName       string `xml:"THIRD_NAME"`
+		FourthName      string `xml:"FOURTH_NAME"`
+		OriginalScript  string `xml:"NAME_ORIGINAL_SCRIPT"`
+	}
+
+	unAlias struct {
+		Name string `xml:"ALIAS_NAME"`
+	}
+)
+
+// ParseUN reads the United Nations Security Council consolidated list.
+// The name in the original script is kept as an alias.
+func ParseUN(r io.Reader) ([]entity.WatchlistEntry, error) {
+	var list unList
+	if err := xml.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	entries := make([]entity.WatchlistEntry, 0, len(list.Individuals)+len(list.Entities))
+	for _, v := range list.Individuals {
+		e, err := v.entry(v.Aliases)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	for _, v := range list.Entities {
+		e, err := v.entry(v.Aliases)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (u unEntry) entry(aliases []unAlias) (entity.WatchlistEntry, error) {
+	e := entity.WatchlistEntry{
+		ID:      strings.TrimSpace(u.ReferenceNumber),
+		Program: strings.TrimSpace(u.ListType),
+	}
+	if e.ID == "" {
+		e.ID = strings.TrimSpace(u.DataID)
+	}
+	e.Name = strings.Join(strings.Fields(strings.Join([]string{
+		u.FirstName, u.SecondName, u.ThirdName, u.FourthName,
+	}, " ")), " ")
+	if e.ID == "" || e.Name == "" {
+		return entity.WatchlistEntry{}, fmt.Errorf("%w: entry %q has no id or name", ErrMalformed, u.DataID)
+	}
+
+	if v := strings.TrimSpace(u.OriginalScript); v != "" {
+		e.Aliases = append(e.Aliases, v)
+	}
+	for _, a := range aliases {
+		if v := strings.TrimSpace(a.Name); v != "" {
+			e.Aliases = append(e.Aliases, v)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,120 @@
+// Package watchlist implements the sanctions screening of names: the
+// watchlists in the OFAC SDN CSV and the UN consolidated list XML formats
+// and the fuzzy matching of the names against them.
+package watchlist
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"alukart32.com/bank/entity"
+)
+
+var (
+	ErrMalformed         = errors.New("malformed watchlist")
+	ErrUnsupportedFormat = errors.New("unsupported watchlist format")
+)
+
+// List matches names against the watchlist entries. It is safe for
+// concurrent use.
+type List struct {
+	entries []entity.WatchlistEntry
+	// names holds the normalized name and aliases of each entry.
+	names [][]name
+}
+
+type name struct {
+	original string
+	tokens   []string
+}
+
+func New(entries []entity.WatchlistEntry) *List {
+	l := &List{
+		entries: entries,
+		names:   make([][]name, len(entries)),
+	}
+	for i, e := range entries {
+		for _, v := range append([]string{e.Name}, e.Aliases...) {
+			if tokens := Normalize(v); len(tokens) > 0 {
+				l.names[i] = append(l.names[i], name{original: v, tokens: tokens})
+			}
+		}
+	}
+	return l
+}
+
+// Load reads the watchlist files, the format is chosen by the extension:
+// .csv for the OFAC SDN list and .xml for the UN consolidated list.
+func Load(paths ...string) (*List, error) {
+	var entries []entity.WatchlistEntry
+	for _, path := range paths {
+		v, err := loadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		entries = append(entries, v...)
+	}
+	return New(entries), nil
+}
+
+func loadFile(path string) ([]entity.WatchlistEntry, error) {
+	var parse func(io.Reader) ([]entity.WatchlistEntry, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		parse = ParseSDN
+	case ".xml":
+		parse = ParseUN
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parse(f)
+}
+
+// Len returns the number of the watchlist entries.
+func (l *List) Len() int {
+	return len(l.entries)
+}
+
+// Match returns the entries with a name or alias similar to the name at
+// least by the min score, the most similar first.
+func (l *List) Match(name string, min float64) []entity.ScreeningMatch {
+	tokens := Normalize(name)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var matches []entity.ScreeningMatch
+	for i, names := range l.names {
+		best := entity.ScreeningMatch{}
+		for _, n := range names {
+			if score := Similarity(tokens, n.tokens); score > best.Score {
+				best.Name, best.Score = n.original, score
+			}
+		}
+		best.Score = math.Round(best.Score*1000) / 1000
+		if best.Score < min || best.Score == 0 {
+			continue
+		}
+
+		best.EntryID, best.Program = l.entries[i].ID, l.entries[i].Program
+		matches = append(matches, best)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
@@ -0,0 +1,129 @@
+package watchlist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alukart32.com/bank/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSDN(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "sdn.csv"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	entries, err := ParseSDN(f)
+	require.NoError(t, err)
+
+	expected := []entity.WatchlistEntry{
+		{
+			ID:      "101",
+			Name:    "IVANOV, Sergei Petrovich",
+			Aliases: []string{"IVANOFF, Sergey", "IVANOV, Serhiy"},
+			Program: "RUSSIA-EO14024",
+		},
+		{ID: "102", Name: "NORTHWIND TRADING LLC", Program: "SDGT"},
+		{ID: "103", Name: "AL-RASHID, Omar", Program: "SDGT] [IRGC"},
+	}
+	assert.Equal(t, expected, entries)
+}
+
+func TestParseUN(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "consolidated.xml"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	entries, err := ParseUN(f)
+	require.NoError(t, err)
+
+	expected := []entity.WatchlistEntry{
+		{
+			ID:      "KPi.901",
+			Name:    "DMITRY ALEKSANDROVICH SOKOLOV",
+			Aliases: []string{"Дмитрий Александрович Соколов", "Dmitri Sokoloff"},
+			Program: "DPRK",
+		},
+		{
+			ID:      "KPe.902",
+			Name:    "BLUE HARBOR SHIPPING CO.",
+			Aliases: []string{"Blue Harbour Shipping"},
+			Program: "DPRK",
+		},
+	}
+	assert.Equal(t, expected, entries)
+}
+
+func TestParseMalformed(t *testing.T) {
+	_, err := ParseSDN(strings.NewReader("101,\"IVANOV\n"))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = ParseSDN(strings.NewReader("101,-0- ,individual,SDGT\n"))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = ParseUN(strings.NewReader("<CONSOLIDATED_LIST><INDIVIDUALS>"))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = Load(filepath.Join("testdata", "list.json"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{"Vladimir Putin", []string{"putin", "vladimir"}},
+		{"ПУТИН, Владимир", []string{"putin", "vladimir"}},
+		{"Щукин Юрий Ёжиков", []string{"ezhikov", "iurii", "shchukin"}},
+		{"José  Núñez-García", []string{"garcia", "jose", "nunez"}},
+		{"O'Brien", []string{"obrien"}},
+		{" ,. ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Normalize(tt.name)
+			if len(tt.expected) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	l, err := Load(filepath.Join("testdata", "sdn.csv"), filepath.Join("testdata", "consolidated.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, l.Len())
+
+	tests := []struct {
+		name    string
+		entryID string
+		// minScore is the lowest expected score of the match
+		minScore float64
+	}{
+		{"Sergei Petrovich Ivanov", "101", 1},
+		{"Иванов Сергей Петрович", "101", 0.9},
+		{"Sergey Ivanoff", "101", 1},
+		{"Соколов Дмитрий Александрович", "KPi.901", 1},
+		{"Dmitriy Sokolov", "KPi.901", 0.85},
+		{"Northwind Trading", "102", 0.9},
+		{"Omar Al Rashid", "103", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := l.Match(tt.name, 0.8)
+			require.NotEmpty(t, matches)
+			assert.Equal(t, tt.entryID, matches[0].EntryID)
+			assert.GreaterOrEqual(t, matches[0].Score, tt.minScore)
+		})
+	}
+
+	for _, name := range []string{"Anna Smirnova", "Sergei", "Ivan Petrov", ""} {
+		assert.Empty(t, l.Match(name, 0.85), name)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS payment_imports;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE "payment_imports" (
+  "owner" varchar NOT NULL,
+  "msg_id" varchar(35) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("owner", "msg_id")
+);
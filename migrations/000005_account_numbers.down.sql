@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "number";
+
+DROP SEQUENCE IF EXISTS "account_number_seq";
@@ -0,0 +1,3 @@
+CREATE SEQUENCE "account_number_seq";
+
+ALTER TABLE "accounts" ADD COLUMN "number" varchar(34) UNIQUE;
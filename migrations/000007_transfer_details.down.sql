@@ -0,0 +1,9 @@
+ALTER TABLE "entries"
+  DROP COLUMN IF EXISTS "metadata",
+  DROP COLUMN IF EXISTS "reference",
+  DROP COLUMN IF EXISTS "description";
+
+ALTER TABLE "transfers"
+  DROP COLUMN IF EXISTS "metadata",
+  DROP COLUMN IF EXISTS "reference",
+  DROP COLUMN IF EXISTS "description";
@@ -0,0 +1,11 @@
+ALTER TABLE "transfers"
+  ADD COLUMN "description" varchar(140) NOT NULL DEFAULT '',
+  ADD COLUMN "reference" varchar(35) NOT NULL DEFAULT '',
+  ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+ALTER TABLE "entries"
+  ADD COLUMN "description" varchar(140) NOT NULL DEFAULT '',
+  ADD COLUMN "reference" varchar(35) NOT NULL DEFAULT '',
+  ADD COLUMN "metadata" jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX ON "transfers" ("reference") WHERE "reference" <> '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS transfers_from_account_id_created_at_idx;
+DROP TABLE IF EXISTS account_limits;
+DROP TABLE IF EXISTS limit_tiers;
@@ -0,0 +1,28 @@
+CREATE TABLE "limit_tiers" (
+  "name" varchar(32) PRIMARY KEY,
+  -- a zero limit is not enforced
+  "max_single" bigint NOT NULL DEFAULT 0,
+  "daily" bigint NOT NULL DEFAULT 0,
+  "monthly" bigint NOT NULL DEFAULT 0,
+  "hourly_count" bigint NOT NULL DEFAULT 0
+);
+
+INSERT INTO "limit_tiers" ("name", "max_single", "daily", "monthly", "hourly_count") VALUES
+  ('standard', 10000000, 30000000, 300000000, 20),
+  ('premium', 100000000, 300000000, 3000000000, 100);
+
+CREATE TABLE "account_limits" (
+  "account_id" uuid PRIMARY KEY,
+  "tier" varchar(32) NOT NULL DEFAULT 'standard',
+  -- overrides of the tier limits, null means the tier limit
+  "max_single" bigint,
+  "daily" bigint,
+  "monthly" bigint,
+  "hourly_count" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "account_limits_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+  CONSTRAINT "account_limits_tier_fk" FOREIGN KEY ("tier") REFERENCES "limit_tiers" ("name")
+);
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transfers_from_account_id_to_account_id_idx;
+DROP TABLE IF EXISTS transfer_reviews;
@@ -0,0 +1,29 @@
+CREATE TABLE "transfer_reviews" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" uuid NOT NULL,
+  "to_account_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  "description" varchar(140) NOT NULL DEFAULT '',
+  "reference" varchar(35) NOT NULL DEFAULT '',
+  "metadata" jsonb NOT NULL DEFAULT '{}',
+  "score" integer NOT NULL,
+  -- the triggered risk rules
+  "signals" jsonb NOT NULL DEFAULT '[]',
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  -- the transfer executed on approval
+  "transfer_id" bigint,
+  "reviewed_by" varchar NOT NULL DEFAULT '',
+  "comment" varchar(280) NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_reviews" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "transfer_reviews" ("status", "id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "to_account_id");
@@ -0,0 +1,37 @@
+CREATE TABLE "approval_policies" (
+  "account_id" uuid PRIMARY KEY,
+  -- the lowest amount of the transfers that need an approval
+  "threshold" bigint NOT NULL,
+  -- the users allowed to approve the transfers of others
+  "approvers" varchar[] NOT NULL,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "approval_policies_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "transfer_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" uuid NOT NULL,
+  "to_account_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  "description" varchar(140) NOT NULL DEFAULT '',
+  "reference" varchar(35) NOT NULL DEFAULT '',
+  "metadata" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar(16) NOT NULL DEFAULT 'pending_approval',
+  "initiated_by" varchar NOT NULL,
+  "decided_by" varchar NOT NULL DEFAULT '',
+  "comment" varchar(280) NOT NULL DEFAULT '',
+  -- the transfer executed on approval
+  "transfer_id" bigint,
+  "decided_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_approvals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "transfer_approvals" ("status", "expires_at");
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS transfer_transitions;
+DROP INDEX IF EXISTS transfers_status_idx;
+ALTER TABLE "transfers"
+  DROP CONSTRAINT IF EXISTS "transfers_status_check",
+  DROP COLUMN IF EXISTS "status",
+  DROP COLUMN IF EXISTS "failure_reason",
+  DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,27 @@
+ALTER TABLE "transfers"
+  ADD COLUMN "status" varchar(16) NOT NULL DEFAULT 'completed',
+  ADD COLUMN "failure_reason" varchar(32) NOT NULL DEFAULT '',
+  ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN
+    ('pending', 'processing', 'completed', 'failed', 'reversed', 'cancelled'));
+
+UPDATE "transfers" SET "updated_at" = "created_at";
+
+CREATE INDEX ON "transfers" ("status");
+
+CREATE TABLE "transfer_transitions" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  -- empty for the initial status
+  "from_status" varchar(16) NOT NULL DEFAULT '',
+  "to_status" varchar(16) NOT NULL,
+  "reason" varchar(32) NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfer_transitions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "transfer_transitions" ("transfer_id");
+
+INSERT INTO "transfer_transitions" ("transfer_id", "to_status", "created_at")
+SELECT "id", 'completed', "created_at" FROM "transfers";
@@ -0,0 +1,20 @@
+CREATE TABLE "screening_alerts" (
+  "id" bigserial PRIMARY KEY,
+  -- the screened owner name
+  "name" varchar NOT NULL,
+  "trigger" varchar(16) NOT NULL,
+  -- the opened or updated account, or the transfer recipient
+  "account_id" uuid NOT NULL,
+  "score" double precision NOT NULL,
+  "matches" jsonb NOT NULL DEFAULT '[]',
+  "decision" varchar(8) NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar NOT NULL DEFAULT '',
+  "comment" varchar(280) NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "screening_alerts" ("status", "id");
+
+CREATE INDEX ON "screening_alerts" ("account_id", "name");
@@ -0,0 +1,29 @@
+CREATE TABLE "audit_log" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "request_id" varchar(64) NOT NULL DEFAULT '',
+  "client_ip" varchar(45) NOT NULL DEFAULT '',
+  "action" varchar(32) NOT NULL,
+  "target_type" varchar(16) NOT NULL,
+  "target_id" varchar NOT NULL,
+  -- json keeps the snapshots as they are hashed, jsonb reformats them
+  "before" json NOT NULL DEFAULT 'null',
+  "after" json NOT NULL DEFAULT 'null',
+  "prev_hash" char(64) NOT NULL,
+  "hash" char(64) NOT NULL UNIQUE,
+  "created_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "audit_log" ("target_type", "target_id", "id");
+
+CREATE INDEX ON "audit_log" ("actor", "id");
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "audit_log"
+FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
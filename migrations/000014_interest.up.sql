@@ -0,0 +1,53 @@
+CREATE TABLE "interest_products" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar(70) NOT NULL UNIQUE,
+  "currency" currency NOT NULL,
+  -- the annual rate in basis points
+  "annual_rate_bp" integer NOT NULL,
+  "day_count" varchar(8) NOT NULL,
+  -- the bank account the interest is paid from
+  "expense_account_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_products_rate" CHECK (annual_rate_bp BETWEEN 0 AND 10000),
+  CONSTRAINT "interest_products_day_count" CHECK (day_count IN ('ACT/365', '30/360')),
+  CONSTRAINT "interest_products_expense_account_fk" FOREIGN KEY ("expense_account_id") REFERENCES "accounts" ("id")
+);
+
+CREATE TABLE "account_products" (
+  "account_id" uuid PRIMARY KEY,
+  "product_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "account_products_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+  CONSTRAINT "account_products_product_fk" FOREIGN KEY ("product_id") REFERENCES "interest_products" ("id")
+);
+
+CREATE TABLE "interest_accruals" (
+  "account_id" uuid NOT NULL,
+  "accrual_date" date NOT NULL,
+  "product_id" bigint NOT NULL,
+  -- the end-of-day balance and the terms it accrued by
+  "balance" bigint NOT NULL,
+  "annual_rate_bp" integer NOT NULL,
+  "day_count" varchar(8) NOT NULL,
+  "days" bigint NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "accrual_date"),
+  CONSTRAINT "interest_accruals_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "interest_postings" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" uuid NOT NULL,
+  -- the first day of the posted month
+  "period" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carry_micros" bigint NOT NULL,
+  -- null if nothing is posted
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "interest_postings_period" UNIQUE ("account_id", "period"),
+  CONSTRAINT "interest_postings_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE,
+  CONSTRAINT "interest_postings_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id")
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS fee_charges;
+DROP TABLE IF EXISTS fee_schedules;
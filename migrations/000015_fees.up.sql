@@ -0,0 +1,49 @@
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar(70) NOT NULL,
+  "kind" varchar(16) NOT NULL,
+  "applies_to" varchar(16) NOT NULL,
+  -- null applies to the accounts without a schedule of their product
+  "product_id" bigint,
+  "currency" currency NOT NULL,
+  "fixed" bigint NOT NULL DEFAULT 0,
+  "rate_bp" integer NOT NULL DEFAULT 0,
+  -- the bounds of the percentage and tiered fees, a zero max is not enforced
+  "min_amount" bigint NOT NULL DEFAULT 0,
+  "max_amount" bigint NOT NULL DEFAULT 0,
+  "tiers" jsonb NOT NULL DEFAULT '[]',
+  -- the bank account the fee is paid to
+  "revenue_account_id" uuid NOT NULL,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "fee_schedules_kind" CHECK (kind IN ('fixed', 'percentage', 'tiered')),
+  CONSTRAINT "fee_schedules_applies_to" CHECK (applies_to IN ('transfer_own', 'transfer_p2p', 'maintenance')),
+  CONSTRAINT "fee_schedules_maintenance_product" CHECK (applies_to <> 'maintenance' OR product_id IS NOT NULL),
+  CONSTRAINT "fee_schedules_product_fk" FOREIGN KEY ("product_id") REFERENCES "interest_products" ("id"),
+  CONSTRAINT "fee_schedules_revenue_account_fk" FOREIGN KEY ("revenue_account_id") REFERENCES "accounts" ("id")
+);
+
+CREATE INDEX ON "fee_schedules" ("applies_to", "currency") WHERE active;
+
+CREATE TABLE "fee_charges" (
+  "id" bigserial PRIMARY KEY,
+  "schedule_id" bigint NOT NULL,
+  "account_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  -- the charged transfer or the first day of the charged maintenance month
+  "transfer_id" bigint,
+  "period" date,
+  "debit_entry_id" bigint NOT NULL UNIQUE,
+  "credit_entry_id" bigint NOT NULL UNIQUE,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "fee_charges_period" UNIQUE ("schedule_id", "account_id", "period"),
+  CONSTRAINT "fee_charges_schedule_fk" FOREIGN KEY ("schedule_id") REFERENCES "fee_schedules" ("id"),
+  CONSTRAINT "fee_charges_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"),
+  CONSTRAINT "fee_charges_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id"),
+  CONSTRAINT "fee_charges_debit_entry_fk" FOREIGN KEY ("debit_entry_id") REFERENCES "entries" ("id"),
+  CONSTRAINT "fee_charges_credit_entry_fk" FOREIGN KEY ("credit_entry_id") REFERENCES "entries" ("id")
+);
+
+CREATE INDEX ON "fee_charges" ("account_id", "id");
+
+CREATE INDEX ON "fee_charges" ("transfer_id");
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS gl_accounts;
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS positive_balance;
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS accounts_kind;
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE "accounts" ADD CONSTRAINT positive_balance CHECK (balance >= 0);
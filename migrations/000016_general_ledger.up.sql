@@ -0,0 +1,36 @@
+-- the ledger accounts are owned by the bank, they go negative on the
+-- debits of the assets and expenses
+ALTER TABLE "accounts" ADD COLUMN "kind" varchar(16) NOT NULL DEFAULT 'customer';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_kind" CHECK (kind IN ('customer', 'ledger'));
+
+ALTER TABLE "accounts" DROP CONSTRAINT positive_balance;
+
+ALTER TABLE "accounts" ADD CONSTRAINT positive_balance CHECK (balance >= 0 OR kind = 'ledger');
+
+CREATE TABLE "gl_accounts" (
+  "account_id" uuid PRIMARY KEY,
+  "code" varchar(10) NOT NULL,
+  "name" varchar(70) NOT NULL,
+  "type" varchar(16) NOT NULL,
+  "currency" currency NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "gl_accounts_code" UNIQUE ("code", "currency"),
+  CONSTRAINT "gl_accounts_type" CHECK (type IN ('asset', 'liability', 'equity', 'income', 'expense'))
+);
+
+-- the default chart of accounts in each currency
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT gen_random_uuid(), C.code, C.name, C.type, U.currency
+FROM (VALUES
+  ('1000', 'Cash', 'asset'),
+  ('1900', 'Suspense', 'asset'),
+  ('4000', 'Fee income', 'income'),
+  ('5000', 'Interest expense', 'expense')
+) AS C (code, name, type)
+CROSS JOIN unnest(enum_range(NULL::currency)) AS U (currency);
+
+INSERT INTO accounts (id, owner, currency, kind)
+SELECT account_id, '', currency, 'ledger' FROM gl_accounts;
+
+ALTER TABLE "gl_accounts" ADD CONSTRAINT "gl_accounts_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cash_operations;
+DROP SEQUENCE IF EXISTS cash_reference_seq;
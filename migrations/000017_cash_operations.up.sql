@@ -0,0 +1,41 @@
+CREATE SEQUENCE "cash_reference_seq";
+
+CREATE TABLE "cash_operations" (
+  "id" bigserial PRIMARY KEY,
+  -- the unique reference printed on the receipt
+  "reference" varchar(35) NOT NULL UNIQUE,
+  "direction" varchar(16) NOT NULL,
+  "channel" varchar(16) NOT NULL,
+  "teller_id" varchar(70) NOT NULL DEFAULT '',
+  "account_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" currency NOT NULL,
+  -- the account balance after the operation
+  "balance" bigint NOT NULL,
+  "entry_id" bigint NOT NULL UNIQUE,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "cash_operations_direction" CHECK (direction IN ('deposit', 'withdrawal')),
+  CONSTRAINT "cash_operations_channel" CHECK (channel IN ('teller', 'atm', 'card_topup')),
+  CONSTRAINT "cash_operations_amount" CHECK (amount > 0),
+  CONSTRAINT "cash_operations_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"),
+  CONSTRAINT "cash_operations_entry_fk" FOREIGN KEY ("entry_id") REFERENCES "entries" ("id")
+);
+
+CREATE INDEX ON "cash_operations" ("account_id", "channel", "direction", "created_at");
+
+-- the GL accounts of the ATM and card channels, the teller cash stays
+-- in the vault cash account
+WITH chart AS (
+  SELECT gen_random_uuid() AS account_id, C.code, C.name, C.type, U.currency
+  FROM (VALUES
+    ('1010', 'ATM cash', 'asset'),
+    ('1200', 'Card settlement', 'asset')
+  ) AS C (code, name, type)
+  CROSS JOIN unnest(enum_range(NULL::currency)) AS U (currency)
+), ledger AS (
+  INSERT INTO accounts (id, owner, currency, kind)
+  SELECT account_id, '', currency, 'ledger' FROM chart
+)
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT account_id, code, name, type, currency FROM chart;
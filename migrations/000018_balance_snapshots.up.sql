@@ -0,0 +1,11 @@
+CREATE TABLE "balance_snapshots" (
+  "account_id" uuid NOT NULL,
+  -- the end of the UTC day, the balance holds the entries made before it
+  "as_of" timestamptz NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "as_of"),
+  CONSTRAINT "balance_snapshots_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "entries" ("account_id", "created_at");
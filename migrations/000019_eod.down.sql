@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS daily_statements;
+DROP TABLE IF EXISTS eod_steps;
+DROP TABLE IF EXISTS eod_runs;
+DROP TABLE IF EXISTS business_date;
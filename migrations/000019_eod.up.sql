@@ -0,0 +1,50 @@
+-- the only row holds the open business date, it is frozen while the EOD
+-- run of the date is in progress
+CREATE TABLE "business_date" (
+  "singleton" boolean PRIMARY KEY DEFAULT true,
+  "date" date NOT NULL,
+  "frozen" boolean NOT NULL DEFAULT false,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "business_date_singleton" CHECK (singleton)
+);
+
+INSERT INTO "business_date" ("date") VALUES ((now() AT TIME ZONE 'UTC')::date);
+
+CREATE TABLE "eod_runs" (
+  "id" bigserial PRIMARY KEY,
+  "business_date" date NOT NULL UNIQUE,
+  "status" varchar(16) NOT NULL DEFAULT 'running',
+  "error" varchar NOT NULL DEFAULT '',
+  "started_by" varchar NOT NULL,
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "finished_at" timestamptz,
+  CONSTRAINT "eod_runs_status" CHECK (status IN ('running', 'completed', 'failed'))
+);
+
+CREATE TABLE "eod_steps" (
+  "run_id" bigint NOT NULL,
+  "step" varchar(32) NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'running',
+  -- the batch run of the step
+  "result" jsonb NOT NULL DEFAULT '{}',
+  "error" varchar NOT NULL DEFAULT '',
+  "started_at" timestamptz NOT NULL DEFAULT (now()),
+  "finished_at" timestamptz,
+  PRIMARY KEY ("run_id", "step"),
+  CONSTRAINT "eod_steps_status" CHECK (status IN ('running', 'completed', 'failed')),
+  CONSTRAINT "eod_steps_run_fk" FOREIGN KEY ("run_id") REFERENCES "eod_runs" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE "daily_statements" (
+  "account_id" uuid NOT NULL,
+  "statement_date" date NOT NULL,
+  "opening_balance" bigint NOT NULL,
+  "closing_balance" bigint NOT NULL,
+  -- the totals of the debit and credit entries of the date
+  "debits" bigint NOT NULL,
+  "credits" bigint NOT NULL,
+  "entries" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "statement_date"),
+  CONSTRAINT "daily_statements_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS external_transfers;
+DROP TABLE IF EXISTS clearing_batches;
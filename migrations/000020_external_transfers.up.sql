@@ -0,0 +1,55 @@
+CREATE TABLE "clearing_batches" (
+  "id" bigserial PRIMARY KEY,
+  "file_name" varchar(64) NOT NULL UNIQUE,
+  "currency" currency NOT NULL,
+  "count" bigint NOT NULL,
+  "total" bigint NOT NULL,
+  -- created until the file is delivered, processed with its response
+  "status" varchar(16) NOT NULL DEFAULT 'created',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "clearing_batches_status" CHECK (status IN ('created', 'sent', 'processed'))
+);
+
+CREATE TABLE "external_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" uuid NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" currency NOT NULL,
+  "beneficiary_name" varchar(70) NOT NULL,
+  "beneficiary_account" varchar(34) NOT NULL,
+  "beneficiary_bank" varchar(11) NOT NULL,
+  "description" varchar(140) NOT NULL DEFAULT '',
+  "reference" varchar(35) NOT NULL DEFAULT '',
+  "status" varchar(16) NOT NULL DEFAULT 'pending',
+  "batch_id" bigint,
+  "return_reason" varchar(4) NOT NULL DEFAULT '',
+  -- the debit of the customer account
+  "entry_id" bigint NOT NULL UNIQUE,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "external_transfers_amount" CHECK (amount > 0),
+  CONSTRAINT "external_transfers_status" CHECK (status IN ('pending', 'sent', 'settled', 'returned')),
+  CONSTRAINT "external_transfers_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"),
+  CONSTRAINT "external_transfers_batch_fk" FOREIGN KEY ("batch_id") REFERENCES "clearing_batches" ("id"),
+  CONSTRAINT "external_transfers_entry_fk" FOREIGN KEY ("entry_id") REFERENCES "entries" ("id")
+);
+
+CREATE INDEX ON "external_transfers" ("account_id");
+
+CREATE INDEX ON "external_transfers" ("status");
+
+CREATE INDEX ON "external_transfers" ("batch_id");
+
+-- the account of the bank at the clearing house, the suspense account
+-- holds the transfers until they are settled or returned
+WITH chart AS (
+  SELECT gen_random_uuid() AS account_id, '1100' AS code, 'Clearing house' AS name, 'asset' AS type, U.currency
+  FROM unnest(enum_range(NULL::currency)) AS U (currency)
+), ledger AS (
+  INSERT INTO accounts (id, owner, currency, kind)
+  SELECT account_id, '', currency, 'ledger' FROM chart
+)
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT account_id, code, name, type, currency FROM chart;
@@ -0,0 +1,59 @@
+CREATE TABLE "loans" (
+  "id" bigserial PRIMARY KEY,
+  -- the account the loan is disbursed to and repaid from
+  "account_id" uuid NOT NULL,
+  "principal" bigint NOT NULL,
+  "currency" currency NOT NULL,
+  -- the annual rates in basis points
+  "annual_rate_bp" integer NOT NULL,
+  "penalty_rate_bp" integer NOT NULL,
+  "term_months" integer NOT NULL,
+  "method" varchar(16) NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'active',
+  -- the principal not repaid yet
+  "outstanding" bigint NOT NULL,
+  "disbursed_on" date NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "loans_principal" CHECK (principal > 0),
+  CONSTRAINT "loans_outstanding" CHECK (outstanding BETWEEN 0 AND principal),
+  CONSTRAINT "loans_rates" CHECK (annual_rate_bp BETWEEN 0 AND 10000 AND penalty_rate_bp BETWEEN 0 AND 10000),
+  CONSTRAINT "loans_method" CHECK (method IN ('annuity', 'linear')),
+  CONSTRAINT "loans_status" CHECK (status IN ('active', 'repaid')),
+  CONSTRAINT "loans_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id")
+);
+
+CREATE INDEX ON "loans" ("account_id");
+
+CREATE TABLE "loan_installments" (
+  "loan_id" bigint NOT NULL,
+  "number" integer NOT NULL,
+  "due_date" date NOT NULL,
+  "principal" bigint NOT NULL,
+  "interest" bigint NOT NULL,
+  -- the penalty interest paid with the late installment
+  "penalty" bigint NOT NULL DEFAULT 0,
+  "status" varchar(16) NOT NULL DEFAULT 'scheduled',
+  "paid_on" date,
+  PRIMARY KEY ("loan_id", "number"),
+  CONSTRAINT "loan_installments_status" CHECK (status IN ('scheduled', 'late', 'paid')),
+  CONSTRAINT "loan_installments_loan_fk" FOREIGN KEY ("loan_id") REFERENCES "loans" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "loan_installments" ("due_date") WHERE status <> 'paid';
+
+-- the principal lent to the customers and the interest earned on it
+WITH chart AS (
+  SELECT gen_random_uuid() AS account_id, C.code, C.name, C.type, U.currency
+  FROM (VALUES
+    ('1300', 'Loans', 'asset'),
+    ('4100', 'Interest income', 'income')
+  ) AS C (code, name, type)
+  CROSS JOIN unnest(enum_range(NULL::currency)) AS U (currency)
+), ledger AS (
+  INSERT INTO accounts (id, owner, currency, kind)
+  SELECT account_id, '', currency, 'ledger' FROM chart
+)
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT account_id, code, name, type, currency FROM chart;
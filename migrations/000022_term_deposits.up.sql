@@ -0,0 +1,48 @@
+CREATE TABLE "term_deposits" (
+  "id" bigserial PRIMARY KEY,
+  -- the account the principal is taken from
+  "account_id" uuid NOT NULL,
+  -- the account the deposit is paid out to
+  "payout_account_id" uuid NOT NULL,
+  "principal" bigint NOT NULL,
+  "currency" currency NOT NULL,
+  -- the annual rate in basis points
+  "annual_rate_bp" integer NOT NULL,
+  "term_months" integer NOT NULL,
+  -- the dates of the current term
+  "start_date" date NOT NULL,
+  "maturity_date" date NOT NULL,
+  "maturity_action" varchar(16) NOT NULL,
+  "early_withdrawal" varchar(16) NOT NULL,
+  -- taken off the annual rate of the early withdrawal
+  "early_penalty_bp" integer NOT NULL DEFAULT 0,
+  "status" varchar(16) NOT NULL DEFAULT 'active',
+  "rollovers" integer NOT NULL DEFAULT 0,
+  -- the interest paid out or added to the principal
+  "interest_paid" bigint NOT NULL DEFAULT 0,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "term_deposits_principal" CHECK (principal > 0),
+  CONSTRAINT "term_deposits_rates" CHECK (annual_rate_bp BETWEEN 0 AND 10000 AND early_penalty_bp BETWEEN 0 AND 10000),
+  CONSTRAINT "term_deposits_maturity_action" CHECK (maturity_action IN ('payout', 'rollover')),
+  CONSTRAINT "term_deposits_early_withdrawal" CHECK (early_withdrawal IN ('blocked', 'penalty')),
+  CONSTRAINT "term_deposits_status" CHECK (status IN ('active', 'matured', 'withdrawn')),
+  CONSTRAINT "term_deposits_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id"),
+  CONSTRAINT "term_deposits_payout_account_fk" FOREIGN KEY ("payout_account_id") REFERENCES "accounts" ("id")
+);
+
+CREATE INDEX ON "term_deposits" ("account_id");
+
+CREATE INDEX ON "term_deposits" ("maturity_date") WHERE status = 'active';
+
+-- the principal of the term deposits owed to the customers
+WITH chart AS (
+  SELECT gen_random_uuid() AS account_id, '2100' AS code, 'Term deposits' AS name, 'liability' AS type, U.currency
+  FROM unnest(enum_range(NULL::currency)) AS U (currency)
+), ledger AS (
+  INSERT INTO accounts (id, owner, currency, kind)
+  SELECT account_id, '', currency, 'ledger' FROM chart
+)
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT account_id, code, name, type, currency FROM chart;
@@ -0,0 +1,23 @@
+CREATE TABLE "account_holders" (
+  "account_id" uuid NOT NULL,
+  "holder" varchar NOT NULL,
+  "role" varchar(16) NOT NULL,
+  "status" varchar(16) NOT NULL DEFAULT 'invited',
+  -- the holder who invited this one, empty for the primary holder
+  "invited_by" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "accepted_at" timestamptz,
+  PRIMARY KEY ("account_id", "holder"),
+  CONSTRAINT "account_holders_role" CHECK (role IN ('primary', 'joint', 'signatory', 'viewer')),
+  CONSTRAINT "account_holders_status" CHECK (status IN ('invited', 'active')),
+  CONSTRAINT "account_holders_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX ON "account_holders" ("holder");
+
+-- the owner of the account is its only primary holder
+CREATE UNIQUE INDEX "account_holders_primary" ON "account_holders" ("account_id") WHERE role = 'primary';
+
+INSERT INTO account_holders (account_id, holder, role, status, accepted_at)
+SELECT id, owner, 'primary', 'active', created_at FROM accounts
+WHERE kind = 'customer' AND owner <> '';
@@ -0,0 +1,5 @@
+ALTER TABLE "transfers"
+  DROP CONSTRAINT IF EXISTS "transfers_status_check",
+  ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN
+    ('pending', 'processing', 'completed', 'failed', 'reversed', 'cancelled')),
+  ADD COLUMN "failure_reason" varchar(32) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- The transfers are executed at once, so they are only completed or
+-- reversed. Nothing could move a transfer to the other statuses.
+ALTER TABLE "transfers"
+  DROP CONSTRAINT "transfers_status_check",
+  ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN ('completed', 'reversed')),
+  DROP COLUMN "failure_reason";
@@ -0,0 +1,16 @@
+ALTER TABLE "fee_charges"
+  DROP COLUMN "external_transfer_id";
+
+ALTER TABLE "fee_schedules"
+  DROP CONSTRAINT "fee_schedules_applies_to",
+  ADD CONSTRAINT "fee_schedules_applies_to" CHECK (applies_to IN ('transfer_own', 'transfer_p2p', 'maintenance'));
+
+ALTER TABLE "external_transfers"
+  DROP CONSTRAINT "external_transfers_hold",
+  DROP COLUMN "comment",
+  DROP COLUMN "reviewed_by",
+  DROP COLUMN "signals",
+  DROP COLUMN "score",
+  DROP COLUMN "hold",
+  DROP CONSTRAINT "external_transfers_status",
+  ADD CONSTRAINT "external_transfers_status" CHECK (status IN ('pending', 'sent', 'settled', 'returned'));
@@ -0,0 +1,30 @@
+-- The external transfers pass the limits, the risk rules, the screening
+-- and the approval policy of the account before they are submitted. The
+-- held transfers keep the amount in the suspense account until they are
+-- released to the next batch or rejected and refunded.
+ALTER TABLE "external_transfers"
+  DROP CONSTRAINT "external_transfers_status",
+  ADD CONSTRAINT "external_transfers_status" CHECK (status IN
+    ('held', 'pending', 'sent', 'settled', 'returned', 'rejected')),
+  -- approval waits for an approver of the account, review for an operator
+  ADD COLUMN "hold" varchar(16) NOT NULL DEFAULT '',
+  ADD COLUMN "score" integer NOT NULL DEFAULT 0,
+  -- the triggered risk rules
+  ADD COLUMN "signals" jsonb NOT NULL DEFAULT '[]',
+  ADD COLUMN "reviewed_by" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "comment" varchar(280) NOT NULL DEFAULT '',
+  ADD CONSTRAINT "external_transfers_hold" CHECK (hold IN ('', 'approval', 'review'));
+
+CREATE INDEX ON "external_transfers" ("hold") WHERE status = 'held';
+
+ALTER TABLE "fee_schedules"
+  DROP CONSTRAINT "fee_schedules_applies_to",
+  ADD CONSTRAINT "fee_schedules_applies_to" CHECK (applies_to IN
+    ('transfer_own', 'transfer_p2p', 'external', 'maintenance'));
+
+ALTER TABLE "fee_charges"
+  ADD COLUMN "external_transfer_id" bigint,
+  ADD CONSTRAINT "fee_charges_external_transfer_fk" FOREIGN KEY ("external_transfer_id")
+    REFERENCES "external_transfers" ("id");
+
+CREATE INDEX ON "fee_charges" ("external_transfer_id");
@@ -0,0 +1 @@
+ALTER TABLE "audit_log" ALTER COLUMN "target_type" TYPE varchar(16);
@@ -0,0 +1,2 @@
+-- "external_transfer" doesn't fit the target type of 16 characters
+ALTER TABLE "audit_log" ALTER COLUMN "target_type" TYPE varchar(32);
@@ -0,0 +1,7 @@
+DELETE FROM "transfers" WHERE "status" NOT IN ('completed', 'reversed');
+ALTER TABLE "transfers"
+  DROP CONSTRAINT "transfers_status_check",
+  ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN ('completed', 'reversed')),
+  DROP COLUMN "failure_reason",
+  ALTER COLUMN "from_entry_id" SET NOT NULL,
+  ALTER COLUMN "to_entry_id" SET NOT NULL;
@@ -0,0 +1,10 @@
+-- The held transfers are saved as pending until the review or the
+-- approval is closed. The failed and cancelled transfers keep the reason
+-- and have no entries.
+ALTER TABLE "transfers"
+  DROP CONSTRAINT "transfers_status_check",
+  ADD CONSTRAINT "transfers_status_check" CHECK ("status" IN
+    ('pending', 'processing', 'completed', 'failed', 'reversed', 'cancelled')),
+  ADD COLUMN "failure_reason" varchar(32) NOT NULL DEFAULT '',
+  ALTER COLUMN "from_entry_id" DROP NOT NULL,
+  ALTER COLUMN "to_entry_id" DROP NOT NULL;
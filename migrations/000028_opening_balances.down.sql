@@ -0,0 +1,7 @@
+DELETE FROM entries
+WHERE account_id IN (SELECT account_id FROM gl_accounts WHERE code = '3000');
+
+WITH opening AS (
+  DELETE FROM gl_accounts WHERE code = '3000' RETURNING account_id
+)
+DELETE FROM accounts WHERE id IN (SELECT account_id FROM opening);
@@ -0,0 +1,28 @@
+-- the opening balances account of each currency
+WITH chart AS (
+  SELECT gen_random_uuid() AS account_id, U.currency
+  FROM unnest(enum_range(NULL::currency)) AS U (currency)
+), ledger AS (
+  INSERT INTO accounts (id, owner, currency, kind)
+  SELECT account_id, '', currency, 'ledger' FROM chart
+)
+INSERT INTO gl_accounts (account_id, code, name, type, currency)
+SELECT account_id, '3000', 'Opening balances', 'equity', currency FROM chart;
+
+-- the customer balances made before the ledger have no counter entries,
+-- the opening balances account takes over what is off in each currency
+INSERT INTO entries (account_id, amount, description)
+SELECT G.account_id, -B.balance, 'Opening balance'
+FROM gl_accounts AS G
+JOIN (
+  SELECT currency, sum(balance)::bigint AS balance
+  FROM accounts
+  GROUP BY currency
+) AS B ON B.currency = G.currency
+WHERE G.code = '3000' AND B.balance <> 0;
+
+UPDATE accounts AS A
+SET balance = E.amount
+FROM entries AS E
+JOIN gl_accounts AS G ON G.account_id = E.account_id
+WHERE A.id = E.account_id AND G.code = '3000';
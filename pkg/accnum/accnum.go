@@ -0,0 +1,163 @@
+// Package accnum implements human-readable account numbers in the IBAN
+// layout: country code, two check digits and the basic account number,
+// where the check digits are computed with the ISO 7064 MOD 97-10 scheme.
+// It also checks the identifiers of the other banks: the BICs and the US
+// routing numbers.
+package accnum
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	minLength = 5
+	maxLength = 34
+)
+
+var (
+	ErrFormat   = errors.New("account number has invalid format")
+	ErrChecksum = errors.New("account number has invalid check digits")
+)
+
+// Format describes the basic account number: a fixed bank code followed
+// by a zero padded sequence number.
+type Format struct {
+	CountryCode    string
+	BankCode       string
+	SequenceLength int
+}
+
+// Generator builds and validates the account numbers of a format.
+type Generator struct {
+	format Format
+	length int
+}
+
+func New(f Format) (*Generator, error) {
+	f.CountryCode = strings.ToUpper(f.CountryCode)
+	f.BankCode = strings.ToUpper(f.BankCode)
+
+	if len(f.CountryCode) != 2 || !isLetters(f.CountryCode) {
+		return nil, fmt.Errorf("%w: country code %q", ErrFormat, f.CountryCode)
+	}
+	if !isAlnum(f.BankCode) || f.SequenceLength <= 0 {
+		return nil, fmt.Errorf("%w: bank code %q, sequence length %d", ErrFormat, f.BankCode, f.SequenceLength)
+	}
+
+	length := 4 + len(f.BankCode) + f.SequenceLength
+	if length > maxLength {
+		return nil, fmt.Errorf("%w: length %d exceeds %d", ErrFormat, length, maxLength)
+	}
+
+	return &Generator{format: f, length: length}, nil
+}
+
+// Generate returns the account number of the sequence number.
+func (g *Generator) Generate(seq int64) (string, error) {
+	bban := fmt.Sprintf("%s%0*d", g.format.BankCode, g.format.SequenceLength, seq)
+	if seq < 0 || len(bban) != len(g.format.BankCode)+g.format.SequenceLength {
+		return "", fmt.Errorf("%w: sequence %d overflows", ErrFormat, seq)
+	}
+
+	return g.format.CountryCode + checkDigits(g.format.CountryCode, bban) + bban, nil
+}
+
+// Validate checks that the number belongs to the format and has valid
+// check digits. It expects a normalized number.
+func (g *Generator) Validate(number string) error {
+	if err := Validate(number); err != nil {
+		return err
+	}
+	if len(number) != g.length ||
+		!strings.HasPrefix(number, g.format.CountryCode) ||
+		!strings.HasPrefix(number[4:], g.format.BankCode) {
+		return ErrFormat
+	}
+	return nil
+}
+
+// Validate checks the layout and check digits of any IBAN-like number.
+func Validate(number string) error {
+	if len(number) < minLength || len(number) > maxLength ||
+		!isLetters(number[:2]) || !isDigits(number[2:4]) || !isAlnum(number[4:]) {
+		return ErrFormat
+	}
+	if checkDigits(number[:2], number[4:]) != number[2:4] {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Normalize removes the spaces people use to group the digits and
+// upper-cases the letters.
+func Normalize(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// IsBIC reports whether s is a business identifier code: 4 letters of
+// the bank, 2 letters of the country, 2 letters or digits of the location
+// and an optional branch of 3 letters or digits.
+func IsBIC(s string) bool {
+	return (len(s) == 8 || len(s) == 11) && isLetters(s[:6]) && isAlnum(s[6:])
+}
+
+// IsRoutingNumber reports whether s is an ABA routing number: 9 digits
+// with the weights 3, 7, 1 summing up to a multiple of 10.
+func IsRoutingNumber(s string) bool {
+	if len(s) != 9 || !isDigits(s) {
+		return false
+	}
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i, r := range s {
+		sum += int(r-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
+
+// checkDigits computes 98 - (bban + country + "00") mod 97 where the
+// letters are replaced with numbers from 10 (A) to 35 (Z).
+func checkDigits(country, bban string) string {
+	var digits strings.Builder
+	for _, r := range bban + country + "00" {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	mod := new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return fmt.Sprintf("%02d", 98-mod)
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
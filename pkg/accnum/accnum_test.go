@@ -0,0 +1,73 @@
+package accnum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	// well known IBAN examples
+	for _, number := range []string{
+		"GB82WEST12345698765432",
+		"DE89370400440532013000",
+		"RU0204452560040702810412345678901",
+	} {
+		assert.NoError(t, Validate(number), number)
+	}
+
+	assert.ErrorIs(t, Validate("GB82WEST12345698765433"), ErrChecksum)
+	assert.ErrorIs(t, Validate("GB28WEST12345698765432"), ErrChecksum)
+	assert.ErrorIs(t, Validate("G182WEST12345698765432"), ErrFormat)
+	assert.ErrorIs(t, Validate("GB82"), ErrFormat)
+	assert.ErrorIs(t, Validate("gb82west12345698765432"), ErrFormat)
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := New(Format{CountryCode: "ru", BankCode: "GOBK", SequenceLength: 10})
+	require.NoError(t, err)
+
+	number, err := g.Generate(42)
+	require.NoError(t, err)
+	assert.Len(t, number, 18)
+	assert.Equal(t, "RU", number[:2])
+	assert.Equal(t, "GOBK0000000042", number[4:])
+	assert.NoError(t, g.Validate(number))
+
+	// a single mistyped digit is always detected
+	mistyped := []byte(number)
+	mistyped[len(mistyped)-1] = '3'
+	assert.ErrorIs(t, g.Validate(string(mistyped)), ErrChecksum)
+
+	_, err = g.Generate(1e10)
+	assert.ErrorIs(t, err, ErrFormat)
+
+	other, err := New(Format{CountryCode: "RU", BankCode: "OTHR", SequenceLength: 10})
+	require.NoError(t, err)
+	foreign, err := other.Generate(42)
+	require.NoError(t, err)
+	assert.ErrorIs(t, g.Validate(foreign), ErrFormat)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "GB82WEST12345698765432", Normalize(" gb82 west 1234 5698 7654 32 "))
+}
+
+func TestIsBIC(t *testing.T) {
+	for _, bic := range []string{"DEUTDEFF", "COBADEFFXXX", "WESTGB2L", "SABRRUMM012"} {
+		assert.True(t, IsBIC(bic), bic)
+	}
+	for _, bic := range []string{"", "DEUTDE", "DEUTDEFFX", "DEUT12FF", "deutdeff", "DEUTDEFF-01"} {
+		assert.False(t, IsBIC(bic), bic)
+	}
+}
+
+func TestIsRoutingNumber(t *testing.T) {
+	for _, number := range []string{"011000015", "021000021", "121000248"} {
+		assert.True(t, IsRoutingNumber(number), number)
+	}
+	for _, number := range []string{"", "02100002", "021000022", "02100002A", "0210000210"} {
+		assert.False(t, IsRoutingNumber(number), number)
+	}
+}
@@ -0,0 +1,53 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"alukart32.com/bank/pkg/zerologx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	handledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server.",
+	}, []string{"method", "code"})
+
+	handlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Response latency of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// LoggingUnary logs every RPC with its status code and latency.
+func LoggingUnary(l zerologx.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		l.Info("grpc %s %s %s", info.FullMethod, status.Code(err), time.Since(start))
+		if err != nil {
+			l.Error(err, "grpc - "+info.FullMethod)
+		}
+		return resp, err
+	}
+}
+
+// MetricsUnary exposes the count and latency of RPCs to prometheus.
+func MetricsUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		handlingSeconds.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		handledTotal.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
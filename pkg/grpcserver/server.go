@@ -0,0 +1,61 @@
+package grpcserver
+
+import (
+	"net"
+	"time"
+
+	"alukart32.com/bank/config"
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	server          *grpc.Server
+	addr            string
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+// New starts serving the registered services of server on the configured port.
+func New(server *grpc.Server, cfg config.GRPC) *Server {
+	s := &Server{
+		server:          server,
+		addr:            net.JoinHostPort("", cfg.Port),
+		notify:          make(chan error, 1),
+		shutdownTimeout: cfg.ShutdownTimeout,
+	}
+
+	s.start()
+	return s
+}
+
+func (s *Server) start() {
+	go func() {
+		lis, err := net.Listen("tcp", s.addr)
+		if err == nil {
+			err = s.server.Serve(lis)
+		}
+		s.notify <- err
+		close(s.notify)
+	}()
+}
+
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Shutdown stops the server gracefully. The pending RPCs are cancelled
+// if they don't finish within the shutdown timeout.
+func (s *Server) Shutdown() error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.shutdownTimeout):
+		s.server.Stop()
+	}
+	return nil
+}
@@ -2,23 +2,30 @@ package httpserver
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"time"
 
 	"alukart32.com/bank/config"
 )
 
+type connKey struct{}
+
 type Server struct {
-	server          http.Server
+	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
 }
 
 func New(handler http.Handler, cfg config.HTTP) *Server {
-	httpServer := http.Server{
+	httpServer := &http.Server{
 		Handler:      handler,
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connKey{}, c)
+		},
 	}
 
 	s := Server{
@@ -48,3 +55,14 @@ func (s *Server) Shutdown() error {
 
 	return s.server.Shutdown(ctx)
 }
+
+// DisableWriteTimeout clears the write deadline of the connection of the
+// request, so the long-lived responses such as event streams aren't cut
+// by the write timeout. The deadline is set again for the next request.
+func DisableWriteTimeout(r *http.Request) error {
+	c, ok := r.Context().Value(connKey{}).(net.Conn)
+	if !ok {
+		return errors.New("httpserver: request connection not found")
+	}
+	return c.SetWriteDeadline(time.Time{})
+}
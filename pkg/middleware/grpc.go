@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type ctxKey int
+
+const (
+	ctxSubjectKey ctxKey = iota
+	ctxRolesKey
+)
+
+// AuthUnary verifies the HS256 bearer token passed in the authorization
+// metadata and stores its subject and roles in the request context.
+func AuthUnary(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get("authorization"); len(v) > 0 {
+				token = strings.TrimPrefix(v[0], "Bearer ")
+			}
+		}
+
+		cl, err := parseToken(token, []byte(secret))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(NewContext(ctx, cl.Subject, cl.Roles...), req)
+	}
+}
+
+// NewContext returns the context carrying the authenticated subject and
+// its roles.
+func NewContext(ctx context.Context, subject string, roles ...string) context.Context {
+	ctx = context.WithValue(ctx, ctxSubjectKey, subject)
+	return context.WithValue(ctx, ctxRolesKey, roles)
+}
+
+// SubjectFromContext returns the authenticated subject stored by AuthUnary.
+func SubjectFromContext(ctx context.Context) string {
+	s, _ := ctx.Value(ctxSubjectKey).(string)
+	return s
+}
+
+// HasRoleInContext reports whether the subject stored by AuthUnary holds the role.
+func HasRoleInContext(ctx context.Context, role string) bool {
+	roles, _ := ctx.Value(ctxRolesKey).([]string)
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
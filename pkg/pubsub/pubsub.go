@@ -0,0 +1,71 @@
+// Package pubsub implements a simple in-process topic based message broker.
+package pubsub
+
+import "sync"
+
+// Broker fans out published messages to the subscribers of a topic.
+//
+// A subscriber which doesn't keep up with the publisher is dropped: its
+// channel is closed so that the consumer can reconnect and catch up.
+type Broker struct {
+	mux    sync.Mutex
+	subs   map[string]map[chan interface{}]struct{}
+	buffer int
+}
+
+// New returns a broker with the given subscriber buffer size.
+func New(buffer int) *Broker {
+	return &Broker{
+		subs:   make(map[string]map[chan interface{}]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe registers a new subscriber of the topic. The returned func
+// cancels the subscription and may be called more than once.
+func (b *Broker) Subscribe(topic string) (<-chan interface{}, func()) {
+	ch := make(chan interface{}, b.buffer)
+
+	b.mux.Lock()
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan interface{}]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	b.mux.Unlock()
+
+	return ch, func() {
+		b.mux.Lock()
+		b.remove(topic, ch)
+		b.mux.Unlock()
+	}
+}
+
+// Publish sends msg to every subscriber of the topic without blocking.
+func (b *Broker) Publish(topic string, msg interface{}) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- msg:
+		default:
+			b.remove(topic, ch)
+		}
+	}
+}
+
+func (b *Broker) remove(topic string, ch chan interface{}) {
+	subs, ok := b.subs[topic]
+	if !ok {
+		return
+	}
+	if _, ok = subs[ch]; !ok {
+		return
+	}
+
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(b.subs, topic)
+	}
+}
@@ -0,0 +1,40 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish(t *testing.T) {
+	b := New(2)
+
+	sub1, cancel1 := b.Subscribe("topic")
+	defer cancel1()
+	sub2, cancel2 := b.Subscribe("topic")
+	defer cancel2()
+	other, cancel3 := b.Subscribe("other")
+	defer cancel3()
+
+	b.Publish("topic", 1)
+
+	assert.Equal(t, 1, <-sub1)
+	assert.Equal(t, 1, <-sub2)
+	assert.Len(t, other, 0)
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	b := New(1)
+
+	sub, cancel := b.Subscribe("topic")
+	b.Publish("topic", 1)
+	b.Publish("topic", 2)
+
+	require.Equal(t, 1, <-sub)
+	_, ok := <-sub
+	assert.False(t, ok)
+
+	// cancel of a dropped subscriber is a no-op
+	cancel()
+}